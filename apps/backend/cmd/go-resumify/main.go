@@ -3,6 +3,7 @@ package main
 import (
 	"context"
 	"errors"
+	"flag"
 	"net/http"
 	"os"
 	"os/signal"
@@ -21,6 +22,9 @@ import (
 const DefaultContextTimeout = 30
 
 func main() {
+	enqueueOnly := flag.Bool("enqueue-only", false, "only enqueue background jobs and leave processing them to cmd/worker")
+	flag.Parse()
+
 	cfg, err := config.LoadConfig()
 	if err != nil {
 		panic("failed to load config: " + err.Error())
@@ -52,6 +56,14 @@ func main() {
 	}
 	handlers := handler.NewHandlers(srv, services)
 
+	// Start background jobs now that services have registered their task
+	// handlers, unless a separate worker processes them
+	if *enqueueOnly {
+		log.Info().Msg("enqueue only, background jobs are processed by the worker")
+	} else if err := srv.Job.Start(); err != nil {
+		log.Fatal().Err(err).Msg("failed to start job server")
+	}
+
 	// Initialize router
 	r := router.NewRouter(srv, handlers, services)
 
@@ -0,0 +1,66 @@
+package main
+
+import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/config"
+	"github.com/recreatedev/Resumify/internal/logger"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+// main runs the background job server without the HTTP API, so workers can
+// be scaled separately from API processes started with -enqueue-only.
+// Migrations are left to the API.
+func main() {
+	cfg, err := config.LoadConfig()
+	if err != nil {
+		panic("failed to load config: " + err.Error())
+	}
+
+	// Initialize New Relic logger service
+	loggerService := logger.NewLoggerService(cfg.Observability)
+	defer loggerService.Shutdown()
+
+	log := logger.NewLoggerWithService(cfg.Observability, loggerService)
+
+	// Initialize server
+	srv, err := server.New(cfg, &log, loggerService)
+	if err != nil {
+		log.Fatal().Err(err).Msg("failed to initialize server")
+	}
+
+	// Initialize repositories and services, which register their task handlers
+	repos := repository.NewRepositories(srv)
+	if _, err := service.NewServices(srv, repos); err != nil {
+		log.Fatal().Err(err).Msg("could not create services")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if err := srv.Job.Start(); err != nil {
+		log.Fatal().Err(err).Msg("failed to start job server")
+	}
+
+	log.Info().
+		Int("concurrency", cfg.Worker.Concurrency).
+		Interface("queues", cfg.Worker.Queues()).
+		Msg("worker started")
+
+	// Wait for interrupt signal, then let running tasks finish
+	<-ctx.Done()
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(cfg.Worker.ShutdownTimeout)*time.Second)
+	defer cancel()
+
+	if err := srv.Shutdown(ctx); err != nil {
+		log.Fatal().Err(err).Msg("worker forced to shutdown")
+	}
+
+	log.Info().Msg("worker exited properly")
+}
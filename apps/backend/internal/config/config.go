@@ -18,6 +18,15 @@ type Config struct {
 	Auth          AuthConfig           `koanf:"auth" validate:"required"`
 	Redis         RedisConfig          `koanf:"redis" validate:"required"`
 	Integration   IntegrationConfig    `koanf:"integration" validate:"required"`
+	Storage       StorageConfig        `koanf:"storage"`
+	Trash         TrashConfig          `koanf:"trash"`
+	Outbox        OutboxConfig         `koanf:"outbox"`
+	DataExport    DataExportConfig     `koanf:"dataexport"`
+	ResumeExport  ResumeExportConfig   `koanf:"resumeexport"`
+	CertReminder  CertReminderConfig   `koanf:"certreminder"`
+	Worker        WorkerConfig         `koanf:"worker"`
+	Maintenance   MaintenanceConfig    `koanf:"maintenance"`
+	Webhook       WebhookConfig        `koanf:"webhook"`
 	Observability *ObservabilityConfig `koanf:"observability"`
 }
 
@@ -31,6 +40,10 @@ type ServerConfig struct {
 	WriteTimeout       int      `koanf:"write_timeout" validate:"required"`
 	IdleTimeout        int      `koanf:"idle_timeout" validate:"required"`
 	CORSAllowedOrigins []string `koanf:"cors_allowed_origins" validate:"required"`
+	// FrontendURL is the base URL of the web app, used for links in emails
+	FrontendURL string `koanf:"frontend_url"`
+	// PublicURL is the base URL of this API, used for download links in emails
+	PublicURL string `koanf:"public_url"`
 }
 
 type DatabaseConfig struct {
@@ -50,13 +63,217 @@ type RedisConfig struct {
 }
 
 type IntegrationConfig struct {
-	ResendAPIKey string `koanf:"resend_api_key" validate:"required"`
+	// EmailTransport selects how email is delivered: resend, smtp, file
+	// (writes .eml files to EmailOutboxDir) or memory (kept for tests)
+	EmailTransport string `koanf:"email_transport" validate:"omitempty,oneof=resend smtp file memory"`
+	// ResendAPIKey is required by the resend transport
+	ResendAPIKey string `koanf:"resend_api_key" validate:"required_if=EmailTransport resend"`
+	// ResendWebhookSecret is the signing secret of the Resend webhook
+	// endpoint that reports delivered, bounced and complained emails
+	ResendWebhookSecret string `koanf:"resend_webhook_secret"`
+	// EmailFromName and EmailFromAddress are the sender of outgoing email
+	EmailFromName    string `koanf:"email_from_name"`
+	EmailFromAddress string `koanf:"email_from_address" validate:"omitempty,email"`
+	// SMTPHost is required by the smtp transport
+	SMTPHost     string `koanf:"smtp_host" validate:"required_if=EmailTransport smtp"`
+	SMTPPort     int    `koanf:"smtp_port" validate:"min=0,max=65535"`
+	SMTPUsername string `koanf:"smtp_username"`
+	SMTPPassword string `koanf:"smtp_password"`
+	// EmailOutboxDir is where the file transport writes emails
+	EmailOutboxDir string `koanf:"email_outbox_dir"`
 }
 
 type AuthConfig struct {
 	SecretKey string `koanf:"secret_key" validate:"required"`
+	// WebhookSecret is the signing secret of the Clerk webhook endpoint
+	WebhookSecret string `koanf:"webhook_secret"`
 }
 
+type StorageConfig struct {
+	// Backend selects where blobs are stored: local (files under LocalDir) or
+	// s3 (any S3-compatible service)
+	Backend string `koanf:"backend" validate:"omitempty,oneof=local s3"`
+	// LocalDir is where the local backend stores blobs
+	LocalDir string `koanf:"local_dir"`
+	// S3Endpoint, S3Bucket and the keys are required by the s3 backend
+	S3Endpoint  string `koanf:"s3_endpoint" validate:"required_if=Backend s3,omitempty,url"`
+	S3Region    string `koanf:"s3_region"`
+	S3Bucket    string `koanf:"s3_bucket" validate:"required_if=Backend s3"`
+	S3AccessKey string `koanf:"s3_access_key" validate:"required_if=Backend s3"`
+	S3SecretKey string `koanf:"s3_secret_key" validate:"required_if=Backend s3"`
+	// S3PathStyle addresses the bucket in the path rather than the host name,
+	// as MinIO and most self-hosted services expect
+	S3PathStyle bool `koanf:"s3_path_style"`
+}
+
+type TrashConfig struct {
+	// RetentionDays is how long a deleted resume stays restorable before it is
+	// purged. It defaults to 30 when unset; an explicit 0 is rejected rather
+	// than silently replaced by the default
+	RetentionDays int `koanf:"retention_days" validate:"min=1"`
+	// PurgeCron is the cron spec for the purge job
+	PurgeCron string `koanf:"purge_cron"`
+}
+
+type OutboxConfig struct {
+	// DispatchCron is the schedule on which pending domain events are dispatched
+	DispatchCron string `koanf:"dispatch_cron"`
+	// BatchSize is the number of events dispatched per run
+	BatchSize int `koanf:"batch_size" validate:"min=0"`
+	// MaxAttempts is the number of failed dispatches after which an event is
+	// dead-lettered and no longer retried
+	MaxAttempts int `koanf:"max_attempts" validate:"min=0"`
+	// RetentionHours is how long dispatched events are kept
+	RetentionHours int `koanf:"retention_hours" validate:"min=0"`
+	// PruneCron is the cron spec for the job removing dispatched events
+	PruneCron string `koanf:"prune_cron"`
+}
+
+type DataExportConfig struct {
+	// RetentionHours is how long a finished export can be downloaded
+	RetentionHours int `koanf:"retention_hours" validate:"min=0"`
+	// PurgeCron is the cron spec for the job removing expired exports
+	PurgeCron string `koanf:"purge_cron"`
+}
+
+type ResumeExportConfig struct {
+	// RetentionHours is how long a rendered resume is kept for download and
+	// reuse after it was last requested
+	RetentionHours int `koanf:"retention_hours" validate:"min=0"`
+	// PurgeCron is the cron spec for the job removing expired renders
+	PurgeCron string `koanf:"purge_cron"`
+}
+
+type CertReminderConfig struct {
+	// WindowDays are the days before expiry at which a certification is reminded
+	WindowDays []int `koanf:"window_days" validate:"dive,min=1"`
+	// Cron is the cron spec for the job sending the reminders
+	Cron string `koanf:"cron"`
+}
+
+// ExpiringWithinDays is the largest reminder window; certifications expiring
+// within it are reported as expiring
+func (c CertReminderConfig) ExpiringWithinDays() int {
+	days := 0
+	for _, window := range c.WindowDays {
+		if window > days {
+			days = window
+		}
+	}
+	return days
+}
+
+type WorkerConfig struct {
+	// Concurrency is the number of tasks a worker process runs at once
+	Concurrency int `koanf:"concurrency" validate:"min=0"`
+	// QueueCritical, QueueDefault and QueueLow weight how often each queue is
+	// polled. A queue weighted 0 is not processed by the worker; when no
+	// weight is set the defaults apply.
+	QueueCritical int `koanf:"queue_critical" validate:"min=0"`
+	QueueDefault  int `koanf:"queue_default" validate:"min=0"`
+	QueueLow      int `koanf:"queue_low" validate:"min=0"`
+	// ShutdownTimeout is how long, in seconds, running tasks get to finish
+	// when the worker stops
+	ShutdownTimeout int `koanf:"shutdown_timeout" validate:"min=0"`
+}
+
+// Queues maps the queues the worker processes to their weights
+func (c WorkerConfig) Queues() map[string]int {
+	queues := make(map[string]int, 3)
+	for name, weight := range map[string]int{
+		"critical": c.QueueCritical,
+		"default":  c.QueueDefault,
+		"low":      c.QueueLow,
+	} {
+		if weight > 0 {
+			queues[name] = weight
+		}
+	}
+	return queues
+}
+
+type WebhookConfig struct {
+	// AllowPrivateTargets lets deliveries reach loopback, private and
+	// link-local addresses. It is meant for local development only.
+	AllowPrivateTargets bool `koanf:"allow_private_targets"`
+}
+
+type MaintenanceConfig struct {
+	// PruneTasksCron is the cron spec for the job deleting old completed task
+	// records from Redis
+	PruneTasksCron string `koanf:"prune_tasks_cron"`
+	// TaskRetentionHours is how long completed task records are kept. Event
+	// tasks are deduplicated by their records for 24 hours, so it cannot be
+	// lower.
+	TaskRetentionHours int `koanf:"task_retention_hours" validate:"omitempty,min=24"`
+	// CompactOrderCron is the cron spec for the job closing the order_index
+	// gaps left by deleted items
+	CompactOrderCron string `koanf:"compact_order_cron"`
+	// IntegrityCheckCron is the cron spec for the job reporting sections
+	// without items
+	IntegrityCheckCron string `koanf:"integrity_check_cron"`
+}
+
+const DefaultFrontendURL = "http://localhost:5173"
+
+const (
+	DefaultEmailTransport   = "resend"
+	DefaultEmailFromName    = "Resumify"
+	DefaultEmailFromAddress = "onboarding@resend.dev"
+	DefaultSMTPPort         = 587
+	DefaultEmailOutboxDir   = "tmp/emails"
+)
+
+const DefaultPublicURL = "http://localhost:8080"
+
+const (
+	DefaultStorageBackend  = "local"
+	DefaultStorageLocalDir = "tmp/storage"
+	DefaultStorageS3Region = "us-east-1"
+)
+
+const (
+	DefaultTrashRetentionDays = 30
+	DefaultTrashPurgeCron     = "0 3 * * *"
+)
+
+const (
+	DefaultOutboxDispatchCron   = "@every 5s"
+	DefaultOutboxBatchSize      = 100
+	DefaultOutboxMaxAttempts    = 10
+	DefaultOutboxRetentionHours = 168
+	DefaultOutboxPruneCron      = "@hourly"
+)
+
+const (
+	DefaultDataExportRetentionHours = 48
+	DefaultDataExportPurgeCron      = "@hourly"
+)
+
+const (
+	DefaultResumeExportRetentionHours = 24
+	DefaultResumeExportPurgeCron      = "@hourly"
+)
+
+const DefaultCertReminderCron = "0 8 * * *"
+
+var DefaultCertReminderWindowDays = []int{90, 30, 7}
+
+const (
+	DefaultWorkerConcurrency     = 10
+	DefaultWorkerQueueCritical   = 6
+	DefaultWorkerQueueDefault    = 3
+	DefaultWorkerQueueLow        = 1
+	DefaultWorkerShutdownTimeout = 30
+)
+
+const (
+	DefaultMaintenancePruneTasksCron     = "@hourly"
+	DefaultMaintenanceTaskRetentionHours = 24
+	DefaultMaintenanceCompactOrderCron   = "0 4 * * *"
+	DefaultMaintenanceIntegrityCheckCron = "0 2 * * *"
+)
+
 func LoadConfig() (*Config, error) {
 	logger := zerolog.New(zerolog.ConsoleWriter{Out: os.Stderr}).With().Timestamp().Logger()
 
@@ -89,6 +306,19 @@ func LoadConfig() (*Config, error) {
 		logger.Fatal().Err(err).Msg("could not unmarshal main config")
 	}
 
+	// The email transport, storage backend and trash retention are defaulted
+	// before validation, which requires the settings of the selected ones and
+	// must tell an unset retention apart from an explicit 0
+	if mainConfig.Integration.EmailTransport == "" {
+		mainConfig.Integration.EmailTransport = DefaultEmailTransport
+	}
+	if mainConfig.Storage.Backend == "" {
+		mainConfig.Storage.Backend = DefaultStorageBackend
+	}
+	if !k.Exists("trash.retention_days") {
+		mainConfig.Trash.RetentionDays = DefaultTrashRetentionDays
+	}
+
 	validate := validator.New()
 
 	err = validate.Struct(mainConfig)
@@ -96,6 +326,108 @@ func LoadConfig() (*Config, error) {
 		logger.Fatal().Err(err).Msg("config validation failed")
 	}
 
+	// Set default frontend URL if not provided
+	if mainConfig.Server.FrontendURL == "" {
+		mainConfig.Server.FrontendURL = DefaultFrontendURL
+	}
+	if mainConfig.Server.PublicURL == "" {
+		mainConfig.Server.PublicURL = DefaultPublicURL
+	}
+
+	// Set default email sender if not provided
+	if mainConfig.Integration.EmailFromName == "" {
+		mainConfig.Integration.EmailFromName = DefaultEmailFromName
+	}
+	if mainConfig.Integration.EmailFromAddress == "" {
+		mainConfig.Integration.EmailFromAddress = DefaultEmailFromAddress
+	}
+	if mainConfig.Integration.SMTPPort == 0 {
+		mainConfig.Integration.SMTPPort = DefaultSMTPPort
+	}
+	if mainConfig.Integration.EmailOutboxDir == "" {
+		mainConfig.Integration.EmailOutboxDir = DefaultEmailOutboxDir
+	}
+
+	// Set default storage settings if not provided
+	if mainConfig.Storage.LocalDir == "" {
+		mainConfig.Storage.LocalDir = DefaultStorageLocalDir
+	}
+	if mainConfig.Storage.S3Region == "" {
+		mainConfig.Storage.S3Region = DefaultStorageS3Region
+	}
+
+	// Set default trash settings if not provided
+	if mainConfig.Trash.PurgeCron == "" {
+		mainConfig.Trash.PurgeCron = DefaultTrashPurgeCron
+	}
+
+	// Set default outbox settings if not provided
+	if mainConfig.Outbox.DispatchCron == "" {
+		mainConfig.Outbox.DispatchCron = DefaultOutboxDispatchCron
+	}
+	if mainConfig.Outbox.BatchSize == 0 {
+		mainConfig.Outbox.BatchSize = DefaultOutboxBatchSize
+	}
+	if mainConfig.Outbox.MaxAttempts == 0 {
+		mainConfig.Outbox.MaxAttempts = DefaultOutboxMaxAttempts
+	}
+	if mainConfig.Outbox.RetentionHours == 0 {
+		mainConfig.Outbox.RetentionHours = DefaultOutboxRetentionHours
+	}
+	if mainConfig.Outbox.PruneCron == "" {
+		mainConfig.Outbox.PruneCron = DefaultOutboxPruneCron
+	}
+
+	// Set default data export settings if not provided
+	if mainConfig.DataExport.RetentionHours == 0 {
+		mainConfig.DataExport.RetentionHours = DefaultDataExportRetentionHours
+	}
+	if mainConfig.DataExport.PurgeCron == "" {
+		mainConfig.DataExport.PurgeCron = DefaultDataExportPurgeCron
+	}
+
+	// Set default resume export settings if not provided
+	if mainConfig.ResumeExport.RetentionHours == 0 {
+		mainConfig.ResumeExport.RetentionHours = DefaultResumeExportRetentionHours
+	}
+	if mainConfig.ResumeExport.PurgeCron == "" {
+		mainConfig.ResumeExport.PurgeCron = DefaultResumeExportPurgeCron
+	}
+
+	// Set default certification reminder settings if not provided
+	if len(mainConfig.CertReminder.WindowDays) == 0 {
+		mainConfig.CertReminder.WindowDays = DefaultCertReminderWindowDays
+	}
+	if mainConfig.CertReminder.Cron == "" {
+		mainConfig.CertReminder.Cron = DefaultCertReminderCron
+	}
+
+	if mainConfig.Worker.Concurrency == 0 {
+		mainConfig.Worker.Concurrency = DefaultWorkerConcurrency
+	}
+	if len(mainConfig.Worker.Queues()) == 0 {
+		mainConfig.Worker.QueueCritical = DefaultWorkerQueueCritical
+		mainConfig.Worker.QueueDefault = DefaultWorkerQueueDefault
+		mainConfig.Worker.QueueLow = DefaultWorkerQueueLow
+	}
+	if mainConfig.Worker.ShutdownTimeout == 0 {
+		mainConfig.Worker.ShutdownTimeout = DefaultWorkerShutdownTimeout
+	}
+
+	// Set default maintenance settings if not provided
+	if mainConfig.Maintenance.PruneTasksCron == "" {
+		mainConfig.Maintenance.PruneTasksCron = DefaultMaintenancePruneTasksCron
+	}
+	if mainConfig.Maintenance.TaskRetentionHours == 0 {
+		mainConfig.Maintenance.TaskRetentionHours = DefaultMaintenanceTaskRetentionHours
+	}
+	if mainConfig.Maintenance.CompactOrderCron == "" {
+		mainConfig.Maintenance.CompactOrderCron = DefaultMaintenanceCompactOrderCron
+	}
+	if mainConfig.Maintenance.IntegrityCheckCron == "" {
+		mainConfig.Maintenance.IntegrityCheckCron = DefaultMaintenanceIntegrityCheckCron
+	}
+
 	// Set default observability config if not provided
 	if mainConfig.Observability == nil {
 		mainConfig.Observability = DefaultObservabilityConfig()
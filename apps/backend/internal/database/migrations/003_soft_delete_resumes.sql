@@ -0,0 +1,8 @@
+-- Soft deletion for resumes; trashed rows are purged by a scheduled job
+ALTER TABLE resumes ADD COLUMN deleted_at TIMESTAMPTZ;
+
+-- Active resume lookups by user
+CREATE INDEX idx_resumes_user_id_active ON resumes(user_id) WHERE deleted_at IS NULL;
+
+-- Trash listing and purge
+CREATE INDEX idx_resumes_deleted_at ON resumes(deleted_at) WHERE deleted_at IS NOT NULL;
@@ -0,0 +1,287 @@
+-- Career library: user-level canonical resume items that resume rows can reference.
+-- A linked resume row inherits every content column it leaves NULL from its library item,
+-- so editing the library item updates every resume that has not overridden that field.
+
+-- LIBRARY EXPERIENCE
+CREATE TABLE library_experience (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  company TEXT,
+  position TEXT,
+  start_date DATE,
+  end_date DATE,
+  location TEXT,
+  description TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_library_experience_user_id ON library_experience(user_id);
+
+ALTER TABLE experience ADD COLUMN library_item_id UUID REFERENCES library_experience(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_experience_library_item_id ON experience(library_item_id) WHERE library_item_id IS NOT NULL;
+
+-- Experience rows with library values filled in for fields that are not overridden
+CREATE VIEW experience_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  COALESCE(e.company, l.company) AS company,
+  COALESCE(e.position, l.position) AS position,
+  COALESCE(e.start_date, l.start_date) AS start_date,
+  COALESCE(e.end_date, l.end_date) AS end_date,
+  COALESCE(e.location, l.location) AS location,
+  COALESCE(e.description, l.description) AS description,
+  e.order_index,
+  e.created_at,
+  e.updated_at
+FROM experience e
+LEFT JOIN library_experience l ON e.library_item_id = l.id;
+
+-- LIBRARY EDUCATION
+CREATE TABLE library_education (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  institution TEXT,
+  degree TEXT,
+  field_of_study TEXT,
+  start_date DATE,
+  end_date DATE,
+  grade TEXT,
+  description TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_library_education_user_id ON library_education(user_id);
+
+ALTER TABLE education ADD COLUMN library_item_id UUID REFERENCES library_education(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_education_library_item_id ON education(library_item_id) WHERE library_item_id IS NOT NULL;
+
+-- Education rows with library values filled in for fields that are not overridden
+CREATE VIEW education_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  COALESCE(e.institution, l.institution) AS institution,
+  COALESCE(e.degree, l.degree) AS degree,
+  COALESCE(e.field_of_study, l.field_of_study) AS field_of_study,
+  COALESCE(e.start_date, l.start_date) AS start_date,
+  COALESCE(e.end_date, l.end_date) AS end_date,
+  COALESCE(e.grade, l.grade) AS grade,
+  COALESCE(e.description, l.description) AS description,
+  e.order_index,
+  e.created_at,
+  e.updated_at
+FROM education e
+LEFT JOIN library_education l ON e.library_item_id = l.id;
+
+-- LIBRARY PROJECTS
+CREATE TABLE library_projects (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  name TEXT,
+  role TEXT,
+  description TEXT,
+  link TEXT,
+  technologies TEXT[],
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_library_projects_user_id ON library_projects(user_id);
+
+ALTER TABLE projects ADD COLUMN library_item_id UUID REFERENCES library_projects(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_projects_library_item_id ON projects(library_item_id) WHERE library_item_id IS NOT NULL;
+
+-- Project rows with library values filled in for fields that are not overridden
+CREATE VIEW projects_resolved AS
+SELECT
+  p.id,
+  p.resume_id,
+  p.library_item_id,
+  COALESCE(p.name, l.name) AS name,
+  COALESCE(p.role, l.role) AS role,
+  COALESCE(p.description, l.description) AS description,
+  COALESCE(p.link, l.link) AS link,
+  COALESCE(p.technologies, l.technologies) AS technologies,
+  p.order_index,
+  p.created_at,
+  p.updated_at
+FROM projects p
+LEFT JOIN library_projects l ON p.library_item_id = l.id;
+
+-- LIBRARY SKILLS
+CREATE TABLE library_skills (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  name TEXT,
+  level TEXT,
+  category TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_library_skills_user_id ON library_skills(user_id);
+
+ALTER TABLE skills ADD COLUMN library_item_id UUID REFERENCES library_skills(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_skills_library_item_id ON skills(library_item_id) WHERE library_item_id IS NOT NULL;
+
+-- Skill rows with library values filled in for fields that are not overridden
+CREATE VIEW skills_resolved AS
+SELECT
+  s.id,
+  s.resume_id,
+  s.library_item_id,
+  COALESCE(s.name, l.name) AS name,
+  COALESCE(s.level, l.level) AS level,
+  COALESCE(s.category, l.category) AS category,
+  s.order_index
+FROM skills s
+LEFT JOIN library_skills l ON s.library_item_id = l.id;
+
+-- LIBRARY CERTIFICATIONS
+CREATE TABLE library_certifications (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  name TEXT,
+  organization TEXT,
+  issue_date DATE,
+  expiry_date DATE,
+  credential_id TEXT,
+  credential_url TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_library_certifications_user_id ON library_certifications(user_id);
+
+ALTER TABLE certifications ADD COLUMN library_item_id UUID REFERENCES library_certifications(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_certifications_library_item_id ON certifications(library_item_id) WHERE library_item_id IS NOT NULL;
+
+-- Certification rows with library values filled in for fields that are not overridden
+CREATE VIEW certifications_resolved AS
+SELECT
+  c.id,
+  c.resume_id,
+  c.library_item_id,
+  COALESCE(c.name, l.name) AS name,
+  COALESCE(c.organization, l.organization) AS organization,
+  COALESCE(c.issue_date, l.issue_date) AS issue_date,
+  COALESCE(c.expiry_date, l.expiry_date) AS expiry_date,
+  COALESCE(c.credential_id, l.credential_id) AS credential_id,
+  COALESCE(c.credential_url, l.credential_url) AS credential_url,
+  c.order_index
+FROM certifications c
+LEFT JOIN library_certifications l ON c.library_item_id = l.id;
+
+-- Move existing rows into the library: one library item per distinct entry per user,
+-- then link each row to its item and drop the now-inherited copies of the content.
+INSERT INTO library_experience (user_id, company, position, start_date, end_date, location, description)
+SELECT DISTINCT r.user_id, e.company, e.position, e.start_date, e.end_date, e.location, e.description
+FROM experience e
+JOIN resumes r ON e.resume_id = r.id;
+
+UPDATE experience e
+SET library_item_id = l.id
+FROM resumes r, library_experience l
+WHERE e.resume_id = r.id
+  AND l.user_id = r.user_id
+  AND l.company IS NOT DISTINCT FROM e.company
+  AND l.position IS NOT DISTINCT FROM e.position
+  AND l.start_date IS NOT DISTINCT FROM e.start_date
+  AND l.end_date IS NOT DISTINCT FROM e.end_date
+  AND l.location IS NOT DISTINCT FROM e.location
+  AND l.description IS NOT DISTINCT FROM e.description;
+
+UPDATE experience
+SET company = NULL, position = NULL, start_date = NULL, end_date = NULL, location = NULL, description = NULL
+WHERE library_item_id IS NOT NULL;
+
+INSERT INTO library_education (user_id, institution, degree, field_of_study, start_date, end_date, grade, description)
+SELECT DISTINCT r.user_id, e.institution, e.degree, e.field_of_study, e.start_date, e.end_date, e.grade, e.description
+FROM education e
+JOIN resumes r ON e.resume_id = r.id;
+
+UPDATE education e
+SET library_item_id = l.id
+FROM resumes r, library_education l
+WHERE e.resume_id = r.id
+  AND l.user_id = r.user_id
+  AND l.institution IS NOT DISTINCT FROM e.institution
+  AND l.degree IS NOT DISTINCT FROM e.degree
+  AND l.field_of_study IS NOT DISTINCT FROM e.field_of_study
+  AND l.start_date IS NOT DISTINCT FROM e.start_date
+  AND l.end_date IS NOT DISTINCT FROM e.end_date
+  AND l.grade IS NOT DISTINCT FROM e.grade
+  AND l.description IS NOT DISTINCT FROM e.description;
+
+UPDATE education
+SET institution = NULL, degree = NULL, field_of_study = NULL, start_date = NULL, end_date = NULL, grade = NULL, description = NULL
+WHERE library_item_id IS NOT NULL;
+
+INSERT INTO library_projects (user_id, name, role, description, link, technologies)
+SELECT DISTINCT r.user_id, p.name, p.role, p.description, p.link, p.technologies
+FROM projects p
+JOIN resumes r ON p.resume_id = r.id;
+
+UPDATE projects p
+SET library_item_id = l.id
+FROM resumes r, library_projects l
+WHERE p.resume_id = r.id
+  AND l.user_id = r.user_id
+  AND l.name IS NOT DISTINCT FROM p.name
+  AND l.role IS NOT DISTINCT FROM p.role
+  AND l.description IS NOT DISTINCT FROM p.description
+  AND l.link IS NOT DISTINCT FROM p.link
+  AND l.technologies IS NOT DISTINCT FROM p.technologies;
+
+UPDATE projects
+SET name = NULL, role = NULL, description = NULL, link = NULL, technologies = NULL
+WHERE library_item_id IS NOT NULL;
+
+INSERT INTO library_skills (user_id, name, level, category)
+SELECT DISTINCT r.user_id, s.name, s.level, s.category
+FROM skills s
+JOIN resumes r ON s.resume_id = r.id;
+
+UPDATE skills s
+SET library_item_id = l.id
+FROM resumes r, library_skills l
+WHERE s.resume_id = r.id
+  AND l.user_id = r.user_id
+  AND l.name IS NOT DISTINCT FROM s.name
+  AND l.level IS NOT DISTINCT FROM s.level
+  AND l.category IS NOT DISTINCT FROM s.category;
+
+UPDATE skills
+SET name = NULL, level = NULL, category = NULL
+WHERE library_item_id IS NOT NULL;
+
+INSERT INTO library_certifications (user_id, name, organization, issue_date, expiry_date, credential_id, credential_url)
+SELECT DISTINCT r.user_id, c.name, c.organization, c.issue_date, c.expiry_date, c.credential_id, c.credential_url
+FROM certifications c
+JOIN resumes r ON c.resume_id = r.id;
+
+UPDATE certifications c
+SET library_item_id = l.id
+FROM resumes r, library_certifications l
+WHERE c.resume_id = r.id
+  AND l.user_id = r.user_id
+  AND l.name IS NOT DISTINCT FROM c.name
+  AND l.organization IS NOT DISTINCT FROM c.organization
+  AND l.issue_date IS NOT DISTINCT FROM c.issue_date
+  AND l.expiry_date IS NOT DISTINCT FROM c.expiry_date
+  AND l.credential_id IS NOT DISTINCT FROM c.credential_id
+  AND l.credential_url IS NOT DISTINCT FROM c.credential_url;
+
+UPDATE certifications
+SET name = NULL, organization = NULL, issue_date = NULL, expiry_date = NULL, credential_id = NULL, credential_url = NULL
+WHERE library_item_id IS NOT NULL;
@@ -0,0 +1,150 @@
+-- skills and certifications had no timestamps; they are needed for entity tags
+ALTER TABLE skills ADD COLUMN created_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE skills ADD COLUMN updated_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE certifications ADD COLUMN created_at TIMESTAMPTZ DEFAULT NOW();
+ALTER TABLE certifications ADD COLUMN updated_at TIMESTAMPTZ DEFAULT NOW();
+
+-- Keep updated_at current on every write; ETags are derived from it
+CREATE TRIGGER set_resumes_updated_at
+BEFORE UPDATE ON resumes
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_resume_sections_updated_at
+BEFORE UPDATE ON resume_sections
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_experience_updated_at
+BEFORE UPDATE ON experience
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_education_updated_at
+BEFORE UPDATE ON education
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_projects_updated_at
+BEFORE UPDATE ON projects
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_skills_updated_at
+BEFORE UPDATE ON skills
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_certifications_updated_at
+BEFORE UPDATE ON certifications
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_library_experience_updated_at
+BEFORE UPDATE ON library_experience
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_library_education_updated_at
+BEFORE UPDATE ON library_education
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_library_projects_updated_at
+BEFORE UPDATE ON library_projects
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_library_skills_updated_at
+BEFORE UPDATE ON library_skills
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TRIGGER set_library_certifications_updated_at
+BEFORE UPDATE ON library_certifications
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- A linked row changes whenever its library item does, so resolved rows report
+-- the later of the two modification times
+CREATE OR REPLACE VIEW experience_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  COALESCE(e.company, l.company) AS company,
+  COALESCE(e.position, l.position) AS position,
+  COALESCE(e.start_date, l.start_date) AS start_date,
+  COALESCE(e.end_date, l.end_date) AS end_date,
+  COALESCE(e.location, l.location) AS location,
+  COALESCE(e.description, l.description) AS description,
+  e.order_index,
+  e.created_at,
+  GREATEST(e.updated_at, l.updated_at) AS updated_at
+FROM experience e
+LEFT JOIN library_experience l ON e.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW education_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  COALESCE(e.institution, l.institution) AS institution,
+  COALESCE(e.degree, l.degree) AS degree,
+  COALESCE(e.field_of_study, l.field_of_study) AS field_of_study,
+  COALESCE(e.start_date, l.start_date) AS start_date,
+  COALESCE(e.end_date, l.end_date) AS end_date,
+  COALESCE(e.grade, l.grade) AS grade,
+  COALESCE(e.description, l.description) AS description,
+  e.order_index,
+  e.created_at,
+  GREATEST(e.updated_at, l.updated_at) AS updated_at
+FROM education e
+LEFT JOIN library_education l ON e.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW projects_resolved AS
+SELECT
+  p.id,
+  p.resume_id,
+  p.library_item_id,
+  COALESCE(p.name, l.name) AS name,
+  COALESCE(p.role, l.role) AS role,
+  COALESCE(p.description, l.description) AS description,
+  COALESCE(p.link, l.link) AS link,
+  COALESCE(p.technologies, l.technologies) AS technologies,
+  p.order_index,
+  p.created_at,
+  GREATEST(p.updated_at, l.updated_at) AS updated_at
+FROM projects p
+LEFT JOIN library_projects l ON p.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW skills_resolved AS
+SELECT
+  s.id,
+  s.resume_id,
+  s.library_item_id,
+  COALESCE(s.name, l.name) AS name,
+  COALESCE(s.level, l.level) AS level,
+  COALESCE(s.category, l.category) AS category,
+  s.order_index,
+  s.created_at,
+  GREATEST(s.updated_at, l.updated_at) AS updated_at
+FROM skills s
+LEFT JOIN library_skills l ON s.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW certifications_resolved AS
+SELECT
+  c.id,
+  c.resume_id,
+  c.library_item_id,
+  COALESCE(c.name, l.name) AS name,
+  COALESCE(c.organization, l.organization) AS organization,
+  COALESCE(c.issue_date, l.issue_date) AS issue_date,
+  COALESCE(c.expiry_date, l.expiry_date) AS expiry_date,
+  COALESCE(c.credential_id, l.credential_id) AS credential_id,
+  COALESCE(c.credential_url, l.credential_url) AS credential_url,
+  c.order_index,
+  c.created_at,
+  GREATEST(c.updated_at, l.updated_at) AS updated_at
+FROM certifications c
+LEFT JOIN library_certifications l ON c.library_item_id = l.id;
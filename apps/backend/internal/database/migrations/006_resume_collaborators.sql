@@ -0,0 +1,42 @@
+-- Resume collaborators. An invitation is addressed to an email and is bound to
+-- the accepting user; only the hash of the invitation token is stored.
+CREATE TABLE resume_collaborators (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+  email TEXT NOT NULL,
+  user_id TEXT, -- from Clerk, set when the invitation is accepted
+  role TEXT NOT NULL CHECK (role IN ('viewer', 'commenter', 'editor')),
+  invited_by TEXT NOT NULL,
+  invite_token_hash TEXT UNIQUE,
+  accepted_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE UNIQUE INDEX idx_resume_collaborators_resume_email ON resume_collaborators(resume_id, lower(email));
+CREATE UNIQUE INDEX idx_resume_collaborators_resume_user ON resume_collaborators(resume_id, user_id) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_resume_collaborators_user_id ON resume_collaborators(user_id) WHERE accepted_at IS NOT NULL;
+
+CREATE TRIGGER set_resume_collaborators_updated_at
+BEFORE UPDATE ON resume_collaborators
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Every user's role on every active resume: the owner plus accepted collaborators.
+-- Repositories scope resume data through this view.
+CREATE VIEW resume_access AS
+SELECT
+  r.id AS resume_id,
+  r.user_id,
+  'owner' AS role
+FROM resumes r
+WHERE r.deleted_at IS NULL
+UNION ALL
+SELECT
+  c.resume_id,
+  c.user_id,
+  c.role
+FROM resume_collaborators c
+JOIN resumes r ON r.id = c.resume_id
+WHERE c.accepted_at IS NOT NULL
+  AND r.deleted_at IS NULL;
@@ -0,0 +1,71 @@
+-- Review comments anchored to a resume or one of its sections or items, with an
+-- optional field name. Replies point at the root comment of their thread.
+CREATE TABLE resume_comments (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+  parent_id UUID REFERENCES resume_comments(id) ON DELETE CASCADE,
+  entity_type TEXT NOT NULL CHECK (entity_type IN ('resume', 'section', 'education', 'experience', 'project', 'skill', 'certification')),
+  entity_id UUID NOT NULL,
+  field_name TEXT,
+  author_id TEXT NOT NULL, -- from Clerk
+  body TEXT NOT NULL,
+  resolved_at TIMESTAMPTZ,
+  resolved_by TEXT,
+  archived_at TIMESTAMPTZ, -- set when the anchored entity is deleted
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_resume_comments_resume_id ON resume_comments(resume_id);
+CREATE INDEX idx_resume_comments_entity ON resume_comments(entity_type, entity_id);
+CREATE INDEX idx_resume_comments_parent_id ON resume_comments(parent_id);
+
+CREATE TRIGGER set_resume_comments_updated_at
+BEFORE UPDATE ON resume_comments
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Archive comments whose anchor is deleted, including deletes cascading from a
+-- section or resume, so threads are kept rather than orphaned. The entity type
+-- is passed as the trigger argument.
+CREATE OR REPLACE FUNCTION trigger_archive_anchored_comments()
+RETURNS TRIGGER AS $$
+BEGIN
+    UPDATE resume_comments
+    SET archived_at = CURRENT_TIMESTAMP
+    WHERE entity_type = TG_ARGV[0]
+      AND entity_id = OLD.id
+      AND archived_at IS NULL;
+    RETURN OLD;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER archive_resume_sections_comments
+AFTER DELETE ON resume_sections
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('section');
+
+CREATE TRIGGER archive_education_comments
+AFTER DELETE ON education
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('education');
+
+CREATE TRIGGER archive_experience_comments
+AFTER DELETE ON experience
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('experience');
+
+CREATE TRIGGER archive_projects_comments
+AFTER DELETE ON projects
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('project');
+
+CREATE TRIGGER archive_skills_comments
+AFTER DELETE ON skills
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('skill');
+
+CREATE TRIGGER archive_certifications_comments
+AFTER DELETE ON certifications
+FOR EACH ROW
+EXECUTE FUNCTION trigger_archive_anchored_comments('certification');
@@ -0,0 +1,49 @@
+-- Organization workspaces. Organizations and their roles live in Clerk; members
+-- are mirrored here from their session claims with the resume role their
+-- organization role grants, so repositories can authorize through resume_access.
+ALTER TABLE resumes ADD COLUMN organization_id TEXT; -- from Clerk, NULL for personal resumes
+
+CREATE INDEX idx_resumes_organization_id ON resumes(organization_id) WHERE organization_id IS NOT NULL;
+
+CREATE TABLE organization_members (
+  organization_id TEXT NOT NULL, -- from Clerk
+  user_id TEXT NOT NULL, -- from Clerk
+  org_role TEXT NOT NULL,
+  resume_role TEXT NOT NULL CHECK (resume_role IN ('viewer', 'commenter', 'editor')),
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW(),
+  PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_members_user_id ON organization_members(user_id);
+
+CREATE TRIGGER set_organization_members_updated_at
+BEFORE UPDATE ON organization_members
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- A user may now reach a resume several ways; callers pick the strongest role.
+CREATE OR REPLACE VIEW resume_access AS
+SELECT
+  r.id AS resume_id,
+  r.user_id,
+  'owner' AS role
+FROM resumes r
+WHERE r.deleted_at IS NULL
+UNION ALL
+SELECT
+  c.resume_id,
+  c.user_id,
+  c.role
+FROM resume_collaborators c
+JOIN resumes r ON r.id = c.resume_id
+WHERE c.accepted_at IS NOT NULL
+  AND r.deleted_at IS NULL
+UNION ALL
+SELECT
+  r.id AS resume_id,
+  m.user_id,
+  m.resume_role AS role
+FROM resumes r
+JOIN organization_members m ON m.organization_id = r.organization_id
+WHERE r.deleted_at IS NULL;
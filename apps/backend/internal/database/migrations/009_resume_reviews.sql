@@ -0,0 +1,40 @@
+-- Coach review workflow. A review pins the version of the resume that was
+-- submitted; every status change is recorded in resume_review_events.
+CREATE TABLE resume_reviews (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+  submitter_id TEXT NOT NULL, -- from Clerk
+  reviewer_id TEXT NOT NULL, -- from Clerk
+  status TEXT NOT NULL CHECK (status IN ('submitted', 'in_review', 'changes_requested', 'approved', 'withdrawn')),
+  message TEXT,
+  resume_version TEXT NOT NULL, -- ETag of the resume when submitted
+  snapshot JSONB NOT NULL, -- the resume document as submitted
+  submitted_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  started_at TIMESTAMPTZ,
+  decided_at TIMESTAMPTZ,
+  withdrawn_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- A resume has at most one review awaiting a decision
+CREATE UNIQUE INDEX idx_resume_reviews_open ON resume_reviews(resume_id) WHERE status IN ('submitted', 'in_review');
+CREATE INDEX idx_resume_reviews_reviewer_status ON resume_reviews(reviewer_id, status);
+CREATE INDEX idx_resume_reviews_resume_id ON resume_reviews(resume_id);
+
+CREATE TRIGGER set_resume_reviews_updated_at
+BEFORE UPDATE ON resume_reviews
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TABLE resume_review_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  review_id UUID NOT NULL REFERENCES resume_reviews(id) ON DELETE CASCADE,
+  from_status TEXT,
+  to_status TEXT NOT NULL,
+  actor_id TEXT NOT NULL, -- from Clerk
+  note TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_resume_review_events_review_id ON resume_review_events(review_id);
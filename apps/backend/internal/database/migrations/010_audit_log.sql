@@ -0,0 +1,32 @@
+-- Append-only audit log of every mutating operation. Rows outlive the
+-- resumes and entries they describe, so nothing here references other tables.
+CREATE TABLE audit_log (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  actor_id TEXT NOT NULL, -- from Clerk
+  resume_id UUID, -- the resume the change belongs to, if any
+  entity_type TEXT NOT NULL,
+  entity_id UUID,
+  action TEXT NOT NULL,
+  before JSONB,
+  after JSONB,
+  request_id TEXT,
+  ip_address TEXT,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_audit_log_actor_created_at ON audit_log(actor_id, created_at DESC);
+CREATE INDEX idx_audit_log_resume_created_at ON audit_log(resume_id, created_at DESC) WHERE resume_id IS NOT NULL;
+
+-- The log is append-only: updates and deletes are rejected
+CREATE OR REPLACE FUNCTION trigger_reject_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER reject_audit_log_update
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW
+EXECUTE FUNCTION trigger_reject_audit_log_change();
+
@@ -0,0 +1,13 @@
+-- Transactional outbox. Domain events are written in the same transaction as
+-- the change that caused them and dispatched to the job queue afterwards.
+CREATE TABLE outbox_events (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  attempts INTEGER NOT NULL DEFAULT 0,
+  last_error TEXT,
+  dispatched_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+
+CREATE INDEX idx_outbox_events_pending ON outbox_events(created_at) WHERE dispatched_at IS NULL;
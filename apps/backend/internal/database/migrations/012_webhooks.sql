@@ -0,0 +1,132 @@
+-- Outgoing webhooks. Endpoints subscribe to event types; every attempt to
+-- deliver an event to an endpoint is kept in webhook_deliveries.
+CREATE TABLE webhook_endpoints (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  url TEXT NOT NULL,
+  description TEXT,
+  secret TEXT NOT NULL, -- signs deliveries, so it is kept in the clear
+  event_types TEXT[] NOT NULL,
+  active BOOLEAN NOT NULL DEFAULT TRUE,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_webhook_endpoints_user_id ON webhook_endpoints(user_id);
+
+CREATE TRIGGER set_webhook_endpoints_updated_at
+BEFORE UPDATE ON webhook_endpoints
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+CREATE TABLE webhook_deliveries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  endpoint_id UUID NOT NULL REFERENCES webhook_endpoints(id) ON DELETE CASCADE,
+  event_id UUID NOT NULL, -- the outbox event being delivered
+  event_type TEXT NOT NULL,
+  payload JSONB NOT NULL,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'succeeded', 'failed')),
+  attempts INTEGER NOT NULL DEFAULT 0,
+  response_status INTEGER,
+  response_body TEXT,
+  error TEXT,
+  redelivery_of UUID REFERENCES webhook_deliveries(id) ON DELETE SET NULL,
+  last_attempt_at TIMESTAMPTZ,
+  delivered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- An event is fanned out to an endpoint once; manual redeliveries are extra rows
+CREATE UNIQUE INDEX idx_webhook_deliveries_endpoint_event ON webhook_deliveries(endpoint_id, event_id) WHERE redelivery_of IS NULL;
+CREATE INDEX idx_webhook_deliveries_endpoint_created_at ON webhook_deliveries(endpoint_id, created_at DESC);
+
+CREATE TRIGGER set_webhook_deliveries_updated_at
+BEFORE UPDATE ON webhook_deliveries
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- Resume domain events are raised by triggers so that every write path,
+-- including cascades and whole-document saves, records them in its own
+-- transaction. A change to a resume that already has the same event waiting
+-- in the outbox is folded into it. The event types match the job package.
+CREATE OR REPLACE FUNCTION outbox_add_resume_event(new_event_type TEXT, changed_resume_id UUID)
+RETURNS VOID AS $$
+BEGIN
+    INSERT INTO outbox_events (event_type, payload)
+    SELECT new_event_type, jsonb_build_object('resume_id', changed_resume_id)
+    WHERE NOT EXISTS (
+        SELECT 1
+        FROM outbox_events o
+        WHERE o.event_type = new_event_type
+          AND o.dispatched_at IS NULL
+          AND o.payload->>'resume_id' = changed_resume_id::TEXT
+    );
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trigger_resume_domain_events()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF OLD.deleted_at IS NULL AND NEW.deleted_at IS NOT NULL THEN
+        PERFORM outbox_add_resume_event('event:resume.deleted', NEW.id);
+    ELSIF NEW.deleted_at IS NULL THEN
+        PERFORM outbox_add_resume_event('event:resume.updated', NEW.id);
+    END IF;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER raise_resume_domain_events
+AFTER UPDATE ON resumes
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_domain_events();
+
+-- Changes to the content of an active resume update the resume
+CREATE OR REPLACE FUNCTION trigger_resume_content_domain_events()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed_resume_id UUID;
+BEGIN
+    IF TG_OP = 'DELETE' THEN
+        changed_resume_id := OLD.resume_id;
+    ELSE
+        changed_resume_id := NEW.resume_id;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM resumes WHERE id = changed_resume_id AND deleted_at IS NULL) THEN
+        PERFORM outbox_add_resume_event('event:resume.updated', changed_resume_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER raise_resume_sections_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON resume_sections
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
+
+CREATE TRIGGER raise_education_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON education
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
+
+CREATE TRIGGER raise_experience_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON experience
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
+
+CREATE TRIGGER raise_projects_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON projects
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
+
+CREATE TRIGGER raise_skills_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON skills
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
+
+CREATE TRIGGER raise_certifications_domain_events
+AFTER INSERT OR UPDATE OR DELETE ON certifications
+FOR EACH ROW
+EXECUTE FUNCTION trigger_resume_content_domain_events();
@@ -0,0 +1,16 @@
+-- Profiles of Clerk users, kept in sync by Clerk's user webhooks so that
+-- profile fields can be read without calling Clerk.
+CREATE TABLE users (
+  id TEXT PRIMARY KEY, -- from Clerk
+  email TEXT,
+  first_name TEXT,
+  last_name TEXT,
+  image_url TEXT,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TRIGGER set_users_updated_at
+BEFORE UPDATE ON users
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,25 @@
+-- Exports of everything a user owns, built by a background job. The archive is
+-- kept until its signed download link expires.
+CREATE TABLE data_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'ready', 'failed')),
+  archive BYTEA,
+  size_bytes BIGINT,
+  error TEXT,
+  expires_at TIMESTAMPTZ,
+  completed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_data_exports_user_id ON data_exports(user_id);
+CREATE INDEX idx_data_exports_expires_at ON data_exports(expires_at) WHERE expires_at IS NOT NULL;
+
+-- A user has at most one export being built at a time
+CREATE UNIQUE INDEX idx_data_exports_user_in_progress ON data_exports(user_id) WHERE status IN ('pending', 'running');
+
+CREATE TRIGGER set_data_exports_updated_at
+BEFORE UPDATE ON data_exports
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
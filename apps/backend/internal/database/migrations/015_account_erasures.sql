@@ -0,0 +1,36 @@
+-- Receipts of account erasures. A receipt outlives the data it describes and
+-- records only the Clerk user ID and how many rows each table lost.
+CREATE TABLE account_erasures (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  user_id TEXT NOT NULL, -- from Clerk
+  source TEXT NOT NULL CHECK (source IN ('user', 'clerk')),
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'running', 'completed', 'failed')),
+  rows_affected JSONB NOT NULL DEFAULT '{}', -- rows deleted or anonymized, keyed by table and action
+  cancelled_jobs INTEGER NOT NULL DEFAULT 0,
+  error TEXT,
+  completed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_account_erasures_user_id ON account_erasures(user_id);
+
+-- A user has at most one erasure in progress at a time
+CREATE UNIQUE INDEX idx_account_erasures_user_in_progress ON account_erasures(user_id) WHERE status IN ('pending', 'running');
+
+CREATE TRIGGER set_account_erasures_updated_at
+BEFORE UPDATE ON account_erasures
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
+
+-- The audit log stays append-only, except that an erasure may anonymize the
+-- entries of the erased user. Erasure transactions set resumify.audit_erasure.
+CREATE OR REPLACE FUNCTION trigger_reject_audit_log_change()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF TG_OP = 'UPDATE' AND current_setting('resumify.audit_erasure', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    RAISE EXCEPTION 'audit_log is append-only';
+END;
+$$ LANGUAGE plpgsql;
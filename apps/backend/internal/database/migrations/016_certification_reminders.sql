@@ -0,0 +1,12 @@
+-- Expiry reminders sent for certifications, one per reminder window. Reminders
+-- are keyed by the expiry date, so a renewed certification is reminded again.
+CREATE TABLE certification_reminders (
+  certification_id UUID NOT NULL REFERENCES certifications(id) ON DELETE CASCADE,
+  expiry_date DATE NOT NULL,
+  window_days INT NOT NULL,
+  sent_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (certification_id, expiry_date, window_days)
+);
+
+CREATE INDEX idx_certifications_expiry_date ON certifications(expiry_date) WHERE expiry_date IS NOT NULL;
+CREATE INDEX idx_library_certifications_expiry_date ON library_certifications(expiry_date) WHERE expiry_date IS NOT NULL;
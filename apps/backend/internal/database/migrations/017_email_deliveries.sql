@@ -0,0 +1,28 @@
+-- Every email send, keyed by the idempotency key of the task that sends it so a
+-- retried task never sends twice. Provider webhooks move sent emails on to
+-- delivered, bounced or complained.
+CREATE TABLE email_deliveries (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  idempotency_key TEXT NOT NULL UNIQUE,
+  user_id TEXT, -- from Clerk, unset for recipients without an account
+  template TEXT NOT NULL,
+  recipient TEXT NOT NULL,
+  provider TEXT NOT NULL,
+  provider_message_id TEXT,
+  status TEXT NOT NULL DEFAULT 'pending' CHECK (status IN ('pending', 'sent', 'failed', 'delivered', 'bounced', 'complained')),
+  error TEXT,
+  attempts INT NOT NULL DEFAULT 0,
+  last_attempt_at TIMESTAMPTZ,
+  sent_at TIMESTAMPTZ,
+  delivered_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE INDEX idx_email_deliveries_user_id ON email_deliveries(user_id, created_at DESC) WHERE user_id IS NOT NULL;
+CREATE INDEX idx_email_deliveries_provider_message_id ON email_deliveries(provider, provider_message_id) WHERE provider_message_id IS NOT NULL;
+
+CREATE TRIGGER set_email_deliveries_updated_at
+BEFORE UPDATE ON email_deliveries
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
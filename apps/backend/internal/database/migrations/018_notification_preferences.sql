@@ -0,0 +1,17 @@
+-- Which optional emails a user wants. A row is created the first time the user
+-- changes a preference or unsubscribes; until then the defaults of
+-- notification.DefaultPreferences apply. Transactional emails are always sent.
+CREATE TABLE notification_preferences (
+  user_id TEXT PRIMARY KEY, -- from Clerk
+  reminders BOOLEAN NOT NULL,
+  review_requests BOOLEAN NOT NULL,
+  share_view_digests BOOLEAN NOT NULL,
+  product_news BOOLEAN NOT NULL,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+CREATE TRIGGER set_notification_preferences_updated_at
+BEFORE UPDATE ON notification_preferences
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
@@ -0,0 +1,26 @@
+-- Resumes rendered to a download format by a background job. Renders are
+-- keyed by a hash of the resume document, so requesting an unchanged resume
+-- again reuses the stored output until it expires.
+CREATE TABLE resume_exports (
+  id UUID PRIMARY KEY DEFAULT gen_random_uuid(),
+  resume_id UUID NOT NULL REFERENCES resumes(id) ON DELETE CASCADE,
+  format TEXT NOT NULL CHECK (format IN ('html', 'json')),
+  document_hash TEXT NOT NULL,
+  status TEXT NOT NULL DEFAULT 'queued' CHECK (status IN ('queued', 'running', 'done', 'failed')),
+  content BYTEA,
+  size_bytes BIGINT,
+  error TEXT,
+  expires_at TIMESTAMPTZ,
+  completed_at TIMESTAMPTZ,
+  created_at TIMESTAMPTZ DEFAULT NOW(),
+  updated_at TIMESTAMPTZ DEFAULT NOW()
+);
+
+-- A failed render does not block requesting the same document again
+CREATE UNIQUE INDEX idx_resume_exports_document ON resume_exports(resume_id, format, document_hash) WHERE status <> 'failed';
+CREATE INDEX idx_resume_exports_expires_at ON resume_exports(expires_at) WHERE expires_at IS NOT NULL;
+
+CREATE TRIGGER set_resume_exports_updated_at
+BEFORE UPDATE ON resume_exports
+FOR EACH ROW
+EXECUTE FUNCTION trigger_set_updated_at();
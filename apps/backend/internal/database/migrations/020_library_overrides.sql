@@ -0,0 +1,149 @@
+-- Linked resume rows record which fields they override instead of treating every
+-- non-NULL column as an override. An overridden field shows the row's own value,
+-- NULL included, so one resume can clear a value its library item still has.
+ALTER TABLE experience ADD COLUMN overridden_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE education ADD COLUMN overridden_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE projects ADD COLUMN overridden_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE skills ADD COLUMN overridden_fields TEXT[] NOT NULL DEFAULT '{}';
+ALTER TABLE certifications ADD COLUMN overridden_fields TEXT[] NOT NULL DEFAULT '{}';
+
+-- Until now a linked row overrode exactly the fields it stored a value for
+UPDATE experience
+SET overridden_fields = ARRAY_REMOVE(ARRAY[
+  CASE WHEN company IS NOT NULL THEN 'company' END,
+  CASE WHEN position IS NOT NULL THEN 'position' END,
+  CASE WHEN start_date IS NOT NULL THEN 'start_date' END,
+  CASE WHEN end_date IS NOT NULL THEN 'end_date' END,
+  CASE WHEN location IS NOT NULL THEN 'location' END,
+  CASE WHEN description IS NOT NULL THEN 'description' END
+], NULL)
+WHERE library_item_id IS NOT NULL;
+
+UPDATE education
+SET overridden_fields = ARRAY_REMOVE(ARRAY[
+  CASE WHEN institution IS NOT NULL THEN 'institution' END,
+  CASE WHEN degree IS NOT NULL THEN 'degree' END,
+  CASE WHEN field_of_study IS NOT NULL THEN 'field_of_study' END,
+  CASE WHEN start_date IS NOT NULL THEN 'start_date' END,
+  CASE WHEN end_date IS NOT NULL THEN 'end_date' END,
+  CASE WHEN grade IS NOT NULL THEN 'grade' END,
+  CASE WHEN description IS NOT NULL THEN 'description' END
+], NULL)
+WHERE library_item_id IS NOT NULL;
+
+UPDATE projects
+SET overridden_fields = ARRAY_REMOVE(ARRAY[
+  CASE WHEN name IS NOT NULL THEN 'name' END,
+  CASE WHEN role IS NOT NULL THEN 'role' END,
+  CASE WHEN description IS NOT NULL THEN 'description' END,
+  CASE WHEN link IS NOT NULL THEN 'link' END,
+  CASE WHEN technologies IS NOT NULL THEN 'technologies' END
+], NULL)
+WHERE library_item_id IS NOT NULL;
+
+UPDATE skills
+SET overridden_fields = ARRAY_REMOVE(ARRAY[
+  CASE WHEN name IS NOT NULL THEN 'name' END,
+  CASE WHEN level IS NOT NULL THEN 'level' END,
+  CASE WHEN category IS NOT NULL THEN 'category' END
+], NULL)
+WHERE library_item_id IS NOT NULL;
+
+UPDATE certifications
+SET overridden_fields = ARRAY_REMOVE(ARRAY[
+  CASE WHEN name IS NOT NULL THEN 'name' END,
+  CASE WHEN organization IS NOT NULL THEN 'organization' END,
+  CASE WHEN issue_date IS NOT NULL THEN 'issue_date' END,
+  CASE WHEN expiry_date IS NOT NULL THEN 'expiry_date' END,
+  CASE WHEN credential_id IS NOT NULL THEN 'credential_id' END,
+  CASE WHEN credential_url IS NOT NULL THEN 'credential_url' END
+], NULL)
+WHERE library_item_id IS NOT NULL;
+
+-- Unlinked rows and overridden fields show the row's value, everything else the library's
+CREATE OR REPLACE VIEW experience_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  CASE WHEN l.id IS NULL OR 'company' = ANY(e.overridden_fields) THEN e.company ELSE l.company END AS company,
+  CASE WHEN l.id IS NULL OR 'position' = ANY(e.overridden_fields) THEN e.position ELSE l.position END AS position,
+  CASE WHEN l.id IS NULL OR 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END AS start_date,
+  CASE WHEN l.id IS NULL OR 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END AS end_date,
+  CASE WHEN l.id IS NULL OR 'location' = ANY(e.overridden_fields) THEN e.location ELSE l.location END AS location,
+  CASE WHEN l.id IS NULL OR 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END AS description,
+  e.order_index,
+  e.created_at,
+  GREATEST(e.updated_at, l.updated_at) AS updated_at,
+  e.overridden_fields
+FROM experience e
+LEFT JOIN library_experience l ON e.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW education_resolved AS
+SELECT
+  e.id,
+  e.resume_id,
+  e.library_item_id,
+  CASE WHEN l.id IS NULL OR 'institution' = ANY(e.overridden_fields) THEN e.institution ELSE l.institution END AS institution,
+  CASE WHEN l.id IS NULL OR 'degree' = ANY(e.overridden_fields) THEN e.degree ELSE l.degree END AS degree,
+  CASE WHEN l.id IS NULL OR 'field_of_study' = ANY(e.overridden_fields) THEN e.field_of_study ELSE l.field_of_study END AS field_of_study,
+  CASE WHEN l.id IS NULL OR 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END AS start_date,
+  CASE WHEN l.id IS NULL OR 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END AS end_date,
+  CASE WHEN l.id IS NULL OR 'grade' = ANY(e.overridden_fields) THEN e.grade ELSE l.grade END AS grade,
+  CASE WHEN l.id IS NULL OR 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END AS description,
+  e.order_index,
+  e.created_at,
+  GREATEST(e.updated_at, l.updated_at) AS updated_at,
+  e.overridden_fields
+FROM education e
+LEFT JOIN library_education l ON e.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW projects_resolved AS
+SELECT
+  p.id,
+  p.resume_id,
+  p.library_item_id,
+  CASE WHEN l.id IS NULL OR 'name' = ANY(p.overridden_fields) THEN p.name ELSE l.name END AS name,
+  CASE WHEN l.id IS NULL OR 'role' = ANY(p.overridden_fields) THEN p.role ELSE l.role END AS role,
+  CASE WHEN l.id IS NULL OR 'description' = ANY(p.overridden_fields) THEN p.description ELSE l.description END AS description,
+  CASE WHEN l.id IS NULL OR 'link' = ANY(p.overridden_fields) THEN p.link ELSE l.link END AS link,
+  CASE WHEN l.id IS NULL OR 'technologies' = ANY(p.overridden_fields) THEN p.technologies ELSE l.technologies END AS technologies,
+  p.order_index,
+  p.created_at,
+  GREATEST(p.updated_at, l.updated_at) AS updated_at,
+  p.overridden_fields
+FROM projects p
+LEFT JOIN library_projects l ON p.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW skills_resolved AS
+SELECT
+  s.id,
+  s.resume_id,
+  s.library_item_id,
+  CASE WHEN l.id IS NULL OR 'name' = ANY(s.overridden_fields) THEN s.name ELSE l.name END AS name,
+  CASE WHEN l.id IS NULL OR 'level' = ANY(s.overridden_fields) THEN s.level ELSE l.level END AS level,
+  CASE WHEN l.id IS NULL OR 'category' = ANY(s.overridden_fields) THEN s.category ELSE l.category END AS category,
+  s.order_index,
+  s.created_at,
+  GREATEST(s.updated_at, l.updated_at) AS updated_at,
+  s.overridden_fields
+FROM skills s
+LEFT JOIN library_skills l ON s.library_item_id = l.id;
+
+CREATE OR REPLACE VIEW certifications_resolved AS
+SELECT
+  c.id,
+  c.resume_id,
+  c.library_item_id,
+  CASE WHEN l.id IS NULL OR 'name' = ANY(c.overridden_fields) THEN c.name ELSE l.name END AS name,
+  CASE WHEN l.id IS NULL OR 'organization' = ANY(c.overridden_fields) THEN c.organization ELSE l.organization END AS organization,
+  CASE WHEN l.id IS NULL OR 'issue_date' = ANY(c.overridden_fields) THEN c.issue_date ELSE l.issue_date END AS issue_date,
+  CASE WHEN l.id IS NULL OR 'expiry_date' = ANY(c.overridden_fields) THEN c.expiry_date ELSE l.expiry_date END AS expiry_date,
+  CASE WHEN l.id IS NULL OR 'credential_id' = ANY(c.overridden_fields) THEN c.credential_id ELSE l.credential_id END AS credential_id,
+  CASE WHEN l.id IS NULL OR 'credential_url' = ANY(c.overridden_fields) THEN c.credential_url ELSE l.credential_url END AS credential_url,
+  c.order_index,
+  c.created_at,
+  GREATEST(c.updated_at, l.updated_at) AS updated_at,
+  c.overridden_fields
+FROM certifications c
+LEFT JOIN library_certifications l ON c.library_item_id = l.id;
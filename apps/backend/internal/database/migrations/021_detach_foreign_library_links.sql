@@ -0,0 +1,76 @@
+-- Editors could link their own library items into resumes they did not own, so
+-- those resumes showed content another user could change or delete. Linking is
+-- now limited to the resume owner; existing links across users are detached,
+-- keeping the content the resume showed.
+
+UPDATE experience e
+SET
+  company = CASE WHEN 'company' = ANY(e.overridden_fields) THEN e.company ELSE l.company END,
+  position = CASE WHEN 'position' = ANY(e.overridden_fields) THEN e.position ELSE l.position END,
+  start_date = CASE WHEN 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END,
+  end_date = CASE WHEN 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END,
+  location = CASE WHEN 'location' = ANY(e.overridden_fields) THEN e.location ELSE l.location END,
+  description = CASE WHEN 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END,
+  overridden_fields = '{}',
+  library_item_id = NULL
+FROM library_experience l, resumes r
+WHERE e.library_item_id = l.id
+  AND e.resume_id = r.id
+  AND r.user_id <> l.user_id;
+
+UPDATE education e
+SET
+  institution = CASE WHEN 'institution' = ANY(e.overridden_fields) THEN e.institution ELSE l.institution END,
+  degree = CASE WHEN 'degree' = ANY(e.overridden_fields) THEN e.degree ELSE l.degree END,
+  field_of_study = CASE WHEN 'field_of_study' = ANY(e.overridden_fields) THEN e.field_of_study ELSE l.field_of_study END,
+  start_date = CASE WHEN 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END,
+  end_date = CASE WHEN 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END,
+  grade = CASE WHEN 'grade' = ANY(e.overridden_fields) THEN e.grade ELSE l.grade END,
+  description = CASE WHEN 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END,
+  overridden_fields = '{}',
+  library_item_id = NULL
+FROM library_education l, resumes r
+WHERE e.library_item_id = l.id
+  AND e.resume_id = r.id
+  AND r.user_id <> l.user_id;
+
+UPDATE projects p
+SET
+  name = CASE WHEN 'name' = ANY(p.overridden_fields) THEN p.name ELSE l.name END,
+  role = CASE WHEN 'role' = ANY(p.overridden_fields) THEN p.role ELSE l.role END,
+  description = CASE WHEN 'description' = ANY(p.overridden_fields) THEN p.description ELSE l.description END,
+  link = CASE WHEN 'link' = ANY(p.overridden_fields) THEN p.link ELSE l.link END,
+  technologies = CASE WHEN 'technologies' = ANY(p.overridden_fields) THEN p.technologies ELSE l.technologies END,
+  overridden_fields = '{}',
+  library_item_id = NULL
+FROM library_projects l, resumes r
+WHERE p.library_item_id = l.id
+  AND p.resume_id = r.id
+  AND r.user_id <> l.user_id;
+
+UPDATE skills s
+SET
+  name = CASE WHEN 'name' = ANY(s.overridden_fields) THEN s.name ELSE l.name END,
+  level = CASE WHEN 'level' = ANY(s.overridden_fields) THEN s.level ELSE l.level END,
+  category = CASE WHEN 'category' = ANY(s.overridden_fields) THEN s.category ELSE l.category END,
+  overridden_fields = '{}',
+  library_item_id = NULL
+FROM library_skills l, resumes r
+WHERE s.library_item_id = l.id
+  AND s.resume_id = r.id
+  AND r.user_id <> l.user_id;
+
+UPDATE certifications c
+SET
+  name = CASE WHEN 'name' = ANY(c.overridden_fields) THEN c.name ELSE l.name END,
+  organization = CASE WHEN 'organization' = ANY(c.overridden_fields) THEN c.organization ELSE l.organization END,
+  issue_date = CASE WHEN 'issue_date' = ANY(c.overridden_fields) THEN c.issue_date ELSE l.issue_date END,
+  expiry_date = CASE WHEN 'expiry_date' = ANY(c.overridden_fields) THEN c.expiry_date ELSE l.expiry_date END,
+  credential_id = CASE WHEN 'credential_id' = ANY(c.overridden_fields) THEN c.credential_id ELSE l.credential_id END,
+  credential_url = CASE WHEN 'credential_url' = ANY(c.overridden_fields) THEN c.credential_url ELSE l.credential_url END,
+  overridden_fields = '{}',
+  library_item_id = NULL
+FROM library_certifications l, resumes r
+WHERE c.library_item_id = l.id
+  AND c.resume_id = r.id
+  AND r.user_id <> l.user_id;
@@ -0,0 +1,14 @@
+-- Rendered exports move to blob storage under resume-exports/<id>.<format>.
+-- Renders are a cache, so the ones stored in the database are dropped and
+-- rendered again on the next request.
+DELETE FROM resume_exports;
+ALTER TABLE resume_exports DROP COLUMN content;
+
+-- An export outlives its purged resume until the purge job has removed its
+-- blob, so the blob is not left behind when the row goes
+ALTER TABLE resume_exports ALTER COLUMN resume_id DROP NOT NULL;
+ALTER TABLE resume_exports DROP CONSTRAINT resume_exports_resume_id_fkey;
+ALTER TABLE resume_exports ADD CONSTRAINT resume_exports_resume_id_fkey
+  FOREIGN KEY (resume_id) REFERENCES resumes(id) ON DELETE SET NULL;
+
+CREATE INDEX idx_resume_exports_orphaned ON resume_exports(id) WHERE resume_id IS NULL;
@@ -0,0 +1,35 @@
+-- Compacting order indexes only closes gaps between items; it is not an edit.
+-- Compaction transactions set resumify.order_compaction so that the rows keep
+-- their updated_at, and with it their entity tags, and raise no domain events.
+CREATE OR REPLACE FUNCTION trigger_set_updated_at()
+RETURNS TRIGGER AS $$
+BEGIN
+    IF current_setting('resumify.order_compaction', true) = 'on' THEN
+        RETURN NEW;
+    END IF;
+    NEW.updated_at = CURRENT_TIMESTAMP;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE OR REPLACE FUNCTION trigger_resume_content_domain_events()
+RETURNS TRIGGER AS $$
+DECLARE
+    changed_resume_id UUID;
+BEGIN
+    IF current_setting('resumify.order_compaction', true) = 'on' THEN
+        RETURN NULL;
+    END IF;
+
+    IF TG_OP = 'DELETE' THEN
+        changed_resume_id := OLD.resume_id;
+    ELSE
+        changed_resume_id := NEW.resume_id;
+    END IF;
+
+    IF EXISTS (SELECT 1 FROM resumes WHERE id = changed_resume_id AND deleted_at IS NULL) THEN
+        PERFORM outbox_add_resume_event('event:resume.updated', changed_resume_id);
+    END IF;
+    RETURN NULL;
+END;
+$$ LANGUAGE plpgsql;
@@ -0,0 +1,9 @@
+-- Events that keep failing to dispatch are dead-lettered after a number of
+-- attempts instead of being retried forever. They are kept for inspection.
+ALTER TABLE outbox_events ADD COLUMN dead_lettered_at TIMESTAMPTZ;
+
+DROP INDEX idx_outbox_events_pending;
+CREATE INDEX idx_outbox_events_pending ON outbox_events(created_at) WHERE dispatched_at IS NULL AND dead_lettered_at IS NULL;
+
+-- Dispatched events are pruned once they are past the retention period
+CREATE INDEX idx_outbox_events_dispatched_at ON outbox_events(dispatched_at) WHERE dispatched_at IS NOT NULL;
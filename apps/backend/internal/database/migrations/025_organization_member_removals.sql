@@ -0,0 +1,11 @@
+-- Memberships removed through Clerk webhooks. Session claims can outlive a
+-- removal, so a membership mirrored from them is not recreated while its
+-- removal is recorded. Clerk adding the member again clears the removal.
+CREATE TABLE organization_member_removals (
+  organization_id TEXT NOT NULL, -- from Clerk
+  user_id TEXT NOT NULL, -- from Clerk
+  removed_at TIMESTAMPTZ NOT NULL DEFAULT NOW(),
+  PRIMARY KEY (organization_id, user_id)
+);
+
+CREATE INDEX idx_organization_member_removals_user_id ON organization_member_removals(user_id);
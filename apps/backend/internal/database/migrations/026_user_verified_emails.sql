@@ -0,0 +1,3 @@
+-- Email addresses the user verified with Clerk, lower-cased. Invitations can
+-- only be accepted by a user who verified the invited address.
+ALTER TABLE users ADD COLUMN verified_emails TEXT[] NOT NULL DEFAULT '{}';
@@ -0,0 +1,49 @@
+package database
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+)
+
+// Conn is implemented by both the connection pool and a transaction
+type Conn interface {
+	Begin(ctx context.Context) (pgx.Tx, error)
+	Exec(ctx context.Context, sql string, arguments ...any) (pgconn.CommandTag, error)
+	Query(ctx context.Context, sql string, args ...any) (pgx.Rows, error)
+	QueryRow(ctx context.Context, sql string, args ...any) pgx.Row
+}
+
+type txKey struct{}
+
+// Conn returns the transaction started by InTx for ctx, or the pool when ctx
+// is not part of a transaction
+func (db *Database) Conn(ctx context.Context) Conn {
+	if tx, ok := ctx.Value(txKey{}).(pgx.Tx); ok {
+		return tx
+	}
+	return db.Pool
+}
+
+// InTx runs fn in a transaction that is committed when fn returns nil and
+// rolled back otherwise. Queries issued through Conn with the context passed
+// to fn take part in the transaction; nested calls use savepoints.
+func (db *Database) InTx(ctx context.Context, fn func(ctx context.Context) error) error {
+	tx, err := db.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	if err := fn(context.WithValue(ctx, txKey{}, tx)); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return nil
+}
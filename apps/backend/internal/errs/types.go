@@ -54,6 +54,24 @@ func NewNotFoundError(message string, override bool, code *string) *HTTPError {
 	}
 }
 
+func NewPreconditionFailedError(message string, override bool) *HTTPError {
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusPreconditionFailed)),
+		Message:  message,
+		Status:   http.StatusPreconditionFailed,
+		Override: override,
+	}
+}
+
+func NewPreconditionRequiredError(message string, override bool) *HTTPError {
+	return &HTTPError{
+		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusPreconditionRequired)),
+		Message:  message,
+		Status:   http.StatusPreconditionRequired,
+		Override: override,
+	}
+}
+
 func NewInternalServerError() *HTTPError {
 	return &HTTPError{
 		Code:     MakeUpperCaseWithUnderscores(http.StatusText(http.StatusInternalServerError)),
@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type AuditHandler struct {
+	Handler
+	auditService *service.AuditService
+}
+
+func NewAuditHandler(s *server.Server, auditService *service.AuditService) *AuditHandler {
+	return &AuditHandler{
+		Handler:      NewHandler(s),
+		auditService: auditService,
+	}
+}
+
+// GetAuditLog lists the changes made by the current user, or every change made
+// to one of the user's resumes when a resume ID is given
+func (h *AuditHandler) GetAuditLog(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetAuditLogRequest) (*model.PaginatedResponse[audit.EntryResponse], error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+
+			if req.ResumeID == "" {
+				return h.auditService.GetEntriesByActor(c.Request().Context(), userID, page, limit)
+			}
+
+			resumeID, err := uuid.Parse(req.ResumeID)
+			if err != nil {
+				return nil, err
+			}
+			return h.auditService.GetEntriesByResume(c.Request().Context(), userID, resumeID, page, limit)
+		},
+		http.StatusOK,
+		&GetAuditLogRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetAuditLogRequest struct {
+	GetResumesRequest
+	ResumeID string `query:"resumeId" validate:"omitempty,uuid"`
+}
+
+func (r *GetAuditLogRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
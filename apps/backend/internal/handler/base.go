@@ -1,12 +1,20 @@
 package handler
 
 import (
+	"encoding/json"
+	"fmt"
+	"mime"
+	"net/http"
+	"strconv"
 	"time"
 
 	"github.com/labstack/echo/v4"
 	"github.com/newrelic/go-agent/v3/integrations/nrpkgerrors"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
 	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
 	"github.com/recreatedev/Resumify/internal/server"
 	"github.com/recreatedev/Resumify/internal/validation"
 )
@@ -21,6 +29,11 @@ func NewHandler(s *server.Server) Handler {
 	return Handler{server: s}
 }
 
+// ifMatch returns the If-Match precondition sent with the request
+func ifMatch(c echo.Context) string {
+	return c.Request().Header.Get(etag.HeaderIfMatch)
+}
+
 // HandlerFunc represents a typed handler function that processes a request and returns a response
 type HandlerFunc[Req validation.Validatable, Res any] func(c echo.Context, req Req) (Res, error)
 
@@ -40,6 +53,9 @@ type JSONResponseHandler struct {
 }
 
 func (h JSONResponseHandler) Handle(c echo.Context, result interface{}) error {
+	if tagged, ok := result.(etag.Tagged); ok {
+		c.Response().Header().Set(etag.HeaderETag, tagged.EntityTag())
+	}
 	return c.JSON(h.status, result)
 }
 
@@ -77,7 +93,7 @@ type FileResponseHandler struct {
 
 func (h FileResponseHandler) Handle(c echo.Context, result interface{}) error {
 	data := result.([]byte)
-	c.Response().Header().Set("Content-Disposition", "attachment; filename="+h.filename)
+	c.Response().Header().Set("Content-Disposition", attachment(h.filename))
 	return c.Blob(h.status, h.contentType, data)
 }
 
@@ -96,6 +112,144 @@ func (h FileResponseHandler) AddAttributes(txn *newrelic.Transaction, result int
 	}
 }
 
+// InlineResponseHandler handles content displayed by the client, such as
+// rendered HTML, rather than downloaded
+type InlineResponseHandler struct {
+	status      int
+	contentType string
+}
+
+func (h InlineResponseHandler) Handle(c echo.Context, result interface{}) error {
+	return c.Blob(h.status, h.contentType, result.([]byte))
+}
+
+func (h InlineResponseHandler) GetOperation() string {
+	return "handler_inline"
+}
+
+func (h InlineResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
+	if txn != nil {
+		// http.status_code is already set by tracing middleware
+		txn.AddAttribute("inline.content_type", h.contentType)
+		if data, ok := result.([]byte); ok {
+			txn.AddAttribute("inline.size_bytes", len(data))
+		}
+	}
+}
+
+// attachment builds a Content-Disposition header for a download. Filenames
+// with spaces or quotes are quoted and non-ASCII ones are encoded as in
+// RFC 2231, so user-chosen titles cannot break or inject header parameters.
+func attachment(filename string) string {
+	if disposition := mime.FormatMediaType("attachment", map[string]string{"filename": filename}); disposition != "" {
+		return disposition
+	}
+	// Filenames that cannot be encoded, such as ones with control characters,
+	// are left to the client
+	return "attachment"
+}
+
+// DownloadResponseHandler streams stored content as an attachment
+type DownloadResponseHandler struct {
+	status int
+}
+
+func (h DownloadResponseHandler) Handle(c echo.Context, result interface{}) error {
+	download := result.(*model.Download)
+	defer download.Content.Close()
+
+	res := c.Response()
+
+	// Large downloads outlive the server write timeout
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+		middleware.GetLogger(c).Warn().Err(err).Msg("failed to clear write deadline for download")
+	}
+
+	res.Header().Set("Content-Disposition", attachment(download.Filename))
+	res.Header().Set(echo.HeaderContentLength, strconv.FormatInt(download.Size, 10))
+	return c.Stream(h.status, download.ContentType, download.Content)
+}
+
+func (h DownloadResponseHandler) GetOperation() string {
+	return "handler_download"
+}
+
+func (h DownloadResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
+	if txn != nil {
+		// http.status_code is already set by tracing middleware
+		if download, ok := result.(*model.Download); ok {
+			txn.AddAttribute("file.name", download.Filename)
+			txn.AddAttribute("file.content_type", download.ContentType)
+			txn.AddAttribute("file.size_bytes", download.Size)
+		}
+	}
+}
+
+// EventStreamResponseHandler streams a subscription as Server-Sent Events
+type EventStreamResponseHandler struct {
+	heartbeat time.Duration
+}
+
+func (h EventStreamResponseHandler) Handle(c echo.Context, result interface{}) error {
+	subscription := result.(*events.Subscription)
+	defer subscription.Close()
+
+	res := c.Response()
+
+	// The stream outlives the server write timeout
+	if err := http.NewResponseController(res).SetWriteDeadline(time.Time{}); err != nil {
+		middleware.GetLogger(c).Warn().Err(err).Msg("failed to clear write deadline for event stream")
+	}
+
+	res.Header().Set(echo.HeaderContentType, "text/event-stream")
+	res.Header().Set(echo.HeaderCacheControl, "no-cache")
+	res.Header().Set(echo.HeaderConnection, "keep-alive")
+	res.Header().Set("X-Accel-Buffering", "no")
+	res.WriteHeader(http.StatusOK)
+
+	// Tell clients how long to wait before reconnecting
+	if _, err := fmt.Fprintf(res, "retry: %d\n\n", (5 * time.Second).Milliseconds()); err != nil {
+		return nil
+	}
+	res.Flush()
+
+	heartbeat := time.NewTicker(h.heartbeat)
+	defer heartbeat.Stop()
+
+	for {
+		select {
+		case <-c.Request().Context().Done():
+			return nil
+		case <-heartbeat.C:
+			if _, err := fmt.Fprint(res, ": heartbeat\n\n"); err != nil {
+				return nil
+			}
+			res.Flush()
+		case event, ok := <-subscription.Events:
+			if !ok {
+				return nil
+			}
+			data, err := json.Marshal(event)
+			if err != nil {
+				middleware.GetLogger(c).Error().Err(err).Msg("failed to marshal resume event")
+				continue
+			}
+			if _, err := fmt.Fprintf(res, "id: %s\nevent: %s.%s\ndata: %s\n\n", event.ID, event.EntityType, event.Action, data); err != nil {
+				return nil
+			}
+			res.Flush()
+		}
+	}
+}
+
+func (h EventStreamResponseHandler) GetOperation() string {
+	return "handler_event_stream"
+}
+
+func (h EventStreamResponseHandler) AddAttributes(txn *newrelic.Transaction, result interface{}) {
+	// http.status_code is already set by tracing middleware
+}
+
 // handleRequest is the unified handler function that eliminates code duplication
 func handleRequest[Req validation.Validatable](
 	c echo.Context,
@@ -239,6 +393,54 @@ func HandleFile[Req validation.Validatable](
 	}
 }
 
+// HandleInline wraps a handler whose content is returned as is with the
+// given content type
+func HandleInline[Req validation.Validatable](
+	h Handler,
+	handler HandlerFunc[Req, []byte],
+	status int,
+	req Req,
+	contentType string,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+			return handler(c, req)
+		}, InlineResponseHandler{
+			status:      status,
+			contentType: contentType,
+		})
+	}
+}
+
+// HandleDownload wraps a handler whose stored content is streamed to the
+// client as an attachment
+func HandleDownload[Req validation.Validatable](
+	h Handler,
+	handler HandlerFunc[Req, *model.Download],
+	status int,
+	req Req,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+			return handler(c, req)
+		}, DownloadResponseHandler{status: status})
+	}
+}
+
+// HandleEventStream wraps a handler that opens an event subscription and
+// streams it to the client until either side disconnects
+func HandleEventStream[Req validation.Validatable](
+	h Handler,
+	handler HandlerFunc[Req, *events.Subscription],
+	req Req,
+) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		return handleRequest(c, req, func(c echo.Context, req Req) (interface{}, error) {
+			return handler(c, req)
+		}, EventStreamResponseHandler{heartbeat: 15 * time.Second})
+	}
+}
+
 // HandleNoContent wraps a handler with validation, error handling, logging, metrics, and tracing for endpoints that don't return content
 func HandleNoContent[Req validation.Validatable](
 	h Handler,
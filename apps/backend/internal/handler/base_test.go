@@ -0,0 +1,56 @@
+package handler
+
+import (
+	"io"
+	"mime"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDownloadResponseHandlerContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		filename string
+	}{
+		{name: "plain", filename: "resume.pdf"},
+		{name: "spaces", filename: "Ada Lovelace Resume.pdf"},
+		{name: "non-ASCII", filename: "Résumé – Ada Lovelace 履歴書.html"},
+		{name: "header parameters", filename: `resume.pdf"; filename="evil.exe`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			content := "<html></html>"
+			rec := httptest.NewRecorder()
+			c := echo.New().NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+
+			err := DownloadResponseHandler{status: http.StatusOK}.Handle(c, &model.Download{
+				Filename:    tt.filename,
+				ContentType: "text/html; charset=utf-8",
+				Size:        int64(len(content)),
+				Content:     io.NopCloser(strings.NewReader(content)),
+			})
+			require.NoError(t, err)
+
+			header := rec.Header().Get("Content-Disposition")
+			for _, r := range header {
+				assert.Less(t, r, rune(0x80), "header is not ASCII: %s", header)
+			}
+
+			disposition, params, err := mime.ParseMediaType(header)
+			require.NoError(t, err, header)
+			assert.Equal(t, "attachment", disposition)
+			assert.Equal(t, tt.filename, params["filename"])
+			assert.Len(t, params, 1)
+
+			assert.Equal(t, content, rec.Body.String())
+		})
+	}
+}
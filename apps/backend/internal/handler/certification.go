@@ -77,7 +77,7 @@ func (h *CertificationHandler) UpdateCertification(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.certificationService.UpdateCertification(c.Request().Context(), userID, certificationID, req.UpdateCertificationRequest)
+			return h.certificationService.UpdateCertification(c.Request().Context(), userID, certificationID, ifMatch(c), req.UpdateCertificationRequest)
 		},
 		http.StatusOK,
 		&UpdateCertificationRequest{},
@@ -105,7 +105,7 @@ func (h *CertificationHandler) DeleteCertification(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.certificationService.DeleteCertification(c.Request().Context(), userID, certificationID)
+			return h.certificationService.DeleteCertification(c.Request().Context(), userID, certificationID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteCertificationRequest{},
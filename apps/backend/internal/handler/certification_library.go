@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+)
+
+func (h *CertificationHandler) CreateLibraryCertification(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *certification.CreateLibraryCertificationRequest) (*certification.LibraryCertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.certificationService.CreateLibraryCertification(c.Request().Context(), userID, payload)
+		},
+		http.StatusCreated,
+		&certification.CreateLibraryCertificationRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) GetLibraryCertificationsByUserID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListLibraryItemsRequest) ([]certification.LibraryCertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.certificationService.GetLibraryCertificationsByUserID(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListLibraryItemsRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) GetLibraryCertificationByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetLibraryCertificationByIDRequest) (*certification.LibraryCertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.certificationService.GetLibraryCertificationByID(c.Request().Context(), userID, libraryItemID)
+		},
+		http.StatusOK,
+		&GetLibraryCertificationByIDRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) UpdateLibraryCertification(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateLibraryCertificationRequest) (*certification.LibraryCertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.certificationService.UpdateLibraryCertification(c.Request().Context(), userID, libraryItemID, ifMatch(c), req.UpdateLibraryCertificationRequest)
+		},
+		http.StatusOK,
+		&UpdateLibraryCertificationRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) DeleteLibraryCertification(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *DeleteLibraryCertificationRequest) error {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.certificationService.DeleteLibraryCertification(c.Request().Context(), userID, libraryItemID, ifMatch(c))
+		},
+		http.StatusNoContent,
+		&DeleteLibraryCertificationRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) LinkLibraryCertification(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *LinkLibraryCertificationRequest) (*certification.CertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.certificationService.LinkLibraryCertification(c.Request().Context(), userID, libraryItemID, req.LinkLibraryCertificationRequest)
+		},
+		http.StatusCreated,
+		&LinkLibraryCertificationRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) PromoteCertification(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *PromoteCertificationRequest) (*certification.LibraryCertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			certificationID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.certificationService.PromoteCertification(c.Request().Context(), userID, certificationID)
+		},
+		http.StatusCreated,
+		&PromoteCertificationRequest{},
+	)(c)
+}
+
+func (h *CertificationHandler) ResetCertificationOverrides(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResetCertificationOverridesRequest) (*certification.CertificationResponse, error) {
+			userID := middleware.GetUserID(c)
+			certificationID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.certificationService.ResetCertificationOverrides(c.Request().Context(), userID, certificationID, ifMatch(c))
+		},
+		http.StatusOK,
+		&ResetCertificationOverridesRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetLibraryCertificationByIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetLibraryCertificationByIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetLibraryCertificationByIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateLibraryCertificationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*certification.UpdateLibraryCertificationRequest
+}
+
+func (r *UpdateLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateLibraryCertificationRequest.Validate()
+}
+
+func (r *UpdateLibraryCertificationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DeleteLibraryCertificationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DeleteLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DeleteLibraryCertificationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type LinkLibraryCertificationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*certification.LinkLibraryCertificationRequest
+}
+
+func (r *LinkLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.LinkLibraryCertificationRequest.Validate()
+}
+
+func (r *LinkLibraryCertificationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type PromoteCertificationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *PromoteCertificationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *PromoteCertificationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResetCertificationOverridesRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResetCertificationOverridesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResetCertificationOverridesRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
@@ -0,0 +1,35 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/user"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type ClerkWebhookHandler struct {
+	Handler
+	userService *service.UserService
+}
+
+func NewClerkWebhookHandler(s *server.Server, userService *service.UserService) *ClerkWebhookHandler {
+	return &ClerkWebhookHandler{
+		Handler:     NewHandler(s),
+		userService: userService,
+	}
+}
+
+// HandleEvent applies a user lifecycle webhook sent by Clerk
+func (h *ClerkWebhookHandler) HandleEvent(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *user.ClerkEvent) error {
+			return h.userService.HandleClerkEvent(c.Request().Context(), middleware.GetWebhookID(c), req)
+		},
+		http.StatusNoContent,
+		&user.ClerkEvent{},
+	)(c)
+}
@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/lib/webhook"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/service"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const clerkTestSecret = "whsec_Y2xlcmstc2lnbmluZy1zZWNyZXQ="
+
+// signClerkWebhook builds a webhook request signed the way Clerk signs them
+// through Svix
+func signClerkWebhook(t *testing.T, svixID, body string) *http.Request {
+	t.Helper()
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(clerkTestSecret, "whsec_"))
+	require.NoError(t, err)
+
+	timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(svixID + "." + timestamp + "." + body))
+
+	req := httptest.NewRequest(http.MethodPost, "/webhooks/clerk", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set(webhook.HeaderSvixID, svixID)
+	req.Header.Set(webhook.HeaderSvixTimestamp, timestamp)
+	req.Header.Set(webhook.HeaderSvixSignature, "v1,"+base64.StdEncoding.EncodeToString(mac.Sum(nil)))
+
+	return req
+}
+
+func TestClerkWebhookHandler(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	redisAddress := testhelpers.SetupTestRedis(t)
+	testServer.Config.Redis.Address = redisAddress
+	testServer.Config.Auth.WebhookSecret = clerkTestSecret
+	testServer.Job = job.NewJobService(testServer.Logger, testServer.Config, nil)
+	defer testServer.Job.Client.Close()
+
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: redisAddress})
+	defer inspector.Close()
+
+	repos := repository.NewRepositories(testServer)
+	erasureService := service.NewErasureService(testServer, repos)
+	organizationService := service.NewOrganizationService(testServer, repos)
+	userService := service.NewUserService(testServer, repos, erasureService, organizationService)
+	h := NewClerkWebhookHandler(testServer, userService)
+
+	e := echo.New()
+	e.HTTPErrorHandler = middleware.NewGlobalMiddlewares(testServer).GlobalErrorHandler
+	e.POST("/webhooks/clerk", h.HandleEvent, middleware.NewAuthMiddleware(testServer).RequireClerkWebhook)
+
+	ctx := context.Background()
+	send := func(t *testing.T, svixID, body string) int {
+		t.Helper()
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, signClerkWebhook(t, svixID, body))
+		return rec.Code
+	}
+	count := func(t *testing.T, query string, args ...any) int {
+		t.Helper()
+
+		var n int
+		require.NoError(t, testDB.Pool.QueryRow(ctx, query, args...).Scan(&n))
+		return n
+	}
+	welcomeTasks := func(t *testing.T) int {
+		t.Helper()
+
+		tasks, err := inspector.ListPendingTasks("default")
+		require.NoError(t, err)
+
+		n := 0
+		for _, task := range tasks {
+			if task.Type == job.TaskWelcome {
+				n++
+			}
+		}
+		return n
+	}
+
+	t.Run("rejects an unsigned webhook", func(t *testing.T) {
+		req := signClerkWebhook(t, "msg_unsigned", `{"type":"user.deleted","data":{"id":"user_1"}}`)
+		req.Header.Set(webhook.HeaderSvixSignature, "v1,bm90LWEtc2lnbmF0dXJl")
+
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Equal(t, http.StatusUnauthorized, rec.Code)
+		assert.Zero(t, count(t, `SELECT COUNT(*) FROM account_erasures`))
+	})
+
+	t.Run("user.created caches the profile and sends one welcome email", func(t *testing.T) {
+		body := `{"type":"user.created","data":{"id":"user_created","first_name":"Ada","primary_email_address_id":"idn_1","email_addresses":[{"id":"idn_1","email_address":"ada@example.com"}]}}`
+
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_created", body))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM users WHERE id = $1 AND email = $2`, "user_created", "ada@example.com"))
+
+		task, err := inspector.GetTaskInfo("default", "msg_created:"+job.TaskWelcome)
+		require.NoError(t, err)
+		assert.Equal(t, job.TaskWelcome, task.Type)
+
+		// Clerk retries with the same svix-id
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_created", body))
+		assert.Equal(t, 1, welcomeTasks(t))
+	})
+
+	t.Run("user.updated refreshes the profile without an email", func(t *testing.T) {
+		body := `{"type":"user.updated","data":{"id":"user_created","first_name":"Ada","last_name":"Lovelace","primary_email_address_id":"idn_1","email_addresses":[{"id":"idn_1","email_address":"Ada@Example.com","verification":{"status":"verified"}},{"id":"idn_2","email_address":"ada@work.example.com","verification":{"status":"unverified"}}]}}`
+
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_updated", body))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_updated", body))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM users WHERE id = $1 AND last_name = $2`, "user_created", "Lovelace"))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM users WHERE id = $1 AND verified_emails = '{ada@example.com}'`, "user_created"))
+		assert.Equal(t, 1, welcomeTasks(t))
+	})
+
+	t.Run("user.deleted starts one erasure", func(t *testing.T) {
+		body := `{"type":"user.deleted","data":{"id":"user_deleted","deleted":true}}`
+
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_deleted", body))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_deleted", body))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM account_erasures WHERE user_id = $1`, "user_deleted"))
+	})
+
+	t.Run("organization membership events", func(t *testing.T) {
+		members := func(t *testing.T, userID string) int {
+			t.Helper()
+			return count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_1' AND user_id = $1`, userID)
+		}
+		membership := func(eventType, userID, role, permissions string) string {
+			return `{"type":"` + eventType + `","data":{"organization":{"id":"org_1"},"public_user_data":{"user_id":"` + userID + `"},"role":"` + role + `","permissions":[` + permissions + `]}}`
+		}
+
+		created := membership("organizationMembership.created", "user_member", "org:member", `"org:resumes:read"`)
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_created", created))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_created", created))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_1' AND user_id = 'user_member' AND resume_role = 'viewer'`))
+
+		updated := membership("organizationMembership.updated", "user_member", "org:admin", "")
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_updated", updated))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_updated", updated))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_1' AND user_id = 'user_member' AND resume_role = 'editor'`))
+
+		deleted := membership("organizationMembership.deleted", "user_member", "org:admin", "")
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_deleted", deleted))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_deleted", deleted))
+		assert.Zero(t, members(t, "user_member"))
+
+		// A late update does not bring the removed member back
+		lateUpdate := membership("organizationMembership.updated", "user_member", "org:admin", "")
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_member_late_update", lateUpdate))
+		assert.Zero(t, members(t, "user_member"))
+
+		// Losing every resume permission revokes access
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_other_created", membership("organizationMembership.created", "user_other", "org:member", `"org:resumes:comment"`)))
+		assert.Equal(t, 1, members(t, "user_other"))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_other_updated", membership("organizationMembership.updated", "user_other", "org:member", "")))
+		assert.Zero(t, members(t, "user_other"))
+
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_third_created", membership("organizationMembership.created", "user_third", "org:admin", "")))
+		assert.Equal(t, 1, members(t, "user_third"))
+
+		orgDeleted := `{"type":"organization.deleted","data":{"id":"org_1","deleted":true}}`
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_org_deleted", orgDeleted))
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_org_deleted", orgDeleted))
+		assert.Zero(t, count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_1'`))
+	})
+
+	t.Run("stale session claims do not restore a removed membership", func(t *testing.T) {
+		var resumeID string
+		require.NoError(t, testDB.Pool.QueryRow(ctx, `INSERT INTO resumes (user_id, title, organization_id) VALUES ('user_owner', 'Team resume', 'org_2') RETURNING id::TEXT`).Scan(&resumeID))
+
+		// A request made with the session claims of a reader of org_2
+		resumeHandler := NewResumeHandler(testServer, &service.Services{Resume: service.NewResumeService(testServer, repos)})
+		withClaims := func(next echo.HandlerFunc) echo.HandlerFunc {
+			return func(c echo.Context) error {
+				c.Set(middleware.UserIDKey, "user_stale")
+				c.Set(middleware.OrganizationIDKey, "org_2")
+				c.Set(middleware.UserRoleKey, "org:member")
+				c.Set(middleware.PermissionsKey, []string{"org:resumes:read"})
+				return next(c)
+			}
+		}
+		e.GET("/organization/resumes", resumeHandler.GetOrganizationResumes, withClaims, middleware.NewOrganizationMiddleware(testServer, organizationService).SyncMembership)
+
+		visibleResumes := func(t *testing.T) []string {
+			t.Helper()
+
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/organization/resumes", nil))
+			require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+			var page struct {
+				Data []struct {
+					ID string `json:"id"`
+				} `json:"data"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &page))
+
+			ids := []string{}
+			for _, r := range page.Data {
+				ids = append(ids, r.ID)
+			}
+			return ids
+		}
+		member := func(eventType string) string {
+			return `{"type":"` + eventType + `","data":{"organization":{"id":"org_2"},"public_user_data":{"user_id":"user_stale"},"role":"org:member","permissions":["org:resumes:read"]}}`
+		}
+
+		// The first request mirrors the membership from the claims
+		assert.Equal(t, []string{resumeID}, visibleResumes(t))
+		assert.Equal(t, 1, count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_2' AND user_id = 'user_stale'`))
+
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_stale_deleted", member("organizationMembership.deleted")))
+
+		// The claims still name org_2 until the session is refreshed
+		assert.Empty(t, visibleResumes(t))
+		assert.Zero(t, count(t, `SELECT COUNT(*) FROM organization_members WHERE organization_id = 'org_2' AND user_id = 'user_stale'`))
+
+		// Clerk adding the member again restores access
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_stale_created", member("organizationMembership.created")))
+		assert.Equal(t, []string{resumeID}, visibleResumes(t))
+	})
+
+	t.Run("rejects a membership event without a user", func(t *testing.T) {
+		body := `{"type":"organizationMembership.deleted","data":{"organization":{"id":"org_1"}}}`
+		assert.Equal(t, http.StatusBadRequest, send(t, "msg_member_invalid", body))
+	})
+
+	t.Run("acknowledges other event types", func(t *testing.T) {
+		body := `{"type":"session.created","data":{"id":"sess_1"}}`
+		assert.Equal(t, http.StatusNoContent, send(t, "msg_session", body))
+	})
+}
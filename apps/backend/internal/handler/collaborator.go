@@ -0,0 +1,191 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type CollaboratorHandler struct {
+	Handler
+	collaboratorService *service.CollaboratorService
+}
+
+func NewCollaboratorHandler(s *server.Server, collaboratorService *service.CollaboratorService) *CollaboratorHandler {
+	return &CollaboratorHandler{
+		Handler:             NewHandler(s),
+		collaboratorService: collaboratorService,
+	}
+}
+
+// InviteCollaborator invites someone by email to work on a resume
+func (h *CollaboratorHandler) InviteCollaborator(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *InviteCollaboratorRequest) (*collaborator.CollaboratorResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.collaboratorService.InviteCollaborator(c.Request().Context(), userID, resumeID, req.InviteCollaboratorRequest)
+		},
+		http.StatusCreated,
+		&InviteCollaboratorRequest{},
+	)(c)
+}
+
+// GetCollaborators lists the collaborators and pending invitations of a resume
+func (h *CollaboratorHandler) GetCollaborators(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetCollaboratorsRequest) ([]collaborator.CollaboratorResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.collaboratorService.GetCollaborators(c.Request().Context(), userID, resumeID)
+		},
+		http.StatusOK,
+		&GetCollaboratorsRequest{},
+	)(c)
+}
+
+// UpdateCollaboratorRole changes a collaborator's role
+func (h *CollaboratorHandler) UpdateCollaboratorRole(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateCollaboratorRequest) (*collaborator.CollaboratorResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, collaboratorID, err := req.ParseIDs()
+			if err != nil {
+				return nil, err
+			}
+			return h.collaboratorService.UpdateCollaboratorRole(c.Request().Context(), userID, resumeID, collaboratorID, req.UpdateCollaboratorRequest)
+		},
+		http.StatusOK,
+		&UpdateCollaboratorRequest{},
+	)(c)
+}
+
+// RevokeCollaborator removes a collaborator or withdraws a pending invitation
+func (h *CollaboratorHandler) RevokeCollaborator(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *RevokeCollaboratorRequest) error {
+			userID := middleware.GetUserID(c)
+			resumeID, collaboratorID, err := req.ParseIDs()
+			if err != nil {
+				return err
+			}
+			return h.collaboratorService.RevokeCollaborator(c.Request().Context(), userID, resumeID, collaboratorID)
+		},
+		http.StatusNoContent,
+		&RevokeCollaboratorRequest{},
+	)(c)
+}
+
+// AcceptInvitation grants the invited role to the current user
+func (h *CollaboratorHandler) AcceptInvitation(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *AcceptInvitationRequest) (*collaborator.CollaboratorResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.collaboratorService.AcceptInvitation(c.Request().Context(), userID, req.Token)
+		},
+		http.StatusOK,
+		&AcceptInvitationRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type InviteCollaboratorRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+	*collaborator.InviteCollaboratorRequest
+}
+
+func (r *InviteCollaboratorRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.InviteCollaboratorRequest.Validate()
+}
+
+func (r *InviteCollaboratorRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type GetCollaboratorsRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetCollaboratorsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetCollaboratorsRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type UpdateCollaboratorRequest struct {
+	ResumeID       string `param:"id" validate:"required,uuid"`
+	CollaboratorID string `param:"collaboratorId" validate:"required,uuid"`
+	*collaborator.UpdateCollaboratorRequest
+}
+
+func (r *UpdateCollaboratorRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateCollaboratorRequest.Validate()
+}
+
+func (r *UpdateCollaboratorRequest) ParseIDs() (uuid.UUID, uuid.UUID, error) {
+	return parseCollaboratorIDs(r.ResumeID, r.CollaboratorID)
+}
+
+type RevokeCollaboratorRequest struct {
+	ResumeID       string `param:"id" validate:"required,uuid"`
+	CollaboratorID string `param:"collaboratorId" validate:"required,uuid"`
+}
+
+func (r *RevokeCollaboratorRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *RevokeCollaboratorRequest) ParseIDs() (uuid.UUID, uuid.UUID, error) {
+	return parseCollaboratorIDs(r.ResumeID, r.CollaboratorID)
+}
+
+type AcceptInvitationRequest struct {
+	Token string `param:"token" validate:"required,max=100"`
+}
+
+func (r *AcceptInvitationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func parseCollaboratorIDs(resumeID, collaboratorID string) (uuid.UUID, uuid.UUID, error) {
+	parsedResumeID, err := uuid.Parse(resumeID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	parsedCollaboratorID, err := uuid.Parse(collaboratorID)
+	if err != nil {
+		return uuid.Nil, uuid.Nil, err
+	}
+	return parsedResumeID, parsedCollaboratorID, nil
+}
@@ -0,0 +1,221 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/comment"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type CommentHandler struct {
+	Handler
+	commentService *service.CommentService
+}
+
+func NewCommentHandler(s *server.Server, commentService *service.CommentService) *CommentHandler {
+	return &CommentHandler{
+		Handler:        NewHandler(s),
+		commentService: commentService,
+	}
+}
+
+// CreateComment starts a comment thread on a resume entity or replies to one
+func (h *CommentHandler) CreateComment(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *CreateCommentRequest) (*comment.CommentResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.commentService.CreateComment(c.Request().Context(), userID, resumeID, req.CreateCommentRequest)
+		},
+		http.StatusCreated,
+		&CreateCommentRequest{},
+	)(c)
+}
+
+// GetCommentsByResumeID lists the comment threads of a resume
+func (h *CommentHandler) GetCommentsByResumeID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetCommentsByResumeIDRequest) ([]comment.CommentResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.commentService.GetCommentsByResumeID(c.Request().Context(), userID, resumeID, req.filter())
+		},
+		http.StatusOK,
+		&GetCommentsByResumeIDRequest{},
+	)(c)
+}
+
+// GetCommentsByEntity lists the comment threads anchored to one resume entity
+func (h *CommentHandler) GetCommentsByEntity(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetCommentsByEntityRequest) ([]comment.CommentResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			entityID, err := uuid.Parse(req.EntityID)
+			if err != nil {
+				return nil, err
+			}
+			return h.commentService.GetCommentsByEntity(c.Request().Context(), userID, resumeID, comment.EntityType(req.EntityType), entityID, req.filter())
+		},
+		http.StatusOK,
+		&GetCommentsByEntityRequest{},
+	)(c)
+}
+
+// UpdateComment edits the body of the user's comment
+func (h *CommentHandler) UpdateComment(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateCommentRequest) (*comment.CommentResponse, error) {
+			userID := middleware.GetUserID(c)
+			commentID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.commentService.UpdateComment(c.Request().Context(), userID, commentID, req.UpdateCommentRequest)
+		},
+		http.StatusOK,
+		&UpdateCommentRequest{},
+	)(c)
+}
+
+// ResolveComment marks a comment thread as resolved
+func (h *CommentHandler) ResolveComment(c echo.Context) error {
+	return h.setResolved(c, true)
+}
+
+// UnresolveComment reopens a resolved comment thread
+func (h *CommentHandler) UnresolveComment(c echo.Context) error {
+	return h.setResolved(c, false)
+}
+
+func (h *CommentHandler) setResolved(c echo.Context, resolved bool) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *CommentIDRequest) (*comment.CommentResponse, error) {
+			userID := middleware.GetUserID(c)
+			commentID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.commentService.SetCommentResolved(c.Request().Context(), userID, commentID, resolved)
+		},
+		http.StatusOK,
+		&CommentIDRequest{},
+	)(c)
+}
+
+// DeleteComment deletes a comment and its replies
+func (h *CommentHandler) DeleteComment(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *CommentIDRequest) error {
+			userID := middleware.GetUserID(c)
+			commentID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.commentService.DeleteComment(c.Request().Context(), userID, commentID)
+		},
+		http.StatusNoContent,
+		&CommentIDRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type CreateCommentRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+	*comment.CreateCommentRequest
+}
+
+func (r *CreateCommentRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.CreateCommentRequest.Validate()
+}
+
+func (r *CreateCommentRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type GetCommentsByResumeIDRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+	Status   string `query:"status" validate:"omitempty,oneof=active open resolved archived all"`
+}
+
+func (r *GetCommentsByResumeIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetCommentsByResumeIDRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+func (r *GetCommentsByResumeIDRequest) filter() string {
+	if r.Status == "" {
+		return comment.FilterActive
+	}
+	return r.Status
+}
+
+type GetCommentsByEntityRequest struct {
+	GetCommentsByResumeIDRequest
+	EntityType string `param:"entityType" validate:"required,oneof=resume section education experience project skill certification"`
+	EntityID   string `param:"entityId" validate:"required,uuid"`
+}
+
+func (r *GetCommentsByEntityRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type UpdateCommentRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*comment.UpdateCommentRequest
+}
+
+func (r *UpdateCommentRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateCommentRequest.Validate()
+}
+
+func (r *UpdateCommentRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type CommentIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *CommentIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *CommentIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
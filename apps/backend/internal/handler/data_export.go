@@ -0,0 +1,107 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/dataexport"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type DataExportHandler struct {
+	Handler
+	dataExportService *service.DataExportService
+}
+
+func NewDataExportHandler(s *server.Server, dataExportService *service.DataExportService) *DataExportHandler {
+	return &DataExportHandler{
+		Handler:           NewHandler(s),
+		dataExportService: dataExportService,
+	}
+}
+
+// RequestExport starts an export of everything the user owns
+func (h *DataExportHandler) RequestExport(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *RequestDataExportRequest) (*dataexport.DataExportResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.dataExportService.RequestExport(c.Request().Context(), userID)
+		},
+		http.StatusAccepted,
+		&RequestDataExportRequest{},
+	)(c)
+}
+
+// GetExport reports the progress of one of the user's data exports
+func (h *DataExportHandler) GetExport(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *DataExportIDRequest) (*dataexport.DataExportResponse, error) {
+			userID := middleware.GetUserID(c)
+			exportID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.dataExportService.GetExport(c.Request().Context(), userID, exportID)
+		},
+		http.StatusOK,
+		&DataExportIDRequest{},
+	)(c)
+}
+
+// DownloadExport serves a ready export to the holder of a signed link
+func (h *DataExportHandler) DownloadExport(c echo.Context) error {
+	return HandleFile(
+		h.Handler,
+		func(c echo.Context, req *DownloadDataExportRequest) ([]byte, error) {
+			exportID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.dataExportService.DownloadExport(c.Request().Context(), exportID, req.Expires, req.Signature)
+		},
+		http.StatusOK,
+		&DownloadDataExportRequest{},
+		"resumify-data-export.zip",
+		"application/zip",
+	)(c)
+}
+
+// Request DTOs
+
+// RequestDataExportRequest is the empty request for exporting the
+// authenticated user's data
+type RequestDataExportRequest struct{}
+
+func (r *RequestDataExportRequest) Validate() error {
+	return nil
+}
+
+type DataExportIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DataExportIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DataExportIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DownloadDataExportRequest struct {
+	DataExportIDRequest
+	Expires   string `query:"expires" validate:"required,numeric"`
+	Signature string `query:"signature" validate:"required,hexadecimal"`
+}
+
+func (r *DownloadDataExportRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -77,7 +77,7 @@ func (h *EducationHandler) UpdateEducation(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.educationService.UpdateEducation(c.Request().Context(), userID, educationID, req.UpdateEducationRequest)
+			return h.educationService.UpdateEducation(c.Request().Context(), userID, educationID, ifMatch(c), req.UpdateEducationRequest)
 		},
 		http.StatusOK,
 		&UpdateEducationRequest{},
@@ -105,7 +105,7 @@ func (h *EducationHandler) DeleteEducation(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.educationService.DeleteEducation(c.Request().Context(), userID, educationID)
+			return h.educationService.DeleteEducation(c.Request().Context(), userID, educationID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteEducationRequest{},
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/education"
+)
+
+func (h *EducationHandler) CreateLibraryEducation(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *education.CreateLibraryEducationRequest) (*education.LibraryEducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.educationService.CreateLibraryEducation(c.Request().Context(), userID, payload)
+		},
+		http.StatusCreated,
+		&education.CreateLibraryEducationRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) GetLibraryEducationByUserID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListLibraryItemsRequest) ([]education.LibraryEducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.educationService.GetLibraryEducationByUserID(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListLibraryItemsRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) GetLibraryEducationByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetLibraryEducationByIDRequest) (*education.LibraryEducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.educationService.GetLibraryEducationByID(c.Request().Context(), userID, libraryItemID)
+		},
+		http.StatusOK,
+		&GetLibraryEducationByIDRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) UpdateLibraryEducation(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateLibraryEducationRequest) (*education.LibraryEducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.educationService.UpdateLibraryEducation(c.Request().Context(), userID, libraryItemID, ifMatch(c), req.UpdateLibraryEducationRequest)
+		},
+		http.StatusOK,
+		&UpdateLibraryEducationRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) DeleteLibraryEducation(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *DeleteLibraryEducationRequest) error {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.educationService.DeleteLibraryEducation(c.Request().Context(), userID, libraryItemID, ifMatch(c))
+		},
+		http.StatusNoContent,
+		&DeleteLibraryEducationRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) LinkLibraryEducation(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *LinkLibraryEducationRequest) (*education.EducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.educationService.LinkLibraryEducation(c.Request().Context(), userID, libraryItemID, req.LinkLibraryEducationRequest)
+		},
+		http.StatusCreated,
+		&LinkLibraryEducationRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) PromoteEducation(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *PromoteEducationRequest) (*education.LibraryEducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			educationID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.educationService.PromoteEducation(c.Request().Context(), userID, educationID)
+		},
+		http.StatusCreated,
+		&PromoteEducationRequest{},
+	)(c)
+}
+
+func (h *EducationHandler) ResetEducationOverrides(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResetEducationOverridesRequest) (*education.EducationResponse, error) {
+			userID := middleware.GetUserID(c)
+			educationID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.educationService.ResetEducationOverrides(c.Request().Context(), userID, educationID, ifMatch(c))
+		},
+		http.StatusOK,
+		&ResetEducationOverridesRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetLibraryEducationByIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetLibraryEducationByIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetLibraryEducationByIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateLibraryEducationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*education.UpdateLibraryEducationRequest
+}
+
+func (r *UpdateLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateLibraryEducationRequest.Validate()
+}
+
+func (r *UpdateLibraryEducationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DeleteLibraryEducationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DeleteLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DeleteLibraryEducationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type LinkLibraryEducationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*education.LinkLibraryEducationRequest
+}
+
+func (r *LinkLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.LinkLibraryEducationRequest.Validate()
+}
+
+func (r *LinkLibraryEducationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type PromoteEducationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *PromoteEducationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *PromoteEducationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResetEducationOverridesRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResetEducationOverridesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResetEducationOverridesRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
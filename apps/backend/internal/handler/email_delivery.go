@@ -0,0 +1,62 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/emaildelivery"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type EmailDeliveryHandler struct {
+	Handler
+	emailDeliveryService *service.EmailDeliveryService
+}
+
+func NewEmailDeliveryHandler(s *server.Server, emailDeliveryService *service.EmailDeliveryService) *EmailDeliveryHandler {
+	return &EmailDeliveryHandler{
+		Handler:              NewHandler(s),
+		emailDeliveryService: emailDeliveryService,
+	}
+}
+
+// GetDeliveries lists the emails sent to the user
+func (h *EmailDeliveryHandler) GetDeliveries(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetEmailDeliveriesRequest) (*model.PaginatedResponse[emaildelivery.DeliveryResponse], error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+			return h.emailDeliveryService.GetDeliveries(c.Request().Context(), userID, page, limit)
+		},
+		http.StatusOK,
+		&GetEmailDeliveriesRequest{},
+	)(c)
+}
+
+// HandleResendEvent applies a delivery status webhook sent by Resend
+func (h *EmailDeliveryHandler) HandleResendEvent(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *emaildelivery.ResendEvent) error {
+			return h.emailDeliveryService.HandleResendEvent(c.Request().Context(), req)
+		},
+		http.StatusNoContent,
+		&emaildelivery.ResendEvent{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetEmailDeliveriesRequest struct {
+	GetResumesRequest
+}
+
+func (r *GetEmailDeliveriesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,116 @@
+package handler
+
+import (
+	"net/http"
+	"slices"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/email"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// EmailPreviewHandler renders the email templates with their preview data for
+// template development. Its routes are only registered in the local
+// environment.
+type EmailPreviewHandler struct {
+	Handler
+}
+
+func NewEmailPreviewHandler(s *server.Server) *EmailPreviewHandler {
+	return &EmailPreviewHandler{
+		Handler: NewHandler(s),
+	}
+}
+
+// ListTemplates lists every email template with the links to its previews
+func (h *EmailPreviewHandler) ListTemplates(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListEmailTemplatesRequest) ([]EmailTemplateResponse, error) {
+			templates := email.Templates()
+			responses := make([]EmailTemplateResponse, len(templates))
+			for i, name := range templates {
+				responses[i] = EmailTemplateResponse{
+					Name:    name,
+					HTMLURL: "/dev/emails/" + string(name),
+					TextURL: "/dev/emails/" + string(name) + "/text",
+				}
+			}
+			return responses, nil
+		},
+		http.StatusOK,
+		&ListEmailTemplatesRequest{},
+	)(c)
+}
+
+// PreviewHTML renders a template as HTML. Query parameters override the
+// template's preview data.
+func (h *EmailPreviewHandler) PreviewHTML(c echo.Context) error {
+	return HandleInline(
+		h.Handler,
+		func(c echo.Context, req *EmailPreviewRequest) ([]byte, error) {
+			html, _, err := h.preview(c, req)
+			return []byte(html), err
+		},
+		http.StatusOK,
+		&EmailPreviewRequest{},
+		echo.MIMETextHTMLCharsetUTF8,
+	)(c)
+}
+
+// PreviewText renders the plain-text alternative of a template. Query
+// parameters override the template's preview data.
+func (h *EmailPreviewHandler) PreviewText(c echo.Context) error {
+	return HandleInline(
+		h.Handler,
+		func(c echo.Context, req *EmailPreviewRequest) ([]byte, error) {
+			_, text, err := h.preview(c, req)
+			return []byte(text), err
+		},
+		http.StatusOK,
+		&EmailPreviewRequest{},
+		echo.MIMETextPlainCharsetUTF8,
+	)(c)
+}
+
+func (h *EmailPreviewHandler) preview(c echo.Context, req *EmailPreviewRequest) (string, string, error) {
+	name := email.Template(req.Template)
+	if !slices.Contains(email.Templates(), name) {
+		return "", "", errs.NewNotFoundError("email template not found", false, nil)
+	}
+
+	overrides := make(map[string]string)
+	for field, values := range c.QueryParams() {
+		overrides[field] = values[0]
+	}
+
+	return email.Preview(name, overrides)
+}
+
+// Request DTOs
+
+// ListEmailTemplatesRequest is the empty request for listing email templates
+type ListEmailTemplatesRequest struct{}
+
+func (r *ListEmailTemplatesRequest) Validate() error {
+	return nil
+}
+
+type EmailPreviewRequest struct {
+	Template string `param:"template" validate:"required"`
+}
+
+func (r *EmailPreviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Response DTOs
+
+type EmailTemplateResponse struct {
+	Name    email.Template `json:"name"`
+	HTMLURL string         `json:"html_url"`
+	TextURL string         `json:"text_url"`
+}
@@ -0,0 +1,78 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/erasure"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type ErasureHandler struct {
+	Handler
+	erasureService *service.ErasureService
+}
+
+func NewErasureHandler(s *server.Server, erasureService *service.ErasureService) *ErasureHandler {
+	return &ErasureHandler{
+		Handler:        NewHandler(s),
+		erasureService: erasureService,
+	}
+}
+
+// RequestErasure starts erasing everything the user owns
+func (h *ErasureHandler) RequestErasure(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *RequestErasureRequest) (*erasure.ErasureResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.erasureService.RequestErasure(c.Request().Context(), userID, erasure.SourceUser)
+		},
+		http.StatusAccepted,
+		&RequestErasureRequest{},
+	)(c)
+}
+
+// GetErasure returns the receipt of one of the user's erasures
+func (h *ErasureHandler) GetErasure(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ErasureIDRequest) (*erasure.ErasureResponse, error) {
+			userID := middleware.GetUserID(c)
+			erasureID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.erasureService.GetErasure(c.Request().Context(), userID, erasureID)
+		},
+		http.StatusOK,
+		&ErasureIDRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+// RequestErasureRequest is the empty request for erasing the authenticated
+// user's account
+type RequestErasureRequest struct{}
+
+func (r *RequestErasureRequest) Validate() error {
+	return nil
+}
+
+type ErasureIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ErasureIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ErasureIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
@@ -77,7 +77,7 @@ func (h *ExperienceHandler) UpdateExperience(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.experienceService.UpdateExperience(c.Request().Context(), userID, experienceID, req.UpdateExperienceRequest)
+			return h.experienceService.UpdateExperience(c.Request().Context(), userID, experienceID, ifMatch(c), req.UpdateExperienceRequest)
 		},
 		http.StatusOK,
 		&UpdateExperienceRequest{},
@@ -105,7 +105,7 @@ func (h *ExperienceHandler) DeleteExperience(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.experienceService.DeleteExperience(c.Request().Context(), userID, experienceID)
+			return h.experienceService.DeleteExperience(c.Request().Context(), userID, experienceID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteExperienceRequest{},
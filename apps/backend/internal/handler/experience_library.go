@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+)
+
+func (h *ExperienceHandler) CreateLibraryExperience(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *experience.CreateLibraryExperienceRequest) (*experience.LibraryExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.experienceService.CreateLibraryExperience(c.Request().Context(), userID, payload)
+		},
+		http.StatusCreated,
+		&experience.CreateLibraryExperienceRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) GetLibraryExperienceByUserID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListLibraryItemsRequest) ([]experience.LibraryExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.experienceService.GetLibraryExperienceByUserID(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListLibraryItemsRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) GetLibraryExperienceByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetLibraryExperienceByIDRequest) (*experience.LibraryExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.experienceService.GetLibraryExperienceByID(c.Request().Context(), userID, libraryItemID)
+		},
+		http.StatusOK,
+		&GetLibraryExperienceByIDRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) UpdateLibraryExperience(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateLibraryExperienceRequest) (*experience.LibraryExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.experienceService.UpdateLibraryExperience(c.Request().Context(), userID, libraryItemID, ifMatch(c), req.UpdateLibraryExperienceRequest)
+		},
+		http.StatusOK,
+		&UpdateLibraryExperienceRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) DeleteLibraryExperience(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *DeleteLibraryExperienceRequest) error {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.experienceService.DeleteLibraryExperience(c.Request().Context(), userID, libraryItemID, ifMatch(c))
+		},
+		http.StatusNoContent,
+		&DeleteLibraryExperienceRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) LinkLibraryExperience(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *LinkLibraryExperienceRequest) (*experience.ExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.experienceService.LinkLibraryExperience(c.Request().Context(), userID, libraryItemID, req.LinkLibraryExperienceRequest)
+		},
+		http.StatusCreated,
+		&LinkLibraryExperienceRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) PromoteExperience(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *PromoteExperienceRequest) (*experience.LibraryExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			experienceID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.experienceService.PromoteExperience(c.Request().Context(), userID, experienceID)
+		},
+		http.StatusCreated,
+		&PromoteExperienceRequest{},
+	)(c)
+}
+
+func (h *ExperienceHandler) ResetExperienceOverrides(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResetExperienceOverridesRequest) (*experience.ExperienceResponse, error) {
+			userID := middleware.GetUserID(c)
+			experienceID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.experienceService.ResetExperienceOverrides(c.Request().Context(), userID, experienceID, ifMatch(c))
+		},
+		http.StatusOK,
+		&ResetExperienceOverridesRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetLibraryExperienceByIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetLibraryExperienceByIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetLibraryExperienceByIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateLibraryExperienceRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*experience.UpdateLibraryExperienceRequest
+}
+
+func (r *UpdateLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateLibraryExperienceRequest.Validate()
+}
+
+func (r *UpdateLibraryExperienceRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DeleteLibraryExperienceRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DeleteLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DeleteLibraryExperienceRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type LinkLibraryExperienceRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*experience.LinkLibraryExperienceRequest
+}
+
+func (r *LinkLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.LinkLibraryExperienceRequest.Validate()
+}
+
+func (r *LinkLibraryExperienceRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type PromoteExperienceRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *PromoteExperienceRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *PromoteExperienceRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResetExperienceOverridesRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResetExperienceOverridesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResetExperienceOverridesRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
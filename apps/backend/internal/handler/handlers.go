@@ -14,6 +14,19 @@ type Handlers struct {
 	Skill         *SkillHandler
 	Certification *CertificationHandler
 	Section       *SectionHandler
+	Collaborator  *CollaboratorHandler
+	Comment       *CommentHandler
+	Review        *ReviewHandler
+	Audit         *AuditHandler
+	Webhook       *WebhookHandler
+	ClerkWebhook  *ClerkWebhookHandler
+	DataExport    *DataExportHandler
+	Erasure       *ErasureHandler
+	EmailPreview  *EmailPreviewHandler
+	EmailDelivery *EmailDeliveryHandler
+	Notification  *NotificationHandler
+	ResumeExport  *ResumeExportHandler
+	Storage       *StorageHandler
 	OpenAPI       *OpenAPIHandler
 }
 
@@ -27,6 +40,19 @@ func NewHandlers(s *server.Server, services *service.Services) *Handlers {
 		Skill:         NewSkillHandler(s, services.Skill),
 		Certification: NewCertificationHandler(s, services.Certification),
 		Section:       NewSectionHandler(s, services.Section),
+		Collaborator:  NewCollaboratorHandler(s, services.Collaborator),
+		Comment:       NewCommentHandler(s, services.Comment),
+		Review:        NewReviewHandler(s, services.Review),
+		Audit:         NewAuditHandler(s, services.Audit),
+		Webhook:       NewWebhookHandler(s, services.Webhook),
+		ClerkWebhook:  NewClerkWebhookHandler(s, services.User),
+		DataExport:    NewDataExportHandler(s, services.DataExport),
+		Erasure:       NewErasureHandler(s, services.Erasure),
+		EmailPreview:  NewEmailPreviewHandler(s),
+		EmailDelivery: NewEmailDeliveryHandler(s, services.EmailDelivery),
+		Notification:  NewNotificationHandler(s, services.Notification),
+		ResumeExport:  NewResumeExportHandler(s, services.ResumeExport),
+		Storage:       NewStorageHandler(s, services.Storage),
 		OpenAPI:       NewOpenAPIHandler(s),
 	}
 }
@@ -0,0 +1,9 @@
+package handler
+
+// ListLibraryItemsRequest is the empty request for career library listings,
+// which are always scoped to the authenticated user
+type ListLibraryItemsRequest struct{}
+
+func (r *ListLibraryItemsRequest) Validate() error {
+	return nil
+}
@@ -0,0 +1,82 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/notification"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type NotificationHandler struct {
+	Handler
+	notificationService *service.NotificationService
+}
+
+func NewNotificationHandler(s *server.Server, notificationService *service.NotificationService) *NotificationHandler {
+	return &NotificationHandler{
+		Handler:             NewHandler(s),
+		notificationService: notificationService,
+	}
+}
+
+// GetPreferences returns the user's notification preferences
+func (h *NotificationHandler) GetPreferences(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetNotificationPreferencesRequest) (*notification.PreferencesResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.notificationService.GetPreferences(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&GetNotificationPreferencesRequest{},
+	)(c)
+}
+
+// UpdatePreferences changes the user's notification preferences
+func (h *NotificationHandler) UpdatePreferences(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *notification.UpdatePreferencesRequest) (*notification.PreferencesResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.notificationService.UpdatePreferences(c.Request().Context(), userID, req)
+		},
+		http.StatusOK,
+		&notification.UpdatePreferencesRequest{},
+	)(c)
+}
+
+// Unsubscribe applies a signed unsubscribe link. It needs no session, so it
+// also serves one-click List-Unsubscribe requests from mail clients.
+func (h *NotificationHandler) Unsubscribe(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UnsubscribeRequest) (*notification.UnsubscribeResponse, error) {
+			return h.notificationService.Unsubscribe(c.Request().Context(), req.Token)
+		},
+		http.StatusOK,
+		&UnsubscribeRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+// GetNotificationPreferencesRequest is the empty request for getting the
+// user's notification preferences
+type GetNotificationPreferencesRequest struct{}
+
+func (r *GetNotificationPreferencesRequest) Validate() error {
+	return nil
+}
+
+type UnsubscribeRequest struct {
+	Token string `param:"token" validate:"required,max=1024"`
+}
+
+func (r *UnsubscribeRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
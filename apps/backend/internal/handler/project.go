@@ -77,7 +77,7 @@ func (h *ProjectHandler) UpdateProject(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.projectService.UpdateProject(c.Request().Context(), userID, projectID, req.UpdateProjectRequest)
+			return h.projectService.UpdateProject(c.Request().Context(), userID, projectID, ifMatch(c), req.UpdateProjectRequest)
 		},
 		http.StatusOK,
 		&UpdateProjectRequest{},
@@ -105,7 +105,7 @@ func (h *ProjectHandler) DeleteProject(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.projectService.DeleteProject(c.Request().Context(), userID, projectID)
+			return h.projectService.DeleteProject(c.Request().Context(), userID, projectID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteProjectRequest{},
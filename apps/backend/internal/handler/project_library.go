@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/project"
+)
+
+func (h *ProjectHandler) CreateLibraryProject(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *project.CreateLibraryProjectRequest) (*project.LibraryProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.projectService.CreateLibraryProject(c.Request().Context(), userID, payload)
+		},
+		http.StatusCreated,
+		&project.CreateLibraryProjectRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) GetLibraryProjectsByUserID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListLibraryItemsRequest) ([]project.LibraryProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.projectService.GetLibraryProjectsByUserID(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListLibraryItemsRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) GetLibraryProjectByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetLibraryProjectByIDRequest) (*project.LibraryProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.projectService.GetLibraryProjectByID(c.Request().Context(), userID, libraryItemID)
+		},
+		http.StatusOK,
+		&GetLibraryProjectByIDRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) UpdateLibraryProject(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateLibraryProjectRequest) (*project.LibraryProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.projectService.UpdateLibraryProject(c.Request().Context(), userID, libraryItemID, ifMatch(c), req.UpdateLibraryProjectRequest)
+		},
+		http.StatusOK,
+		&UpdateLibraryProjectRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) DeleteLibraryProject(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *DeleteLibraryProjectRequest) error {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.projectService.DeleteLibraryProject(c.Request().Context(), userID, libraryItemID, ifMatch(c))
+		},
+		http.StatusNoContent,
+		&DeleteLibraryProjectRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) LinkLibraryProject(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *LinkLibraryProjectRequest) (*project.ProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.projectService.LinkLibraryProject(c.Request().Context(), userID, libraryItemID, req.LinkLibraryProjectRequest)
+		},
+		http.StatusCreated,
+		&LinkLibraryProjectRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) PromoteProject(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *PromoteProjectRequest) (*project.LibraryProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			projectID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.projectService.PromoteProject(c.Request().Context(), userID, projectID)
+		},
+		http.StatusCreated,
+		&PromoteProjectRequest{},
+	)(c)
+}
+
+func (h *ProjectHandler) ResetProjectOverrides(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResetProjectOverridesRequest) (*project.ProjectResponse, error) {
+			userID := middleware.GetUserID(c)
+			projectID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.projectService.ResetProjectOverrides(c.Request().Context(), userID, projectID, ifMatch(c))
+		},
+		http.StatusOK,
+		&ResetProjectOverridesRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetLibraryProjectByIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetLibraryProjectByIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetLibraryProjectByIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateLibraryProjectRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*project.UpdateLibraryProjectRequest
+}
+
+func (r *UpdateLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateLibraryProjectRequest.Validate()
+}
+
+func (r *UpdateLibraryProjectRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DeleteLibraryProjectRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DeleteLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DeleteLibraryProjectRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type LinkLibraryProjectRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*project.LinkLibraryProjectRequest
+}
+
+func (r *LinkLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.LinkLibraryProjectRequest.Validate()
+}
+
+func (r *LinkLibraryProjectRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type PromoteProjectRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *PromoteProjectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *PromoteProjectRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResetProjectOverridesRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResetProjectOverridesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResetProjectOverridesRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
@@ -7,7 +7,10 @@ import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/lib/events"
 	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/composite"
 	"github.com/recreatedev/Resumify/internal/model/resume"
 	"github.com/recreatedev/Resumify/internal/server"
 	"github.com/recreatedev/Resumify/internal/service"
@@ -61,22 +64,7 @@ func (h *ResumeHandler) GetResumes(c echo.Context) error {
 		h.Handler,
 		func(c echo.Context, req *GetResumesRequest) (*PaginatedResumesResponse, error) {
 			userID := middleware.GetUserID(c)
-
-			// Parse pagination parameters
-			page := 1
-			limit := 20
-
-			if req.Page != "" {
-				if p, err := strconv.Atoi(req.Page); err == nil && p > 0 {
-					page = p
-				}
-			}
-
-			if req.Limit != "" {
-				if l, err := strconv.Atoi(req.Limit); err == nil && l > 0 && l <= 100 {
-					limit = l
-				}
-			}
+			page, limit := req.parsePagination()
 
 			result, err := h.service.GetResumes(c.Request().Context(), userID, page, limit)
 			if err != nil {
@@ -96,6 +84,20 @@ func (h *ResumeHandler) GetResumes(c echo.Context) error {
 	)(c)
 }
 
+// GetSharedResumes retrieves paginated list of resumes shared with the user
+func (h *ResumeHandler) GetSharedResumes(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetResumesRequest) (*model.PaginatedResponse[resume.SharedResumeResponse], error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+			return h.service.GetSharedResumes(c.Request().Context(), userID, page, limit)
+		},
+		http.StatusOK,
+		&GetResumesRequest{},
+	)(c)
+}
+
 // UpdateResume updates a resume
 func (h *ResumeHandler) UpdateResume(c echo.Context) error {
 	return Handle(
@@ -106,13 +108,30 @@ func (h *ResumeHandler) UpdateResume(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.service.UpdateResume(c.Request().Context(), userID, resumeID, req.UpdateResumeRequest)
+			return h.service.UpdateResume(c.Request().Context(), userID, resumeID, ifMatch(c), req.UpdateResumeRequest)
 		},
 		http.StatusOK,
 		&UpdateResumeRequest{},
 	)(c)
 }
 
+// SaveResumeDocument saves the whole resume document atomically
+func (h *ResumeHandler) SaveResumeDocument(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *SaveResumeDocumentRequest) (*composite.ResumeWithSections, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.service.SaveResumeDocument(c.Request().Context(), userID, resumeID, ifMatch(c), req.SaveResumeDocumentRequest)
+		},
+		http.StatusOK,
+		&SaveResumeDocumentRequest{},
+	)(c)
+}
+
 // DeleteResume deletes a resume
 func (h *ResumeHandler) DeleteResume(c echo.Context) error {
 	return HandleNoContent(
@@ -123,7 +142,7 @@ func (h *ResumeHandler) DeleteResume(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.service.DeleteResume(c.Request().Context(), userID, resumeID)
+			return h.service.DeleteResume(c.Request().Context(), userID, resumeID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteResumeRequest{},
@@ -164,6 +183,127 @@ func (h *ResumeHandler) GetResumeWithSections(c echo.Context) error {
 	)(c)
 }
 
+// GetDeletedResumes retrieves paginated list of trashed resumes
+func (h *ResumeHandler) GetDeletedResumes(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetResumesRequest) (*PaginatedResumesResponse, error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+
+			result, err := h.service.GetDeletedResumes(c.Request().Context(), userID, page, limit)
+			if err != nil {
+				return nil, err
+			}
+
+			return &PaginatedResumesResponse{
+				Data:       result.Data,
+				Page:       result.Page,
+				Limit:      result.Limit,
+				Total:      result.Total,
+				TotalPages: result.TotalPages,
+			}, nil
+		},
+		http.StatusOK,
+		&GetResumesRequest{},
+	)(c)
+}
+
+// RestoreResume moves a resume out of the trash
+func (h *ResumeHandler) RestoreResume(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *RestoreResumeRequest) (*resume.ResumeResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.service.RestoreResume(c.Request().Context(), userID, resumeID)
+		},
+		http.StatusOK,
+		&RestoreResumeRequest{},
+	)(c)
+}
+
+// GetOrganizationResumes retrieves paginated list of resumes in the active organization
+func (h *ResumeHandler) GetOrganizationResumes(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetResumesRequest) (*model.PaginatedResponse[resume.SharedResumeResponse], error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+			return h.service.GetOrganizationResumes(c.Request().Context(), userID, middleware.GetOrganizationID(c), page, limit)
+		},
+		http.StatusOK,
+		&GetResumesRequest{},
+	)(c)
+}
+
+// CreateOrganizationResume creates a new resume in the active organization
+func (h *ResumeHandler) CreateOrganizationResume(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *resume.CreateResumeRequest) (*resume.ResumeResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.service.CreateOrganizationResume(c.Request().Context(), userID, middleware.GetOrganizationID(c), req)
+		},
+		http.StatusCreated,
+		&resume.CreateResumeRequest{},
+	)(c)
+}
+
+// MoveResumeToOrganization moves a resume into the active organization
+func (h *ResumeHandler) MoveResumeToOrganization(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResumeOrganizationRequest) (*resume.ResumeResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			organizationID := middleware.GetOrganizationID(c)
+			return h.service.SetResumeOrganization(c.Request().Context(), userID, resumeID, &organizationID)
+		},
+		http.StatusOK,
+		&ResumeOrganizationRequest{},
+	)(c)
+}
+
+// RemoveResumeFromOrganization moves a resume back to the owner's personal workspace
+func (h *ResumeHandler) RemoveResumeFromOrganization(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResumeOrganizationRequest) (*resume.ResumeResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.service.SetResumeOrganization(c.Request().Context(), userID, resumeID, nil)
+		},
+		http.StatusOK,
+		&ResumeOrganizationRequest{},
+	)(c)
+}
+
+// StreamResumeEvents streams changes to a resume as Server-Sent Events
+func (h *ResumeHandler) StreamResumeEvents(c echo.Context) error {
+	return HandleEventStream(
+		h.Handler,
+		func(c echo.Context, req *StreamResumeEventsRequest) (*events.Subscription, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.service.SubscribeResumeEvents(c.Request().Context(), userID, resumeID, c.Request().Header.Get("Last-Event-ID"))
+		},
+		&StreamResumeEventsRequest{},
+	)(c)
+}
+
 // Request DTOs
 
 type GetResumeByIDRequest struct {
@@ -189,6 +329,25 @@ func (r *GetResumesRequest) Validate() error {
 	return validate.Struct(r)
 }
 
+func (r *GetResumesRequest) parsePagination() (int, int) {
+	page := 1
+	limit := 20
+
+	if r.Page != "" {
+		if p, err := strconv.Atoi(r.Page); err == nil && p > 0 {
+			page = p
+		}
+	}
+
+	if r.Limit != "" {
+		if l, err := strconv.Atoi(r.Limit); err == nil && l > 0 && l <= 100 {
+			limit = l
+		}
+	}
+
+	return page, limit
+}
+
 type UpdateResumeRequest struct {
 	ID string `param:"id" validate:"required,uuid"`
 	*resume.UpdateResumeRequest
@@ -206,6 +365,23 @@ func (r *UpdateResumeRequest) ParseID() (uuid.UUID, error) {
 	return uuid.Parse(r.ID)
 }
 
+type SaveResumeDocumentRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*composite.SaveResumeDocumentRequest
+}
+
+func (r *SaveResumeDocumentRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.SaveResumeDocumentRequest.Validate()
+}
+
+func (r *SaveResumeDocumentRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
 type DeleteResumeRequest struct {
 	ID string `param:"id" validate:"required,uuid"`
 }
@@ -232,6 +408,45 @@ func (r *DuplicateResumeRequest) ParseID() (uuid.UUID, error) {
 	return uuid.Parse(r.ID)
 }
 
+type RestoreResumeRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *RestoreResumeRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *RestoreResumeRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type StreamResumeEventsRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *StreamResumeEventsRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *StreamResumeEventsRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResumeOrganizationRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResumeOrganizationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResumeOrganizationRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
 // Response DTOs
 
 type PaginatedResumesResponse struct {
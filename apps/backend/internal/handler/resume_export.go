@@ -0,0 +1,109 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/resumeexport"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type ResumeExportHandler struct {
+	Handler
+	resumeExportService *service.ResumeExportService
+}
+
+func NewResumeExportHandler(s *server.Server, resumeExportService *service.ResumeExportService) *ResumeExportHandler {
+	return &ResumeExportHandler{
+		Handler:             NewHandler(s),
+		resumeExportService: resumeExportService,
+	}
+}
+
+// RequestExport queues a render of a resume in the requested format
+func (h *ResumeExportHandler) RequestExport(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *CreateResumeExportRequest) (*resumeexport.ResumeExportResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.resumeExportService.RequestExport(c.Request().Context(), userID, resumeID, req.CreateResumeExportRequest)
+		},
+		http.StatusAccepted,
+		&CreateResumeExportRequest{},
+	)(c)
+}
+
+// GetExport reports the progress of a resume export
+func (h *ResumeExportHandler) GetExport(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResumeExportIDRequest) (*resumeexport.ResumeExportResponse, error) {
+			userID := middleware.GetUserID(c)
+			exportID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.resumeExportService.GetExport(c.Request().Context(), userID, exportID)
+		},
+		http.StatusOK,
+		&ResumeExportIDRequest{},
+	)(c)
+}
+
+// DownloadExport streams the rendered resume of a done export
+func (h *ResumeExportHandler) DownloadExport(c echo.Context) error {
+	return HandleDownload(
+		h.Handler,
+		func(c echo.Context, req *ResumeExportIDRequest) (*model.Download, error) {
+			userID := middleware.GetUserID(c)
+			exportID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.resumeExportService.DownloadExport(c.Request().Context(), userID, exportID)
+		},
+		http.StatusOK,
+		&ResumeExportIDRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type CreateResumeExportRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+	*resumeexport.CreateResumeExportRequest
+}
+
+func (r *CreateResumeExportRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.CreateResumeExportRequest.Validate()
+}
+
+func (r *CreateResumeExportRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type ResumeExportIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResumeExportIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResumeExportIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
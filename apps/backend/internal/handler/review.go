@@ -0,0 +1,201 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/review"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type ReviewHandler struct {
+	Handler
+	reviewService *service.ReviewService
+}
+
+func NewReviewHandler(s *server.Server, reviewService *service.ReviewService) *ReviewHandler {
+	return &ReviewHandler{
+		Handler:       NewHandler(s),
+		reviewService: reviewService,
+	}
+}
+
+// SubmitReview submits the current version of a resume to a reviewer
+func (h *ReviewHandler) SubmitReview(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *SubmitReviewRequest) (*review.ReviewResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.reviewService.SubmitReview(c.Request().Context(), userID, resumeID, req.SubmitReviewRequest)
+		},
+		http.StatusCreated,
+		&SubmitReviewRequest{},
+	)(c)
+}
+
+// GetReviewsByResumeID lists the reviews of a resume
+func (h *ReviewHandler) GetReviewsByResumeID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetReviewsByResumeIDRequest) ([]review.ReviewResponse, error) {
+			userID := middleware.GetUserID(c)
+			resumeID, err := req.ParseResumeID()
+			if err != nil {
+				return nil, err
+			}
+			return h.reviewService.GetReviewsByResumeID(c.Request().Context(), userID, resumeID)
+		},
+		http.StatusOK,
+		&GetReviewsByResumeIDRequest{},
+	)(c)
+}
+
+// GetReviewQueue lists the reviews assigned to the current user
+func (h *ReviewHandler) GetReviewQueue(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetReviewQueueRequest) (*model.PaginatedResponse[review.ReviewResponse], error) {
+			userID := middleware.GetUserID(c)
+			page, limit := req.parsePagination()
+			return h.reviewService.GetReviewQueue(c.Request().Context(), userID, req.Status, page, limit)
+		},
+		http.StatusOK,
+		&GetReviewQueueRequest{},
+	)(c)
+}
+
+// GetReview retrieves a review with the submitted resume and its history
+func (h *ReviewHandler) GetReview(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetReviewRequest) (*review.ReviewResponse, error) {
+			userID := middleware.GetUserID(c)
+			reviewID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.reviewService.GetReview(c.Request().Context(), userID, reviewID)
+		},
+		http.StatusOK,
+		&GetReviewRequest{},
+	)(c)
+}
+
+// StartReview marks a submitted review as being worked on by the reviewer
+func (h *ReviewHandler) StartReview(c echo.Context) error {
+	return h.transition(c, review.StatusInReview)
+}
+
+// RequestChanges sends a review back to the submitter
+func (h *ReviewHandler) RequestChanges(c echo.Context) error {
+	return h.transition(c, review.StatusChangesRequested)
+}
+
+// ApproveReview approves the submitted version of the resume
+func (h *ReviewHandler) ApproveReview(c echo.Context) error {
+	return h.transition(c, review.StatusApproved)
+}
+
+// ResubmitReview submits the current version of the resume again after changes were requested
+func (h *ReviewHandler) ResubmitReview(c echo.Context) error {
+	return h.transition(c, review.StatusSubmitted)
+}
+
+// WithdrawReview withdraws a review before it is approved
+func (h *ReviewHandler) WithdrawReview(c echo.Context) error {
+	return h.transition(c, review.StatusWithdrawn)
+}
+
+func (h *ReviewHandler) transition(c echo.Context, to review.Status) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *TransitionReviewRequest) (*review.ReviewResponse, error) {
+			userID := middleware.GetUserID(c)
+			reviewID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.reviewService.TransitionReview(c.Request().Context(), userID, reviewID, to, &req.TransitionReviewRequest)
+		},
+		http.StatusOK,
+		&TransitionReviewRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type SubmitReviewRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+	*review.SubmitReviewRequest
+}
+
+func (r *SubmitReviewRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.SubmitReviewRequest.Validate()
+}
+
+func (r *SubmitReviewRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type GetReviewsByResumeIDRequest struct {
+	ResumeID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetReviewsByResumeIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetReviewsByResumeIDRequest) ParseResumeID() (uuid.UUID, error) {
+	return uuid.Parse(r.ResumeID)
+}
+
+type GetReviewQueueRequest struct {
+	GetResumesRequest
+	Status string `query:"status" validate:"omitempty,oneof=submitted in_review changes_requested approved withdrawn"`
+}
+
+func (r *GetReviewQueueRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type GetReviewRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetReviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetReviewRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type TransitionReviewRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	review.TransitionReviewRequest
+}
+
+func (r *TransitionReviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *TransitionReviewRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
@@ -77,7 +77,7 @@ func (h *SectionHandler) UpdateSection(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.sectionService.UpdateSection(c.Request().Context(), userID, sectionID, req.UpdateSectionRequest)
+			return h.sectionService.UpdateSection(c.Request().Context(), userID, sectionID, ifMatch(c), req.UpdateSectionRequest)
 		},
 		http.StatusOK,
 		&UpdateSectionRequest{},
@@ -105,7 +105,7 @@ func (h *SectionHandler) DeleteSection(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.sectionService.DeleteSection(c.Request().Context(), userID, sectionID)
+			return h.sectionService.DeleteSection(c.Request().Context(), userID, sectionID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteSectionRequest{},
@@ -93,7 +93,7 @@ func (h *SkillHandler) UpdateSkill(c echo.Context) error {
 			if err != nil {
 				return nil, err
 			}
-			return h.skillService.UpdateSkill(c.Request().Context(), userID, skillID, req.UpdateSkillRequest)
+			return h.skillService.UpdateSkill(c.Request().Context(), userID, skillID, ifMatch(c), req.UpdateSkillRequest)
 		},
 		http.StatusOK,
 		&UpdateSkillRequest{},
@@ -121,7 +121,7 @@ func (h *SkillHandler) DeleteSkill(c echo.Context) error {
 			if err != nil {
 				return err
 			}
-			return h.skillService.DeleteSkill(c.Request().Context(), userID, skillID)
+			return h.skillService.DeleteSkill(c.Request().Context(), userID, skillID, ifMatch(c))
 		},
 		http.StatusNoContent,
 		&DeleteSkillRequest{},
@@ -0,0 +1,219 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+)
+
+func (h *SkillHandler) CreateLibrarySkill(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, payload *skill.CreateLibrarySkillRequest) (*skill.LibrarySkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.skillService.CreateLibrarySkill(c.Request().Context(), userID, payload)
+		},
+		http.StatusCreated,
+		&skill.CreateLibrarySkillRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) GetLibrarySkillsByUserID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListLibraryItemsRequest) ([]skill.LibrarySkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.skillService.GetLibrarySkillsByUserID(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListLibraryItemsRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) GetLibrarySkillByID(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetLibrarySkillByIDRequest) (*skill.LibrarySkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.skillService.GetLibrarySkillByID(c.Request().Context(), userID, libraryItemID)
+		},
+		http.StatusOK,
+		&GetLibrarySkillByIDRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) UpdateLibrarySkill(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateLibrarySkillRequest) (*skill.LibrarySkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.skillService.UpdateLibrarySkill(c.Request().Context(), userID, libraryItemID, ifMatch(c), req.UpdateLibrarySkillRequest)
+		},
+		http.StatusOK,
+		&UpdateLibrarySkillRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) DeleteLibrarySkill(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *DeleteLibrarySkillRequest) error {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.skillService.DeleteLibrarySkill(c.Request().Context(), userID, libraryItemID, ifMatch(c))
+		},
+		http.StatusNoContent,
+		&DeleteLibrarySkillRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) LinkLibrarySkill(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *LinkLibrarySkillRequest) (*skill.SkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			libraryItemID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.skillService.LinkLibrarySkill(c.Request().Context(), userID, libraryItemID, req.LinkLibrarySkillRequest)
+		},
+		http.StatusCreated,
+		&LinkLibrarySkillRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) PromoteSkill(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *PromoteSkillRequest) (*skill.LibrarySkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			skillID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.skillService.PromoteSkill(c.Request().Context(), userID, skillID)
+		},
+		http.StatusCreated,
+		&PromoteSkillRequest{},
+	)(c)
+}
+
+func (h *SkillHandler) ResetSkillOverrides(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ResetSkillOverridesRequest) (*skill.SkillResponse, error) {
+			userID := middleware.GetUserID(c)
+			skillID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.skillService.ResetSkillOverrides(c.Request().Context(), userID, skillID, ifMatch(c))
+		},
+		http.StatusOK,
+		&ResetSkillOverridesRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type GetLibrarySkillByIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *GetLibrarySkillByIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *GetLibrarySkillByIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateLibrarySkillRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*skill.UpdateLibrarySkillRequest
+}
+
+func (r *UpdateLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateLibrarySkillRequest.Validate()
+}
+
+func (r *UpdateLibrarySkillRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type DeleteLibrarySkillRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *DeleteLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *DeleteLibrarySkillRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type LinkLibrarySkillRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*skill.LinkLibrarySkillRequest
+}
+
+func (r *LinkLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.LinkLibrarySkillRequest.Validate()
+}
+
+func (r *LinkLibrarySkillRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type PromoteSkillRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *PromoteSkillRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *PromoteSkillRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type ResetSkillOverridesRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *ResetSkillOverridesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *ResetSkillOverridesRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
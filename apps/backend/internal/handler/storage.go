@@ -0,0 +1,48 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type StorageHandler struct {
+	Handler
+	storageService *service.StorageService
+}
+
+func NewStorageHandler(s *server.Server, storageService *service.StorageService) *StorageHandler {
+	return &StorageHandler{
+		Handler:        NewHandler(s),
+		storageService: storageService,
+	}
+}
+
+// DownloadBlob streams a locally stored blob to the holder of a signed link
+func (h *StorageHandler) DownloadBlob(c echo.Context) error {
+	return HandleDownload(
+		h.Handler,
+		func(c echo.Context, req *DownloadBlobRequest) (*model.Download, error) {
+			return h.storageService.DownloadBlob(c.Request().Context(), req.Key, req.Expires, req.Signature)
+		},
+		http.StatusOK,
+		&DownloadBlobRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+type DownloadBlobRequest struct {
+	Key       string `param:"*" validate:"required"`
+	Expires   string `query:"expires" validate:"required,numeric"`
+	Signature string `query:"signature" validate:"required,hexadecimal"`
+}
+
+func (r *DownloadBlobRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
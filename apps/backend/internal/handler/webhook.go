@@ -0,0 +1,202 @@
+package handler
+
+import (
+	"net/http"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/middleware"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/webhook"
+	"github.com/recreatedev/Resumify/internal/server"
+	"github.com/recreatedev/Resumify/internal/service"
+)
+
+type WebhookHandler struct {
+	Handler
+	webhookService *service.WebhookService
+}
+
+func NewWebhookHandler(s *server.Server, webhookService *service.WebhookService) *WebhookHandler {
+	return &WebhookHandler{
+		Handler:        NewHandler(s),
+		webhookService: webhookService,
+	}
+}
+
+// CreateEndpoint registers a webhook endpoint and returns its signing secret
+func (h *WebhookHandler) CreateEndpoint(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *webhook.CreateEndpointRequest) (*webhook.EndpointResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.webhookService.CreateEndpoint(c.Request().Context(), userID, req)
+		},
+		http.StatusCreated,
+		&webhook.CreateEndpointRequest{},
+	)(c)
+}
+
+// GetEndpoints lists the user's webhook endpoints
+func (h *WebhookHandler) GetEndpoints(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *ListWebhookEndpointsRequest) ([]webhook.EndpointResponse, error) {
+			userID := middleware.GetUserID(c)
+			return h.webhookService.GetEndpoints(c.Request().Context(), userID)
+		},
+		http.StatusOK,
+		&ListWebhookEndpointsRequest{},
+	)(c)
+}
+
+// GetEndpoint retrieves one of the user's webhook endpoints
+func (h *WebhookHandler) GetEndpoint(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *WebhookEndpointIDRequest) (*webhook.EndpointResponse, error) {
+			userID := middleware.GetUserID(c)
+			endpointID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.webhookService.GetEndpoint(c.Request().Context(), userID, endpointID)
+		},
+		http.StatusOK,
+		&WebhookEndpointIDRequest{},
+	)(c)
+}
+
+// UpdateEndpoint changes a webhook endpoint
+func (h *WebhookHandler) UpdateEndpoint(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *UpdateWebhookEndpointRequest) (*webhook.EndpointResponse, error) {
+			userID := middleware.GetUserID(c)
+			endpointID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			return h.webhookService.UpdateEndpoint(c.Request().Context(), userID, endpointID, req.UpdateEndpointRequest)
+		},
+		http.StatusOK,
+		&UpdateWebhookEndpointRequest{},
+	)(c)
+}
+
+// DeleteEndpoint removes a webhook endpoint and its delivery log
+func (h *WebhookHandler) DeleteEndpoint(c echo.Context) error {
+	return HandleNoContent(
+		h.Handler,
+		func(c echo.Context, req *WebhookEndpointIDRequest) error {
+			userID := middleware.GetUserID(c)
+			endpointID, err := req.ParseID()
+			if err != nil {
+				return err
+			}
+			return h.webhookService.DeleteEndpoint(c.Request().Context(), userID, endpointID)
+		},
+		http.StatusNoContent,
+		&WebhookEndpointIDRequest{},
+	)(c)
+}
+
+// GetDeliveries lists the deliveries made to a webhook endpoint
+func (h *WebhookHandler) GetDeliveries(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *GetWebhookDeliveriesRequest) (*model.PaginatedResponse[webhook.DeliveryResponse], error) {
+			userID := middleware.GetUserID(c)
+			endpointID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			page, limit := req.parsePagination()
+			return h.webhookService.GetDeliveries(c.Request().Context(), userID, endpointID, page, limit)
+		},
+		http.StatusOK,
+		&GetWebhookDeliveriesRequest{},
+	)(c)
+}
+
+// RedeliverWebhook queues an earlier delivery to be sent again
+func (h *WebhookHandler) RedeliverWebhook(c echo.Context) error {
+	return Handle(
+		h.Handler,
+		func(c echo.Context, req *RedeliverWebhookRequest) (*webhook.DeliveryResponse, error) {
+			userID := middleware.GetUserID(c)
+			endpointID, err := req.ParseID()
+			if err != nil {
+				return nil, err
+			}
+			deliveryID, err := uuid.Parse(req.DeliveryID)
+			if err != nil {
+				return nil, err
+			}
+			return h.webhookService.RedeliverWebhook(c.Request().Context(), userID, endpointID, deliveryID)
+		},
+		http.StatusAccepted,
+		&RedeliverWebhookRequest{},
+	)(c)
+}
+
+// Request DTOs
+
+// ListWebhookEndpointsRequest is the empty request for listing the user's
+// webhook endpoints
+type ListWebhookEndpointsRequest struct{}
+
+func (r *ListWebhookEndpointsRequest) Validate() error {
+	return nil
+}
+
+type WebhookEndpointIDRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+}
+
+func (r *WebhookEndpointIDRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+func (r *WebhookEndpointIDRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type UpdateWebhookEndpointRequest struct {
+	ID string `param:"id" validate:"required,uuid"`
+	*webhook.UpdateEndpointRequest
+}
+
+func (r *UpdateWebhookEndpointRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+	return r.UpdateEndpointRequest.Validate()
+}
+
+func (r *UpdateWebhookEndpointRequest) ParseID() (uuid.UUID, error) {
+	return uuid.Parse(r.ID)
+}
+
+type GetWebhookDeliveriesRequest struct {
+	GetResumesRequest
+	WebhookEndpointIDRequest
+}
+
+func (r *GetWebhookDeliveriesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+type RedeliverWebhookRequest struct {
+	WebhookEndpointIDRequest
+	DeliveryID string `param:"deliveryId" validate:"required,uuid"`
+}
+
+func (r *RedeliverWebhookRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
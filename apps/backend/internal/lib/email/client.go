@@ -1,51 +1,184 @@
 package email
 
 import (
-	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
 	"fmt"
-	"html/template"
+	"maps"
+	"net/mail"
+	"strings"
 
-	"github.com/pkg/errors"
-	"github.com/resend/resend-go/v2"
-	"github.com/rs/zerolog"
+	"github.com/google/uuid"
 	"github.com/recreatedev/Resumify/internal/config"
+	"github.com/rs/zerolog"
 )
 
 type Client struct {
-	client *resend.Client
-	logger *zerolog.Logger
+	transport   Transport
+	deliveries  DeliveryLog
+	preferences PreferenceChecker
+	unsubscribe *UnsubscribeSigner
+	frontendURL string
+	publicURL   string
+	from        string
+	fromDomain  string
+	logger      *zerolog.Logger
 }
 
+// NewClient creates a client sending through the transport selected by the
+// config. The config is validated on load, so an unknown transport is a bug.
 func NewClient(cfg *config.Config, logger *zerolog.Logger) *Client {
+	transport, err := NewTransport(&cfg.Integration)
+	if err != nil {
+		panic(err)
+	}
+
+	return NewClientWithTransport(cfg, transport, logger)
+}
+
+// NewClientWithTransport creates a client sending through the given transport
+func NewClientWithTransport(cfg *config.Config, transport Transport, logger *zerolog.Logger) *Client {
+	from := mail.Address{
+		Name:    cfg.Integration.EmailFromName,
+		Address: cfg.Integration.EmailFromAddress,
+	}
+
+	domain := "localhost"
+	if at := strings.LastIndex(from.Address, "@"); at >= 0 {
+		domain = from.Address[at+1:]
+	}
+
 	return &Client{
-		client: resend.NewClient(cfg.Integration.ResendAPIKey),
-		logger: logger,
+		transport:   transport,
+		unsubscribe: NewUnsubscribeSigner(cfg.Auth.SecretKey),
+		frontendURL: cfg.Server.FrontendURL,
+		publicURL:   cfg.Server.PublicURL,
+		from:        from.String(),
+		fromDomain:  domain,
+		logger:      logger,
 	}
 }
 
-func (c *Client) SendEmail(to, subject string, templateName Template, data map[string]string) error {
-	tmplPath := fmt.Sprintf("%s/%s.html", "templates/emails", templateName)
+// SetDeliveryLog records every send in the delivery log. Without one, emails
+// are sent without being recorded or deduplicated.
+func (c *Client) SetDeliveryLog(deliveries DeliveryLog) {
+	c.deliveries = deliveries
+}
 
-	tmpl, err := template.ParseFiles(tmplPath)
-	if err != nil {
-		return errors.Wrapf(err, "failed to parse email template %s", templateName)
+// SetPreferences checks the users' notification preferences before sending
+// emails that are not transactional. Without them, every email is sent.
+func (c *Client) SetPreferences(preferences PreferenceChecker) {
+	c.preferences = preferences
+}
+
+// Outbox returns the in-memory outbox when the client uses the memory
+// transport, and nil otherwise
+func (c *Client) Outbox() *Outbox {
+	outbox, _ := c.transport.(*Outbox)
+	return outbox
+}
+
+// SendEmail renders the template with data and sends it with its plain-text
+// alternative. An envelope without idempotency key is sent every time.
+//
+// Emails to users carry a one-click unsubscribe link in their footer and
+// List-Unsubscribe headers. The link unsubscribes from the email's category,
+// or from every category for transactional emails. Emails of a category the
+// user opted out of are not sent.
+func (c *Client) SendEmail(ctx context.Context, envelope Envelope, subject string, templateName Template, data map[string]any) error {
+	if envelope.IdempotencyKey == "" {
+		envelope.IdempotencyKey = uuid.NewString()
 	}
 
-	var body bytes.Buffer
-	if err := tmpl.Execute(&body, data); err != nil {
-		return errors.Wrapf(err, "failed to execute email template %s", templateName)
+	category, optional := templateCategories[templateName]
+	if optional && envelope.UserID != "" && c.preferences != nil {
+		allowed, err := c.preferences.AllowsEmail(ctx, envelope.UserID, category)
+		if err != nil {
+			return fmt.Errorf("failed to check notification preferences: %w", err)
+		}
+		if !allowed {
+			c.logger.Info().
+				Str("template", string(templateName)).
+				Str("user_id", envelope.UserID).
+				Str("category", string(category)).
+				Msg("Skipping email the user unsubscribed from")
+			return nil
+		}
 	}
 
-	params := &resend.SendEmailRequest{
-		From:    fmt.Sprintf("%s <%s>", "Resumify", "onboarding@resend.dev"),
-		To:      []string{to},
-		Subject: subject,
-		Html:    body.String(),
+	var headers map[string]string
+	if envelope.UserID != "" {
+		token := c.unsubscribe.Sign(envelope.UserID, category)
+
+		data = maps.Clone(data)
+		data["UnsubscribeURL"] = c.frontendURL + "/unsubscribe/" + token
+		headers = map[string]string{
+			"List-Unsubscribe":      "<" + c.publicURL + "/unsubscribe/" + token + ">",
+			"List-Unsubscribe-Post": "List-Unsubscribe=One-Click",
+		}
 	}
 
-	_, err = c.client.Emails.Send(params)
+	html, text, err := Render(templateName, data)
 	if err != nil {
-		return fmt.Errorf("failed to send email: %w", err)
+		return err
+	}
+
+	if c.deliveries != nil {
+		err := c.deliveries.StartAttempt(ctx, &Delivery{
+			IdempotencyKey: envelope.IdempotencyKey,
+			UserID:         envelope.UserID,
+			Template:       templateName,
+			Recipient:      envelope.To,
+			Provider:       c.transport.Name(),
+		})
+		if errors.Is(err, ErrAlreadySent) {
+			c.logger.Info().
+				Str("template", string(templateName)).
+				Str("idempotency_key", envelope.IdempotencyKey).
+				Msg("Skipping email that was already sent")
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("failed to record email delivery: %w", err)
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		return fmt.Errorf("failed to generate message id: %w", err)
+	}
+
+	providerMessageID, sendErr := c.transport.Send(ctx, &Message{
+		ID:             hex.EncodeToString(id) + "@" + c.fromDomain,
+		IdempotencyKey: envelope.IdempotencyKey,
+		From:           c.from,
+		To:             []string{envelope.To},
+		Subject:        subject,
+		Headers:        headers,
+		HTML:           html,
+		Text:           text,
+	})
+
+	if c.deliveries != nil {
+		var err error
+		if sendErr != nil {
+			err = c.deliveries.RecordFailure(ctx, envelope.IdempotencyKey, sendErr)
+		} else {
+			err = c.deliveries.RecordSent(ctx, envelope.IdempotencyKey, providerMessageID)
+		}
+		if err != nil {
+			c.logger.Error().
+				Err(err).
+				Str("template", string(templateName)).
+				Str("idempotency_key", envelope.IdempotencyKey).
+				Msg("Failed to record email delivery outcome")
+		}
+	}
+
+	if sendErr != nil {
+		return fmt.Errorf("failed to send email: %w", sendErr)
 	}
 
 	return nil
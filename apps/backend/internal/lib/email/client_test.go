@@ -0,0 +1,129 @@
+package email
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/recreatedev/Resumify/internal/config"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// memoryDeliveryLog is a DeliveryLog keeping sent idempotency keys in memory
+type memoryDeliveryLog struct {
+	sent map[string]string
+}
+
+func (l *memoryDeliveryLog) StartAttempt(ctx context.Context, delivery *Delivery) error {
+	if _, ok := l.sent[delivery.IdempotencyKey]; ok {
+		return ErrAlreadySent
+	}
+	return nil
+}
+
+func (l *memoryDeliveryLog) RecordSent(ctx context.Context, idempotencyKey, providerMessageID string) error {
+	l.sent[idempotencyKey] = providerMessageID
+	return nil
+}
+
+func (l *memoryDeliveryLog) RecordFailure(ctx context.Context, idempotencyKey string, sendErr error) error {
+	return nil
+}
+
+// optedOut is a PreferenceChecker for a user who unsubscribed from everything
+type optedOut struct{}
+
+func (optedOut) AllowsEmail(ctx context.Context, userID string, category Category) (bool, error) {
+	return false, nil
+}
+
+func newMemoryClient(t *testing.T) (*Client, *Outbox) {
+	t.Helper()
+
+	logger := zerolog.Nop()
+	client := NewClient(&config.Config{
+		Server: config.ServerConfig{
+			FrontendURL: "https://app.example.com",
+			PublicURL:   "https://api.example.com",
+		},
+		Auth: config.AuthConfig{
+			SecretKey: "test-secret",
+		},
+		Integration: config.IntegrationConfig{
+			EmailTransport:   TransportMemory,
+			EmailFromName:    "Resumify",
+			EmailFromAddress: "hello@example.com",
+		},
+	}, &logger)
+
+	outbox := client.Outbox()
+	require.NotNil(t, outbox, "memory transport should expose its outbox")
+
+	return client, outbox
+}
+
+func TestSendWelcomeEmailThroughMemoryTransport(t *testing.T) {
+	ctx := context.Background()
+	client, outbox := newMemoryClient(t)
+
+	err := client.SendWelcomeEmail(ctx, Envelope{To: "ada@example.com", UserID: "user_1"}, "Ada")
+	require.NoError(t, err)
+
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+
+	msg := messages[0]
+	assert.Equal(t, `"Resumify" <hello@example.com>`, msg.From)
+	assert.Equal(t, []string{"ada@example.com"}, msg.To)
+	assert.Equal(t, "Welcome to Resumify!", msg.Subject)
+	assert.True(t, strings.HasSuffix(msg.ID, "@example.com"), msg.ID)
+	assert.NotEmpty(t, msg.IdempotencyKey)
+
+	assert.Contains(t, msg.HTML, "Ada")
+	assert.Contains(t, msg.Text, "Ada")
+	assert.NotContains(t, msg.Text, "<")
+
+	// Emails to users can be unsubscribed from in one click
+	assert.Contains(t, msg.HTML, "https://app.example.com/unsubscribe/")
+	assert.True(t, strings.HasPrefix(msg.Headers["List-Unsubscribe"], "<https://api.example.com/unsubscribe/"))
+	assert.Equal(t, "List-Unsubscribe=One-Click", msg.Headers["List-Unsubscribe-Post"])
+
+	_, err = msg.MIME()
+	require.NoError(t, err)
+
+	outbox.Reset()
+	assert.Empty(t, outbox.Messages())
+}
+
+func TestSendEmailSkipsAlreadySentDeliveries(t *testing.T) {
+	ctx := context.Background()
+	client, outbox := newMemoryClient(t)
+	deliveries := &memoryDeliveryLog{sent: map[string]string{}}
+	client.SetDeliveryLog(deliveries)
+
+	envelope := Envelope{To: "ada@example.com", UserID: "user_1", IdempotencyKey: "task_1"}
+	require.NoError(t, client.SendWelcomeEmail(ctx, envelope, "Ada"))
+	require.NoError(t, client.SendWelcomeEmail(ctx, envelope, "Ada"))
+
+	messages := outbox.Messages()
+	require.Len(t, messages, 1)
+	assert.Equal(t, "task_1", messages[0].IdempotencyKey)
+	assert.Equal(t, messages[0].ID, deliveries.sent["task_1"])
+}
+
+func TestSendEmailRespectsPreferences(t *testing.T) {
+	ctx := context.Background()
+	client, outbox := newMemoryClient(t)
+	client.SetPreferences(optedOut{})
+
+	// Review requests can be unsubscribed from
+	err := client.SendReviewRequestedEmail(ctx, Envelope{To: "ada@example.com", UserID: "user_1"}, "Resume", "Please review", "https://app.example.com/review")
+	require.NoError(t, err)
+	assert.Empty(t, outbox.Messages())
+
+	// The welcome email is transactional and always sent
+	require.NoError(t, client.SendWelcomeEmail(ctx, Envelope{To: "ada@example.com", UserID: "user_1"}, "Ada"))
+	assert.Len(t, outbox.Messages(), 1)
+}
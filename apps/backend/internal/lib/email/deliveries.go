@@ -0,0 +1,36 @@
+package email
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrAlreadySent is returned by a DeliveryLog when the email with the
+// idempotency key was already sent
+var ErrAlreadySent = errors.New("email already sent")
+
+// Envelope addresses an email. Sends with the same idempotency key are one
+// delivery: once it is sent, sending it again does nothing.
+type Envelope struct {
+	To             string
+	UserID         string
+	IdempotencyKey string
+}
+
+// Delivery is one send attempt, as recorded in the delivery log
+type Delivery struct {
+	IdempotencyKey string
+	UserID         string
+	Template       Template
+	Recipient      string
+	Provider       string
+}
+
+// DeliveryLog records every send attempt and its outcome
+type DeliveryLog interface {
+	// StartAttempt records an attempt before the email is sent. It returns
+	// ErrAlreadySent when an earlier attempt was sent.
+	StartAttempt(ctx context.Context, delivery *Delivery) error
+	RecordSent(ctx context.Context, idempotencyKey, providerMessageID string) error
+	RecordFailure(ctx context.Context, idempotencyKey string, sendErr error) error
+}
@@ -1,14 +1,110 @@
 package email
 
-func (c *Client) SendWelcomeEmail(to, firstName string) error {
-	data := map[string]string{
+import "context"
+
+func (c *Client) SendWelcomeEmail(ctx context.Context, envelope Envelope, firstName string) error {
+	data := map[string]any{
 		"UserFirstName": firstName,
 	}
 
 	return c.SendEmail(
-		to,
+		ctx,
+		envelope,
 		"Welcome to Resumify!",
 		TemplateWelcome,
 		data,
 	)
 }
+
+func (c *Client) SendCollaboratorInviteEmail(ctx context.Context, envelope Envelope, resumeTitle, role, acceptURL string) error {
+	data := map[string]any{
+		"ResumeTitle": resumeTitle,
+		"Role":        role,
+		"AcceptURL":   acceptURL,
+	}
+
+	return c.SendEmail(
+		ctx,
+		envelope,
+		"You have been invited to collaborate on a resume",
+		TemplateCollaboratorInvite,
+		data,
+	)
+}
+
+func (c *Client) SendReviewRequestedEmail(ctx context.Context, envelope Envelope, resumeTitle, message, reviewURL string) error {
+	data := map[string]any{
+		"ResumeTitle": resumeTitle,
+		"Message":     message,
+		"ReviewURL":   reviewURL,
+	}
+
+	return c.SendEmail(
+		ctx,
+		envelope,
+		"A resume is waiting for your review",
+		TemplateReviewRequested,
+		data,
+	)
+}
+
+func (c *Client) SendReviewDecisionEmail(ctx context.Context, envelope Envelope, resumeTitle, decision, note, reviewURL string) error {
+	data := map[string]any{
+		"ResumeTitle": resumeTitle,
+		"Decision":    decision,
+		"Note":        note,
+		"ReviewURL":   reviewURL,
+	}
+
+	return c.SendEmail(
+		ctx,
+		envelope,
+		"Your resume has been reviewed",
+		TemplateReviewDecision,
+		data,
+	)
+}
+
+func (c *Client) SendDataExportReadyEmail(ctx context.Context, envelope Envelope, downloadURL, expiresAt string) error {
+	data := map[string]any{
+		"DownloadURL": downloadURL,
+		"ExpiresAt":   expiresAt,
+	}
+
+	return c.SendEmail(
+		ctx,
+		envelope,
+		"Your Resumify data export is ready",
+		TemplateDataExportReady,
+		data,
+	)
+}
+
+// ExpiringCertification is one certification listed in an expiry reminder
+type ExpiringCertification struct {
+	Name         string `json:"name"`
+	Organization string `json:"organization"`
+	ResumeTitle  string `json:"resume_title"`
+	ResumeURL    string `json:"resume_url"`
+	ExpiresOn    string `json:"expires_on"`
+	DaysLeft     int    `json:"days_left"`
+}
+
+func (c *Client) SendCertificationExpiryEmail(ctx context.Context, envelope Envelope, certifications []ExpiringCertification) error {
+	data := map[string]any{
+		"Certifications": certifications,
+	}
+
+	subject := "Your certification is expiring soon"
+	if len(certifications) > 1 {
+		subject = "Your certifications are expiring soon"
+	}
+
+	return c.SendEmail(
+		ctx,
+		envelope,
+		subject,
+		TemplateCertificationExpiry,
+		data,
+	)
+}
@@ -0,0 +1,86 @@
+package email
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// fileTransport writes every email to an .eml file, for local development
+type fileTransport struct {
+	dir string
+}
+
+func newFileTransport(dir string) *fileTransport {
+	return &fileTransport{dir: dir}
+}
+
+func (t *fileTransport) Name() string {
+	return TransportFile
+}
+
+func (t *fileTransport) Send(ctx context.Context, msg *Message) (string, error) {
+	body, err := msg.MIME()
+	if err != nil {
+		return "", err
+	}
+
+	if err := os.MkdirAll(t.dir, 0o755); err != nil {
+		return "", fmt.Errorf("failed to create email outbox directory %s: %w", t.dir, err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return "", fmt.Errorf("failed to name email file: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%s.eml", time.Now().UTC().Format("20060102T150405.000000000"), hex.EncodeToString(suffix))
+	if err := os.WriteFile(filepath.Join(t.dir, name), body, 0o644); err != nil {
+		return "", fmt.Errorf("failed to write email file %s: %w", name, err)
+	}
+
+	return msg.ID, nil
+}
+
+// Outbox keeps sent emails in memory so tests can assert on them
+type Outbox struct {
+	mu       sync.Mutex
+	messages []Message
+}
+
+func NewOutbox() *Outbox {
+	return &Outbox{}
+}
+
+func (o *Outbox) Name() string {
+	return TransportMemory
+}
+
+func (o *Outbox) Send(ctx context.Context, msg *Message) (string, error) {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.messages = append(o.messages, *msg)
+	return msg.ID, nil
+}
+
+// Messages returns the emails sent so far, oldest first
+func (o *Outbox) Messages() []Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	return append([]Message(nil), o.messages...)
+}
+
+// Reset forgets the emails sent so far
+func (o *Outbox) Reset() {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+
+	o.messages = nil
+}
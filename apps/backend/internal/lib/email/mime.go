@@ -0,0 +1,73 @@
+package email
+
+import (
+	"bytes"
+	"fmt"
+	"maps"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
+	"net/textproto"
+	"slices"
+	"strings"
+	"time"
+)
+
+// MIME encodes the message as a multipart/alternative email with its text
+// and HTML parts, as sent over SMTP and written to .eml files
+func (m *Message) MIME() ([]byte, error) {
+	var buf bytes.Buffer
+
+	from, err := mail.ParseAddress(m.From)
+	if err != nil {
+		return nil, fmt.Errorf("invalid sender %q: %w", m.From, err)
+	}
+
+	parts := multipart.NewWriter(&buf)
+	header := []string{
+		"From: " + from.String(),
+		"To: " + strings.Join(m.To, ", "),
+		"Subject: " + mime.QEncoding.Encode("utf-8", m.Subject),
+		"Date: " + time.Now().Format(time.RFC1123Z),
+		"Message-ID: <" + m.ID + ">",
+	}
+	for _, name := range slices.Sorted(maps.Keys(m.Headers)) {
+		header = append(header, name+": "+m.Headers[name])
+	}
+	header = append(header,
+		"MIME-Version: 1.0",
+		"Content-Type: multipart/alternative; boundary="+parts.Boundary(),
+	)
+	buf.WriteString(strings.Join(header, "\r\n") + "\r\n\r\n")
+
+	for _, part := range []struct {
+		contentType string
+		body        string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	} {
+		w, err := parts.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to create email part: %w", err)
+		}
+
+		qp := quotedprintable.NewWriter(w)
+		if _, err := qp.Write([]byte(part.body)); err != nil {
+			return nil, fmt.Errorf("failed to encode email part: %w", err)
+		}
+		if err := qp.Close(); err != nil {
+			return nil, fmt.Errorf("failed to encode email part: %w", err)
+		}
+	}
+
+	if err := parts.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish email: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,25 @@
+package email
+
+import "context"
+
+// Category groups the emails a user can opt out of. Emails without a
+// category are transactional and always sent.
+type Category string
+
+const (
+	CategoryReminders        Category = "reminders"
+	CategoryReviewRequests   Category = "review_requests"
+	CategoryShareViewDigests Category = "share_view_digests"
+	CategoryProductNews      Category = "product_news"
+)
+
+// templateCategories lists the templates that are not transactional
+var templateCategories = map[Template]Category{
+	TemplateReviewRequested:     CategoryReviewRequests,
+	TemplateCertificationExpiry: CategoryReminders,
+}
+
+// PreferenceChecker tells whether a user wants the emails of a category
+type PreferenceChecker interface {
+	AllowsEmail(ctx context.Context, userID string, category Category) (bool, error)
+}
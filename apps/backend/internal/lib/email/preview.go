@@ -1,7 +1,78 @@
 package email
 
-var PreviewData = map[string]map[string]string{
-	"welcome": {
+import (
+	"fmt"
+	"maps"
+	"slices"
+)
+
+// PreviewData is the sample data each template is rendered with by the email
+// preview routes
+var PreviewData = map[Template]map[string]any{
+	TemplateWelcome: {
 		"UserFirstName": "John",
 	},
+	TemplateCollaboratorInvite: {
+		"ResumeTitle": "Software Engineer",
+		"Role":        "editor",
+		"AcceptURL":   "https://example.com/invitations/token",
+	},
+	TemplateReviewRequested: {
+		"ResumeTitle": "Software Engineer",
+		"Message":     "Could you check the experience section before Friday?",
+		"ReviewURL":   "https://example.com/reviews/id",
+	},
+	TemplateReviewDecision: {
+		"ResumeTitle": "Software Engineer",
+		"Decision":    "changes requested",
+		"Note":        "Quantify the impact of your last two roles.",
+		"ReviewURL":   "https://example.com/reviews/id",
+	},
+	TemplateDataExportReady: {
+		"DownloadURL": "https://example.com/downloads/data-exports/id?expires=1767366240&signature=signature",
+		"ExpiresAt":   "January 2, 2026 at 3:04 PM UTC",
+	},
+	TemplateCertificationExpiry: {
+		"Certifications": []ExpiringCertification{
+			{
+				Name:         "AWS Certified Solutions Architect",
+				Organization: "Amazon Web Services",
+				ResumeTitle:  "Software Engineer",
+				ResumeURL:    "https://example.com/resumes/id",
+				ExpiresOn:    "March 3, 2026",
+				DaysLeft:     30,
+			},
+		},
+	},
+}
+
+// Templates returns the name of every email template, sorted
+func Templates() []Template {
+	return slices.Sorted(maps.Keys(templates))
+}
+
+// Preview renders a template with its preview data. Overrides replace the
+// text fields of the data by name; fields holding lists or numbers are kept.
+func Preview(templateName Template, overrides map[string]string) (string, string, error) {
+	if _, ok := templates[templateName]; !ok {
+		return "", "", fmt.Errorf("unknown email template %s", templateName)
+	}
+
+	data := maps.Clone(PreviewData[templateName])
+	if data == nil {
+		data = make(map[string]any, len(overrides))
+	}
+	if _, ok := data["UnsubscribeURL"]; !ok {
+		data["UnsubscribeURL"] = "https://example.com/unsubscribe/token"
+	}
+	for field, value := range overrides {
+		if current, ok := data[field]; ok {
+			if _, isText := current.(string); !isText {
+				continue
+			}
+		}
+		data[field] = value
+	}
+
+	return Render(templateName, data)
 }
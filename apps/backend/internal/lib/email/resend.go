@@ -0,0 +1,40 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/resend/resend-go/v2"
+)
+
+// resendTransport delivers email through the Resend API, which deduplicates
+// sends by idempotency key
+type resendTransport struct {
+	client *resend.Client
+}
+
+func newResendTransport(apiKey string) *resendTransport {
+	return &resendTransport{client: resend.NewClient(apiKey)}
+}
+
+func (t *resendTransport) Name() string {
+	return TransportResend
+}
+
+func (t *resendTransport) Send(ctx context.Context, msg *Message) (string, error) {
+	resp, err := t.client.Emails.SendWithOptions(ctx, &resend.SendEmailRequest{
+		From:    msg.From,
+		To:      msg.To,
+		Subject: msg.Subject,
+		Headers: msg.Headers,
+		Html:    msg.HTML,
+		Text:    msg.Text,
+	}, &resend.SendEmailOptions{
+		IdempotencyKey: msg.IdempotencyKey,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to send email through resend: %w", err)
+	}
+
+	return resp.Id, nil
+}
@@ -0,0 +1,52 @@
+package email
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"strconv"
+)
+
+// smtpTransport delivers email to an SMTP server. The connection is upgraded
+// with STARTTLS whenever the server offers it, and credentials are only sent
+// over TLS or to localhost.
+type smtpTransport struct {
+	addr string
+	auth smtp.Auth
+}
+
+func newSMTPTransport(host string, port int, username, password string) *smtpTransport {
+	var auth smtp.Auth
+	if username != "" {
+		auth = smtp.PlainAuth("", username, password, host)
+	}
+
+	return &smtpTransport{
+		addr: net.JoinHostPort(host, strconv.Itoa(port)),
+		auth: auth,
+	}
+}
+
+func (t *smtpTransport) Name() string {
+	return TransportSMTP
+}
+
+func (t *smtpTransport) Send(ctx context.Context, msg *Message) (string, error) {
+	body, err := msg.MIME()
+	if err != nil {
+		return "", err
+	}
+
+	from, err := mail.ParseAddress(msg.From)
+	if err != nil {
+		return "", fmt.Errorf("invalid sender %q: %w", msg.From, err)
+	}
+
+	if err := smtp.SendMail(t.addr, t.auth, from.Address, msg.To, body); err != nil {
+		return "", fmt.Errorf("failed to send email through smtp server %s: %w", t.addr, err)
+	}
+
+	return msg.ID, nil
+}
@@ -1,7 +1,82 @@
 package email
 
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"io/fs"
+	"path"
+	"strings"
+)
+
 type Template string
 
 const (
-	TemplateWelcome Template = "welcome"
+	TemplateWelcome             Template = "welcome"
+	TemplateCollaboratorInvite  Template = "collaborator_invite"
+	TemplateReviewRequested     Template = "review_requested"
+	TemplateReviewDecision      Template = "review_decision"
+	TemplateDataExportReady     Template = "data_export_ready"
+	TemplateCertificationExpiry Template = "certification_expiry"
 )
+
+// Every email is a page in templates/ that defines its "preview", "title" and
+// "content" blocks, rendered inside the "base" layout of templates/layouts.
+// Pages can use the partials of templates/partials.
+//
+//go:embed templates
+var templateFS embed.FS
+
+var templates = parseTemplates()
+
+// button is the data of the "button" partial
+type button struct {
+	URL   string
+	Label string
+}
+
+func newButton(url, label string) button {
+	return button{URL: url, Label: label}
+}
+
+// parseTemplates parses every page together with its own copy of the layouts
+// and partials. The templates are embedded, so a parse error is a bug.
+func parseTemplates() map[Template]*template.Template {
+	shared := template.Must(template.New("").Funcs(template.FuncMap{
+		"button": newButton,
+	}).ParseFS(templateFS, "templates/layouts/*.html", "templates/partials/*.html"))
+
+	pages, err := fs.Glob(templateFS, "templates/*.html")
+	if err != nil {
+		panic(err)
+	}
+
+	parsed := make(map[Template]*template.Template, len(pages))
+	for _, page := range pages {
+		name := Template(strings.TrimSuffix(path.Base(page), ".html"))
+		parsed[name] = template.Must(template.Must(shared.Clone()).ParseFS(templateFS, page))
+	}
+
+	return parsed
+}
+
+// Render renders an email as HTML together with its plain-text alternative
+func Render(templateName Template, data any) (string, string, error) {
+	tmpl, ok := templates[templateName]
+	if !ok {
+		return "", "", fmt.Errorf("unknown email template %s", templateName)
+	}
+
+	var body bytes.Buffer
+	if err := tmpl.ExecuteTemplate(&body, "base", data); err != nil {
+		return "", "", fmt.Errorf("failed to execute email template %s: %w", templateName, err)
+	}
+
+	text, err := plainText(body.String())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to convert email template %s to text: %w", templateName, err)
+	}
+
+	return body.String(), text, nil
+}
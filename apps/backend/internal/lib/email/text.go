@@ -0,0 +1,91 @@
+package email
+
+import (
+	"regexp"
+	"strings"
+
+	"golang.org/x/net/html"
+)
+
+// blockElements start on a new paragraph in the plain-text alternative
+var blockElements = map[string]bool{
+	"p": true, "div": true, "table": true, "tr": true, "ul": true, "ol": true, "hr": true,
+	"h1": true, "h2": true, "h3": true, "h4": true, "h5": true, "h6": true,
+}
+
+var (
+	spaces      = regexp.MustCompile(`[ \t\r\f\v]+`)
+	blankLines  = regexp.MustCompile(`\n{3,}`)
+	whitespaces = regexp.MustCompile(`\s+`)
+)
+
+// plainText converts a rendered email to its plain-text alternative. Headings,
+// paragraphs and tables become blocks separated by blank lines, list items
+// are bulleted and links are followed by their URL. Hidden elements, such as
+// the preview text, are left out.
+func plainText(document string) (string, error) {
+	root, err := html.Parse(strings.NewReader(document))
+	if err != nil {
+		return "", err
+	}
+
+	var b strings.Builder
+	writeText(&b, root)
+
+	lines := strings.Split(b.String(), "\n")
+	for i, line := range lines {
+		lines[i] = strings.TrimSpace(spaces.ReplaceAllString(line, " "))
+	}
+
+	text := blankLines.ReplaceAllString(strings.Join(lines, "\n"), "\n\n")
+	return strings.TrimSpace(text) + "\n", nil
+}
+
+func writeText(b *strings.Builder, n *html.Node) {
+	switch n.Type {
+	case html.TextNode:
+		b.WriteString(whitespaces.ReplaceAllString(n.Data, " "))
+		return
+	case html.CommentNode:
+		return
+	case html.ElementNode:
+		switch n.Data {
+		case "head", "style", "script":
+			return
+		}
+		if strings.Contains(strings.ReplaceAll(attr(n, "style"), " ", ""), "display:none") {
+			return
+		}
+	}
+
+	switch {
+	case n.Data == "br":
+		b.WriteString("\n")
+	case n.Data == "li":
+		b.WriteString("\n- ")
+	case blockElements[n.Data]:
+		b.WriteString("\n\n")
+	}
+
+	for child := n.FirstChild; child != nil; child = child.NextSibling {
+		writeText(b, child)
+	}
+
+	switch {
+	case n.Data == "a":
+		if href := attr(n, "href"); href != "" && !strings.HasPrefix(href, "#") {
+			b.WriteString(" (" + href + ")")
+		}
+	case blockElements[n.Data]:
+		b.WriteString("\n\n")
+	}
+}
+
+func attr(n *html.Node, key string) string {
+	for _, a := range n.Attr {
+		if a.Key == key {
+			return a.Val
+		}
+	}
+	return ""
+}
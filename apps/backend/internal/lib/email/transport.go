@@ -0,0 +1,52 @@
+package email
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/recreatedev/Resumify/internal/config"
+)
+
+// Email transports selectable with RESUMIFY_INTEGRATION_EMAIL_TRANSPORT
+const (
+	TransportResend = "resend"
+	TransportSMTP   = "smtp"
+	TransportFile   = "file"
+	TransportMemory = "memory"
+)
+
+// Message is a rendered email ready to be delivered. The idempotency key is
+// passed to providers that deduplicate sends.
+type Message struct {
+	ID             string
+	IdempotencyKey string
+	From           string
+	To             []string
+	Subject        string
+	Headers        map[string]string
+	HTML           string
+	Text           string
+}
+
+// Transport delivers rendered emails. Send returns the ID the provider knows
+// the email by, which its webhooks refer to.
+type Transport interface {
+	Name() string
+	Send(ctx context.Context, msg *Message) (string, error)
+}
+
+// NewTransport creates the transport selected by the integration config
+func NewTransport(cfg *config.IntegrationConfig) (Transport, error) {
+	switch cfg.EmailTransport {
+	case TransportResend:
+		return newResendTransport(cfg.ResendAPIKey), nil
+	case TransportSMTP:
+		return newSMTPTransport(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword), nil
+	case TransportFile:
+		return newFileTransport(cfg.EmailOutboxDir), nil
+	case TransportMemory:
+		return NewOutbox(), nil
+	default:
+		return nil, fmt.Errorf("unknown email transport %q", cfg.EmailTransport)
+	}
+}
@@ -0,0 +1,66 @@
+package email
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"strings"
+)
+
+var ErrInvalidUnsubscribeToken = errors.New("invalid unsubscribe token")
+
+// UnsubscribeSigner signs the one-click unsubscribe tokens embedded in emails.
+// A token unsubscribes one user from one category, or from every category
+// when it has none, and does not expire.
+type UnsubscribeSigner struct {
+	key []byte
+}
+
+// NewUnsubscribeSigner derives a token signing key from a server secret, so
+// the secret itself never signs anything that is handed out
+func NewUnsubscribeSigner(secret string) *UnsubscribeSigner {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("resumify:unsubscribe"))
+	return &UnsubscribeSigner{key: mac.Sum(nil)}
+}
+
+// Sign returns the token that unsubscribes the user from the category
+func (s *UnsubscribeSigner) Sign(userID string, category Category) string {
+	payload := []byte(userID + "\n" + string(category))
+	return base64.RawURLEncoding.EncodeToString(payload) + "." + base64.RawURLEncoding.EncodeToString(s.signature(payload))
+}
+
+// Verify checks a token and returns the user and category it unsubscribes
+func (s *UnsubscribeSigner) Verify(token string) (string, Category, error) {
+	encodedPayload, encodedSignature, found := strings.Cut(token, ".")
+	if !found {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(encodedPayload)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(encodedSignature)
+	if err != nil {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	if !hmac.Equal(signature, s.signature(payload)) {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	userID, category, found := strings.Cut(string(payload), "\n")
+	if !found || userID == "" {
+		return "", "", ErrInvalidUnsubscribeToken
+	}
+
+	return userID, Category(category), nil
+}
+
+func (s *UnsubscribeSigner) signature(payload []byte) []byte {
+	mac := hmac.New(sha256.New, s.key)
+	mac.Write(payload)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,55 @@
+package etag
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/errs"
+)
+
+const (
+	HeaderETag    = "ETag"
+	HeaderIfMatch = "If-Match"
+)
+
+// Tagged is implemented by responses that carry an entity tag. The JSON
+// response handler copies the tag into the ETag header.
+type Tagged interface {
+	EntityTag() string
+}
+
+// Format returns the strong entity tag of a row last modified at updatedAt
+func Format(updatedAt time.Time) string {
+	return fmt.Sprintf(`"%x"`, updatedAt.UnixMicro())
+}
+
+// Check enforces an If-Match precondition against the stored modification
+// time of a row. A missing header is rejected with 428 and a stale one with 412.
+func Check(ifMatch string, updatedAt time.Time) error {
+	ifMatch = strings.TrimSpace(ifMatch)
+	if ifMatch == "" {
+		return errs.NewPreconditionRequiredError("If-Match header is required", false)
+	}
+	if ifMatch == "*" {
+		return nil
+	}
+
+	current := Format(updatedAt)
+	for _, tag := range strings.Split(ifMatch, ",") {
+		// If-Match uses strong comparison, so weak tags never match
+		if strings.TrimSpace(tag) == current {
+			return nil
+		}
+	}
+
+	return NewStaleError()
+}
+
+// NewStaleError returns the 412 error for a write whose If-Match precondition
+// no longer holds. Writes repeat the precondition in their WHERE clause and
+// report it when no row matched, so a concurrent change that lands between
+// Check and the write is rejected as well.
+func NewStaleError() error {
+	return errs.NewPreconditionFailedError("the resource was modified since it was fetched; reload and try again", false)
+}
@@ -0,0 +1,348 @@
+package events
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+)
+
+const (
+	ActionCreated   = "created"
+	ActionUpdated   = "updated"
+	ActionDeleted   = "deleted"
+	ActionReordered = "reordered"
+)
+
+const (
+	EntityResume        = "resume"
+	EntityDocument      = "document"
+	EntitySection       = "section"
+	EntityEducation     = "education"
+	EntityExperience    = "experience"
+	EntityProject       = "project"
+	EntitySkill         = "skill"
+	EntityCertification = "certification"
+	EntityComment       = "comment"
+)
+
+const (
+	// historyLength is the approximate number of events kept per resume for
+	// clients resuming with Last-Event-ID
+	historyLength = 500
+	// historyTTL expires the history of resumes nobody has edited for a while
+	historyTTL = 24 * time.Hour
+	// publishQueueSize is the number of events waiting to be published before
+	// new events are dropped
+	publishQueueSize = 1024
+	// publishTimeout bounds publishing one event, so a slow Redis delays live
+	// updates without holding up the queue behind it
+	publishTimeout = 2 * time.Second
+)
+
+// Event is a single change to a resume or one of its entries
+type Event struct {
+	ID         string          `json:"id"`
+	ResumeID   uuid.UUID       `json:"resumeId"`
+	EntityType string          `json:"entityType"`
+	EntityID   *uuid.UUID      `json:"entityId"`
+	Action     string          `json:"action"`
+	Payload    json.RawMessage `json:"payload,omitempty"`
+	OccurredAt time.Time       `json:"occurredAt"`
+}
+
+// Broker fans resume changes out to subscribers through Redis. Every event is
+// appended to a capped per-resume stream, which gives it an ordered ID and
+// allows replay, and then published on a per-resume channel for live delivery.
+// Events are published in the background, one at a time in the order they
+// were recorded.
+type Broker struct {
+	redis  *redis.Client
+	logger *zerolog.Logger
+
+	mu     sync.RWMutex
+	closed bool
+	queue  chan queuedEvent
+	done   chan struct{}
+}
+
+// queuedEvent is an event waiting to be published with the context, detached
+// from cancellation, of the request that recorded it
+type queuedEvent struct {
+	ctx   context.Context
+	event *Event
+}
+
+func NewBroker(redisClient *redis.Client, logger *zerolog.Logger) *Broker {
+	b := &Broker{
+		redis:  redisClient,
+		logger: logger,
+		queue:  make(chan queuedEvent, publishQueueSize),
+		done:   make(chan struct{}),
+	}
+	go b.run()
+
+	return b
+}
+
+// Close stops accepting events and waits until the queued events are
+// published or ctx is done
+func (b *Broker) Close(ctx context.Context) error {
+	b.mu.Lock()
+	if !b.closed {
+		b.closed = true
+		close(b.queue)
+	}
+	b.mu.Unlock()
+
+	select {
+	case <-b.done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("failed to publish queued resume events: %w", ctx.Err())
+	}
+}
+
+// resumeKey names both the history stream and the pub/sub channel of a resume;
+// Redis keeps keys and channels in separate namespaces
+func resumeKey(resumeID uuid.UUID) string {
+	return fmt.Sprintf("resume:events:%s", resumeID)
+}
+
+// Publish records a change to a resume and queues it for publishing without
+// waiting for Redis. Publishing is best effort: the change has already been
+// committed, so failures are logged and never returned.
+func (b *Broker) Publish(ctx context.Context, resumeID uuid.UUID, entityType, action string, entityID *uuid.UUID, payload any) {
+	event := Event{
+		ResumeID:   resumeID,
+		EntityType: entityType,
+		EntityID:   entityID,
+		Action:     action,
+		OccurredAt: time.Now().UTC(),
+	}
+
+	if payload != nil {
+		data, err := json.Marshal(payload)
+		if err != nil {
+			b.logger.Error().Err(err).Str("resume_id", resumeID.String()).Msg("failed to marshal event payload")
+			return
+		}
+		event.Payload = data
+	}
+
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+
+	if b.closed {
+		b.logger.Warn().Str("resume_id", resumeID.String()).Msg("dropped resume event published after shutdown")
+		return
+	}
+
+	select {
+	// The change is committed even if the request is cancelled right after
+	case b.queue <- queuedEvent{ctx: context.WithoutCancel(ctx), event: &event}:
+	default:
+		b.logger.Error().
+			Str("resume_id", resumeID.String()).
+			Str("entity_type", entityType).
+			Str("action", action).
+			Msg("dropped resume event, the publish queue is full")
+	}
+}
+
+// run publishes the queued events until the broker is closed
+func (b *Broker) run() {
+	defer close(b.done)
+
+	for queued := range b.queue {
+		ctx, cancel := context.WithTimeout(queued.ctx, publishTimeout)
+		err := b.publish(ctx, queued.event)
+		cancel()
+
+		if err != nil {
+			b.logger.Error().
+				Err(err).
+				Str("resume_id", queued.event.ResumeID.String()).
+				Str("entity_type", queued.event.EntityType).
+				Str("action", queued.event.Action).
+				Msg("failed to publish resume event")
+		}
+	}
+}
+
+func (b *Broker) publish(ctx context.Context, event *Event) error {
+	key := resumeKey(event.ResumeID)
+
+	data, err := json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	id, err := b.redis.XAdd(ctx, &redis.XAddArgs{
+		Stream: key,
+		MaxLen: historyLength,
+		Approx: true,
+		Values: map[string]any{"event": data},
+	}).Result()
+	if err != nil {
+		return fmt.Errorf("failed to append event to stream: %w", err)
+	}
+
+	if err := b.redis.Expire(ctx, key, historyTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set event stream expiry: %w", err)
+	}
+
+	event.ID = id
+	data, err = json.Marshal(event)
+	if err != nil {
+		return fmt.Errorf("failed to marshal event: %w", err)
+	}
+
+	if err := b.redis.Publish(ctx, key, data).Err(); err != nil {
+		return fmt.Errorf("failed to publish event: %w", err)
+	}
+
+	return nil
+}
+
+// Subscription delivers the events of one resume until it is closed or its
+// context is done
+type Subscription struct {
+	Events <-chan Event
+	pubsub *redis.PubSub
+}
+
+// Close stops the subscription and closes the Events channel
+func (s *Subscription) Close() error {
+	return s.pubsub.Close()
+}
+
+// Subscribe starts delivering the events of a resume. When lastEventID is set,
+// events recorded after it are replayed first so a reconnecting client does
+// not miss changes made while it was away.
+func (b *Broker) Subscribe(ctx context.Context, resumeID uuid.UUID, lastEventID string) (*Subscription, error) {
+	// Subscribe before reading the history so nothing falls between the two
+	pubsub := b.redis.Subscribe(ctx, resumeKey(resumeID))
+	if _, err := pubsub.Receive(ctx); err != nil {
+		pubsub.Close()
+		return nil, fmt.Errorf("failed to subscribe to resume events: %w", err)
+	}
+
+	var history []Event
+	if _, _, ok := parseID(lastEventID); ok {
+		var err error
+		history, err = b.history(ctx, resumeID, lastEventID)
+		if err != nil {
+			pubsub.Close()
+			return nil, err
+		}
+	} else {
+		lastEventID = ""
+	}
+
+	out := make(chan Event, 64)
+	live := pubsub.Channel()
+
+	go func() {
+		defer close(out)
+
+		last := lastEventID
+		send := func(event Event) bool {
+			// Skip events already delivered by the replay
+			if last != "" && !after(event.ID, last) {
+				return true
+			}
+			select {
+			case out <- event:
+				last = event.ID
+				return true
+			case <-ctx.Done():
+				return false
+			}
+		}
+
+		for _, event := range history {
+			if !send(event) {
+				return
+			}
+		}
+
+		for msg := range live {
+			var event Event
+			if err := json.Unmarshal([]byte(msg.Payload), &event); err != nil {
+				b.logger.Error().Err(err).Str("resume_id", resumeID.String()).Msg("failed to decode resume event")
+				continue
+			}
+			if !send(event) {
+				return
+			}
+		}
+	}()
+
+	return &Subscription{
+		Events: out,
+		pubsub: pubsub,
+	}, nil
+}
+
+// history returns the events recorded after lastEventID
+func (b *Broker) history(ctx context.Context, resumeID uuid.UUID, lastEventID string) ([]Event, error) {
+	messages, err := b.redis.XRange(ctx, resumeKey(resumeID), "("+lastEventID, "+").Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to read resume event history: %w", err)
+	}
+
+	events := make([]Event, 0, len(messages))
+	for _, msg := range messages {
+		raw, ok := msg.Values["event"].(string)
+		if !ok {
+			continue
+		}
+		var event Event
+		if err := json.Unmarshal([]byte(raw), &event); err != nil {
+			b.logger.Error().Err(err).Str("resume_id", resumeID.String()).Msg("failed to decode resume event")
+			continue
+		}
+		event.ID = msg.ID
+		events = append(events, event)
+	}
+
+	return events, nil
+}
+
+// parseID splits a Redis stream ID of the form "<ms>-<seq>"
+func parseID(id string) (uint64, uint64, bool) {
+	msPart, seqPart, found := strings.Cut(id, "-")
+	if !found {
+		return 0, 0, false
+	}
+	ms, err := strconv.ParseUint(msPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	seq, err := strconv.ParseUint(seqPart, 10, 64)
+	if err != nil {
+		return 0, 0, false
+	}
+	return ms, seq, true
+}
+
+// after reports whether stream ID a was recorded after stream ID b
+func after(a, b string) bool {
+	aMs, aSeq, okA := parseID(a)
+	bMs, bSeq, okB := parseID(b)
+	if !okA || !okB {
+		return true
+	}
+	if aMs != bMs {
+		return aMs > bMs
+	}
+	return aSeq > bSeq
+}
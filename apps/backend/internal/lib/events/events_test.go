@@ -0,0 +1,73 @@
+package events
+
+import (
+	"context"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/redis/go-redis/v9"
+	"github.com/rs/zerolog"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stalledRedis accepts connections and never answers, like a Redis server that
+// stopped responding
+func stalledRedis(t *testing.T) string {
+	t.Helper()
+
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+
+	conns := make(chan net.Conn, 16)
+	go func() {
+		defer close(conns)
+		for {
+			conn, err := listener.Accept()
+			if err != nil {
+				return
+			}
+			conns <- conn
+		}
+	}()
+
+	t.Cleanup(func() {
+		listener.Close()
+		for conn := range conns {
+			conn.Close()
+		}
+	})
+
+	return listener.Addr().String()
+}
+
+func TestPublishDoesNotWaitForRedis(t *testing.T) {
+	client := redis.NewClient(&redis.Options{
+		Addr:                  stalledRedis(t),
+		ReadTimeout:           -1,
+		WriteTimeout:          -1,
+		ContextTimeoutEnabled: true,
+	})
+	defer client.Close()
+
+	logger := zerolog.Nop()
+	broker := NewBroker(client, &logger)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	start := time.Now()
+	broker.Publish(ctx, uuid.New(), EntityResume, ActionUpdated, nil, map[string]string{"title": "Resume"})
+	// The request finishing does not cancel the queued events
+	cancel()
+	assert.Less(t, time.Since(start), publishTimeout/2, "Publish waited for Redis")
+
+	// The queued event gives up after the publish timeout
+	closeCtx, closeCancel := context.WithTimeout(context.Background(), 2*publishTimeout)
+	defer closeCancel()
+	require.NoError(t, broker.Close(closeCtx))
+	assert.GreaterOrEqual(t, time.Since(start), publishTimeout)
+
+	// Events published after shutdown are dropped
+	broker.Publish(context.Background(), uuid.New(), EntityResume, ActionUpdated, nil, nil)
+}
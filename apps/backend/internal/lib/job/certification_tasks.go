@@ -0,0 +1,20 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskCertificationReminders = "certification:expiry_reminders"
+)
+
+func NewCertificationRemindersTask() *asynq.Task {
+	return asynq.NewTask(TaskCertificationReminders, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute),
+		// Only one run should be pending at any time
+		asynq.Unique(time.Hour))
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskBuildDataExport  = "data_export:build"
+	TaskPurgeDataExports = "data_export:purge"
+)
+
+type BuildDataExportPayload struct {
+	ExportID string `json:"export_id"`
+}
+
+// NewBuildDataExportTask creates the task building a data export. The task ID
+// is the export ID, so an export is only ever queued once.
+func NewBuildDataExportTask(exportID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(BuildDataExportPayload{
+		ExportID: exportID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskBuildDataExport, payload,
+		asynq.TaskID(exportID.String()),
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute)), nil
+}
+
+func NewPurgeDataExportsTask() *asynq.Task {
+	return asynq.NewTask(TaskPurgeDataExports, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute),
+		// Only one purge should be pending at any time
+		asynq.Unique(time.Hour))
+}
@@ -5,19 +5,27 @@ import (
 	"time"
 
 	"github.com/hibiken/asynq"
+	"github.com/recreatedev/Resumify/internal/lib/email"
 )
 
 const (
-	TaskWelcome = "email:welcome"
+	TaskWelcome             = "email:welcome"
+	TaskCollaboratorInvite  = "email:collaborator_invite"
+	TaskReviewRequested     = "email:review_requested"
+	TaskReviewDecision      = "email:review_decision"
+	TaskDataExportReady     = "email:data_export_ready"
+	TaskCertificationExpiry = "email:certification_expiry"
 )
 
 type WelcomeEmailPayload struct {
+	UserID    string `json:"user_id"`
 	To        string `json:"to"`
 	FirstName string `json:"first_name"`
 }
 
-func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
+func NewWelcomeEmailTask(userID, to, firstName string) (*asynq.Task, error) {
 	payload, err := json.Marshal(WelcomeEmailPayload{
+		UserID:    userID,
 		To:        to,
 		FirstName: firstName,
 	})
@@ -30,3 +38,127 @@ func NewWelcomeEmailTask(to, firstName string) (*asynq.Task, error) {
 		asynq.Queue("default"),
 		asynq.Timeout(30*time.Second)), nil
 }
+
+type CollaboratorInviteEmailPayload struct {
+	To          string `json:"to"`
+	ResumeTitle string `json:"resume_title"`
+	Role        string `json:"role"`
+	AcceptURL   string `json:"accept_url"`
+}
+
+func NewCollaboratorInviteEmailTask(to, resumeTitle, role, acceptURL string) (*asynq.Task, error) {
+	payload, err := json.Marshal(CollaboratorInviteEmailPayload{
+		To:          to,
+		ResumeTitle: resumeTitle,
+		Role:        role,
+		AcceptURL:   acceptURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskCollaboratorInvite, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+// ReviewRequestedEmailPayload addresses the reviewer by Clerk user ID; the
+// email address is looked up when the task runs
+type ReviewRequestedEmailPayload struct {
+	UserID      string `json:"user_id"`
+	ResumeTitle string `json:"resume_title"`
+	Message     string `json:"message"`
+	ReviewURL   string `json:"review_url"`
+}
+
+func NewReviewRequestedEmailTask(userID, resumeTitle, message, reviewURL string) (*asynq.Task, error) {
+	payload, err := json.Marshal(ReviewRequestedEmailPayload{
+		UserID:      userID,
+		ResumeTitle: resumeTitle,
+		Message:     message,
+		ReviewURL:   reviewURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskReviewRequested, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+// ReviewDecisionEmailPayload addresses the submitter by Clerk user ID; the
+// email address is looked up when the task runs
+type ReviewDecisionEmailPayload struct {
+	UserID      string `json:"user_id"`
+	ResumeTitle string `json:"resume_title"`
+	Decision    string `json:"decision"`
+	Note        string `json:"note"`
+	ReviewURL   string `json:"review_url"`
+}
+
+func NewReviewDecisionEmailTask(userID, resumeTitle, decision, note, reviewURL string) (*asynq.Task, error) {
+	payload, err := json.Marshal(ReviewDecisionEmailPayload{
+		UserID:      userID,
+		ResumeTitle: resumeTitle,
+		Decision:    decision,
+		Note:        note,
+		ReviewURL:   reviewURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskReviewDecision, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+// DataExportReadyEmailPayload addresses the user by Clerk user ID; the email
+// address is looked up when the task runs
+type DataExportReadyEmailPayload struct {
+	UserID      string `json:"user_id"`
+	DownloadURL string `json:"download_url"`
+	ExpiresAt   string `json:"expires_at"`
+}
+
+func NewDataExportReadyEmailTask(userID, downloadURL, expiresAt string) (*asynq.Task, error) {
+	payload, err := json.Marshal(DataExportReadyEmailPayload{
+		UserID:      userID,
+		DownloadURL: downloadURL,
+		ExpiresAt:   expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskDataExportReady, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second)), nil
+}
+
+// CertificationExpiryEmailPayload addresses the user by Clerk user ID; the
+// email address is looked up when the task runs
+type CertificationExpiryEmailPayload struct {
+	UserID         string                        `json:"user_id"`
+	Certifications []email.ExpiringCertification `json:"certifications"`
+}
+
+func NewCertificationExpiryEmailTask(userID string, certifications []email.ExpiringCertification) (*asynq.Task, error) {
+	payload, err := json.Marshal(CertificationExpiryEmailPayload{
+		UserID:         userID,
+		Certifications: certifications,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskCertificationExpiry, payload,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(30*time.Second)), nil
+}
@@ -0,0 +1,35 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskEraseAccount = "account:erase"
+)
+
+type EraseAccountPayload struct {
+	ErasureID string `json:"erasure_id"`
+}
+
+// NewEraseAccountTask creates the task erasing a user's account. The task ID
+// is the erasure ID, so an erasure is only ever queued once. Erasure works in
+// batches and can resume where a failed attempt stopped.
+func NewEraseAccountTask(erasureID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(EraseAccountPayload{
+		ErasureID: erasureID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskEraseAccount, payload,
+		asynq.TaskID(erasureID.String()),
+		asynq.MaxRetry(5),
+		asynq.Queue("low"),
+		asynq.Timeout(30*time.Minute)), nil
+}
@@ -0,0 +1,129 @@
+package job
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskDispatchOutbox = "outbox:dispatch"
+	TaskPruneOutbox    = "outbox:prune"
+)
+
+// Domain event types. Events written to the outbox are dispatched as tasks of
+// the same type, so every event type needs a registered handler.
+const (
+	EventReviewSubmitted = "event:review.submitted"
+	EventReviewDecided   = "event:review.decided"
+	// Resume events are raised by database triggers; see 012_webhooks.sql
+	EventResumeUpdated = "event:resume.updated"
+	EventResumeDeleted = "event:resume.deleted"
+)
+
+// eventRetention keeps completed event tasks, and the side effects enqueued
+// by their handlers, long enough for redeliveries to be recognised
+const eventRetention = 24 * time.Hour
+
+// DomainEventPayload is the task payload of a dispatched domain event. The
+// event ID is stable across redeliveries and keys the side effects of handlers.
+type DomainEventPayload struct {
+	EventID string          `json:"event_id"`
+	Data    json.RawMessage `json:"data"`
+}
+
+// ReviewSubmittedEvent is raised when a resume is submitted or resubmitted
+// for review
+type ReviewSubmittedEvent struct {
+	ReviewID    string `json:"review_id"`
+	ReviewerID  string `json:"reviewer_id"`
+	ResumeTitle string `json:"resume_title"`
+	Message     string `json:"message"`
+	ReviewURL   string `json:"review_url"`
+}
+
+// ReviewDecidedEvent is raised when a reviewer requests changes or approves
+type ReviewDecidedEvent struct {
+	ReviewID    string `json:"review_id"`
+	SubmitterID string `json:"submitter_id"`
+	ResumeTitle string `json:"resume_title"`
+	Decision    string `json:"decision"`
+	Note        string `json:"note"`
+	ReviewURL   string `json:"review_url"`
+}
+
+// ResumeChangedEvent is raised when a resume or its content changes, and when
+// a resume is moved to the trash
+type ResumeChangedEvent struct {
+	ResumeID string `json:"resume_id"`
+}
+
+func NewDispatchOutboxTask() *asynq.Task {
+	return asynq.NewTask(TaskDispatchOutbox, nil,
+		asynq.MaxRetry(0),
+		asynq.Queue("critical"),
+		asynq.Timeout(time.Minute),
+		// Runs that overlap would only skip each other's locked events
+		asynq.Unique(time.Minute))
+}
+
+func NewPruneOutboxTask() *asynq.Task {
+	return asynq.NewTask(TaskPruneOutbox, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute),
+		// Only one prune should be pending at any time
+		asynq.Unique(time.Hour))
+}
+
+// NewDomainEventTask wraps an outbox event in a task. The task ID is the event
+// ID, so an event dispatched twice is only enqueued once.
+func NewDomainEventTask(eventID uuid.UUID, eventType string, data json.RawMessage) (*asynq.Task, error) {
+	payload, err := json.Marshal(DomainEventPayload{
+		EventID: eventID.String(),
+		Data:    data,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(eventType, payload,
+		asynq.TaskID(eventID.String()),
+		asynq.MaxRetry(10),
+		asynq.Queue("default"),
+		asynq.Timeout(30*time.Second),
+		asynq.Retention(eventRetention)), nil
+}
+
+// EventHandler adapts a typed domain event handler to a task handler
+func EventHandler[T any](fn func(ctx context.Context, eventID string, data T) error) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		var p DomainEventPayload
+		if err := json.Unmarshal(t.Payload(), &p); err != nil {
+			return fmt.Errorf("failed to unmarshal %s event payload: %w", t.Type(), err)
+		}
+
+		var data T
+		if err := json.Unmarshal(p.Data, &data); err != nil {
+			return fmt.Errorf("failed to unmarshal %s event data: %w", t.Type(), err)
+		}
+
+		return fn(ctx, p.EventID, data)
+	}
+}
+
+// EnqueueOnce enqueues a side effect of a domain event or an incoming webhook.
+// The key makes the side effect idempotent: handling the same event again does
+// not repeat it.
+func (j *JobService) EnqueueOnce(ctx context.Context, key string, task *asynq.Task) error {
+	_, err := j.Client.EnqueueContext(ctx, task, asynq.TaskID(key), asynq.Retention(eventRetention))
+	if err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue %s task: %w", task.Type(), err)
+	}
+	return nil
+}
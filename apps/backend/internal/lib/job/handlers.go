@@ -17,6 +17,27 @@ func (j *JobService) InitHandlers(config *config.Config, logger *zerolog.Logger)
 	emailClient = email.NewClient(config, logger)
 }
 
+// SetEmailDeliveryLog records the emails sent by tasks in the delivery log
+func (j *JobService) SetEmailDeliveryLog(deliveries email.DeliveryLog) {
+	emailClient.SetDeliveryLog(deliveries)
+}
+
+// SetEmailPreferences skips the emails users unsubscribed from
+func (j *JobService) SetEmailPreferences(preferences email.PreferenceChecker) {
+	emailClient.SetPreferences(preferences)
+}
+
+// emailEnvelope addresses the email sent by the running task. The task ID is
+// kept across retries, so it is the idempotency key of the email.
+func emailEnvelope(ctx context.Context, to, userID string) email.Envelope {
+	taskID, _ := asynq.GetTaskID(ctx)
+	return email.Envelope{
+		To:             to,
+		UserID:         userID,
+		IdempotencyKey: taskID,
+	}
+}
+
 func (j *JobService) handleWelcomeEmailTask(ctx context.Context, t *asynq.Task) error {
 	var p WelcomeEmailPayload
 	if err := json.Unmarshal(t.Payload(), &p); err != nil {
@@ -29,7 +50,8 @@ func (j *JobService) handleWelcomeEmailTask(ctx context.Context, t *asynq.Task)
 		Msg("Processing welcome email task")
 
 	err := emailClient.SendWelcomeEmail(
-		p.To,
+		ctx,
+		emailEnvelope(ctx, p.To, p.UserID),
 		p.FirstName,
 	)
 	if err != nil {
@@ -47,3 +69,210 @@ func (j *JobService) handleWelcomeEmailTask(ctx context.Context, t *asynq.Task)
 		Msg("Successfully sent welcome email")
 	return nil
 }
+
+func (j *JobService) handleCollaboratorInviteEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p CollaboratorInviteEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal collaborator invite email payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "collaborator_invite").
+		Str("to", p.To).
+		Msg("Processing collaborator invite email task")
+
+	err := emailClient.SendCollaboratorInviteEmail(
+		ctx,
+		emailEnvelope(ctx, p.To, ""),
+		p.ResumeTitle,
+		p.Role,
+		p.AcceptURL,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "collaborator_invite").
+			Str("to", p.To).
+			Err(err).
+			Msg("Failed to send collaborator invite email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "collaborator_invite").
+		Str("to", p.To).
+		Msg("Successfully sent collaborator invite email")
+	return nil
+}
+
+func (j *JobService) handleReviewRequestedEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p ReviewRequestedEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal review requested email payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "review_requested").
+		Str("user_id", p.UserID).
+		Msg("Processing review requested email task")
+
+	to, err := lookupUserEmail(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = emailClient.SendReviewRequestedEmail(
+		ctx,
+		emailEnvelope(ctx, to, p.UserID),
+		p.ResumeTitle,
+		p.Message,
+		p.ReviewURL,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "review_requested").
+			Str("user_id", p.UserID).
+			Err(err).
+			Msg("Failed to send review requested email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "review_requested").
+		Str("user_id", p.UserID).
+		Msg("Successfully sent review requested email")
+	return nil
+}
+
+func (j *JobService) handleReviewDecisionEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p ReviewDecisionEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal review decision email payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "review_decision").
+		Str("user_id", p.UserID).
+		Msg("Processing review decision email task")
+
+	to, err := lookupUserEmail(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = emailClient.SendReviewDecisionEmail(
+		ctx,
+		emailEnvelope(ctx, to, p.UserID),
+		p.ResumeTitle,
+		p.Decision,
+		p.Note,
+		p.ReviewURL,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "review_decision").
+			Str("user_id", p.UserID).
+			Err(err).
+			Msg("Failed to send review decision email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "review_decision").
+		Str("user_id", p.UserID).
+		Msg("Successfully sent review decision email")
+	return nil
+}
+
+func (j *JobService) handleDataExportReadyEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p DataExportReadyEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal data export ready email payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "data_export_ready").
+		Str("user_id", p.UserID).
+		Msg("Processing data export ready email task")
+
+	to, err := lookupUserEmail(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = emailClient.SendDataExportReadyEmail(
+		ctx,
+		emailEnvelope(ctx, to, p.UserID),
+		p.DownloadURL,
+		p.ExpiresAt,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "data_export_ready").
+			Str("user_id", p.UserID).
+			Err(err).
+			Msg("Failed to send data export ready email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "data_export_ready").
+		Str("user_id", p.UserID).
+		Msg("Successfully sent data export ready email")
+	return nil
+}
+
+func (j *JobService) handleCertificationExpiryEmailTask(ctx context.Context, t *asynq.Task) error {
+	var p CertificationExpiryEmailPayload
+	if err := json.Unmarshal(t.Payload(), &p); err != nil {
+		return fmt.Errorf("failed to unmarshal certification expiry email payload: %w", err)
+	}
+
+	j.logger.Info().
+		Str("type", "certification_expiry").
+		Str("user_id", p.UserID).
+		Int("certifications", len(p.Certifications)).
+		Msg("Processing certification expiry email task")
+
+	to, err := lookupUserEmail(ctx, p.UserID)
+	if err != nil {
+		return err
+	}
+
+	err = emailClient.SendCertificationExpiryEmail(
+		ctx,
+		emailEnvelope(ctx, to, p.UserID),
+		p.Certifications,
+	)
+	if err != nil {
+		j.logger.Error().
+			Str("type", "certification_expiry").
+			Str("user_id", p.UserID).
+			Err(err).
+			Msg("Failed to send certification expiry email")
+		return err
+	}
+
+	j.logger.Info().
+		Str("type", "certification_expiry").
+		Str("user_id", p.UserID).
+		Msg("Successfully sent certification expiry email")
+	return nil
+}
+
+func (j *JobService) handleReviewSubmittedEvent(ctx context.Context, eventID string, e ReviewSubmittedEvent) error {
+	task, err := NewReviewRequestedEmailTask(e.ReviewerID, e.ResumeTitle, e.Message, e.ReviewURL)
+	if err != nil {
+		return fmt.Errorf("failed to create review requested email task: %w", err)
+	}
+
+	return j.EnqueueOnce(ctx, eventID+":"+TaskReviewRequested, task)
+}
+
+func (j *JobService) handleReviewDecidedEvent(ctx context.Context, eventID string, e ReviewDecidedEvent) error {
+	task, err := NewReviewDecisionEmailTask(e.SubmitterID, e.ResumeTitle, e.Decision, e.Note, e.ReviewURL)
+	if err != nil {
+		return fmt.Errorf("failed to create review decision email task: %w", err)
+	}
+
+	return j.EnqueueOnce(ctx, eventID+":"+TaskReviewDecision, task)
+}
@@ -1,50 +1,205 @@
 package job
 
 import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
 	"github.com/hibiken/asynq"
+	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/rs/zerolog"
 	"github.com/recreatedev/Resumify/internal/config"
 )
 
 type JobService struct {
-	Client *asynq.Client
-	server *asynq.Server
-	logger *zerolog.Logger
+	Client    *asynq.Client
+	server    *asynq.Server
+	scheduler *asynq.Scheduler
+	inspector *asynq.Inspector
+	mux       *asynq.ServeMux
+	logger    *zerolog.Logger
+	newRelic  *newrelic.Application
 }
 
-func NewJobService(logger *zerolog.Logger, cfg *config.Config) *JobService {
+// NewJobService creates the job client and server. The New Relic application
+// records the runs of recurring jobs and may be nil.
+func NewJobService(logger *zerolog.Logger, cfg *config.Config, newRelic *newrelic.Application) *JobService {
 	redisAddr := cfg.Redis.Address
 
 	client := asynq.NewClient(asynq.RedisClientOpt{
 		Addr: redisAddr,
 	})
 
+	// Tasks go to the critical, default or low queue; the worker polls them
+	// in proportion to their weights
 	server := asynq.NewServer(
 		asynq.RedisClientOpt{Addr: redisAddr},
 		asynq.Config{
-			Concurrency: 10,
-			Queues: map[string]int{
-				"critical": 6, // Higher priority queue for important emails
-				"default":  3, // Default priority for most emails
-				"low":      1, // Lower priority for non-urgent emails
-			},
+			Concurrency:     cfg.Worker.Concurrency,
+			Queues:          cfg.Worker.Queues(),
+			ShutdownTimeout: time.Duration(cfg.Worker.ShutdownTimeout) * time.Second,
+			RetryDelayFunc:  retryDelay,
 		},
 	)
 
+	scheduler := asynq.NewScheduler(
+		asynq.RedisClientOpt{Addr: redisAddr},
+		&asynq.SchedulerOpts{},
+	)
+
+	inspector := asynq.NewInspector(asynq.RedisClientOpt{Addr: redisAddr})
+
 	return &JobService{
-		Client: client,
-		server: server,
-		logger: logger,
+		Client:    client,
+		server:    server,
+		scheduler: scheduler,
+		inspector: inspector,
+		mux:       asynq.NewServeMux(),
+		logger:    logger,
+		newRelic:  newRelic,
+	}
+}
+
+// HandleFunc registers a handler for a task type. Handlers must be
+// registered before Start is called.
+func (j *JobService) HandleFunc(taskType string, handler func(context.Context, *asynq.Task) error) {
+	j.mux.HandleFunc(taskType, handler)
+}
+
+// RegisterPeriodicTask enqueues the task on every tick of the cron spec once
+// the job server is started.
+func (j *JobService) RegisterPeriodicTask(cronspec string, task *asynq.Task) error {
+	entryID, err := j.scheduler.Register(cronspec, task)
+	if err != nil {
+		return fmt.Errorf("failed to register periodic task %s with cronspec %q: %w", task.Type(), cronspec, err)
+	}
+
+	j.logger.Info().
+		Str("task", task.Type()).
+		Str("cronspec", cronspec).
+		Str("entry_id", entryID).
+		Msg("Registered periodic task")
+
+	return nil
+}
+
+// CancelTasks deletes the pending, scheduled and retrying tasks of every
+// queue that match. Tasks that are already running are left to finish. It
+// returns the number of tasks deleted.
+func (j *JobService) CancelTasks(match func(*asynq.TaskInfo) bool) (int, error) {
+	queues, err := j.inspector.Queues()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list queues: %w", err)
 	}
+
+	cancelled := 0
+	for _, queue := range queues {
+		listers := []func(string, ...asynq.ListOption) ([]*asynq.TaskInfo, error){
+			j.inspector.ListPendingTasks,
+			j.inspector.ListScheduledTasks,
+			j.inspector.ListRetryTasks,
+		}
+
+		// Collect the matches first, deleting would shift the pages
+		var ids []string
+		for _, list := range listers {
+			for page := 1; ; page++ {
+				tasks, err := list(queue, asynq.PageSize(100), asynq.Page(page))
+				if err != nil {
+					return cancelled, fmt.Errorf("failed to list tasks of queue %s: %w", queue, err)
+				}
+				for _, task := range tasks {
+					if match(task) {
+						ids = append(ids, task.ID)
+					}
+				}
+				if len(tasks) < 100 {
+					break
+				}
+			}
+		}
+
+		for _, id := range ids {
+			err := j.inspector.DeleteTask(queue, id)
+			// The task started or finished in the meantime
+			if errors.Is(err, asynq.ErrTaskNotFound) {
+				continue
+			}
+			if err != nil {
+				return cancelled, fmt.Errorf("failed to delete task %s of queue %s: %w", id, queue, err)
+			}
+			cancelled++
+		}
+	}
+
+	return cancelled, nil
+}
+
+// PruneCompletedTasks deletes the records of tasks that completed more than
+// olderThan ago from every queue. It returns the number of records deleted.
+func (j *JobService) PruneCompletedTasks(olderThan time.Duration) (int, error) {
+	queues, err := j.inspector.Queues()
+	if err != nil {
+		return 0, fmt.Errorf("failed to list queues: %w", err)
+	}
+
+	cutoff := time.Now().Add(-olderThan)
+	pruned := 0
+	for _, queue := range queues {
+		// Collect the records first, deleting would shift the pages
+		var ids []string
+		for page := 1; ; page++ {
+			tasks, err := j.inspector.ListCompletedTasks(queue, asynq.PageSize(100), asynq.Page(page))
+			if err != nil {
+				return pruned, fmt.Errorf("failed to list completed tasks of queue %s: %w", queue, err)
+			}
+			for _, task := range tasks {
+				if task.CompletedAt.Before(cutoff) {
+					ids = append(ids, task.ID)
+				}
+			}
+			if len(tasks) < 100 {
+				break
+			}
+		}
+
+		for _, id := range ids {
+			err := j.inspector.DeleteTask(queue, id)
+			// The record expired in the meantime
+			if errors.Is(err, asynq.ErrTaskNotFound) {
+				continue
+			}
+			if err != nil {
+				return pruned, fmt.Errorf("failed to delete completed task %s of queue %s: %w", id, queue, err)
+			}
+			pruned++
+		}
+	}
+
+	return pruned, nil
 }
 
 func (j *JobService) Start() error {
 	// Register task handlers
-	mux := asynq.NewServeMux()
-	mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
+	j.mux.HandleFunc(TaskWelcome, j.handleWelcomeEmailTask)
+	j.mux.HandleFunc(TaskCollaboratorInvite, j.handleCollaboratorInviteEmailTask)
+	j.mux.HandleFunc(TaskReviewRequested, j.handleReviewRequestedEmailTask)
+	j.mux.HandleFunc(TaskReviewDecision, j.handleReviewDecisionEmailTask)
+	j.mux.HandleFunc(TaskDataExportReady, j.handleDataExportReadyEmailTask)
+	j.mux.HandleFunc(TaskCertificationExpiry, j.handleCertificationExpiryEmailTask)
+
+	// Register domain event handlers
+	j.mux.HandleFunc(EventReviewSubmitted, EventHandler(j.handleReviewSubmittedEvent))
+	j.mux.HandleFunc(EventReviewDecided, EventHandler(j.handleReviewDecidedEvent))
 
 	j.logger.Info().Msg("Starting background job server")
-	if err := j.server.Start(mux); err != nil {
+	if err := j.server.Start(j.mux); err != nil {
+		return err
+	}
+
+	j.logger.Info().Msg("Starting periodic task scheduler")
+	if err := j.scheduler.Start(); err != nil {
 		return err
 	}
 
@@ -53,6 +208,8 @@ func (j *JobService) Start() error {
 
 func (j *JobService) Stop() {
 	j.logger.Info().Msg("Stopping background job server")
+	j.scheduler.Shutdown()
 	j.server.Shutdown()
+	j.inspector.Close()
 	j.Client.Close()
 }
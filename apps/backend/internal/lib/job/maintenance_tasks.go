@@ -0,0 +1,40 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskPruneCompletedTasks = "maintenance:prune_completed_tasks"
+	TaskCompactOrderIndexes = "maintenance:compact_order_indexes"
+	TaskCheckSections       = "maintenance:check_sections"
+)
+
+func NewPruneCompletedTasksTask() *asynq.Task {
+	return asynq.NewTask(TaskPruneCompletedTasks, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute),
+		// Only one prune should be pending at any time
+		asynq.Unique(time.Hour))
+}
+
+func NewCompactOrderIndexesTask() *asynq.Task {
+	return asynq.NewTask(TaskCompactOrderIndexes, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute),
+		// Only one compaction should be pending at any time
+		asynq.Unique(time.Hour))
+}
+
+func NewCheckSectionsTask() *asynq.Task {
+	return asynq.NewTask(TaskCheckSections, nil,
+		asynq.MaxRetry(1),
+		asynq.Queue("low"),
+		asynq.Timeout(10*time.Minute),
+		// Only one check should be pending at any time
+		asynq.Unique(time.Hour))
+}
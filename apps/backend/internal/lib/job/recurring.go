@@ -0,0 +1,79 @@
+package job
+
+import (
+	"context"
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+// RecurringJob is a task the scheduler enqueues on a cron schedule. Run does
+// one run of the job and returns the number of items it handled.
+type RecurringJob struct {
+	Cron string
+	Task *asynq.Task
+	Run  func(ctx context.Context) (int64, error)
+}
+
+// RegisterRecurringJob handles the job's task with its Run and schedules the
+// task on the job's cron spec. Every run is logged and recorded in New Relic
+// as RecurringJobStarted and RecurringJobFinished events.
+func (j *JobService) RegisterRecurringJob(job RecurringJob) error {
+	j.mux.HandleFunc(job.Task.Type(), j.recurringJobHandler(job))
+	return j.RegisterPeriodicTask(job.Cron, job.Task)
+}
+
+func (j *JobService) recurringJobHandler(job RecurringJob) func(context.Context, *asynq.Task) error {
+	return func(ctx context.Context, t *asynq.Task) error {
+		start := time.Now()
+
+		j.logger.Debug().
+			Str("job", t.Type()).
+			Msg("Recurring job started")
+		j.recordJobEvent("RecurringJobStarted", map[string]interface{}{
+			"job": t.Type(),
+		})
+
+		processed, err := job.Run(ctx)
+		duration := time.Since(start)
+
+		if err != nil {
+			j.logger.Error().
+				Err(err).
+				Str("job", t.Type()).
+				Dur("duration", duration).
+				Msg("Recurring job failed")
+			j.recordJobEvent("RecurringJobFinished", map[string]interface{}{
+				"job":           t.Type(),
+				"status":        "failed",
+				"duration_ms":   duration.Milliseconds(),
+				"error_message": err.Error(),
+			})
+			return err
+		}
+
+		// Runs with nothing to do are frequent for jobs on short schedules
+		logEvent := j.logger.Debug()
+		if processed > 0 {
+			logEvent = j.logger.Info()
+		}
+		logEvent.
+			Str("job", t.Type()).
+			Int64("processed", processed).
+			Dur("duration", duration).
+			Msg("Recurring job finished")
+		j.recordJobEvent("RecurringJobFinished", map[string]interface{}{
+			"job":         t.Type(),
+			"status":      "succeeded",
+			"duration_ms": duration.Milliseconds(),
+			"processed":   processed,
+		})
+		return nil
+	}
+}
+
+func (j *JobService) recordJobEvent(eventType string, params map[string]interface{}) {
+	if j.newRelic != nil {
+		j.newRelic.RecordCustomEvent(eventType, params)
+	}
+}
@@ -0,0 +1,44 @@
+package job
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskRenderResumeExport = "resume_export:render"
+	TaskPurgeResumeExports = "resume_export:purge"
+)
+
+type RenderResumeExportPayload struct {
+	ExportID string `json:"export_id"`
+}
+
+// NewRenderResumeExportTask creates the task rendering a resume export. The
+// task ID is the export ID, so an export is only ever queued once.
+func NewRenderResumeExportTask(exportID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(RenderResumeExportPayload{
+		ExportID: exportID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskRenderResumeExport, payload,
+		asynq.TaskID(exportID.String()),
+		asynq.MaxRetry(3),
+		asynq.Queue("default"),
+		asynq.Timeout(5*time.Minute)), nil
+}
+
+func NewPurgeResumeExportsTask() *asynq.Task {
+	return asynq.NewTask(TaskPurgeResumeExports, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute),
+		// Only one purge should be pending at any time
+		asynq.Unique(time.Hour))
+}
@@ -0,0 +1,20 @@
+package job
+
+import (
+	"time"
+
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskPurgeTrash = "resume:purge_trash"
+)
+
+func NewPurgeTrashTask() *asynq.Task {
+	return asynq.NewTask(TaskPurgeTrash, nil,
+		asynq.MaxRetry(3),
+		asynq.Queue("low"),
+		asynq.Timeout(5*time.Minute),
+		// Only one purge should be pending at any time
+		asynq.Unique(time.Hour))
+}
@@ -0,0 +1,24 @@
+package job
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/clerk/clerk-sdk-go/v2/user"
+)
+
+// lookupUserEmail returns the primary email address of a Clerk user
+func lookupUserEmail(ctx context.Context, userID string) (string, error) {
+	clerkUser, err := user.Get(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get clerk user %s: %w", userID, err)
+	}
+
+	for _, address := range clerkUser.EmailAddresses {
+		if clerkUser.PrimaryEmailAddressID != nil && address.ID == *clerkUser.PrimaryEmailAddressID {
+			return address.EmailAddress, nil
+		}
+	}
+
+	return "", fmt.Errorf("clerk user %s has no primary email address", userID)
+}
@@ -0,0 +1,59 @@
+package job
+
+import (
+	"encoding/json"
+	"math"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+)
+
+const (
+	TaskWebhookDelivery = "webhook:deliver"
+)
+
+const (
+	// webhookMaxRetry retries a failing delivery for about eight hours
+	webhookMaxRetry = 10
+	// webhookRetryDelay is the delay before the first retry; it doubles with
+	// every further retry up to webhookMaxRetryDelay
+	webhookRetryDelay    = 30 * time.Second
+	webhookMaxRetryDelay = 6 * time.Hour
+)
+
+type WebhookDeliveryPayload struct {
+	DeliveryID string `json:"delivery_id"`
+}
+
+// NewWebhookDeliveryTask creates the task delivering a webhook. The task ID is
+// the delivery ID, so a delivery is only ever queued once.
+func NewWebhookDeliveryTask(deliveryID uuid.UUID) (*asynq.Task, error) {
+	payload, err := json.Marshal(WebhookDeliveryPayload{
+		DeliveryID: deliveryID.String(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return asynq.NewTask(TaskWebhookDelivery, payload,
+		asynq.TaskID(deliveryID.String()),
+		asynq.MaxRetry(webhookMaxRetry),
+		asynq.Queue("default"),
+		asynq.Timeout(time.Minute),
+		asynq.Retention(eventRetention)), nil
+}
+
+// retryDelay backs webhook deliveries off exponentially; other tasks use the
+// asynq default
+func retryDelay(n int, err error, t *asynq.Task) time.Duration {
+	if t.Type() != TaskWebhookDelivery {
+		return asynq.DefaultRetryDelayFunc(n, err, t)
+	}
+
+	delay := time.Duration(float64(webhookRetryDelay) * math.Pow(2, float64(n)))
+	if delay > webhookMaxRetryDelay || delay <= 0 {
+		delay = webhookMaxRetryDelay
+	}
+	return delay
+}
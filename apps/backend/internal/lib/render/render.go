@@ -0,0 +1,48 @@
+package render
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"html/template"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/model/composite"
+)
+
+//go:embed templates/*.html
+var templates embed.FS
+
+var resumeTemplate = template.Must(template.New("resume.html").Funcs(template.FuncMap{
+	"date": formatDate,
+}).ParseFS(templates, "templates/resume.html"))
+
+// HTML renders a resume document as a standalone HTML page. Collections
+// whose section is hidden are left out.
+func HTML(document *composite.ResumeWithSections) ([]byte, error) {
+	hidden := make(map[string]bool, len(document.Sections))
+	for _, section := range document.Sections {
+		if !section.IsVisible {
+			hidden[section.Name] = true
+		}
+	}
+
+	var body bytes.Buffer
+	err := resumeTemplate.Execute(&body, struct {
+		*composite.ResumeWithSections
+		Hidden map[string]bool
+	}{document, hidden})
+	if err != nil {
+		return nil, fmt.Errorf("failed to render resume %s: %w", document.Resume.ID.String(), err)
+	}
+
+	return body.Bytes(), nil
+}
+
+// formatDate formats an optional date as "Jan 2006"
+func formatDate(t *time.Time) string {
+	if t == nil {
+		return ""
+	}
+	return t.Format("Jan 2006")
+}
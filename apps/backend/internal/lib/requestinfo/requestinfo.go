@@ -0,0 +1,25 @@
+package requestinfo
+
+import "context"
+
+type contextKey struct{}
+
+// Info identifies the HTTP request that caused a change
+type Info struct {
+	RequestID string
+	IPAddress string
+}
+
+// WithInfo returns a copy of ctx carrying the request info
+func WithInfo(ctx context.Context, info Info) context.Context {
+	return context.WithValue(ctx, contextKey{}, info)
+}
+
+// FromContext returns the request info carried by ctx. Work not started by a
+// request, such as background jobs, has none.
+func FromContext(ctx context.Context) Info {
+	if info, ok := ctx.Value(contextKey{}).(Info); ok {
+		return info
+	}
+	return Info{}
+}
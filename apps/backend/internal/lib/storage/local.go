@@ -0,0 +1,163 @@
+package storage
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"mime"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/signedurl"
+)
+
+// LocalDownloadPrefix is the path under which the API serves signed links to
+// blobs of the local backend
+const LocalDownloadPrefix = "/downloads/storage/"
+
+// LocalStorage keeps blobs as files under a directory, for local development
+// and single-instance deployments. Signed links point at this API. Content
+// types are not stored; they are inferred from the key's extension.
+type LocalStorage struct {
+	dir       string
+	signer    *signedurl.Signer
+	publicURL string
+}
+
+func NewLocalStorage(dir, secret, publicURL string) *LocalStorage {
+	return &LocalStorage{
+		dir:       dir,
+		signer:    signedurl.NewSigner(secret),
+		publicURL: strings.TrimRight(publicURL, "/"),
+	}
+}
+
+func (s *LocalStorage) Name() string {
+	return BackendLocal
+}
+
+// Put writes the blob to a temporary file first, so readers never see a
+// partly written blob
+func (s *LocalStorage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("failed to create storage directory for %s: %w", key, err)
+	}
+
+	suffix := make([]byte, 4)
+	if _, err := rand.Read(suffix); err != nil {
+		return fmt.Errorf("failed to name temporary file for %s: %w", key, err)
+	}
+	tmp := path + ".tmp-" + hex.EncodeToString(suffix)
+
+	file, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0o644)
+	if err != nil {
+		return fmt.Errorf("failed to create %s: %w", key, err)
+	}
+	defer os.Remove(tmp)
+
+	written, err := io.Copy(file, content)
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("failed to write %s: %w", key, err)
+	}
+	if written != size {
+		return fmt.Errorf("failed to write %s: got %d bytes, expected %d", key, written, size)
+	}
+
+	if err := os.Rename(tmp, path); err != nil {
+		return fmt.Errorf("failed to store %s: %w", key, err)
+	}
+
+	return nil
+}
+
+func (s *LocalStorage) Get(ctx context.Context, key string) (*Object, error) {
+	path, err := s.path(key)
+	if err != nil {
+		return nil, err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		if errors.Is(err, fs.ErrNotExist) {
+			return nil, ErrNotFound
+		}
+		return nil, fmt.Errorf("failed to open %s: %w", key, err)
+	}
+
+	info, err := file.Stat()
+	if err != nil {
+		file.Close()
+		return nil, fmt.Errorf("failed to stat %s: %w", key, err)
+	}
+
+	contentType := mime.TypeByExtension(filepath.Ext(path))
+	if contentType == "" {
+		contentType = "application/octet-stream"
+	}
+
+	return &Object{
+		Content:     file,
+		Size:        info.Size(),
+		ContentType: contentType,
+	}, nil
+}
+
+func (s *LocalStorage) Delete(ctx context.Context, key string) error {
+	path, err := s.path(key)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+
+	return nil
+}
+
+// SignedURL links to the API's download route for the blob, authorized by an
+// HMAC signature that expires
+func (s *LocalStorage) SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	path := s.downloadPath(key)
+	return fmt.Sprintf("%s%s?%s", s.publicURL, path, s.signer.Sign(path, expiresAt)), nil
+}
+
+// VerifySignedURL checks the expires and signature query parameters of a
+// request for the blob under key. The errors are those of signedurl.
+func (s *LocalStorage) VerifySignedURL(key, expires, signature string, now time.Time) error {
+	if err := validateKey(key); err != nil {
+		return signedurl.ErrInvalidSignature
+	}
+	return s.signer.Verify(s.downloadPath(key), expires, signature, now)
+}
+
+func (s *LocalStorage) path(key string) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+	return filepath.Join(s.dir, filepath.FromSlash(key)), nil
+}
+
+// downloadPath is the path of the download route for the blob, which is what
+// gets signed
+func (s *LocalStorage) downloadPath(key string) string {
+	return LocalDownloadPrefix + key
+}
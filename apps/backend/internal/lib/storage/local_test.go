@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/signedurl"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLocalStorageSignedURL(t *testing.T) {
+	ctx := context.Background()
+	s := NewLocalStorage(t.TempDir(), "test-secret", "https://api.example.com/")
+	now := time.Now().Truncate(time.Second)
+
+	signed, err := s.SignedURL(ctx, "exports/report.html", now.Add(time.Hour))
+	require.NoError(t, err)
+	require.True(t, strings.HasPrefix(signed, "https://api.example.com"+LocalDownloadPrefix+"exports/report.html?"), signed)
+
+	u, err := url.Parse(signed)
+	require.NoError(t, err)
+	expires := u.Query().Get("expires")
+	signature := u.Query().Get("signature")
+	key := strings.TrimPrefix(u.Path, LocalDownloadPrefix)
+
+	t.Run("valid until it expires", func(t *testing.T) {
+		assert.NoError(t, s.VerifySignedURL(key, expires, signature, now))
+		assert.NoError(t, s.VerifySignedURL(key, expires, signature, now.Add(time.Hour)))
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		err := s.VerifySignedURL(key, expires, signature, now.Add(time.Hour+time.Second))
+		assert.ErrorIs(t, err, signedurl.ErrExpired)
+	})
+
+	t.Run("tampered", func(t *testing.T) {
+		laterExpiry := strconv.FormatInt(now.Add(24*time.Hour).Unix(), 10)
+
+		tests := []struct {
+			name      string
+			key       string
+			expires   string
+			signature string
+		}{
+			{"other key", "exports/other.html", expires, signature},
+			{"extended expiry", key, laterExpiry, signature},
+			{"altered signature", key, expires, strings.Repeat("0", len(signature))},
+			{"missing signature", key, expires, ""},
+			{"malformed expiry", key, "tomorrow", signature},
+			{"key escaping the storage root", "../" + key, expires, signature},
+		}
+
+		for _, tt := range tests {
+			t.Run(tt.name, func(t *testing.T) {
+				err := s.VerifySignedURL(tt.key, tt.expires, tt.signature, now)
+				assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+			})
+		}
+	})
+
+	t.Run("signed with another secret", func(t *testing.T) {
+		other := NewLocalStorage(t.TempDir(), "other-secret", "https://api.example.com")
+		err := other.VerifySignedURL(key, expires, signature, now)
+		assert.ErrorIs(t, err, signedurl.ErrInvalidSignature)
+	})
+
+	t.Run("rejects invalid keys", func(t *testing.T) {
+		_, err := s.SignedURL(ctx, "exports/../../etc/passwd", now.Add(time.Hour))
+		assert.ErrorIs(t, err, ErrInvalidKey)
+	})
+}
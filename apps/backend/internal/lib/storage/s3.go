@@ -0,0 +1,303 @@
+package storage
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// unsignedPayload tells S3 the request body is not part of the signature,
+// so uploads can be streamed
+const unsignedPayload = "UNSIGNED-PAYLOAD"
+
+// maxPresignDuration is the longest validity S3 accepts for a presigned URL
+const maxPresignDuration = 7 * 24 * time.Hour
+
+// S3Storage keeps blobs in a bucket of an S3-compatible service, such as AWS
+// S3, MinIO or R2. Requests are signed with AWS Signature Version 4.
+type S3Storage struct {
+	endpoint  *url.URL
+	region    string
+	bucket    string
+	accessKey string
+	secretKey string
+	pathStyle bool
+	client    *http.Client
+}
+
+func NewS3Storage(endpoint, region, bucket, accessKey, secretKey string, pathStyle bool) (*S3Storage, error) {
+	u, err := url.Parse(endpoint)
+	if err != nil || u.Host == "" {
+		return nil, fmt.Errorf("invalid s3 endpoint %q", endpoint)
+	}
+
+	return &S3Storage{
+		endpoint:  u,
+		region:    region,
+		bucket:    bucket,
+		accessKey: accessKey,
+		secretKey: secretKey,
+		pathStyle: pathStyle,
+		client:    &http.Client{Timeout: 5 * time.Minute},
+	}, nil
+}
+
+func (s *S3Storage) Name() string {
+	return BackendS3
+}
+
+func (s *S3Storage) Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.objectURL(key).String(), content)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request for %s: %w", key, err)
+	}
+	// S3 rejects uploads without a length
+	req.ContentLength = size
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to put %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("failed to put %s: %w", key, responseError(resp))
+	}
+
+	return nil
+}
+
+func (s *S3Storage) Get(ctx context.Context, key string) (*Object, error) {
+	if err := validateKey(key); err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.objectURL(key).String(), nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create s3 request for %s: %w", key, err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get %s: %w", key, err)
+	}
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		return &Object{
+			Content:     resp.Body,
+			Size:        resp.ContentLength,
+			ContentType: resp.Header.Get("Content-Type"),
+		}, nil
+	case http.StatusNotFound:
+		resp.Body.Close()
+		return nil, ErrNotFound
+	default:
+		defer resp.Body.Close()
+		return nil, fmt.Errorf("failed to get %s: %w", key, responseError(resp))
+	}
+}
+
+func (s *S3Storage) Delete(ctx context.Context, key string) error {
+	if err := validateKey(key); err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodDelete, s.objectURL(key).String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request for %s: %w", key, err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to delete %s: %w", key, err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusNoContent && resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNotFound {
+		return fmt.Errorf("failed to delete %s: %w", key, responseError(resp))
+	}
+
+	return nil
+}
+
+// SignedURL presigns a GET of the blob. S3 caps the validity at seven days.
+func (s *S3Storage) SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error) {
+	if err := validateKey(key); err != nil {
+		return "", err
+	}
+
+	now := time.Now().UTC()
+	validity := expiresAt.Sub(now).Round(time.Second)
+	if validity < time.Second || validity > maxPresignDuration {
+		return "", fmt.Errorf("signed url for %s must be valid between 1 second and 7 days, got %s", key, validity)
+	}
+
+	return s.presign(key, now, validity), nil
+}
+
+func (s *S3Storage) presign(key string, now time.Time, validity time.Duration) string {
+	u := s.objectURL(key)
+	amzDate := now.Format("20060102T150405Z")
+	scope := s.scope(now)
+
+	query := url.Values{}
+	query.Set("X-Amz-Algorithm", "AWS4-HMAC-SHA256")
+	query.Set("X-Amz-Credential", s.accessKey+"/"+scope)
+	query.Set("X-Amz-Date", amzDate)
+	query.Set("X-Amz-Expires", strconv.FormatInt(int64(validity/time.Second), 10))
+	query.Set("X-Amz-SignedHeaders", "host")
+
+	canonicalQuery := canonicalQueryString(query)
+	canonicalRequest := strings.Join([]string{
+		http.MethodGet,
+		u.EscapedPath(),
+		canonicalQuery,
+		"host:" + u.Host + "\n",
+		"host",
+		unsignedPayload,
+	}, "\n")
+
+	u.RawQuery = canonicalQuery + "&X-Amz-Signature=" + s.signature(now, amzDate, canonicalRequest)
+	return u.String()
+}
+
+// CreateBucket creates the configured bucket when it does not exist yet, for
+// setting up local S3 stand-ins such as MinIO
+func (s *S3Storage) CreateBucket(ctx context.Context) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPut, s.bucketURL().String(), nil)
+	if err != nil {
+		return fmt.Errorf("failed to create s3 request for bucket %s: %w", s.bucket, err)
+	}
+
+	resp, err := s.do(req)
+	if err != nil {
+		return fmt.Errorf("failed to create bucket %s: %w", s.bucket, err)
+	}
+	defer resp.Body.Close()
+
+	// 409 means the bucket exists, which is fine when it is ours
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusConflict {
+		return fmt.Errorf("failed to create bucket %s: %w", s.bucket, responseError(resp))
+	}
+
+	return nil
+}
+
+// Helper methods
+
+func (s *S3Storage) bucketURL() *url.URL {
+	u := *s.endpoint
+	if s.pathStyle {
+		u.Path = strings.TrimRight(u.Path, "/") + "/" + s.bucket + "/"
+	} else {
+		u.Host = s.bucket + "." + u.Host
+		u.Path = strings.TrimRight(u.Path, "/") + "/"
+	}
+	return &u
+}
+
+func (s *S3Storage) objectURL(key string) *url.URL {
+	u := s.bucketURL()
+	// Keys only hold characters that need no escaping
+	u.Path += key
+	return u
+}
+
+// do signs the request with the Authorization header and sends it
+func (s *S3Storage) do(req *http.Request) (*http.Response, error) {
+	now := time.Now().UTC()
+	amzDate := now.Format("20060102T150405Z")
+
+	req.Header.Set("X-Amz-Date", amzDate)
+	req.Header.Set("X-Amz-Content-Sha256", unsignedPayload)
+
+	signedHeaders := "host;x-amz-content-sha256;x-amz-date"
+	canonicalRequest := strings.Join([]string{
+		req.Method,
+		req.URL.EscapedPath(),
+		canonicalQueryString(req.URL.Query()),
+		"host:" + req.URL.Host + "\n" +
+			"x-amz-content-sha256:" + unsignedPayload + "\n" +
+			"x-amz-date:" + amzDate + "\n",
+		signedHeaders,
+		unsignedPayload,
+	}, "\n")
+
+	req.Header.Set("Authorization", fmt.Sprintf(
+		"AWS4-HMAC-SHA256 Credential=%s/%s, SignedHeaders=%s, Signature=%s",
+		s.accessKey, s.scope(now), signedHeaders, s.signature(now, amzDate, canonicalRequest),
+	))
+
+	return s.client.Do(req)
+}
+
+func (s *S3Storage) scope(now time.Time) string {
+	return now.Format("20060102") + "/" + s.region + "/s3/aws4_request"
+}
+
+// signature signs a canonical request with the key derived for the day,
+// region and service
+func (s *S3Storage) signature(now time.Time, amzDate, canonicalRequest string) string {
+	hashed := sha256.Sum256([]byte(canonicalRequest))
+	stringToSign := "AWS4-HMAC-SHA256\n" + amzDate + "\n" + s.scope(now) + "\n" + hex.EncodeToString(hashed[:])
+
+	key := hmacSHA256([]byte("AWS4"+s.secretKey), now.Format("20060102"))
+	key = hmacSHA256(key, s.region)
+	key = hmacSHA256(key, "s3")
+	key = hmacSHA256(key, "aws4_request")
+	return hex.EncodeToString(hmacSHA256(key, stringToSign))
+}
+
+func hmacSHA256(key []byte, data string) []byte {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(data))
+	return mac.Sum(nil)
+}
+
+// canonicalQueryString sorts the parameters and escapes them the way AWS
+// signs them, with spaces as %20
+func canonicalQueryString(query url.Values) string {
+	keys := make([]string, 0, len(query))
+	for key := range query {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	var pairs []string
+	for _, key := range keys {
+		values := query[key]
+		sort.Strings(values)
+		for _, value := range values {
+			pairs = append(pairs, awsEscape(key)+"="+awsEscape(value))
+		}
+	}
+	return strings.Join(pairs, "&")
+}
+
+func awsEscape(s string) string {
+	return strings.ReplaceAll(url.QueryEscape(s), "+", "%20")
+}
+
+// responseError describes a failed S3 response by its status and the start of
+// its XML error body
+func responseError(resp *http.Response) error {
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	return fmt.Errorf("s3 responded %s: %s", resp.Status, strings.TrimSpace(string(body)))
+}
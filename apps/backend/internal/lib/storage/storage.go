@@ -0,0 +1,76 @@
+package storage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/config"
+)
+
+// Storage backends selectable with RESUMIFY_STORAGE_BACKEND
+const (
+	BackendLocal = "local"
+	BackendS3    = "s3"
+)
+
+var (
+	ErrNotFound   = errors.New("object not found")
+	ErrInvalidKey = errors.New("invalid object key")
+)
+
+// Object is an open blob. The caller closes Content.
+type Object struct {
+	Content     io.ReadCloser
+	Size        int64
+	ContentType string
+}
+
+// Storage keeps blobs, such as uploads and generated exports, by key. Keys
+// are slash separated paths like "exports/<id>.html".
+type Storage interface {
+	Name() string
+	// Put stores size bytes of content under key, replacing any blob there
+	Put(ctx context.Context, key string, content io.Reader, size int64, contentType string) error
+	// Get opens the blob under key. A missing blob is ErrNotFound.
+	Get(ctx context.Context, key string) (*Object, error)
+	// Delete removes the blob under key. Deleting a missing blob succeeds.
+	Delete(ctx context.Context, key string) error
+	// SignedURL returns a link that downloads the blob under key without a
+	// session until expiresAt
+	SignedURL(ctx context.Context, key string, expiresAt time.Time) (string, error)
+}
+
+// New creates the backend selected by the storage config. Signed links of the
+// local backend are served by this API under publicURL and signed with a key
+// derived from secret.
+func New(cfg *config.StorageConfig, secret, publicURL string) (Storage, error) {
+	switch cfg.Backend {
+	case BackendLocal:
+		return NewLocalStorage(cfg.LocalDir, secret, publicURL), nil
+	case BackendS3:
+		return NewS3Storage(cfg.S3Endpoint, cfg.S3Region, cfg.S3Bucket, cfg.S3AccessKey, cfg.S3SecretKey, cfg.S3PathStyle)
+	default:
+		return nil, fmt.Errorf("unknown storage backend %q", cfg.Backend)
+	}
+}
+
+// validateKey rejects keys that could escape the storage root or that would
+// need escaping in a URL. Keys are non-empty segments of letters, digits, '-',
+// '_' and '.', separated by '/'.
+func validateKey(key string) error {
+	for _, segment := range strings.Split(key, "/") {
+		if segment == "" || segment == "." || segment == ".." {
+			return ErrInvalidKey
+		}
+		for _, c := range segment {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-' || c == '_' || c == '.') {
+				return ErrInvalidKey
+			}
+		}
+	}
+	return nil
+}
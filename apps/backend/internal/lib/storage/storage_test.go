@@ -0,0 +1,117 @@
+package storage_test
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/storage"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// testBackend runs the behavior every backend shares
+func testBackend(t *testing.T, s storage.Storage) {
+	ctx := context.Background()
+
+	read := func(t *testing.T, key string) (string, *storage.Object) {
+		t.Helper()
+
+		object, err := s.Get(ctx, key)
+		require.NoError(t, err)
+		defer object.Content.Close()
+
+		content, err := io.ReadAll(object.Content)
+		require.NoError(t, err)
+		return string(content), object
+	}
+
+	t.Run("put and get", func(t *testing.T) {
+		content := "<html><body>Ada Lovelace</body></html>"
+		err := s.Put(ctx, "exports/resume.html", strings.NewReader(content), int64(len(content)), "text/html; charset=utf-8")
+		require.NoError(t, err)
+
+		got, object := read(t, "exports/resume.html")
+		assert.Equal(t, content, got)
+		assert.Equal(t, int64(len(content)), object.Size)
+		assert.True(t, strings.HasPrefix(object.ContentType, "text/html"), object.ContentType)
+	})
+
+	t.Run("put replaces", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, "exports/replaced.json", strings.NewReader(`{"v":1}`), 7, "application/json"))
+		require.NoError(t, s.Put(ctx, "exports/replaced.json", strings.NewReader(`{"v":22}`), 8, "application/json"))
+
+		got, _ := read(t, "exports/replaced.json")
+		assert.Equal(t, `{"v":22}`, got)
+	})
+
+	t.Run("get missing", func(t *testing.T) {
+		_, err := s.Get(ctx, "exports/missing.html")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		require.NoError(t, s.Put(ctx, "exports/deleted.html", strings.NewReader("gone"), 4, "text/html"))
+		require.NoError(t, s.Delete(ctx, "exports/deleted.html"))
+
+		_, err := s.Get(ctx, "exports/deleted.html")
+		assert.ErrorIs(t, err, storage.ErrNotFound)
+
+		// Deleting a missing blob succeeds
+		assert.NoError(t, s.Delete(ctx, "exports/deleted.html"))
+	})
+
+	t.Run("invalid keys", func(t *testing.T) {
+		for _, key := range []string{"", "/exports/a.html", "exports//a.html", "../a.html", "exports/../a.html", "exports/a b.html", "exports/a?.html"} {
+			assert.ErrorIs(t, s.Put(ctx, key, strings.NewReader("x"), 1, "text/plain"), storage.ErrInvalidKey, key)
+
+			_, err := s.Get(ctx, key)
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+
+			assert.ErrorIs(t, s.Delete(ctx, key), storage.ErrInvalidKey, key)
+
+			_, err = s.SignedURL(ctx, key, time.Now().Add(time.Hour))
+			assert.ErrorIs(t, err, storage.ErrInvalidKey, key)
+		}
+	})
+}
+
+func TestLocalStorage(t *testing.T) {
+	testBackend(t, storage.NewLocalStorage(t.TempDir(), "test-secret", "http://localhost:8080"))
+}
+
+func TestS3Storage(t *testing.T) {
+	s := testhelpers.SetupTestStorage(t)
+
+	testBackend(t, s)
+
+	t.Run("signed url", func(t *testing.T) {
+		ctx := context.Background()
+		content := "signed content"
+		require.NoError(t, s.Put(ctx, "exports/signed.txt", strings.NewReader(content), int64(len(content)), "text/plain"))
+
+		signed, err := s.SignedURL(ctx, "exports/signed.txt", time.Now().Add(time.Minute))
+		require.NoError(t, err)
+
+		resp, err := http.Get(signed)
+		require.NoError(t, err)
+		body, err := io.ReadAll(resp.Body)
+		resp.Body.Close()
+		require.NoError(t, err)
+		assert.Equal(t, http.StatusOK, resp.StatusCode)
+		assert.Equal(t, content, string(body))
+
+		// Changing the signed parameters invalidates the link
+		tampered := strings.Replace(signed, "X-Amz-Expires=60", "X-Amz-Expires=600", 1)
+		require.NotEqual(t, signed, tampered)
+
+		resp, err = http.Get(tampered)
+		require.NoError(t, err)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusForbidden, resp.StatusCode)
+	})
+}
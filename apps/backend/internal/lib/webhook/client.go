@@ -0,0 +1,99 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"syscall"
+	"time"
+)
+
+// ErrPrivateAddress is returned when a delivery would connect to an address
+// that is not publicly routable
+var ErrPrivateAddress = errors.New("webhook target resolves to a non-public address")
+
+// nonPublicPrefixes are ranges that netip does not classify as private or
+// local but that must not be reachable from deliveries either
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),      // "this" network
+	netip.MustParsePrefix("100.64.0.0/10"),  // carrier-grade NAT
+	netip.MustParsePrefix("192.0.0.0/24"),   // IETF protocol assignments
+	netip.MustParsePrefix("198.18.0.0/15"),  // benchmarking
+	netip.MustParsePrefix("240.0.0.0/4"),    // reserved, including broadcast
+	netip.MustParsePrefix("64:ff9b::/96"),   // NAT64, embeds IPv4 addresses
+	netip.MustParsePrefix("64:ff9b:1::/48"), // local-use NAT64
+	netip.MustParsePrefix("2002::/16"),      // 6to4, embeds IPv4 addresses
+	netip.MustParsePrefix("2001::/32"),      // Teredo
+	netip.MustParsePrefix("100::/64"),       // discard-only
+}
+
+// NewHTTPClient returns the client deliveries are sent with. Unless
+// allowPrivate is set, connections to loopback, private, link-local and other
+// non-public addresses are refused after DNS resolution, so a hostname that
+// resolves to an internal address is rejected like the address itself.
+func NewHTTPClient(timeout time.Duration, allowPrivate bool) *http.Client {
+	dialer := &net.Dialer{
+		Timeout:   timeout,
+		KeepAlive: 30 * time.Second,
+	}
+	if !allowPrivate {
+		dialer.Control = refusePrivateAddress
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	// A proxy would make the dialer check the proxy's address instead of the
+	// receiver's
+	transport.Proxy = nil
+	transport.DialContext = dialer.DialContext
+
+	return &http.Client{
+		Timeout:   timeout,
+		Transport: transport,
+		// A redirect is reported as the response rather than followed, so
+		// deliveries only ever go to the registered URL
+		CheckRedirect: func(req *http.Request, via []*http.Request) error {
+			return http.ErrUseLastResponse
+		},
+	}
+}
+
+// refusePrivateAddress is a net.Dialer Control function. It runs for every
+// resolved address right before connecting.
+func refusePrivateAddress(network, address string, _ syscall.RawConn) error {
+	addrPort, err := netip.ParseAddrPort(address)
+	if err != nil {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, address)
+	}
+
+	if !IsPublicAddr(addrPort.Addr()) {
+		return fmt.Errorf("%w: %s", ErrPrivateAddress, addrPort.Addr())
+	}
+
+	return nil
+}
+
+// IsPublicAddr reports whether deliveries may connect to addr
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+
+	if !addr.IsValid() ||
+		addr.IsUnspecified() ||
+		addr.IsLoopback() ||
+		addr.IsPrivate() ||
+		addr.IsLinkLocalUnicast() ||
+		addr.IsLinkLocalMulticast() ||
+		addr.IsInterfaceLocalMulticast() ||
+		addr.IsMulticast() {
+		return false
+	}
+
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+
+	return true
+}
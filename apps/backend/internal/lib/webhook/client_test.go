@@ -0,0 +1,88 @@
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/netip"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsPublicAddr(t *testing.T) {
+	tests := []struct {
+		addr   string
+		public bool
+	}{
+		{"93.184.216.34", true},
+		{"2606:2800:220:1:248:1893:25c8:1946", true},
+		{"127.0.0.1", false},
+		{"::1", false},
+		{"10.1.2.3", false},
+		{"172.16.0.1", false},
+		{"192.168.1.1", false},
+		{"169.254.169.254", false},
+		{"fe80::1", false},
+		{"fc00::1", false},
+		{"0.0.0.0", false},
+		{"::", false},
+		{"100.64.0.1", false},
+		{"224.0.0.1", false},
+		{"255.255.255.255", false},
+		{"::ffff:127.0.0.1", false},
+		{"::ffff:169.254.169.254", false},
+		{"64:ff9b::a9fe:a9fe", false},
+		{"2002:7f00:1::", false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.addr, func(t *testing.T) {
+			assert.Equal(t, tt.public, IsPublicAddr(netip.MustParseAddr(tt.addr)))
+		})
+	}
+}
+
+func TestNewHTTPClient(t *testing.T) {
+	receiver := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer receiver.Close()
+
+	// localhost resolves to a loopback address, which is refused like the
+	// literal address
+	localhostURL := strings.Replace(receiver.URL, "127.0.0.1", "localhost", 1)
+
+	t.Run("refuses private targets", func(t *testing.T) {
+		client := NewHTTPClient(time.Second, false)
+
+		for _, url := range []string{receiver.URL, localhostURL} {
+			_, err := client.Post(url, "application/json", nil)
+			require.Error(t, err, url)
+			assert.ErrorIs(t, err, ErrPrivateAddress, url)
+		}
+	})
+
+	t.Run("allows private targets when configured", func(t *testing.T) {
+		client := NewHTTPClient(time.Second, true)
+
+		resp, err := client.Post(receiver.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusNoContent, resp.StatusCode)
+	})
+
+	t.Run("does not follow redirects", func(t *testing.T) {
+		redirecting := httptest.NewServer(http.RedirectHandler(receiver.URL, http.StatusFound))
+		defer redirecting.Close()
+
+		client := NewHTTPClient(time.Second, true)
+
+		resp, err := client.Post(redirecting.URL, "application/json", nil)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusFound, resp.StatusCode)
+	})
+}
@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+const (
+	HeaderEvent     = "X-Resumify-Event"
+	HeaderDelivery  = "X-Resumify-Delivery"
+	HeaderSignature = "X-Resumify-Signature"
+)
+
+// Sign returns the signature header value for a delivery body sent at the
+// given time. Receivers recompute the HMAC-SHA256 of "<timestamp>.<body>"
+// with the endpoint secret and compare it with v1; the timestamp lets them
+// reject replayed deliveries.
+func Sign(secret string, timestamp time.Time, body []byte) string {
+	ts := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(ts))
+	mac.Write([]byte("."))
+	mac.Write(body)
+
+	return fmt.Sprintf("t=%s,v1=%s", ts, hex.EncodeToString(mac.Sum(nil)))
+}
@@ -0,0 +1,75 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Headers of webhooks sent through Svix, as Clerk's are
+const (
+	HeaderSvixID        = "svix-id"
+	HeaderSvixTimestamp = "svix-timestamp"
+	HeaderSvixSignature = "svix-signature"
+)
+
+// svixTolerance is how far the timestamp of a webhook may be from now
+const svixTolerance = 5 * time.Minute
+
+var (
+	ErrMissingHeaders   = errors.New("missing webhook signature headers")
+	ErrInvalidTimestamp = errors.New("webhook timestamp is outside the tolerance")
+	ErrInvalidSignature = errors.New("no matching webhook signature")
+)
+
+// VerifySvix checks that a webhook was signed with the given Svix secret
+// ("whsec_" followed by the base64 key) and was sent recently. The signature
+// header may hold several space separated signatures, one per active secret.
+func VerifySvix(secret string, header http.Header, body []byte, now time.Time) error {
+	id := header.Get(HeaderSvixID)
+	timestamp := header.Get(HeaderSvixTimestamp)
+	signatures := header.Get(HeaderSvixSignature)
+	if id == "" || timestamp == "" || signatures == "" {
+		return ErrMissingHeaders
+	}
+
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return ErrInvalidTimestamp
+	}
+	sent := time.Unix(seconds, 0)
+	if now.Sub(sent) > svixTolerance || sent.Sub(now) > svixTolerance {
+		return ErrInvalidTimestamp
+	}
+
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(secret, "whsec_"))
+	if err != nil {
+		return errors.New("invalid webhook secret")
+	}
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + timestamp + "."))
+	mac.Write(body)
+	expected := mac.Sum(nil)
+
+	for _, signature := range strings.Fields(signatures) {
+		version, value, ok := strings.Cut(signature, ",")
+		if !ok || version != "v1" {
+			continue
+		}
+		decoded, err := base64.StdEncoding.DecodeString(value)
+		if err != nil {
+			continue
+		}
+		if hmac.Equal(decoded, expected) {
+			return nil
+		}
+	}
+
+	return ErrInvalidSignature
+}
@@ -0,0 +1,156 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// svixSignature signs a webhook the way Svix does
+func svixSignature(secret, id string, timestamp time.Time, body []byte) string {
+	key, _ := base64.StdEncoding.DecodeString(secret[len("whsec_"):])
+
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(id + "." + strconv.FormatInt(timestamp.Unix(), 10) + "."))
+	mac.Write(body)
+
+	return "v1," + base64.StdEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func TestVerifySvix(t *testing.T) {
+	secret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("clerk-signing-secret"))
+	otherSecret := "whsec_" + base64.StdEncoding.EncodeToString([]byte("rotated-signing-secret"))
+	now := time.Unix(1_760_000_000, 0)
+	body := []byte(`{"type":"user.created","data":{"id":"user_1"}}`)
+
+	tests := []struct {
+		name      string
+		id        string
+		timestamp string
+		signature string
+		body      []byte
+		wantErr   error
+	}{
+		{
+			name:      "valid",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now, body),
+			body:      body,
+		},
+		{
+			name:      "wrong secret",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(otherSecret, "msg_1", now, body),
+			body:      body,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "tampered body",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now, body),
+			body:      []byte(`{"type":"user.deleted","data":{"id":"user_1"}}`),
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "signature for another message ID",
+			id:        "msg_2",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now, body),
+			body:      body,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "stale timestamp",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Add(-svixTolerance-time.Second).Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now.Add(-svixTolerance-time.Second), body),
+			body:      body,
+			wantErr:   ErrInvalidTimestamp,
+		},
+		{
+			name:      "future timestamp",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Add(svixTolerance+time.Second).Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now.Add(svixTolerance+time.Second), body),
+			body:      body,
+			wantErr:   ErrInvalidTimestamp,
+		},
+		{
+			name:      "timestamp within tolerance",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Add(-svixTolerance).Unix(), 10),
+			signature: svixSignature(secret, "msg_1", now.Add(-svixTolerance), body),
+			body:      body,
+		},
+		{
+			name:      "malformed timestamp",
+			id:        "msg_1",
+			timestamp: "yesterday",
+			signature: svixSignature(secret, "msg_1", now, body),
+			body:      body,
+			wantErr:   ErrInvalidTimestamp,
+		},
+		{
+			name:      "multiple signatures with one match",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(otherSecret, "msg_1", now, body) + " v1,bm90LWJhc2U2NA== " + svixSignature(secret, "msg_1", now, body),
+			body:      body,
+		},
+		{
+			name:      "multiple signatures without a match",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(otherSecret, "msg_1", now, body) + " v1,bm90LWJhc2U2NA==",
+			body:      body,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "unsupported signature version",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: "v2" + svixSignature(secret, "msg_1", now, body)[len("v1"):],
+			body:      body,
+			wantErr:   ErrInvalidSignature,
+		},
+		{
+			name:      "missing signature",
+			id:        "msg_1",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			body:      body,
+			wantErr:   ErrMissingHeaders,
+		},
+		{
+			name:      "missing ID",
+			timestamp: strconv.FormatInt(now.Unix(), 10),
+			signature: svixSignature(secret, "", now, body),
+			body:      body,
+			wantErr:   ErrMissingHeaders,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			header := http.Header{}
+			header.Set(HeaderSvixID, tt.id)
+			header.Set(HeaderSvixTimestamp, tt.timestamp)
+			header.Set(HeaderSvixSignature, tt.signature)
+
+			err := VerifySvix(secret, header, tt.body, now)
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+			} else {
+				assert.ErrorIs(t, err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -59,6 +59,7 @@ func (auth *AuthMiddleware) RequireAuth(next echo.HandlerFunc) echo.HandlerFunc
 		}
 
 		c.Set("user_id", claims.Subject)
+		c.Set("organization_id", claims.ActiveOrganizationID)
 		c.Set("user_role", claims.ActiveOrganizationRole)
 		c.Set("permissions", claims.Claims.ActiveOrganizationPermissions)
 
@@ -5,15 +5,19 @@ import (
 
 	"github.com/labstack/echo/v4"
 	"github.com/newrelic/go-agent/v3/newrelic"
+	"github.com/recreatedev/Resumify/internal/lib/requestinfo"
 	"github.com/recreatedev/Resumify/internal/logger"
 	"github.com/recreatedev/Resumify/internal/server"
 	"github.com/rs/zerolog"
 )
 
 const (
-	UserIDKey   = "user_id"
-	UserRoleKey = "user_role"
-	LoggerKey   = "logger"
+	UserIDKey         = "user_id"
+	UserRoleKey       = "user_role"
+	OrganizationIDKey = "organization_id"
+	PermissionsKey    = "permissions"
+	LoggerKey         = "logger"
+	WebhookIDKey      = "webhook_id"
 )
 
 type ContextEnhancer struct {
@@ -57,6 +61,12 @@ func (ce *ContextEnhancer) EnhanceContext() echo.MiddlewareFunc {
 
 			// Create a new context with the logger
 			ctx := context.WithValue(c.Request().Context(), LoggerKey, &contextLogger)
+
+			// Carry the request identity down to the audit log
+			ctx = requestinfo.WithInfo(ctx, requestinfo.Info{
+				RequestID: requestID,
+				IPAddress: c.RealIP(),
+			})
 			c.SetRequest(c.Request().WithContext(ctx))
 
 			return next(c)
@@ -87,6 +97,39 @@ func GetUserID(c echo.Context) string {
 	return ""
 }
 
+// GetOrganizationID returns the ID of the user's active Clerk organization, or
+// an empty string when the session has none
+func GetOrganizationID(c echo.Context) string {
+	if organizationID, ok := c.Get(OrganizationIDKey).(string); ok {
+		return organizationID
+	}
+	return ""
+}
+
+// GetUserRole returns the user's role in their active organization
+func GetUserRole(c echo.Context) string {
+	if userRole, ok := c.Get(UserRoleKey).(string); ok {
+		return userRole
+	}
+	return ""
+}
+
+// GetPermissions returns the user's permissions in their active organization
+func GetPermissions(c echo.Context) []string {
+	if permissions, ok := c.Get(PermissionsKey).([]string); ok {
+		return permissions
+	}
+	return nil
+}
+
+// GetWebhookID returns the ID of a verified incoming webhook
+func GetWebhookID(c echo.Context) string {
+	if webhookID, ok := c.Get(WebhookIDKey).(string); ok {
+		return webhookID
+	}
+	return ""
+}
+
 func GetRequestID(c echo.Context) string {
 	if requestID, ok := c.Get("request_id").(string); ok && requestID != "" {
 		return requestID
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/rs/zerolog"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
 	"github.com/recreatedev/Resumify/internal/server"
 	"github.com/recreatedev/Resumify/internal/sqlerr"
 )
@@ -25,6 +26,8 @@ func NewGlobalMiddlewares(s *server.Server) *GlobalMiddlewares {
 func (global *GlobalMiddlewares) CORS() echo.MiddlewareFunc {
 	return middleware.CORSWithConfig(middleware.CORSConfig{
 		AllowOrigins: global.server.Config.Server.CORSAllowedOrigins,
+		// Browsers only hand the ETag to scripts when it is exposed explicitly
+		ExposeHeaders: []string{etag.HeaderETag},
 	})
 }
 
@@ -0,0 +1,82 @@
+package middleware
+
+import (
+	"context"
+	"slices"
+
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// MembershipSyncer records a user's membership of their active organization
+type MembershipSyncer interface {
+	SyncMembership(ctx context.Context, userID, organizationID, orgRole string, permissions []string) error
+}
+
+type OrganizationMiddleware struct {
+	server  *server.Server
+	members MembershipSyncer
+}
+
+func NewOrganizationMiddleware(s *server.Server, members MembershipSyncer) *OrganizationMiddleware {
+	return &OrganizationMiddleware{
+		server:  s,
+		members: members,
+	}
+}
+
+// SyncMembership mirrors the active organization claims of authenticated
+// requests so repositories can authorize organization resumes. A failed sync is
+// logged and the request continues with the membership last recorded.
+func (o *OrganizationMiddleware) SyncMembership(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		organizationID := GetOrganizationID(c)
+		if organizationID == "" {
+			return next(c)
+		}
+
+		err := o.members.SyncMembership(c.Request().Context(), GetUserID(c), organizationID, GetUserRole(c), GetPermissions(c))
+		if err != nil {
+			GetLogger(c).Error().
+				Err(err).
+				Str("organization_id", organizationID).
+				Msg("failed to sync organization membership")
+		}
+
+		return next(c)
+	}
+}
+
+// RequireOrganization rejects requests whose session has no active organization
+func (auth *AuthMiddleware) RequireOrganization(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		if GetOrganizationID(c) == "" {
+			return errs.NewForbiddenError("an active organization is required", false)
+		}
+		return next(c)
+	}
+}
+
+// RequirePermission rejects requests unless the user holds every listed
+// permission in their active organization. Attach it per route after RequireAuth.
+func (auth *AuthMiddleware) RequirePermission(permissions ...string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return auth.RequireOrganization(func(c echo.Context) error {
+			granted := GetPermissions(c)
+			for _, permission := range permissions {
+				if !slices.Contains(granted, permission) {
+					auth.server.Logger.Warn().
+						Str("function", "RequirePermission").
+						Str("user_id", GetUserID(c)).
+						Str("organization_id", GetOrganizationID(c)).
+						Str("permission", permission).
+						Str("request_id", GetRequestID(c)).
+						Msg("missing organization permission")
+					return errs.NewForbiddenError("missing permission: "+permission, false)
+				}
+			}
+			return next(c)
+		})
+	}
+}
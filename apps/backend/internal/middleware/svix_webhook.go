@@ -0,0 +1,62 @@
+package middleware
+
+import (
+	"bytes"
+	"io"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/webhook"
+)
+
+// maxSvixWebhookSize bounds the body read to verify a Svix webhook
+const maxSvixWebhookSize = 1 << 20
+
+// RequireClerkWebhook authenticates a request as a Clerk webhook by its Svix
+// signature
+func (auth *AuthMiddleware) RequireClerkWebhook(next echo.HandlerFunc) echo.HandlerFunc {
+	return auth.requireSvixWebhook("RequireClerkWebhook", "clerk", auth.server.Config.Auth.WebhookSecret, next)
+}
+
+// RequireResendWebhook authenticates a request as a Resend webhook by its
+// Svix signature
+func (auth *AuthMiddleware) RequireResendWebhook(next echo.HandlerFunc) echo.HandlerFunc {
+	return auth.requireSvixWebhook("RequireResendWebhook", "resend", auth.server.Config.Integration.ResendWebhookSecret, next)
+}
+
+// requireSvixWebhook verifies the Svix signature of a webhook with the
+// sender's signing secret. The body is restored so the handler can bind it.
+func (auth *AuthMiddleware) requireSvixWebhook(function, sender, secret string, next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		start := time.Now()
+
+		if secret == "" {
+			auth.server.Logger.Error().
+				Str("function", function).
+				Str("request_id", GetRequestID(c)).
+				Msg(sender + " webhook secret is not configured")
+			return errs.NewUnauthorizedError("Unauthorized", false)
+		}
+
+		body, err := io.ReadAll(io.LimitReader(c.Request().Body, maxSvixWebhookSize))
+		if err != nil {
+			return errs.NewBadRequestError("failed to read request body", false, nil, nil, nil)
+		}
+
+		if err := webhook.VerifySvix(secret, c.Request().Header, body, time.Now()); err != nil {
+			auth.server.Logger.Warn().
+				Err(err).
+				Str("function", function).
+				Str("request_id", GetRequestID(c)).
+				Dur("duration", time.Since(start)).
+				Msg(sender + " webhook verification failed")
+			return errs.NewUnauthorizedError("Unauthorized", false)
+		}
+
+		c.Request().Body = io.NopCloser(bytes.NewReader(body))
+		c.Set(WebhookIDKey, c.Request().Header.Get(webhook.HeaderSvixID))
+
+		return next(c)
+	}
+}
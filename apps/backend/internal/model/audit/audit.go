@@ -0,0 +1,58 @@
+package audit
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Record describes a change to be appended to the audit log. Before and After
+// are marshalled to JSON; either is nil when the entity did not exist on that
+// side of the change.
+type Record struct {
+	ActorID    string
+	ResumeID   *uuid.UUID
+	EntityType string
+	EntityID   *uuid.UUID
+	Action     string
+	Before     any
+	After      any
+}
+
+// Entry is a row of the audit log
+type Entry struct {
+	ID         uuid.UUID       `json:"id" db:"id"`
+	ActorID    string          `json:"actorId" db:"actor_id"`
+	ResumeID   *uuid.UUID      `json:"resumeId" db:"resume_id"`
+	EntityType string          `json:"entityType" db:"entity_type"`
+	EntityID   *uuid.UUID      `json:"entityId" db:"entity_id"`
+	Action     string          `json:"action" db:"action"`
+	Before     json.RawMessage `json:"before" db:"before"`
+	After      json.RawMessage `json:"after" db:"after"`
+	RequestID  *string         `json:"requestId" db:"request_id"`
+	IPAddress  *string         `json:"ipAddress" db:"ip_address"`
+	CreatedAt  time.Time       `json:"createdAt" db:"created_at"`
+}
+
+// Entity types of audited changes that are not part of a resume's live change
+// feed. Resume content uses the entity types of the events package.
+const (
+	EntityCollaborator         = "collaborator"
+	EntityReview               = "review"
+	EntityLibraryEducation     = "library_education"
+	EntityLibraryExperience    = "library_experience"
+	EntityLibraryProject       = "library_project"
+	EntityLibrarySkill         = "library_skill"
+	EntityLibraryCertification = "library_certification"
+	EntityWebhook              = "webhook"
+	EntityDataExport           = "data_export"
+)
+
+// Actions of audited changes beyond the change feed's created, updated,
+// deleted and reordered
+const (
+	ActionRestored    = "restored"
+	ActionAccepted    = "accepted"
+	ActionRedelivered = "redelivered"
+)
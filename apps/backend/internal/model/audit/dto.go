@@ -0,0 +1,22 @@
+package audit
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// EntryResponse represents the response for an audit log entry
+type EntryResponse struct {
+	ID         string          `json:"id"`
+	ActorID    string          `json:"actorId"`
+	ResumeID   *uuid.UUID      `json:"resumeId"`
+	EntityType string          `json:"entityType"`
+	EntityID   *uuid.UUID      `json:"entityId"`
+	Action     string          `json:"action"`
+	Before     json.RawMessage `json:"before"`
+	After      json.RawMessage `json:"after"`
+	RequestID  *string         `json:"requestId"`
+	IPAddress  *string         `json:"ipAddress"`
+	CreatedAt  string          `json:"createdAt"`
+}
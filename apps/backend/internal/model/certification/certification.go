@@ -9,8 +9,9 @@ import (
 
 // Certification represents certification entries
 type Certification struct {
-	model.BaseWithId
+	model.Base
 	ResumeID      uuid.UUID  `json:"resumeId" db:"resume_id"`
+	LibraryItemID *uuid.UUID `json:"libraryItemId" db:"library_item_id"`
 	Name          *string    `json:"name" db:"name"`
 	Organization  *string    `json:"organization" db:"organization"`
 	IssueDate     *time.Time `json:"issueDate" db:"issue_date"`
@@ -18,4 +19,62 @@ type Certification struct {
 	CredentialID  *string    `json:"credentialId" db:"credential_id"`
 	CredentialURL *string    `json:"credentialUrl" db:"credential_url"`
 	OrderIndex    int        `json:"orderIndex" db:"order_index"`
+	// OverriddenFields lists the columns a library-linked row does not inherit
+	OverriddenFields []string `json:"overriddenFields" db:"overridden_fields"`
+}
+
+// LibraryCertification is a canonical certification entry in a user's career library.
+// Resume certification rows that reference it inherit every field they do not override.
+type LibraryCertification struct {
+	model.Base
+	UserID        string     `json:"userId" db:"user_id"`
+	Name          *string    `json:"name" db:"name"`
+	Organization  *string    `json:"organization" db:"organization"`
+	IssueDate     *time.Time `json:"issueDate" db:"issue_date"`
+	ExpiryDate    *time.Time `json:"expiryDate" db:"expiry_date"`
+	CredentialID  *string    `json:"credentialId" db:"credential_id"`
+	CredentialURL *string    `json:"credentialUrl" db:"credential_url"`
+}
+
+// Status is whether a certification is still valid on a given day
+type Status string
+
+const (
+	StatusValid    Status = "valid"
+	StatusExpiring Status = "expiring"
+	StatusExpired  Status = "expired"
+)
+
+// ComputeStatus reports a certification without an expiry date as valid, one
+// past its expiry date as expired and one expiring within expiringWithinDays
+// of today as expiring. Dates are compared as calendar days in UTC.
+func ComputeStatus(expiryDate *time.Time, now time.Time, expiringWithinDays int) Status {
+	if expiryDate == nil {
+		return StatusValid
+	}
+
+	today := now.UTC().Truncate(24 * time.Hour)
+	expiry := expiryDate.UTC().Truncate(24 * time.Hour)
+
+	switch {
+	case expiry.Before(today):
+		return StatusExpired
+	case !expiry.After(today.AddDate(0, 0, expiringWithinDays)):
+		return StatusExpiring
+	default:
+		return StatusValid
+	}
+}
+
+// ExpiryReminder is a certification on an active resume that is due an expiry
+// reminder for the given window
+type ExpiryReminder struct {
+	CertificationID uuid.UUID `db:"certification_id"`
+	UserID          string    `db:"user_id"`
+	ResumeID        uuid.UUID `db:"resume_id"`
+	ResumeTitle     string    `db:"resume_title"`
+	Name            *string   `db:"name"`
+	Organization    *string   `db:"organization"`
+	ExpiryDate      time.Time `db:"expiry_date"`
+	WindowDays      int       `db:"window_days"`
 }
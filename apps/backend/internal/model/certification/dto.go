@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/validation"
 )
 
 // CreateCertificationRequest represents the request to create a new certification entry
@@ -28,19 +29,28 @@ type UpdateCertificationRequest struct {
 	CredentialID  *string    `json:"credentialId" validate:"omitempty,max=100"`
 	CredentialURL *string    `json:"credentialUrl" validate:"omitempty,url"`
 	OrderIndex    *int       `json:"orderIndex" validate:"omitempty,min=0"`
+	// Clear lists fields to empty. A cleared field of a library-linked entry
+	// stays empty instead of falling back to the library value.
+	Clear []string `json:"clear" validate:"omitempty,dive,oneof=name organization issueDate expiryDate credentialId credentialUrl"`
 }
 
 // CertificationResponse represents the response for certification data
 type CertificationResponse struct {
-	ID            string     `json:"id"`
-	ResumeID      uuid.UUID  `json:"resumeId"`
-	Name          *string    `json:"name"`
-	Organization  *string    `json:"organization"`
-	IssueDate     *time.Time `json:"issueDate"`
-	ExpiryDate    *time.Time `json:"expiryDate"`
-	CredentialID  *string    `json:"credentialId"`
-	CredentialURL *string    `json:"credentialUrl"`
-	OrderIndex    int        `json:"orderIndex"`
+	ID               string     `json:"id"`
+	ResumeID         uuid.UUID  `json:"resumeId"`
+	LibraryItemID    *uuid.UUID `json:"libraryItemId"`
+	OverriddenFields []string   `json:"overriddenFields"`
+	Name             *string    `json:"name"`
+	Organization     *string    `json:"organization"`
+	IssueDate        *time.Time `json:"issueDate"`
+	ExpiryDate       *time.Time `json:"expiryDate"`
+	Status           Status     `json:"status"`
+	CredentialID     *string    `json:"credentialId"`
+	CredentialURL    *string    `json:"credentialUrl"`
+	OrderIndex       int        `json:"orderIndex"`
+	CreatedAt        string     `json:"createdAt"`
+	UpdatedAt        string     `json:"updatedAt"`
+	ETag             string     `json:"etag"`
 }
 
 // BulkUpdateCertificationsRequest represents the request to update multiple certification entries order
@@ -63,7 +73,18 @@ func (r *CreateCertificationRequest) Validate() error {
 // Validate implements the Validatable interface for UpdateCertificationRequest
 func (r *UpdateCertificationRequest) Validate() error {
 	validate := validator.New()
-	return validate.Struct(r)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	return validation.CheckClearedFields(r.Clear, map[string]bool{
+		"name":          r.Name != nil,
+		"organization":  r.Organization != nil,
+		"issueDate":     r.IssueDate != nil,
+		"expiryDate":    r.ExpiryDate != nil,
+		"credentialId":  r.CredentialID != nil,
+		"credentialUrl": r.CredentialURL != nil,
+	})
 }
 
 // Validate implements the Validatable interface for BulkUpdateCertificationsRequest
@@ -71,3 +92,71 @@ func (r *BulkUpdateCertificationsRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// CreateLibraryCertificationRequest represents the request to add a certification entry to the career library
+type CreateLibraryCertificationRequest struct {
+	Name          *string    `json:"name" validate:"omitempty,max=200"`
+	Organization  *string    `json:"organization" validate:"omitempty,max=200"`
+	IssueDate     *time.Time `json:"issueDate"`
+	ExpiryDate    *time.Time `json:"expiryDate"`
+	CredentialID  *string    `json:"credentialId" validate:"omitempty,max=100"`
+	CredentialURL *string    `json:"credentialUrl" validate:"omitempty,url"`
+}
+
+// UpdateLibraryCertificationRequest represents the request to update a certification entry in the career library
+type UpdateLibraryCertificationRequest struct {
+	Name          *string    `json:"name" validate:"omitempty,max=200"`
+	Organization  *string    `json:"organization" validate:"omitempty,max=200"`
+	IssueDate     *time.Time `json:"issueDate"`
+	ExpiryDate    *time.Time `json:"expiryDate"`
+	CredentialID  *string    `json:"credentialId" validate:"omitempty,max=100"`
+	CredentialURL *string    `json:"credentialUrl" validate:"omitempty,url"`
+}
+
+// LibraryCertificationResponse represents the response for career library certification data
+type LibraryCertificationResponse struct {
+	ID            string     `json:"id"`
+	Name          *string    `json:"name"`
+	Organization  *string    `json:"organization"`
+	IssueDate     *time.Time `json:"issueDate"`
+	ExpiryDate    *time.Time `json:"expiryDate"`
+	CredentialID  *string    `json:"credentialId"`
+	CredentialURL *string    `json:"credentialUrl"`
+	CreatedAt     string     `json:"createdAt"`
+	UpdatedAt     string     `json:"updatedAt"`
+	ETag          string     `json:"etag"`
+}
+
+// LinkLibraryCertificationRequest represents the request to add a career library certification entry to a resume
+type LinkLibraryCertificationRequest struct {
+	ResumeID   uuid.UUID `json:"resumeId" validate:"required"`
+	OrderIndex int       `json:"orderIndex" validate:"min=0"`
+}
+
+// Validate implements the Validatable interface for CreateLibraryCertificationRequest
+func (r *CreateLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateLibraryCertificationRequest
+func (r *UpdateLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for LinkLibraryCertificationRequest
+func (r *LinkLibraryCertificationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// EntityTag implements the etag.Tagged interface for CertificationResponse
+func (r *CertificationResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for LibraryCertificationResponse
+func (r *LibraryCertificationResponse) EntityTag() string {
+	return r.ETag
+}
@@ -0,0 +1,56 @@
+package collaborator
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Role is a user's level of access to a resume. Each role includes the
+// permissions of the roles ranked below it.
+type Role string
+
+const (
+	RoleViewer    Role = "viewer"
+	RoleCommenter Role = "commenter"
+	RoleEditor    Role = "editor"
+	RoleOwner     Role = "owner"
+)
+
+// roleRanks orders the roles from least to most privileged
+var roleRanks = map[Role]int{
+	RoleViewer:    1,
+	RoleCommenter: 2,
+	RoleEditor:    3,
+	RoleOwner:     4,
+}
+
+// Includes reports whether the role grants at least the required role
+func (r Role) Includes(required Role) bool {
+	rank, ok := roleRanks[r]
+	return ok && rank >= roleRanks[required]
+}
+
+// RolesIncluding returns every role that grants at least the required role
+func RolesIncluding(required Role) []Role {
+	var roles []Role
+	for _, role := range []Role{RoleViewer, RoleCommenter, RoleEditor, RoleOwner} {
+		if role.Includes(required) {
+			roles = append(roles, role)
+		}
+	}
+	return roles
+}
+
+// Collaborator represents a user invited to work on someone else's resume
+type Collaborator struct {
+	model.Base
+	ResumeID   uuid.UUID  `json:"resumeId" db:"resume_id"`
+	Email      string     `json:"email" db:"email"`
+	UserID     *string    `json:"userId" db:"user_id"`
+	Role       Role       `json:"role" db:"role"`
+	InvitedBy  string     `json:"invitedBy" db:"invited_by"`
+	TokenHash  *string    `json:"-" db:"invite_token_hash"`
+	AcceptedAt *time.Time `json:"acceptedAt" db:"accepted_at"`
+}
@@ -0,0 +1,48 @@
+package collaborator
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// InviteCollaboratorRequest represents the request to invite a collaborator by email
+type InviteCollaboratorRequest struct {
+	Email string `json:"email" validate:"required,email,max=254"`
+	Role  Role   `json:"role" validate:"required,oneof=viewer commenter editor"`
+}
+
+// UpdateCollaboratorRequest represents the request to change a collaborator's role
+type UpdateCollaboratorRequest struct {
+	Role Role `json:"role" validate:"required,oneof=viewer commenter editor"`
+}
+
+// CollaboratorResponse represents the response for collaborator data
+type CollaboratorResponse struct {
+	ID         string    `json:"id"`
+	ResumeID   uuid.UUID `json:"resumeId"`
+	Email      string    `json:"email"`
+	UserID     *string   `json:"userId"`
+	Role       Role      `json:"role"`
+	Status     string    `json:"status"`
+	InvitedBy  string    `json:"invitedBy"`
+	AcceptedAt *string   `json:"acceptedAt"`
+	CreatedAt  string    `json:"createdAt"`
+	UpdatedAt  string    `json:"updatedAt"`
+}
+
+const (
+	StatusPending  = "pending"
+	StatusAccepted = "accepted"
+)
+
+// Validate implements the Validatable interface for InviteCollaboratorRequest
+func (r *InviteCollaboratorRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateCollaboratorRequest
+func (r *UpdateCollaboratorRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,38 @@
+package comment
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// EntityType is the kind of resume entity a comment is anchored to
+type EntityType string
+
+const (
+	EntityResume        EntityType = "resume"
+	EntitySection       EntityType = "section"
+	EntityEducation     EntityType = "education"
+	EntityExperience    EntityType = "experience"
+	EntityProject       EntityType = "project"
+	EntitySkill         EntityType = "skill"
+	EntityCertification EntityType = "certification"
+)
+
+// Comment represents a review comment on a resume. Root comments carry the
+// anchor and the resolved state of their thread; replies reference the root
+// comment through ParentID.
+type Comment struct {
+	model.Base
+	ResumeID   uuid.UUID  `json:"resumeId" db:"resume_id"`
+	ParentID   *uuid.UUID `json:"parentId" db:"parent_id"`
+	EntityType EntityType `json:"entityType" db:"entity_type"`
+	EntityID   uuid.UUID  `json:"entityId" db:"entity_id"`
+	FieldName  *string    `json:"fieldName" db:"field_name"`
+	AuthorID   string     `json:"authorId" db:"author_id"`
+	Body       string     `json:"body" db:"body"`
+	ResolvedAt *time.Time `json:"resolvedAt" db:"resolved_at"`
+	ResolvedBy *string    `json:"resolvedBy" db:"resolved_by"`
+	ArchivedAt *time.Time `json:"archivedAt" db:"archived_at"`
+}
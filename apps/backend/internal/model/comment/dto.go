@@ -0,0 +1,66 @@
+package comment
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CreateCommentRequest represents the request to comment on a resume entity or
+// to reply to an existing thread. Replies inherit the anchor of the thread.
+type CreateCommentRequest struct {
+	ParentID   *uuid.UUID  `json:"parentId"`
+	EntityType *EntityType `json:"entityType" validate:"required_without=ParentID,omitempty,oneof=resume section education experience project skill certification"`
+	EntityID   *uuid.UUID  `json:"entityId" validate:"required_without=ParentID"`
+	FieldName  *string     `json:"fieldName" validate:"omitempty,min=1,max=100"`
+	Body       string      `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// UpdateCommentRequest represents the request to edit the body of a comment
+type UpdateCommentRequest struct {
+	Body string `json:"body" validate:"required,min=1,max=5000"`
+}
+
+// CommentResponse represents the response for a comment. Root comments list
+// their replies in creation order.
+type CommentResponse struct {
+	ID         string            `json:"id"`
+	ResumeID   uuid.UUID         `json:"resumeId"`
+	ParentID   *uuid.UUID        `json:"parentId"`
+	EntityType EntityType        `json:"entityType"`
+	EntityID   uuid.UUID         `json:"entityId"`
+	FieldName  *string           `json:"fieldName"`
+	AuthorID   string            `json:"authorId"`
+	Body       string            `json:"body"`
+	Resolved   bool              `json:"resolved"`
+	ResolvedAt *string           `json:"resolvedAt"`
+	ResolvedBy *string           `json:"resolvedBy"`
+	Archived   bool              `json:"archived"`
+	ArchivedAt *string           `json:"archivedAt"`
+	Replies    []CommentResponse `json:"replies,omitempty"`
+	CreatedAt  string            `json:"createdAt"`
+	UpdatedAt  string            `json:"updatedAt"`
+}
+
+// Thread filters for comment lists
+const (
+	// FilterActive lists open and resolved threads on existing entities
+	FilterActive = "active"
+	FilterOpen   = "open"
+	// FilterResolved lists resolved threads on existing entities
+	FilterResolved = "resolved"
+	// FilterArchived lists threads whose anchored entity was deleted
+	FilterArchived = "archived"
+	FilterAll      = "all"
+)
+
+// Validate implements the Validatable interface for CreateCommentRequest
+func (r *CreateCommentRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateCommentRequest
+func (r *UpdateCommentRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -1,6 +1,7 @@
 package composite
 
 import (
+	"github.com/recreatedev/Resumify/internal/lib/etag"
 	"github.com/recreatedev/Resumify/internal/model/certification"
 	"github.com/recreatedev/Resumify/internal/model/education"
 	"github.com/recreatedev/Resumify/internal/model/experience"
@@ -20,3 +21,9 @@ type ResumeWithSections struct {
 	Skills         []skill.Skill                 `json:"skills"`
 	Certifications []certification.Certification `json:"certifications"`
 }
+
+// EntityTag implements the etag.Tagged interface; a resume document is
+// versioned by its resume row, which every document save touches
+func (r *ResumeWithSections) EntityTag() string {
+	return etag.Format(r.Resume.UpdatedAt)
+}
@@ -0,0 +1,107 @@
+package composite
+
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/education"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+	"github.com/recreatedev/Resumify/internal/model/project"
+	"github.com/recreatedev/Resumify/internal/model/section"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+	"github.com/recreatedev/Resumify/internal/validation"
+)
+
+// SaveResumeDocumentRequest represents a whole resume submitted in one save.
+// A collection that is omitted is left untouched; a collection that is sent
+// replaces the stored one: entries without an ID are created, entries with an
+// ID are updated, stored entries missing from the list are deleted, and each
+// entry's 1-based position in the list becomes its order index.
+type SaveResumeDocumentRequest struct {
+	Title          *string                 `json:"title" validate:"omitempty,min=1,max=100"`
+	Theme          *string                 `json:"theme" validate:"omitempty,oneof=default modern classic professional"`
+	Sections       []DocumentSection       `json:"sections"`
+	Education      []DocumentEducation     `json:"education"`
+	Experience     []DocumentExperience    `json:"experience"`
+	Projects       []DocumentProject       `json:"projects"`
+	Skills         []DocumentSkill         `json:"skills"`
+	Certifications []DocumentCertification `json:"certifications"`
+}
+
+// DocumentSection represents a resume section within a saved document
+type DocumentSection struct {
+	ID *uuid.UUID `json:"id"`
+	section.UpdateSectionRequest
+}
+
+// DocumentEducation represents an education entry within a saved document
+type DocumentEducation struct {
+	ID *uuid.UUID `json:"id"`
+	education.UpdateEducationRequest
+}
+
+// DocumentExperience represents an experience entry within a saved document
+type DocumentExperience struct {
+	ID *uuid.UUID `json:"id"`
+	experience.UpdateExperienceRequest
+}
+
+// DocumentProject represents a project entry within a saved document
+type DocumentProject struct {
+	ID *uuid.UUID `json:"id"`
+	project.UpdateProjectRequest
+}
+
+// DocumentSkill represents a skill entry within a saved document
+type DocumentSkill struct {
+	ID *uuid.UUID `json:"id"`
+	skill.UpdateSkillRequest
+}
+
+// DocumentCertification represents a certification entry within a saved document
+type DocumentCertification struct {
+	ID *uuid.UUID `json:"id"`
+	certification.UpdateCertificationRequest
+}
+
+// Validate implements the Validatable interface for SaveResumeDocumentRequest.
+// Entry errors are reported with indexed paths such as "experience[2].company".
+func (r *SaveResumeDocumentRequest) Validate() error {
+	validate := validator.New()
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	var fieldErrors validation.CustomValidationErrors
+
+	for i := range r.Sections {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("sections", i, &r.Sections[i].UpdateSectionRequest)...)
+		if r.Sections[i].ID == nil && r.Sections[i].Name == nil {
+			fieldErrors = append(fieldErrors, validation.CustomValidationError{
+				Field:   validation.ItemPath("sections", i, "name"),
+				Message: "is required",
+			})
+		}
+	}
+	for i := range r.Education {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("education", i, &r.Education[i].UpdateEducationRequest)...)
+	}
+	for i := range r.Experience {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("experience", i, &r.Experience[i].UpdateExperienceRequest)...)
+	}
+	for i := range r.Projects {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("projects", i, &r.Projects[i].UpdateProjectRequest)...)
+	}
+	for i := range r.Skills {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("skills", i, &r.Skills[i].UpdateSkillRequest)...)
+	}
+	for i := range r.Certifications {
+		fieldErrors = append(fieldErrors, validation.ValidateItem("certifications", i, &r.Certifications[i].UpdateCertificationRequest)...)
+	}
+
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+
+	return nil
+}
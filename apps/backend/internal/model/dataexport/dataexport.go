@@ -0,0 +1,36 @@
+package dataexport
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Status is the progress of a data export
+type Status string
+
+const (
+	StatusPending Status = "pending"
+	StatusRunning Status = "running"
+	StatusReady   Status = "ready"
+	StatusFailed  Status = "failed"
+)
+
+// DataExport is a request for a copy of everything a user owns. The archive
+// itself is only loaded for downloads.
+type DataExport struct {
+	model.Base
+	UserID      string     `json:"userId" db:"user_id"`
+	Status      Status     `json:"status" db:"status"`
+	SizeBytes   *int64     `json:"sizeBytes" db:"size_bytes"`
+	Error       *string    `json:"error" db:"error"`
+	ExpiresAt   *time.Time `json:"expiresAt" db:"expires_at"`
+	CompletedAt *time.Time `json:"completedAt" db:"completed_at"`
+}
+
+// File is one JSON file of an export, holding the user's rows of one table
+type File struct {
+	Name string
+	Data json.RawMessage
+}
@@ -0,0 +1,14 @@
+package dataexport
+
+// DataExportResponse represents the response for data export status. The
+// signed download URL is set once the export is ready.
+type DataExportResponse struct {
+	ID          string  `json:"id"`
+	Status      Status  `json:"status"`
+	SizeBytes   *int64  `json:"sizeBytes"`
+	DownloadURL *string `json:"downloadUrl"`
+	Error       *string `json:"error"`
+	ExpiresAt   *string `json:"expiresAt"`
+	CompletedAt *string `json:"completedAt"`
+	CreatedAt   string  `json:"createdAt"`
+}
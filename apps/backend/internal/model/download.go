@@ -0,0 +1,12 @@
+package model
+
+import "io"
+
+// Download is stored content streamed to the client as an attachment. The
+// response handler closes Content once it is written.
+type Download struct {
+	Filename    string
+	ContentType string
+	Size        int64
+	Content     io.ReadCloser
+}
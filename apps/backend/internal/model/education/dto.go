@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/validation"
 )
 
 // CreateEducationRequest represents the request to create a new education entry
@@ -30,22 +31,28 @@ type UpdateEducationRequest struct {
 	Grade        *string    `json:"grade" validate:"omitempty,max=50"`
 	Description  *string    `json:"description" validate:"omitempty,max=1000"`
 	OrderIndex   *int       `json:"orderIndex" validate:"omitempty,min=0"`
+	// Clear lists fields to empty. A cleared field of a library-linked entry
+	// stays empty instead of falling back to the library value.
+	Clear []string `json:"clear" validate:"omitempty,dive,oneof=institution degree fieldOfStudy startDate endDate grade description"`
 }
 
 // EducationResponse represents the response for education data
 type EducationResponse struct {
-	ID           string     `json:"id"`
-	ResumeID     uuid.UUID  `json:"resumeId"`
-	Institution  *string    `json:"institution"`
-	Degree       *string    `json:"degree"`
-	FieldOfStudy *string    `json:"fieldOfStudy"`
-	StartDate    *time.Time `json:"startDate"`
-	EndDate      *time.Time `json:"endDate"`
-	Grade        *string    `json:"grade"`
-	Description  *string    `json:"description"`
-	OrderIndex   int        `json:"orderIndex"`
-	CreatedAt    string     `json:"createdAt"`
-	UpdatedAt    string     `json:"updatedAt"`
+	ID               string     `json:"id"`
+	ResumeID         uuid.UUID  `json:"resumeId"`
+	LibraryItemID    *uuid.UUID `json:"libraryItemId"`
+	OverriddenFields []string   `json:"overriddenFields"`
+	Institution      *string    `json:"institution"`
+	Degree           *string    `json:"degree"`
+	FieldOfStudy     *string    `json:"fieldOfStudy"`
+	StartDate        *time.Time `json:"startDate"`
+	EndDate          *time.Time `json:"endDate"`
+	Grade            *string    `json:"grade"`
+	Description      *string    `json:"description"`
+	OrderIndex       int        `json:"orderIndex"`
+	CreatedAt        string     `json:"createdAt"`
+	UpdatedAt        string     `json:"updatedAt"`
+	ETag             string     `json:"etag"`
 }
 
 // BulkUpdateEducationRequest represents the request to update multiple education entries order
@@ -68,7 +75,19 @@ func (r *CreateEducationRequest) Validate() error {
 // Validate implements the Validatable interface for UpdateEducationRequest
 func (r *UpdateEducationRequest) Validate() error {
 	validate := validator.New()
-	return validate.Struct(r)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	return validation.CheckClearedFields(r.Clear, map[string]bool{
+		"institution":  r.Institution != nil,
+		"degree":       r.Degree != nil,
+		"fieldOfStudy": r.FieldOfStudy != nil,
+		"startDate":    r.StartDate != nil,
+		"endDate":      r.EndDate != nil,
+		"grade":        r.Grade != nil,
+		"description":  r.Description != nil,
+	})
 }
 
 // Validate implements the Validatable interface for BulkUpdateEducationRequest
@@ -76,3 +95,74 @@ func (r *BulkUpdateEducationRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// CreateLibraryEducationRequest represents the request to add an education entry to the career library
+type CreateLibraryEducationRequest struct {
+	Institution  *string    `json:"institution" validate:"omitempty,max=200"`
+	Degree       *string    `json:"degree" validate:"omitempty,max=100"`
+	FieldOfStudy *string    `json:"fieldOfStudy" validate:"omitempty,max=100"`
+	StartDate    *time.Time `json:"startDate"`
+	EndDate      *time.Time `json:"endDate"`
+	Grade        *string    `json:"grade" validate:"omitempty,max=50"`
+	Description  *string    `json:"description" validate:"omitempty,max=1000"`
+}
+
+// UpdateLibraryEducationRequest represents the request to update an education entry in the career library
+type UpdateLibraryEducationRequest struct {
+	Institution  *string    `json:"institution" validate:"omitempty,max=200"`
+	Degree       *string    `json:"degree" validate:"omitempty,max=100"`
+	FieldOfStudy *string    `json:"fieldOfStudy" validate:"omitempty,max=100"`
+	StartDate    *time.Time `json:"startDate"`
+	EndDate      *time.Time `json:"endDate"`
+	Grade        *string    `json:"grade" validate:"omitempty,max=50"`
+	Description  *string    `json:"description" validate:"omitempty,max=1000"`
+}
+
+// LibraryEducationResponse represents the response for career library education data
+type LibraryEducationResponse struct {
+	ID           string     `json:"id"`
+	Institution  *string    `json:"institution"`
+	Degree       *string    `json:"degree"`
+	FieldOfStudy *string    `json:"fieldOfStudy"`
+	StartDate    *time.Time `json:"startDate"`
+	EndDate      *time.Time `json:"endDate"`
+	Grade        *string    `json:"grade"`
+	Description  *string    `json:"description"`
+	CreatedAt    string     `json:"createdAt"`
+	UpdatedAt    string     `json:"updatedAt"`
+	ETag         string     `json:"etag"`
+}
+
+// LinkLibraryEducationRequest represents the request to add a career library education entry to a resume
+type LinkLibraryEducationRequest struct {
+	ResumeID   uuid.UUID `json:"resumeId" validate:"required"`
+	OrderIndex int       `json:"orderIndex" validate:"min=0"`
+}
+
+// Validate implements the Validatable interface for CreateLibraryEducationRequest
+func (r *CreateLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateLibraryEducationRequest
+func (r *UpdateLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for LinkLibraryEducationRequest
+func (r *LinkLibraryEducationRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// EntityTag implements the etag.Tagged interface for EducationResponse
+func (r *EducationResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for LibraryEducationResponse
+func (r *LibraryEducationResponse) EntityTag() string {
+	return r.ETag
+}
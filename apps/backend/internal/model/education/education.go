@@ -10,7 +10,25 @@ import (
 // Education represents education entries
 type Education struct {
 	model.Base
-	ResumeID     uuid.UUID  `json:"resumeId" db:"resume_id"`
+	ResumeID      uuid.UUID  `json:"resumeId" db:"resume_id"`
+	LibraryItemID *uuid.UUID `json:"libraryItemId" db:"library_item_id"`
+	Institution   *string    `json:"institution" db:"institution"`
+	Degree        *string    `json:"degree" db:"degree"`
+	FieldOfStudy  *string    `json:"fieldOfStudy" db:"field_of_study"`
+	StartDate     *time.Time `json:"startDate" db:"start_date"`
+	EndDate       *time.Time `json:"endDate" db:"end_date"`
+	Grade         *string    `json:"grade" db:"grade"`
+	Description   *string    `json:"description" db:"description"`
+	OrderIndex    int        `json:"orderIndex" db:"order_index"`
+	// OverriddenFields lists the columns a library-linked row does not inherit
+	OverriddenFields []string `json:"overriddenFields" db:"overridden_fields"`
+}
+
+// LibraryEducation is a canonical education entry in a user's career library.
+// Resume education rows that reference it inherit every field they do not override.
+type LibraryEducation struct {
+	model.Base
+	UserID       string     `json:"userId" db:"user_id"`
 	Institution  *string    `json:"institution" db:"institution"`
 	Degree       *string    `json:"degree" db:"degree"`
 	FieldOfStudy *string    `json:"fieldOfStudy" db:"field_of_study"`
@@ -18,5 +36,4 @@ type Education struct {
 	EndDate      *time.Time `json:"endDate" db:"end_date"`
 	Grade        *string    `json:"grade" db:"grade"`
 	Description  *string    `json:"description" db:"description"`
-	OrderIndex   int        `json:"orderIndex" db:"order_index"`
 }
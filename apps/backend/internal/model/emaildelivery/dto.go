@@ -0,0 +1,47 @@
+package emaildelivery
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// Resend webhook event types that change the status of a delivery. Other
+// event types are acknowledged and ignored.
+const (
+	ResendEmailDelivered  = "email.delivered"
+	ResendEmailBounced    = "email.bounced"
+	ResendEmailComplained = "email.complained"
+)
+
+// ResendEvent is the body of a Resend webhook about an email
+type ResendEvent struct {
+	Type string `json:"type" validate:"required"`
+	Data struct {
+		EmailID string `json:"email_id"`
+		Bounce  *struct {
+			Type    string `json:"type"`
+			Message string `json:"message"`
+		} `json:"bounce"`
+	} `json:"data"`
+}
+
+// Validate implements the Validatable interface for ResendEvent
+func (e *ResendEvent) Validate() error {
+	validate := validator.New()
+	return validate.Struct(e)
+}
+
+// DeliveryResponse represents the response for an email delivery
+type DeliveryResponse struct {
+	ID                string  `json:"id"`
+	Template          string  `json:"template"`
+	Recipient         string  `json:"recipient"`
+	Provider          string  `json:"provider"`
+	ProviderMessageID *string `json:"providerMessageId"`
+	Status            Status  `json:"status"`
+	Error             *string `json:"error"`
+	Attempts          int     `json:"attempts"`
+	LastAttemptAt     *string `json:"lastAttemptAt"`
+	SentAt            *string `json:"sentAt"`
+	DeliveredAt       *string `json:"deliveredAt"`
+	CreatedAt         string  `json:"createdAt"`
+}
@@ -0,0 +1,41 @@
+package emaildelivery
+
+import (
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Status is the progress of an email delivery
+type Status string
+
+const (
+	// StatusPending deliveries are being sent
+	StatusPending Status = "pending"
+	// StatusSent deliveries were accepted by the provider
+	StatusSent Status = "sent"
+	// StatusFailed deliveries were not accepted by the provider; the task
+	// sending them retries until it runs out of retries
+	StatusFailed     Status = "failed"
+	StatusDelivered  Status = "delivered"
+	StatusBounced    Status = "bounced"
+	StatusComplained Status = "complained"
+)
+
+// Delivery is one email, from its first send attempt to its final status
+// reported by the provider
+type Delivery struct {
+	model.Base
+	IdempotencyKey    string     `json:"idempotencyKey" db:"idempotency_key"`
+	UserID            *string    `json:"userId" db:"user_id"`
+	Template          string     `json:"template" db:"template"`
+	Recipient         string     `json:"recipient" db:"recipient"`
+	Provider          string     `json:"provider" db:"provider"`
+	ProviderMessageID *string    `json:"providerMessageId" db:"provider_message_id"`
+	Status            Status     `json:"status" db:"status"`
+	Error             *string    `json:"error" db:"error"`
+	Attempts          int        `json:"attempts" db:"attempts"`
+	LastAttemptAt     *time.Time `json:"lastAttemptAt" db:"last_attempt_at"`
+	SentAt            *time.Time `json:"sentAt" db:"sent_at"`
+	DeliveredAt       *time.Time `json:"deliveredAt" db:"delivered_at"`
+}
@@ -0,0 +1,13 @@
+package erasure
+
+// ErasureResponse represents the receipt of an account erasure
+type ErasureResponse struct {
+	ID            string           `json:"id"`
+	Source        Source           `json:"source"`
+	Status        Status           `json:"status"`
+	RowsAffected  map[string]int64 `json:"rowsAffected"`
+	CancelledJobs int              `json:"cancelledJobs"`
+	Error         *string          `json:"error"`
+	CompletedAt   *string          `json:"completedAt"`
+	CreatedAt     string           `json:"createdAt"`
+}
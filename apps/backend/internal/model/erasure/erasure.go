@@ -0,0 +1,42 @@
+package erasure
+
+import (
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Source is who asked for an erasure
+type Source string
+
+const (
+	SourceUser  Source = "user"
+	SourceClerk Source = "clerk"
+)
+
+// Status is the progress of an erasure
+type Status string
+
+const (
+	StatusPending   Status = "pending"
+	StatusRunning   Status = "running"
+	StatusCompleted Status = "completed"
+	StatusFailed    Status = "failed"
+)
+
+// AnonymousUserID replaces the erased user's ID in rows that are kept because
+// they belong to other users' resumes or to the audit log
+const AnonymousUserID = "erased-user"
+
+// Erasure is the receipt of an account erasure. RowsAffected counts the rows
+// deleted, anonymized or detached so far, keyed by table and action.
+type Erasure struct {
+	model.Base
+	UserID        string           `json:"userId" db:"user_id"`
+	Source        Source           `json:"source" db:"source"`
+	Status        Status           `json:"status" db:"status"`
+	RowsAffected  map[string]int64 `json:"rowsAffected" db:"rows_affected"`
+	CancelledJobs int              `json:"cancelledJobs" db:"cancelled_jobs"`
+	Error         *string          `json:"error" db:"error"`
+	CompletedAt   *time.Time       `json:"completedAt" db:"completed_at"`
+}
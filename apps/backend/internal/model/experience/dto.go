@@ -5,6 +5,7 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/validation"
 )
 
 // CreateExperienceRequest represents the request to create a new experience entry
@@ -28,21 +29,27 @@ type UpdateExperienceRequest struct {
 	Location    *string    `json:"location" validate:"omitempty,max=200"`
 	Description *string    `json:"description" validate:"omitempty,max=2000"`
 	OrderIndex  *int       `json:"orderIndex" validate:"omitempty,min=0"`
+	// Clear lists fields to empty. A cleared field of a library-linked entry
+	// stays empty instead of falling back to the library value.
+	Clear []string `json:"clear" validate:"omitempty,dive,oneof=company position startDate endDate location description"`
 }
 
 // ExperienceResponse represents the response for experience data
 type ExperienceResponse struct {
-	ID          string     `json:"id"`
-	ResumeID    uuid.UUID  `json:"resumeId"`
-	Company     *string    `json:"company"`
-	Position    *string    `json:"position"`
-	StartDate   *time.Time `json:"startDate"`
-	EndDate     *time.Time `json:"endDate"`
-	Location    *string    `json:"location"`
-	Description *string    `json:"description"`
-	OrderIndex  int        `json:"orderIndex"`
-	CreatedAt   string     `json:"createdAt"`
-	UpdatedAt   string     `json:"updatedAt"`
+	ID               string     `json:"id"`
+	ResumeID         uuid.UUID  `json:"resumeId"`
+	LibraryItemID    *uuid.UUID `json:"libraryItemId"`
+	OverriddenFields []string   `json:"overriddenFields"`
+	Company          *string    `json:"company"`
+	Position         *string    `json:"position"`
+	StartDate        *time.Time `json:"startDate"`
+	EndDate          *time.Time `json:"endDate"`
+	Location         *string    `json:"location"`
+	Description      *string    `json:"description"`
+	OrderIndex       int        `json:"orderIndex"`
+	CreatedAt        string     `json:"createdAt"`
+	UpdatedAt        string     `json:"updatedAt"`
+	ETag             string     `json:"etag"`
 }
 
 // BulkUpdateExperienceRequest represents the request to update multiple experience entries order
@@ -65,7 +72,18 @@ func (r *CreateExperienceRequest) Validate() error {
 // Validate implements the Validatable interface for UpdateExperienceRequest
 func (r *UpdateExperienceRequest) Validate() error {
 	validate := validator.New()
-	return validate.Struct(r)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	return validation.CheckClearedFields(r.Clear, map[string]bool{
+		"company":     r.Company != nil,
+		"position":    r.Position != nil,
+		"startDate":   r.StartDate != nil,
+		"endDate":     r.EndDate != nil,
+		"location":    r.Location != nil,
+		"description": r.Description != nil,
+	})
 }
 
 // Validate implements the Validatable interface for BulkUpdateExperienceRequest
@@ -73,3 +91,71 @@ func (r *BulkUpdateExperienceRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// CreateLibraryExperienceRequest represents the request to add an experience entry to the career library
+type CreateLibraryExperienceRequest struct {
+	Company     *string    `json:"company" validate:"omitempty,max=200"`
+	Position    *string    `json:"position" validate:"omitempty,max=200"`
+	StartDate   *time.Time `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+	Location    *string    `json:"location" validate:"omitempty,max=200"`
+	Description *string    `json:"description" validate:"omitempty,max=2000"`
+}
+
+// UpdateLibraryExperienceRequest represents the request to update an experience entry in the career library
+type UpdateLibraryExperienceRequest struct {
+	Company     *string    `json:"company" validate:"omitempty,max=200"`
+	Position    *string    `json:"position" validate:"omitempty,max=200"`
+	StartDate   *time.Time `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+	Location    *string    `json:"location" validate:"omitempty,max=200"`
+	Description *string    `json:"description" validate:"omitempty,max=2000"`
+}
+
+// LibraryExperienceResponse represents the response for career library experience data
+type LibraryExperienceResponse struct {
+	ID          string     `json:"id"`
+	Company     *string    `json:"company"`
+	Position    *string    `json:"position"`
+	StartDate   *time.Time `json:"startDate"`
+	EndDate     *time.Time `json:"endDate"`
+	Location    *string    `json:"location"`
+	Description *string    `json:"description"`
+	CreatedAt   string     `json:"createdAt"`
+	UpdatedAt   string     `json:"updatedAt"`
+	ETag        string     `json:"etag"`
+}
+
+// LinkLibraryExperienceRequest represents the request to add a career library experience entry to a resume
+type LinkLibraryExperienceRequest struct {
+	ResumeID   uuid.UUID `json:"resumeId" validate:"required"`
+	OrderIndex int       `json:"orderIndex" validate:"min=0"`
+}
+
+// Validate implements the Validatable interface for CreateLibraryExperienceRequest
+func (r *CreateLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateLibraryExperienceRequest
+func (r *UpdateLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for LinkLibraryExperienceRequest
+func (r *LinkLibraryExperienceRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// EntityTag implements the etag.Tagged interface for ExperienceResponse
+func (r *ExperienceResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for LibraryExperienceResponse
+func (r *LibraryExperienceResponse) EntityTag() string {
+	return r.ETag
+}
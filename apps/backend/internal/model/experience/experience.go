@@ -10,12 +10,28 @@ import (
 // Experience represents work experience entries
 type Experience struct {
 	model.Base
-	ResumeID    uuid.UUID  `json:"resumeId" db:"resume_id"`
+	ResumeID      uuid.UUID  `json:"resumeId" db:"resume_id"`
+	LibraryItemID *uuid.UUID `json:"libraryItemId" db:"library_item_id"`
+	Company       *string    `json:"company" db:"company"`
+	Position      *string    `json:"position" db:"position"`
+	StartDate     *time.Time `json:"startDate" db:"start_date"`
+	EndDate       *time.Time `json:"endDate" db:"end_date"`
+	Location      *string    `json:"location" db:"location"`
+	Description   *string    `json:"description" db:"description"`
+	OrderIndex    int        `json:"orderIndex" db:"order_index"`
+	// OverriddenFields lists the columns a library-linked row does not inherit
+	OverriddenFields []string `json:"overriddenFields" db:"overridden_fields"`
+}
+
+// LibraryExperience is a canonical experience entry in a user's career library.
+// Resume experience rows that reference it inherit every field they do not override.
+type LibraryExperience struct {
+	model.Base
+	UserID      string     `json:"userId" db:"user_id"`
 	Company     *string    `json:"company" db:"company"`
 	Position    *string    `json:"position" db:"position"`
 	StartDate   *time.Time `json:"startDate" db:"start_date"`
 	EndDate     *time.Time `json:"endDate" db:"end_date"`
 	Location    *string    `json:"location" db:"location"`
 	Description *string    `json:"description" db:"description"`
-	OrderIndex  int        `json:"orderIndex" db:"order_index"`
 }
@@ -0,0 +1,34 @@
+package notification
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// UpdatePreferencesRequest changes the categories that are set
+type UpdatePreferencesRequest struct {
+	Reminders        *bool `json:"reminders"`
+	ReviewRequests   *bool `json:"reviewRequests"`
+	ShareViewDigests *bool `json:"shareViewDigests"`
+	ProductNews      *bool `json:"productNews"`
+}
+
+// PreferencesResponse represents the response for notification preferences
+type PreferencesResponse struct {
+	Reminders        bool `json:"reminders"`
+	ReviewRequests   bool `json:"reviewRequests"`
+	ShareViewDigests bool `json:"shareViewDigests"`
+	ProductNews      bool `json:"productNews"`
+}
+
+// UnsubscribeResponse represents the response for an unsubscribe link. The
+// category is unset when the link unsubscribed from every category.
+type UnsubscribeResponse struct {
+	Category    *string             `json:"category"`
+	Preferences PreferencesResponse `json:"preferences"`
+}
+
+// Validate implements the Validatable interface for UpdatePreferencesRequest
+func (r *UpdatePreferencesRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
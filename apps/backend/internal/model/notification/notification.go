@@ -0,0 +1,28 @@
+package notification
+
+import (
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Preferences are the optional emails a user wants, one opt-in per category
+type Preferences struct {
+	UserID           string `json:"userId" db:"user_id"`
+	Reminders        bool   `json:"reminders" db:"reminders"`
+	ReviewRequests   bool   `json:"reviewRequests" db:"review_requests"`
+	ShareViewDigests bool   `json:"shareViewDigests" db:"share_view_digests"`
+	ProductNews      bool   `json:"productNews" db:"product_news"`
+	model.BaseWithCreatedAt
+	model.BaseWithUpdatedAt
+}
+
+// DefaultPreferences are the preferences of a user who never changed them.
+// Users get the emails about their own resumes, but not product news.
+func DefaultPreferences(userID string) *Preferences {
+	return &Preferences{
+		UserID:           userID,
+		Reminders:        true,
+		ReviewRequests:   true,
+		ShareViewDigests: true,
+		ProductNews:      false,
+	}
+}
@@ -0,0 +1,44 @@
+package organization
+
+import (
+	"slices"
+
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+)
+
+// Clerk organization role and the custom permissions Resumify checks. The
+// permissions must be created in Clerk and assigned to organization roles.
+const (
+	RoleAdmin = "org:admin"
+
+	PermissionResumesRead    = "org:resumes:read"
+	PermissionResumesComment = "org:resumes:comment"
+	PermissionResumesManage  = "org:resumes:manage"
+	PermissionResumesCreate  = "org:resumes:create"
+)
+
+// Member is a user's membership of an organization, mirrored from Clerk
+type Member struct {
+	OrganizationID string            `json:"organizationId" db:"organization_id"`
+	UserID         string            `json:"userId" db:"user_id"`
+	OrgRole        string            `json:"orgRole" db:"org_role"`
+	ResumeRole     collaborator.Role `json:"resumeRole" db:"resume_role"`
+	model.BaseWithCreatedAt
+	model.BaseWithUpdatedAt
+}
+
+// ResumeRoleFor returns the role an organization member holds on the
+// organization's resumes, or an empty role when they have no access
+func ResumeRoleFor(orgRole string, permissions []string) collaborator.Role {
+	if orgRole == RoleAdmin || slices.Contains(permissions, PermissionResumesManage) {
+		return collaborator.RoleEditor
+	}
+	if slices.Contains(permissions, PermissionResumesComment) {
+		return collaborator.RoleCommenter
+	}
+	if slices.Contains(permissions, PermissionResumesRead) {
+		return collaborator.RoleViewer
+	}
+	return ""
+}
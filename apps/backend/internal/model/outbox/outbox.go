@@ -0,0 +1,21 @@
+package outbox
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Event is a domain event waiting in, dispatched from, or dead-lettered in
+// the outbox
+type Event struct {
+	ID             uuid.UUID       `json:"id" db:"id"`
+	EventType      string          `json:"eventType" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	LastError      *string         `json:"lastError" db:"last_error"`
+	DispatchedAt   *time.Time      `json:"dispatchedAt" db:"dispatched_at"`
+	DeadLetteredAt *time.Time      `json:"deadLetteredAt" db:"dead_lettered_at"`
+	CreatedAt      time.Time       `json:"createdAt" db:"created_at"`
+}
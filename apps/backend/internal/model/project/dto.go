@@ -3,6 +3,7 @@ package project
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/validation"
 )
 
 // CreateProjectRequest represents the request to create a new project entry
@@ -24,20 +25,26 @@ type UpdateProjectRequest struct {
 	Link         *string  `json:"link" validate:"omitempty,url"`
 	Technologies []string `json:"technologies" validate:"omitempty,max=20"`
 	OrderIndex   *int     `json:"orderIndex" validate:"omitempty,min=0"`
+	// Clear lists fields to empty. A cleared field of a library-linked entry
+	// stays empty instead of falling back to the library value.
+	Clear []string `json:"clear" validate:"omitempty,dive,oneof=name role description link technologies"`
 }
 
 // ProjectResponse represents the response for project data
 type ProjectResponse struct {
-	ID           string    `json:"id"`
-	ResumeID     uuid.UUID `json:"resumeId"`
-	Name         *string   `json:"name"`
-	Role         *string   `json:"role"`
-	Description  *string   `json:"description"`
-	Link         *string   `json:"link"`
-	Technologies []string  `json:"technologies"`
-	OrderIndex   int       `json:"orderIndex"`
-	CreatedAt    string    `json:"createdAt"`
-	UpdatedAt    string    `json:"updatedAt"`
+	ID               string     `json:"id"`
+	ResumeID         uuid.UUID  `json:"resumeId"`
+	LibraryItemID    *uuid.UUID `json:"libraryItemId"`
+	OverriddenFields []string   `json:"overriddenFields"`
+	Name             *string    `json:"name"`
+	Role             *string    `json:"role"`
+	Description      *string    `json:"description"`
+	Link             *string    `json:"link"`
+	Technologies     []string   `json:"technologies"`
+	OrderIndex       int        `json:"orderIndex"`
+	CreatedAt        string     `json:"createdAt"`
+	UpdatedAt        string     `json:"updatedAt"`
+	ETag             string     `json:"etag"`
 }
 
 // BulkUpdateProjectsRequest represents the request to update multiple project entries order
@@ -60,7 +67,17 @@ func (r *CreateProjectRequest) Validate() error {
 // Validate implements the Validatable interface for UpdateProjectRequest
 func (r *UpdateProjectRequest) Validate() error {
 	validate := validator.New()
-	return validate.Struct(r)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	return validation.CheckClearedFields(r.Clear, map[string]bool{
+		"name":         r.Name != nil,
+		"role":         r.Role != nil,
+		"description":  r.Description != nil,
+		"link":         r.Link != nil,
+		"technologies": r.Technologies != nil,
+	})
 }
 
 // Validate implements the Validatable interface for BulkUpdateProjectsRequest
@@ -68,3 +85,68 @@ func (r *BulkUpdateProjectsRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// CreateLibraryProjectRequest represents the request to add a project entry to the career library
+type CreateLibraryProjectRequest struct {
+	Name         *string  `json:"name" validate:"omitempty,max=200"`
+	Role         *string  `json:"role" validate:"omitempty,max=200"`
+	Description  *string  `json:"description" validate:"omitempty,max=2000"`
+	Link         *string  `json:"link" validate:"omitempty,url"`
+	Technologies []string `json:"technologies" validate:"omitempty,max=20"`
+}
+
+// UpdateLibraryProjectRequest represents the request to update a project entry in the career library
+type UpdateLibraryProjectRequest struct {
+	Name         *string  `json:"name" validate:"omitempty,max=200"`
+	Role         *string  `json:"role" validate:"omitempty,max=200"`
+	Description  *string  `json:"description" validate:"omitempty,max=2000"`
+	Link         *string  `json:"link" validate:"omitempty,url"`
+	Technologies []string `json:"technologies" validate:"omitempty,max=20"`
+}
+
+// LibraryProjectResponse represents the response for career library project data
+type LibraryProjectResponse struct {
+	ID           string   `json:"id"`
+	Name         *string  `json:"name"`
+	Role         *string  `json:"role"`
+	Description  *string  `json:"description"`
+	Link         *string  `json:"link"`
+	Technologies []string `json:"technologies"`
+	CreatedAt    string   `json:"createdAt"`
+	UpdatedAt    string   `json:"updatedAt"`
+	ETag         string   `json:"etag"`
+}
+
+// LinkLibraryProjectRequest represents the request to add a career library project entry to a resume
+type LinkLibraryProjectRequest struct {
+	ResumeID   uuid.UUID `json:"resumeId" validate:"required"`
+	OrderIndex int       `json:"orderIndex" validate:"min=0"`
+}
+
+// Validate implements the Validatable interface for CreateLibraryProjectRequest
+func (r *CreateLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateLibraryProjectRequest
+func (r *UpdateLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for LinkLibraryProjectRequest
+func (r *LinkLibraryProjectRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// EntityTag implements the etag.Tagged interface for ProjectResponse
+func (r *ProjectResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for LibraryProjectResponse
+func (r *LibraryProjectResponse) EntityTag() string {
+	return r.ETag
+}
@@ -8,11 +8,26 @@ import (
 // Project represents project entries
 type Project struct {
 	model.Base
-	ResumeID     uuid.UUID `json:"resumeId" db:"resume_id"`
-	Name         *string   `json:"name" db:"name"`
-	Role         *string   `json:"role" db:"role"`
-	Description  *string   `json:"description" db:"description"`
-	Link         *string   `json:"link" db:"link"`
-	Technologies []string  `json:"technologies" db:"technologies"`
-	OrderIndex   int       `json:"orderIndex" db:"order_index"`
+	ResumeID      uuid.UUID  `json:"resumeId" db:"resume_id"`
+	LibraryItemID *uuid.UUID `json:"libraryItemId" db:"library_item_id"`
+	Name          *string    `json:"name" db:"name"`
+	Role          *string    `json:"role" db:"role"`
+	Description   *string    `json:"description" db:"description"`
+	Link          *string    `json:"link" db:"link"`
+	Technologies  []string   `json:"technologies" db:"technologies"`
+	OrderIndex    int        `json:"orderIndex" db:"order_index"`
+	// OverriddenFields lists the columns a library-linked row does not inherit
+	OverriddenFields []string `json:"overriddenFields" db:"overridden_fields"`
+}
+
+// LibraryProject is a canonical project entry in a user's career library.
+// Resume project rows that reference it inherit every field they do not override.
+type LibraryProject struct {
+	model.Base
+	UserID       string   `json:"userId" db:"user_id"`
+	Name         *string  `json:"name" db:"name"`
+	Role         *string  `json:"role" db:"role"`
+	Description  *string  `json:"description" db:"description"`
+	Link         *string  `json:"link" db:"link"`
+	Technologies []string `json:"technologies" db:"technologies"`
 }
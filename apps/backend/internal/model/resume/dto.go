@@ -1,6 +1,9 @@
 package resume
 
-import "github.com/go-playground/validator/v10"
+import (
+	"github.com/go-playground/validator/v10"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+)
 
 // CreateResumeRequest represents the request to create a new resume
 type CreateResumeRequest struct {
@@ -16,21 +19,34 @@ type UpdateResumeRequest struct {
 
 // ResumeResponse represents the response for resume data
 type ResumeResponse struct {
-	ID        string `json:"id"`
-	UserID    string `json:"userId"`
-	Title     string `json:"title"`
-	Theme     string `json:"theme"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID             string  `json:"id"`
+	UserID         string  `json:"userId"`
+	OrganizationID *string `json:"organizationId"`
+	Title          string  `json:"title"`
+	Theme          string  `json:"theme"`
+	CreatedAt      string  `json:"createdAt"`
+	UpdatedAt      string  `json:"updatedAt"`
+	ETag           string  `json:"etag"`
 }
 
 // ResumeSummaryResponse represents a summary of resume data (for lists)
 type ResumeSummaryResponse struct {
-	ID        string `json:"id"`
-	Title     string `json:"title"`
-	Theme     string `json:"theme"`
-	CreatedAt string `json:"createdAt"`
-	UpdatedAt string `json:"updatedAt"`
+	ID             string  `json:"id"`
+	OrganizationID *string `json:"organizationId"`
+	Title          string  `json:"title"`
+	Theme          string  `json:"theme"`
+	CreatedAt      string  `json:"createdAt"`
+	UpdatedAt      string  `json:"updatedAt"`
+	DeletedAt      *string `json:"deletedAt,omitempty"`
+	ETag           string  `json:"etag"`
+}
+
+// SharedResumeResponse represents a resume shared with the user, directly or
+// through an organization (for lists)
+type SharedResumeResponse struct {
+	ResumeSummaryResponse
+	OwnerID string            `json:"ownerId"`
+	Role    collaborator.Role `json:"role"`
 }
 
 // Validate implements the Validatable interface for CreateResumeRequest
@@ -44,3 +60,13 @@ func (r *UpdateResumeRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// EntityTag implements the etag.Tagged interface for ResumeResponse
+func (r *ResumeResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for ResumeSummaryResponse
+func (r *ResumeSummaryResponse) EntityTag() string {
+	return r.ETag
+}
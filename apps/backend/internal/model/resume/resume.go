@@ -1,13 +1,24 @@
 package resume
 
 import (
+	"time"
+
 	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 )
 
 // Resume represents a user's resume
 type Resume struct {
 	model.Base
-	UserID string `json:"userId" db:"user_id"`
-	Title  string `json:"title" db:"title"`
-	Theme  string `json:"theme" db:"theme"`
+	UserID         string     `json:"userId" db:"user_id"`
+	OrganizationID *string    `json:"organizationId" db:"organization_id"`
+	Title          string     `json:"title" db:"title"`
+	Theme          string     `json:"theme" db:"theme"`
+	DeletedAt      *time.Time `json:"deletedAt" db:"deleted_at"`
+}
+
+// SharedResume is a resume the user collaborates on, with the user's role
+type SharedResume struct {
+	Resume
+	Role collaborator.Role `json:"role" db:"role"`
 }
@@ -0,0 +1,31 @@
+package resumeexport
+
+import (
+	"github.com/go-playground/validator/v10"
+)
+
+// CreateResumeExportRequest represents the request to render a resume to a
+// download format
+type CreateResumeExportRequest struct {
+	Format Format `json:"format" validate:"required,oneof=html json"`
+}
+
+func (r *CreateResumeExportRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// ResumeExportResponse represents the response for resume export status. The
+// download URL is set once the render is done.
+type ResumeExportResponse struct {
+	ID          string  `json:"id"`
+	ResumeID    string  `json:"resumeId"`
+	Format      Format  `json:"format"`
+	Status      Status  `json:"status"`
+	SizeBytes   *int64  `json:"sizeBytes"`
+	DownloadURL *string `json:"downloadUrl"`
+	Error       *string `json:"error"`
+	ExpiresAt   *string `json:"expiresAt"`
+	CompletedAt *string `json:"completedAt"`
+	CreatedAt   string  `json:"createdAt"`
+}
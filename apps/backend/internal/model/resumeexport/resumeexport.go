@@ -0,0 +1,66 @@
+package resumeexport
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Status is the progress of a resume export
+type Status string
+
+const (
+	StatusQueued  Status = "queued"
+	StatusRunning Status = "running"
+	StatusDone    Status = "done"
+	StatusFailed  Status = "failed"
+)
+
+// Format is the file format a resume is rendered to
+type Format string
+
+const (
+	FormatHTML Format = "html"
+	FormatJSON Format = "json"
+)
+
+// ContentType is the media type of renders in the format
+func (f Format) ContentType() string {
+	switch f {
+	case FormatJSON:
+		return "application/json"
+	default:
+		return "text/html; charset=utf-8"
+	}
+}
+
+// ResumeExport is a resume rendered to a format. The rendered content is kept
+// in blob storage under StorageKey.
+type ResumeExport struct {
+	model.Base
+	ResumeID     uuid.UUID  `json:"resumeId" db:"resume_id"`
+	Format       Format     `json:"format" db:"format"`
+	DocumentHash string     `json:"documentHash" db:"document_hash"`
+	Status       Status     `json:"status" db:"status"`
+	SizeBytes    *int64     `json:"sizeBytes" db:"size_bytes"`
+	Error        *string    `json:"error" db:"error"`
+	ExpiresAt    *time.Time `json:"expiresAt" db:"expires_at"`
+	CompletedAt  *time.Time `json:"completedAt" db:"completed_at"`
+}
+
+// Blob identifies the stored content of an export
+type Blob struct {
+	ID     uuid.UUID `db:"id"`
+	Format Format    `db:"format"`
+}
+
+// StorageKey is the blob storage key of the export's rendered content
+func (b Blob) StorageKey() string {
+	return "resume-exports/" + b.ID.String() + "." + string(b.Format)
+}
+
+// Blob identifies the stored content of the export
+func (e *ResumeExport) Blob() Blob {
+	return Blob{ID: e.ID, Format: e.Format}
+}
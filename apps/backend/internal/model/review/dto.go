@@ -0,0 +1,60 @@
+package review
+
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// SubmitReviewRequest represents the request to submit a resume for review
+type SubmitReviewRequest struct {
+	ReviewerID string  `json:"reviewerId" validate:"required,max=100"`
+	Message    *string `json:"message" validate:"omitempty,max=2000"`
+}
+
+// TransitionReviewRequest represents the optional note left with a status change
+type TransitionReviewRequest struct {
+	Note *string `json:"note" validate:"omitempty,max=2000"`
+}
+
+// ReviewResponse represents the response for review data. The snapshot and
+// history are only included for a single review.
+type ReviewResponse struct {
+	ID            string          `json:"id"`
+	ResumeID      uuid.UUID       `json:"resumeId"`
+	SubmitterID   string          `json:"submitterId"`
+	ReviewerID    string          `json:"reviewerId"`
+	Status        Status          `json:"status"`
+	Message       *string         `json:"message"`
+	ResumeVersion string          `json:"resumeVersion"`
+	SubmittedAt   string          `json:"submittedAt"`
+	StartedAt     *string         `json:"startedAt"`
+	DecidedAt     *string         `json:"decidedAt"`
+	WithdrawnAt   *string         `json:"withdrawnAt"`
+	Snapshot      json.RawMessage `json:"snapshot,omitempty"`
+	History       []EventResponse `json:"history,omitempty"`
+	CreatedAt     string          `json:"createdAt"`
+	UpdatedAt     string          `json:"updatedAt"`
+}
+
+// EventResponse represents one status change in a review's history
+type EventResponse struct {
+	FromStatus *Status `json:"fromStatus"`
+	ToStatus   Status  `json:"toStatus"`
+	ActorID    string  `json:"actorId"`
+	Note       *string `json:"note"`
+	CreatedAt  string  `json:"createdAt"`
+}
+
+// Validate implements the Validatable interface for SubmitReviewRequest
+func (r *SubmitReviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for TransitionReviewRequest
+func (r *TransitionReviewRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
@@ -0,0 +1,91 @@
+package review
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// Status is a step of the review workflow
+type Status string
+
+const (
+	StatusSubmitted        Status = "submitted"
+	StatusInReview         Status = "in_review"
+	StatusChangesRequested Status = "changes_requested"
+	StatusApproved         Status = "approved"
+	StatusWithdrawn        Status = "withdrawn"
+)
+
+// Actor is the party of a review allowed to make a transition
+type Actor string
+
+const (
+	ActorSubmitter Actor = "submitter"
+	ActorReviewer  Actor = "reviewer"
+)
+
+// Transition is an allowed status change and who may make it
+type Transition struct {
+	From  Status
+	To    Status
+	Actor Actor
+}
+
+// transitions is the review state machine. Approved and withdrawn reviews are
+// final; a resume needing another round is submitted again.
+var transitions = []Transition{
+	{From: StatusSubmitted, To: StatusInReview, Actor: ActorReviewer},
+	{From: StatusSubmitted, To: StatusChangesRequested, Actor: ActorReviewer},
+	{From: StatusSubmitted, To: StatusApproved, Actor: ActorReviewer},
+	{From: StatusInReview, To: StatusChangesRequested, Actor: ActorReviewer},
+	{From: StatusInReview, To: StatusApproved, Actor: ActorReviewer},
+	{From: StatusChangesRequested, To: StatusSubmitted, Actor: ActorSubmitter},
+	{From: StatusSubmitted, To: StatusWithdrawn, Actor: ActorSubmitter},
+	{From: StatusInReview, To: StatusWithdrawn, Actor: ActorSubmitter},
+	{From: StatusChangesRequested, To: StatusWithdrawn, Actor: ActorSubmitter},
+}
+
+// FindTransition returns the transition from one status to another, if allowed
+func FindTransition(from, to Status) (Transition, bool) {
+	for _, transition := range transitions {
+		if transition.From == from && transition.To == to {
+			return transition, true
+		}
+	}
+	return Transition{}, false
+}
+
+// IsOpen reports whether the review still awaits a decision from the reviewer
+func (s Status) IsOpen() bool {
+	return s == StatusSubmitted || s == StatusInReview
+}
+
+// Review is a request for a coach to review a version of a resume
+type Review struct {
+	model.Base
+	ResumeID      uuid.UUID       `json:"resumeId" db:"resume_id"`
+	SubmitterID   string          `json:"submitterId" db:"submitter_id"`
+	ReviewerID    string          `json:"reviewerId" db:"reviewer_id"`
+	Status        Status          `json:"status" db:"status"`
+	Message       *string         `json:"message" db:"message"`
+	ResumeVersion string          `json:"resumeVersion" db:"resume_version"`
+	Snapshot      json.RawMessage `json:"snapshot" db:"snapshot"`
+	SubmittedAt   time.Time       `json:"submittedAt" db:"submitted_at"`
+	StartedAt     *time.Time      `json:"startedAt" db:"started_at"`
+	DecidedAt     *time.Time      `json:"decidedAt" db:"decided_at"`
+	WithdrawnAt   *time.Time      `json:"withdrawnAt" db:"withdrawn_at"`
+}
+
+// Event records one status change of a review
+type Event struct {
+	model.BaseWithId
+	ReviewID   uuid.UUID `json:"reviewId" db:"review_id"`
+	FromStatus *Status   `json:"fromStatus" db:"from_status"`
+	ToStatus   Status    `json:"toStatus" db:"to_status"`
+	ActorID    string    `json:"actorId" db:"actor_id"`
+	Note       *string   `json:"note" db:"note"`
+	model.BaseWithCreatedAt
+}
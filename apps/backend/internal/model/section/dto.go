@@ -32,6 +32,7 @@ type SectionResponse struct {
 	OrderIndex  int       `json:"orderIndex"`
 	CreatedAt   string    `json:"createdAt"`
 	UpdatedAt   string    `json:"updatedAt"`
+	ETag        string    `json:"etag"`
 }
 
 // BulkUpdateSectionsRequest represents the request to update multiple sections order
@@ -62,3 +63,8 @@ func (r *BulkUpdateSectionsRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// EntityTag implements the etag.Tagged interface for SectionResponse
+func (r *SectionResponse) EntityTag() string {
+	return r.ETag
+}
@@ -3,6 +3,7 @@ package skill
 import (
 	"github.com/go-playground/validator/v10"
 	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/validation"
 )
 
 // CreateSkillRequest represents the request to create a new skill entry
@@ -20,16 +21,24 @@ type UpdateSkillRequest struct {
 	Level      *string `json:"level" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
 	Category   *string `json:"category" validate:"omitempty,max=50"`
 	OrderIndex *int    `json:"orderIndex" validate:"omitempty,min=0"`
+	// Clear lists fields to empty. A cleared field of a library-linked entry
+	// stays empty instead of falling back to the library value.
+	Clear []string `json:"clear" validate:"omitempty,dive,oneof=name level category"`
 }
 
 // SkillResponse represents the response for skill data
 type SkillResponse struct {
-	ID         string    `json:"id"`
-	ResumeID   uuid.UUID `json:"resumeId"`
-	Name       *string   `json:"name"`
-	Level      *string   `json:"level"`
-	Category   *string   `json:"category"`
-	OrderIndex int       `json:"orderIndex"`
+	ID               string     `json:"id"`
+	ResumeID         uuid.UUID  `json:"resumeId"`
+	LibraryItemID    *uuid.UUID `json:"libraryItemId"`
+	OverriddenFields []string   `json:"overriddenFields"`
+	Name             *string    `json:"name"`
+	Level            *string    `json:"level"`
+	Category         *string    `json:"category"`
+	OrderIndex       int        `json:"orderIndex"`
+	CreatedAt        string     `json:"createdAt"`
+	UpdatedAt        string     `json:"updatedAt"`
+	ETag             string     `json:"etag"`
 }
 
 // BulkUpdateSkillsRequest represents the request to update multiple skill entries order
@@ -58,7 +67,15 @@ func (r *CreateSkillRequest) Validate() error {
 // Validate implements the Validatable interface for UpdateSkillRequest
 func (r *UpdateSkillRequest) Validate() error {
 	validate := validator.New()
-	return validate.Struct(r)
+	if err := validate.Struct(r); err != nil {
+		return err
+	}
+
+	return validation.CheckClearedFields(r.Clear, map[string]bool{
+		"name":     r.Name != nil,
+		"level":    r.Level != nil,
+		"category": r.Category != nil,
+	})
 }
 
 // Validate implements the Validatable interface for BulkUpdateSkillsRequest
@@ -66,3 +83,62 @@ func (r *BulkUpdateSkillsRequest) Validate() error {
 	validate := validator.New()
 	return validate.Struct(r)
 }
+
+// CreateLibrarySkillRequest represents the request to add a skill entry to the career library
+type CreateLibrarySkillRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	Level    *string `json:"level" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	Category *string `json:"category" validate:"omitempty,max=50"`
+}
+
+// UpdateLibrarySkillRequest represents the request to update a skill entry in the career library
+type UpdateLibrarySkillRequest struct {
+	Name     *string `json:"name" validate:"omitempty,max=100"`
+	Level    *string `json:"level" validate:"omitempty,oneof=Beginner Intermediate Advanced Expert"`
+	Category *string `json:"category" validate:"omitempty,max=50"`
+}
+
+// LibrarySkillResponse represents the response for career library skill data
+type LibrarySkillResponse struct {
+	ID        string  `json:"id"`
+	Name      *string `json:"name"`
+	Level     *string `json:"level"`
+	Category  *string `json:"category"`
+	CreatedAt string  `json:"createdAt"`
+	UpdatedAt string  `json:"updatedAt"`
+	ETag      string  `json:"etag"`
+}
+
+// LinkLibrarySkillRequest represents the request to add a career library skill entry to a resume
+type LinkLibrarySkillRequest struct {
+	ResumeID   uuid.UUID `json:"resumeId" validate:"required"`
+	OrderIndex int       `json:"orderIndex" validate:"min=0"`
+}
+
+// Validate implements the Validatable interface for CreateLibrarySkillRequest
+func (r *CreateLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateLibrarySkillRequest
+func (r *UpdateLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for LinkLibrarySkillRequest
+func (r *LinkLibrarySkillRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// EntityTag implements the etag.Tagged interface for SkillResponse
+func (r *SkillResponse) EntityTag() string {
+	return r.ETag
+}
+
+// EntityTag implements the etag.Tagged interface for LibrarySkillResponse
+func (r *LibrarySkillResponse) EntityTag() string {
+	return r.ETag
+}
@@ -7,10 +7,23 @@ import (
 
 // Skill represents skill entries
 type Skill struct {
-	model.BaseWithId
-	ResumeID   uuid.UUID `json:"resumeId" db:"resume_id"`
-	Name       *string   `json:"name" db:"name"`
-	Level      *string   `json:"level" db:"level"`
-	Category   *string   `json:"category" db:"category"`
-	OrderIndex int       `json:"orderIndex" db:"order_index"`
+	model.Base
+	ResumeID      uuid.UUID  `json:"resumeId" db:"resume_id"`
+	LibraryItemID *uuid.UUID `json:"libraryItemId" db:"library_item_id"`
+	Name          *string    `json:"name" db:"name"`
+	Level         *string    `json:"level" db:"level"`
+	Category      *string    `json:"category" db:"category"`
+	OrderIndex    int        `json:"orderIndex" db:"order_index"`
+	// OverriddenFields lists the columns a library-linked row does not inherit
+	OverriddenFields []string `json:"overriddenFields" db:"overridden_fields"`
+}
+
+// LibrarySkill is a canonical skill entry in a user's career library.
+// Resume skill rows that reference it inherit every field they do not override.
+type LibrarySkill struct {
+	model.Base
+	UserID   string  `json:"userId" db:"user_id"`
+	Name     *string `json:"name" db:"name"`
+	Level    *string `json:"level" db:"level"`
+	Category *string `json:"category" db:"category"`
 }
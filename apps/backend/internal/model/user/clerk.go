@@ -0,0 +1,90 @@
+package user
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+)
+
+// Clerk webhook event types handled by the app
+const (
+	ClerkUserCreated = "user.created"
+	ClerkUserUpdated = "user.updated"
+	ClerkUserDeleted = "user.deleted"
+
+	ClerkMembershipCreated   = "organizationMembership.created"
+	ClerkMembershipUpdated   = "organizationMembership.updated"
+	ClerkMembershipDeleted   = "organizationMembership.deleted"
+	ClerkOrganizationDeleted = "organization.deleted"
+)
+
+// ClerkEvent is the body of a Clerk webhook. Data depends on the event type.
+type ClerkEvent struct {
+	Type string          `json:"type" validate:"required"`
+	Data json.RawMessage `json:"data" validate:"required"`
+}
+
+// ClerkUserData is the data of user.created and user.updated events
+type ClerkUserData struct {
+	ID                    string  `json:"id"`
+	FirstName             *string `json:"first_name"`
+	LastName              *string `json:"last_name"`
+	ImageURL              *string `json:"image_url"`
+	PrimaryEmailAddressID *string `json:"primary_email_address_id"`
+	EmailAddresses        []struct {
+		ID           string `json:"id"`
+		EmailAddress string `json:"email_address"`
+		Verification *struct {
+			Status string `json:"status"`
+		} `json:"verification"`
+	} `json:"email_addresses"`
+}
+
+// ClerkDeletedData is the data of user.deleted and organization.deleted events
+type ClerkDeletedData struct {
+	ID      string `json:"id"`
+	Deleted bool   `json:"deleted"`
+}
+
+// ClerkMembershipData is the data of organizationMembership events
+type ClerkMembershipData struct {
+	Organization struct {
+		ID string `json:"id"`
+	} `json:"organization"`
+	PublicUserData struct {
+		UserID string `json:"user_id"`
+	} `json:"public_user_data"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+}
+
+// PrimaryEmail returns the user's primary email address, if any
+func (d *ClerkUserData) PrimaryEmail() *string {
+	if d.PrimaryEmailAddressID == nil {
+		return nil
+	}
+	for _, address := range d.EmailAddresses {
+		if address.ID == *d.PrimaryEmailAddressID {
+			return &address.EmailAddress
+		}
+	}
+	return nil
+}
+
+// VerifiedEmails returns the user's verified email addresses, lower-cased
+func (d *ClerkUserData) VerifiedEmails() []string {
+	emails := []string{}
+	for _, address := range d.EmailAddresses {
+		if address.Verification != nil && address.Verification.Status == "verified" {
+			emails = append(emails, strings.ToLower(address.EmailAddress))
+		}
+	}
+	return emails
+}
+
+// Validate implements the Validatable interface for ClerkEvent
+func (e *ClerkEvent) Validate() error {
+	validate := validator.New()
+	return validate.Struct(e)
+}
@@ -0,0 +1,17 @@
+package user
+
+import (
+	"time"
+)
+
+// User is the cached profile of a Clerk user
+type User struct {
+	ID             string    `json:"id" db:"id"`
+	Email          *string   `json:"email" db:"email"`
+	VerifiedEmails []string  `json:"verifiedEmails" db:"verified_emails"`
+	FirstName      *string   `json:"firstName" db:"first_name"`
+	LastName       *string   `json:"lastName" db:"last_name"`
+	ImageURL       *string   `json:"imageUrl" db:"image_url"`
+	CreatedAt      time.Time `json:"createdAt" db:"created_at"`
+	UpdatedAt      time.Time `json:"updatedAt" db:"updated_at"`
+}
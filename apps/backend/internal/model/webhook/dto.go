@@ -0,0 +1,66 @@
+package webhook
+
+import (
+	"encoding/json"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+)
+
+// CreateEndpointRequest represents the request to register a webhook endpoint
+type CreateEndpointRequest struct {
+	URL         string      `json:"url" validate:"required,url,startswith=http,max=2048"`
+	Description *string     `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []EventType `json:"eventTypes" validate:"required,min=1,unique,dive,oneof=resume.updated resume.deleted"`
+}
+
+// UpdateEndpointRequest represents the request to change a webhook endpoint
+type UpdateEndpointRequest struct {
+	URL         *string     `json:"url" validate:"omitempty,url,startswith=http,max=2048"`
+	Description *string     `json:"description" validate:"omitempty,max=255"`
+	EventTypes  []EventType `json:"eventTypes" validate:"omitempty,min=1,unique,dive,oneof=resume.updated resume.deleted"`
+	Active      *bool       `json:"active"`
+}
+
+// EndpointResponse represents the response for webhook endpoint data. The
+// signing secret is only returned when the endpoint is created.
+type EndpointResponse struct {
+	ID          string      `json:"id"`
+	URL         string      `json:"url"`
+	Description *string     `json:"description"`
+	EventTypes  []EventType `json:"eventTypes"`
+	Active      bool        `json:"active"`
+	Secret      *string     `json:"secret,omitempty"`
+	CreatedAt   string      `json:"createdAt"`
+	UpdatedAt   string      `json:"updatedAt"`
+}
+
+// DeliveryResponse represents the response for a webhook delivery
+type DeliveryResponse struct {
+	ID             string          `json:"id"`
+	EndpointID     uuid.UUID       `json:"endpointId"`
+	EventID        uuid.UUID       `json:"eventId"`
+	EventType      EventType       `json:"eventType"`
+	Payload        json.RawMessage `json:"payload"`
+	Status         DeliveryStatus  `json:"status"`
+	Attempts       int             `json:"attempts"`
+	ResponseStatus *int            `json:"responseStatus"`
+	ResponseBody   *string         `json:"responseBody"`
+	Error          *string         `json:"error"`
+	RedeliveryOf   *uuid.UUID      `json:"redeliveryOf"`
+	LastAttemptAt  *string         `json:"lastAttemptAt"`
+	DeliveredAt    *string         `json:"deliveredAt"`
+	CreatedAt      string          `json:"createdAt"`
+}
+
+// Validate implements the Validatable interface for CreateEndpointRequest
+func (r *CreateEndpointRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
+
+// Validate implements the Validatable interface for UpdateEndpointRequest
+func (r *UpdateEndpointRequest) Validate() error {
+	validate := validator.New()
+	return validate.Struct(r)
+}
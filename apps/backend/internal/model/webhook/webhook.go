@@ -0,0 +1,74 @@
+package webhook
+
+import (
+	"encoding/json"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model"
+)
+
+// EventType is an event a webhook endpoint can subscribe to. There is no
+// application.status_changed event until job applications are tracked.
+type EventType string
+
+const (
+	EventResumeUpdated EventType = "resume.updated"
+	EventResumeDeleted EventType = "resume.deleted"
+)
+
+// DeliveryStatus is the outcome of delivering an event to an endpoint
+type DeliveryStatus string
+
+const (
+	// DeliveryPending deliveries are waiting for their first attempt or a retry
+	DeliveryPending   DeliveryStatus = "pending"
+	DeliverySucceeded DeliveryStatus = "succeeded"
+	// DeliveryFailed deliveries ran out of retries
+	DeliveryFailed DeliveryStatus = "failed"
+)
+
+// Endpoint is a URL registered by a user to receive events about their resumes
+type Endpoint struct {
+	model.Base
+	UserID      string      `json:"userId" db:"user_id"`
+	URL         string      `json:"url" db:"url"`
+	Description *string     `json:"description" db:"description"`
+	Secret      string      `json:"-" db:"secret"`
+	EventTypes  []EventType `json:"eventTypes" db:"event_types"`
+	Active      bool        `json:"active" db:"active"`
+}
+
+// Delivery is an attempt to deliver one event to one endpoint, retried until
+// it succeeds or runs out of retries
+type Delivery struct {
+	model.Base
+	EndpointID     uuid.UUID       `json:"endpointId" db:"endpoint_id"`
+	EventID        uuid.UUID       `json:"eventId" db:"event_id"`
+	EventType      EventType       `json:"eventType" db:"event_type"`
+	Payload        json.RawMessage `json:"payload" db:"payload"`
+	Status         DeliveryStatus  `json:"status" db:"status"`
+	Attempts       int             `json:"attempts" db:"attempts"`
+	ResponseStatus *int            `json:"responseStatus" db:"response_status"`
+	ResponseBody   *string         `json:"responseBody" db:"response_body"`
+	Error          *string         `json:"error" db:"error"`
+	RedeliveryOf   *uuid.UUID      `json:"redeliveryOf" db:"redelivery_of"`
+	LastAttemptAt  *time.Time      `json:"lastAttemptAt" db:"last_attempt_at"`
+	DeliveredAt    *time.Time      `json:"deliveredAt" db:"delivered_at"`
+}
+
+// DeliveryTarget is a delivery together with the endpoint it goes to
+type DeliveryTarget struct {
+	Delivery
+	URL    string `db:"url"`
+	Secret string `db:"secret"`
+	Active bool   `db:"active"`
+}
+
+// Attempt is the outcome of one delivery attempt
+type Attempt struct {
+	Status         DeliveryStatus
+	ResponseStatus *int
+	ResponseBody   *string
+	Error          *string
+}
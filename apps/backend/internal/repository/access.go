@@ -0,0 +1,31 @@
+package repository
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+)
+
+// Resume scopes shared by every repository. Each is a subquery selecting the
+// IDs of the active resumes on which the user bound to @user_id holds at least
+// the given role, so owners and collaborators are authorized the same way.
+var (
+	viewableResumes    = resumesWithRole(collaborator.RoleViewer)
+	commentableResumes = resumesWithRole(collaborator.RoleCommenter)
+	editableResumes    = resumesWithRole(collaborator.RoleEditor)
+	ownedResumes       = resumesWithRole(collaborator.RoleOwner)
+)
+
+func resumesWithRole(required collaborator.Role) string {
+	roles := collaborator.RolesIncluding(required)
+	quoted := make([]string, len(roles))
+	for i, role := range roles {
+		quoted[i] = fmt.Sprintf("'%s'", role)
+	}
+
+	return fmt.Sprintf(
+		"SELECT resume_id FROM resume_access WHERE user_id = @user_id AND role IN (%s)",
+		strings.Join(quoted, ", "),
+	)
+}
@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type AuditRepository struct {
+	server *server.Server
+}
+
+func NewAuditRepository(s *server.Server) *AuditRepository {
+	return &AuditRepository{server: s}
+}
+
+// CreateEntry appends an entry to the audit log
+func (r *AuditRepository) CreateEntry(ctx context.Context, entry *audit.Entry) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		INSERT INTO
+			audit_log (
+				actor_id,
+				resume_id,
+				entity_type,
+				entity_id,
+				action,
+				before,
+				after,
+				request_id,
+				ip_address
+			)
+		VALUES
+			(
+				@actor_id,
+				@resume_id,
+				@entity_type,
+				@entity_id,
+				@action,
+				@before,
+				@after,
+				@request_id,
+				@ip_address
+			)
+	`, pgx.NamedArgs{
+		"actor_id":    entry.ActorID,
+		"resume_id":   entry.ResumeID,
+		"entity_type": entry.EntityType,
+		"entity_id":   entry.EntityID,
+		"action":      entry.Action,
+		"before":      entry.Before,
+		"after":       entry.After,
+		"request_id":  entry.RequestID,
+		"ip_address":  entry.IPAddress,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert audit entry for actor_id=%s entity_type=%s action=%s: %w", entry.ActorID, entry.EntityType, entry.Action, err)
+	}
+
+	return nil
+}
+
+// GetEntriesByActor returns the changes made by the user, newest first
+func (r *AuditRepository) GetEntriesByActor(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[audit.Entry], error) {
+	return r.getEntries(ctx, "a.actor_id=@user_id", pgx.NamedArgs{"user_id": userID}, page, limit)
+}
+
+// GetEntriesByResume returns the changes made to a resume by anyone, newest
+// first. Callers must check that the user may read the resume's history.
+func (r *AuditRepository) GetEntriesByResume(ctx context.Context, resumeID uuid.UUID, page, limit int) (*model.PaginatedResponse[audit.Entry], error) {
+	return r.getEntries(ctx, "a.resume_id=@resume_id", pgx.NamedArgs{"resume_id": resumeID}, page, limit)
+}
+
+func (r *AuditRepository) getEntries(ctx context.Context, condition string, args pgx.NamedArgs, page, limit int) (*model.PaginatedResponse[audit.Entry], error) {
+	stmt := `
+		SELECT
+			a.*
+		FROM
+			audit_log a
+		WHERE
+			` + condition + `
+		ORDER BY a.created_at DESC, a.id DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	queryArgs := pgx.NamedArgs{
+		"limit":  limit,
+		"offset": (page - 1) * limit,
+	}
+	for key, value := range args {
+		queryArgs[key] = value
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, queryArgs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get audit entries query: %w", err)
+	}
+
+	entries, err := pgx.CollectRows(rows, pgx.RowToStructByName[audit.Entry])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:audit_log: %w", err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			audit_log a
+		WHERE
+			` + condition
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, args).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of audit entries: %w", err)
+	}
+
+	return &model.PaginatedResponse[audit.Entry]{
+		Data:       entries,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
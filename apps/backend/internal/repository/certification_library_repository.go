@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+)
+
+func (r *CertificationRepository) CreateLibraryCertification(ctx context.Context, userID string, payload *certification.CreateLibraryCertificationRequest) (*certification.LibraryCertification, error) {
+	stmt := `
+		INSERT INTO
+			library_certifications (
+				user_id,
+				name,
+				organization,
+				issue_date,
+				expiry_date,
+				credential_id,
+				credential_url
+			)
+		VALUES
+			(
+				@user_id,
+				@name,
+				@organization,
+				@issue_date,
+				@expiry_date,
+				@credential_id,
+				@credential_url
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":        userID,
+		"name":           payload.Name,
+		"organization":   payload.Organization,
+		"issue_date":     payload.IssueDate,
+		"expiry_date":    payload.ExpiryDate,
+		"credential_id":  payload.CredentialID,
+		"credential_url": payload.CredentialURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create library certification query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[certification.LibraryCertification])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_certifications for user_id=%s: %w", userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *CertificationRepository) GetLibraryCertificationByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*certification.LibraryCertification, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_certifications
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      libraryItemID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library certification by id query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[certification.LibraryCertification])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_certifications for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *CertificationRepository) GetLibraryCertificationsByUserID(ctx context.Context, userID string) ([]certification.LibraryCertification, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_certifications
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library certification by user query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItems, err := pgx.CollectRows(rows, pgx.RowToStructByName[certification.LibraryCertification])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []certification.LibraryCertification{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:library_certifications for user_id=%s: %w", userID, err)
+	}
+
+	return libraryItems, nil
+}
+
+func (r *CertificationRepository) UpdateLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time, payload *certification.UpdateLibraryCertificationRequest) (*certification.LibraryCertification, error) {
+	stmt := `UPDATE library_certifications SET `
+	args := pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"expected_updated_at": expectedUpdatedAt,
+	}
+	setClauses := []string{}
+
+	if payload.Name != nil {
+		setClauses = append(setClauses, "name = @name")
+		args["name"] = *payload.Name
+	}
+	if payload.Organization != nil {
+		setClauses = append(setClauses, "organization = @organization")
+		args["organization"] = *payload.Organization
+	}
+	if payload.IssueDate != nil {
+		setClauses = append(setClauses, "issue_date = @issue_date")
+		args["issue_date"] = *payload.IssueDate
+	}
+	if payload.ExpiryDate != nil {
+		setClauses = append(setClauses, "expiry_date = @expiry_date")
+		args["expiry_date"] = *payload.ExpiryDate
+	}
+	if payload.CredentialID != nil {
+		setClauses = append(setClauses, "credential_id = @credential_id")
+		args["credential_id"] = *payload.CredentialID
+	}
+	if payload.CredentialURL != nil {
+		setClauses = append(setClauses, "credential_url = @credential_url")
+		args["credential_url"] = *payload.CredentialURL
+	}
+
+	if len(setClauses) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND updated_at = @expected_updated_at RETURNING *`
+
+	args["user_id"] = userID
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update library certification query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[certification.LibraryCertification])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_certifications for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+// DeleteLibraryCertification removes a library item. Resume rows linked to it keep their
+// current content: inherited values are copied into the rows before the link is dropped.
+func (r *CertificationRepository) DeleteLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE certifications c
+		SET
+			name = CASE WHEN 'name' = ANY(c.overridden_fields) THEN c.name ELSE l.name END,
+			organization = CASE WHEN 'organization' = ANY(c.overridden_fields) THEN c.organization ELSE l.organization END,
+			issue_date = CASE WHEN 'issue_date' = ANY(c.overridden_fields) THEN c.issue_date ELSE l.issue_date END,
+			expiry_date = CASE WHEN 'expiry_date' = ANY(c.overridden_fields) THEN c.expiry_date ELSE l.expiry_date END,
+			credential_id = CASE WHEN 'credential_id' = ANY(c.overridden_fields) THEN c.credential_id ELSE l.credential_id END,
+			credential_url = CASE WHEN 'credential_url' = ANY(c.overridden_fields) THEN c.credential_url ELSE l.credential_url END,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM library_certifications l
+		WHERE c.library_item_id = l.id
+		AND l.id = @id
+		AND l.user_id = @user_id
+		AND l.updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach certifications from library_item_id=%s: %w", libraryItemID.String(), err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		DELETE FROM library_certifications
+		WHERE id = @id
+		AND user_id = @user_id
+		AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete library certification: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("library certification not found: %w", pgx.ErrNoRows)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LinkLibraryCertification adds a library item to a resume as a certification row with no overrides.
+func (r *CertificationRepository) LinkLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *certification.LinkLibraryCertificationRequest) (*certification.Certification, error) {
+	var certificationID uuid.UUID
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		INSERT INTO
+			certifications (resume_id, library_item_id, order_index)
+		SELECT
+			@resume_id,
+			l.id,
+			@order_index
+		FROM
+			library_certifications l
+		WHERE
+			l.id=@library_item_id
+			AND l.user_id=@user_id
+			AND @resume_id IN (`+ownedResumes+`)
+		RETURNING
+		id
+	`, pgx.NamedArgs{
+		"resume_id":       payload.ResumeID,
+		"library_item_id": libraryItemID,
+		"order_index":     payload.OrderIndex,
+		"user_id":         userID,
+	}).Scan(&certificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library certification library_item_id=%s to resume_id=%s: %w", libraryItemID.String(), payload.ResumeID.String(), err)
+	}
+
+	return r.GetCertificationByID(ctx, userID, certificationID)
+}
+
+// PromoteCertification copies a resume certification row into the career library and links the
+// row to the new library item, so its content is inherited from then on.
+func (r *CertificationRepository) PromoteCertification(ctx context.Context, userID string, certificationID uuid.UUID) (*certification.LibraryCertification, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			library_certifications (
+				user_id,
+				name,
+				organization,
+				issue_date,
+				expiry_date,
+				credential_id,
+				credential_url
+			)
+		SELECT
+			@user_id,
+			c.name,
+			c.organization,
+			c.issue_date,
+			c.expiry_date,
+			c.credential_id,
+			c.credential_url
+		FROM
+			certifications c
+		WHERE
+			c.id=@id
+			AND c.library_item_id IS NULL
+			AND c.resume_id IN (`+ownedResumes+`)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"id":      certificationID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute promote certification query for certification_id=%s user_id=%s: %w", certificationID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[certification.LibraryCertification])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_certifications for certification_id=%s user_id=%s: %w", certificationID.String(), userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE certifications
+		SET
+			library_item_id = @library_item_id,
+			name = NULL,
+			organization = NULL,
+			issue_date = NULL,
+			expiry_date = NULL,
+			credential_id = NULL,
+			credential_url = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":              certificationID,
+		"library_item_id": libraryItem.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link certification to library item for certification_id=%s: %w", certificationID.String(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &libraryItem, nil
+}
+
+// ResetCertificationOverrides clears every per-resume override on a linked certification row so
+// it shows the library item's content again.
+func (r *CertificationRepository) ResetCertificationOverrides(ctx context.Context, userID string, certificationID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE certifications
+		SET
+			name = NULL,
+			organization = NULL,
+			issue_date = NULL,
+			expiry_date = NULL,
+			credential_id = NULL,
+			credential_url = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+		AND library_item_id IS NOT NULL
+		AND `+resolvedUnchanged("certifications", "library_certifications")+`
+		AND resume_id IN (`+editableResumes+`)
+	`, pgx.NamedArgs{
+		"id":                  certificationID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset certification overrides: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("linked certification not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -75,11 +76,10 @@ func (r *CertificationRepository) GetCertificationByID(ctx context.Context, user
 		SELECT
 			c.*
 		FROM
-			certifications c
-		JOIN resumes r ON c.resume_id = r.id
+			certifications_resolved c
 		WHERE
 			c.id=@id
-			AND r.user_id=@user_id
+			AND c.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -103,11 +103,10 @@ func (r *CertificationRepository) GetCertificationsByResumeID(ctx context.Contex
 		SELECT
 			c.*
 		FROM
-			certifications c
-		JOIN resumes r ON c.resume_id = r.id
+			certifications_resolved c
 		WHERE
 			c.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND c.resume_id IN (` + viewableResumes + `)
 		ORDER BY c.order_index ASC
 	`
 
@@ -130,48 +129,64 @@ func (r *CertificationRepository) GetCertificationsByResumeID(ctx context.Contex
 	return certificationItems, nil
 }
 
-func (r *CertificationRepository) UpdateCertification(ctx context.Context, userID string, certificationID uuid.UUID, payload *certification.UpdateCertificationRequest) (*certification.Certification, error) {
+func (r *CertificationRepository) UpdateCertification(ctx context.Context, userID string, certificationID uuid.UUID, expectedUpdatedAt time.Time, payload *certification.UpdateCertificationRequest) (*certification.Certification, error) {
 	stmt := `UPDATE certifications SET `
 	args := pgx.NamedArgs{
-		"id": certificationID,
+		"id":                  certificationID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
+	overridden := []string{}
 
 	if payload.Name != nil {
 		setClauses = append(setClauses, "name = @name")
 		args["name"] = *payload.Name
+		overridden = append(overridden, "name")
 	}
 	if payload.Organization != nil {
 		setClauses = append(setClauses, "organization = @organization")
 		args["organization"] = *payload.Organization
+		overridden = append(overridden, "organization")
 	}
 	if payload.IssueDate != nil {
 		setClauses = append(setClauses, "issue_date = @issue_date")
 		args["issue_date"] = *payload.IssueDate
+		overridden = append(overridden, "issue_date")
 	}
 	if payload.ExpiryDate != nil {
 		setClauses = append(setClauses, "expiry_date = @expiry_date")
 		args["expiry_date"] = *payload.ExpiryDate
+		overridden = append(overridden, "expiry_date")
 	}
 	if payload.CredentialID != nil {
 		setClauses = append(setClauses, "credential_id = @credential_id")
 		args["credential_id"] = *payload.CredentialID
+		overridden = append(overridden, "credential_id")
 	}
 	if payload.CredentialURL != nil {
 		setClauses = append(setClauses, "credential_url = @credential_url")
 		args["credential_url"] = *payload.CredentialURL
+		overridden = append(overridden, "credential_url")
 	}
 	if payload.OrderIndex != nil {
 		setClauses = append(setClauses, "order_index = @order_index")
 		args["order_index"] = *payload.OrderIndex
 	}
 
+	for _, column := range clearedColumns("certifications", payload.Clear) {
+		setClauses = append(setClauses, column+" = NULL")
+		overridden = append(overridden, column)
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
 	if len(setClauses) == 0 {
 		return nil, fmt.Errorf("no fields to update")
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND ` + resolvedUnchanged("certifications", "library_certifications") + ` AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -185,11 +200,16 @@ func (r *CertificationRepository) UpdateCertification(ctx context.Context, userI
 		return nil, fmt.Errorf("failed to collect row from table:certifications for certification_id=%s user_id=%s: %w", certificationID.String(), userID, err)
 	}
 
+	// Linked rows only store their overrides, so read back the resolved values
+	if certificationItem.LibraryItemID != nil {
+		return r.GetCertificationByID(ctx, userID, certificationID)
+	}
+
 	return &certificationItem, nil
 }
 
 func (r *CertificationRepository) BulkUpdateCertificationOrder(ctx context.Context, userID string, payload *certification.BulkUpdateCertificationsRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -200,7 +220,7 @@ func (r *CertificationRepository) BulkUpdateCertificationOrder(ctx context.Conte
 			UPDATE certifications 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          certificationUpdate.ID,
 			"order_index": certificationUpdate.OrderIndex,
@@ -214,21 +234,109 @@ func (r *CertificationRepository) BulkUpdateCertificationOrder(ctx context.Conte
 	return tx.Commit(ctx)
 }
 
-func (r *CertificationRepository) DeleteCertification(ctx context.Context, userID string, certificationID uuid.UUID) error {
+func (r *CertificationRepository) DeleteCertification(ctx context.Context, userID string, certificationID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM certifications
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND `+resolvedUnchanged("certifications", "library_certifications")+`
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      certificationID,
-		"user_id": userID,
+		"id":                  certificationID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete certification: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("certification not found")
+		return fmt.Errorf("certification not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+// GetDueExpiryReminders lists the certifications on active resumes that expire
+// within one of the reminder windows and have not been reminded for the
+// smallest window they fall into. It is used by the reminder job and is not
+// scoped to a user.
+func (r *CertificationRepository) GetDueExpiryReminders(ctx context.Context, windowDays []int, today time.Time) ([]certification.ExpiryReminder, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		WITH due AS (
+			SELECT
+				c.id AS certification_id,
+				r.user_id,
+				r.id AS resume_id,
+				r.title AS resume_title,
+				c.name,
+				c.organization,
+				c.expiry_date,
+				(
+					SELECT MIN(w) FROM unnest(@window_days::INT[]) w
+					WHERE c.expiry_date <= @today::DATE + w
+				) AS window_days
+			FROM
+				certifications_resolved c
+				JOIN resumes r ON r.id = c.resume_id
+			WHERE
+				r.deleted_at IS NULL
+				AND c.expiry_date >= @today::DATE
+		)
+		SELECT
+			due.*
+		FROM
+			due
+		WHERE
+			due.window_days IS NOT NULL
+			AND NOT EXISTS (
+				SELECT 1 FROM certification_reminders cr
+				WHERE cr.certification_id = due.certification_id
+					AND cr.expiry_date = due.expiry_date
+					AND cr.window_days = due.window_days
+			)
+		ORDER BY due.user_id, due.expiry_date ASC
+	`, pgx.NamedArgs{
+		"window_days": windowDays,
+		"today":       today.Format(time.DateOnly),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get due expiry reminders query: %w", err)
+	}
+
+	reminders, err := pgx.CollectRows(rows, pgx.RowToStructByName[certification.ExpiryReminder])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:certifications: %w", err)
+	}
+
+	return reminders, nil
+}
+
+// RecordExpiryReminders marks the reminders as sent
+func (r *CertificationRepository) RecordExpiryReminders(ctx context.Context, reminders []certification.ExpiryReminder) error {
+	certificationIDs := make([]uuid.UUID, len(reminders))
+	expiryDates := make([]time.Time, len(reminders))
+	windowDays := make([]int, len(reminders))
+	for i, reminder := range reminders {
+		certificationIDs[i] = reminder.CertificationID
+		expiryDates[i] = reminder.ExpiryDate
+		windowDays[i] = reminder.WindowDays
+	}
+
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		INSERT INTO
+			certification_reminders (certification_id, expiry_date, window_days)
+		SELECT
+			*
+		FROM
+			unnest(@certification_ids::UUID[], @expiry_dates::DATE[], @window_days::INT[])
+		ON CONFLICT DO NOTHING
+	`, pgx.NamedArgs{
+		"certification_ids": certificationIDs,
+		"expiry_dates":      expiryDates,
+		"window_days":       windowDays,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record expiry reminders: %w", err)
 	}
 
 	return nil
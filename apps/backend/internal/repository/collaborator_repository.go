@@ -0,0 +1,249 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type CollaboratorRepository struct {
+	server *server.Server
+}
+
+func NewCollaboratorRepository(s *server.Server) *CollaboratorRepository {
+	return &CollaboratorRepository{server: s}
+}
+
+// CreateInvitation records a pending invitation. Only the owner of the resume
+// can invite collaborators.
+func (r *CollaboratorRepository) CreateInvitation(ctx context.Context, userID string, resumeID uuid.UUID, tokenHash string, payload *collaborator.InviteCollaboratorRequest) (*collaborator.Collaborator, error) {
+	stmt := `
+		INSERT INTO
+			resume_collaborators (
+				resume_id,
+				email,
+				role,
+				invited_by,
+				invite_token_hash
+			)
+		SELECT
+			r.id,
+			@email,
+			@role,
+			@user_id,
+			@invite_token_hash
+		FROM
+			resumes r
+		WHERE
+			r.id=@resume_id
+			AND r.id IN (` + ownedResumes + `)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"resume_id":         resumeID,
+		"email":             payload.Email,
+		"role":              payload.Role,
+		"user_id":           userID,
+		"invite_token_hash": tokenHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create invitation query for resume_id=%s: %w", resumeID.String(), err)
+	}
+
+	collaboratorItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_collaborators for resume_id=%s: %w", resumeID.String(), err)
+	}
+
+	return &collaboratorItem, nil
+}
+
+// GetCollaboratorByID returns a collaborator of a resume owned by the user
+func (r *CollaboratorRepository) GetCollaboratorByID(ctx context.Context, userID string, resumeID, collaboratorID uuid.UUID) (*collaborator.Collaborator, error) {
+	stmt := `
+		SELECT
+			c.*
+		FROM
+			resume_collaborators c
+		WHERE
+			c.id=@id
+			AND c.resume_id=@resume_id
+			AND c.resume_id IN (` + ownedResumes + `)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":        collaboratorID,
+		"resume_id": resumeID,
+		"user_id":   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get collaborator by id query for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	collaboratorItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_collaborators for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	return &collaboratorItem, nil
+}
+
+// GetCollaboratorsByResumeID lists the collaborators and pending invitations
+// of a resume owned by the user
+func (r *CollaboratorRepository) GetCollaboratorsByResumeID(ctx context.Context, userID string, resumeID uuid.UUID) ([]collaborator.Collaborator, error) {
+	stmt := `
+		SELECT
+			c.*
+		FROM
+			resume_collaborators c
+		WHERE
+			c.resume_id=@resume_id
+			AND c.resume_id IN (` + ownedResumes + `)
+		ORDER BY c.created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"resume_id": resumeID,
+		"user_id":   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get collaborators by resume query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	collaborators, err := pgx.CollectRows(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []collaborator.Collaborator{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:resume_collaborators for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return collaborators, nil
+}
+
+// GetInvitationByTokenHash returns the pending invitation for a token
+func (r *CollaboratorRepository) GetInvitationByTokenHash(ctx context.Context, tokenHash string) (*collaborator.Collaborator, error) {
+	stmt := `
+		SELECT
+			c.*
+		FROM
+			resume_collaborators c
+		JOIN resumes r ON c.resume_id = r.id
+		WHERE
+			c.invite_token_hash=@invite_token_hash
+			AND c.accepted_at IS NULL
+			AND r.deleted_at IS NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"invite_token_hash": tokenHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get invitation by token query: %w", err)
+	}
+
+	collaboratorItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_collaborators for invitation token: %w", err)
+	}
+
+	return &collaboratorItem, nil
+}
+
+// AcceptInvitation binds a pending invitation to the accepting user. The token
+// is cleared so it cannot be used again.
+func (r *CollaboratorRepository) AcceptInvitation(ctx context.Context, userID string, collaboratorID uuid.UUID) (*collaborator.Collaborator, error) {
+	stmt := `
+		UPDATE resume_collaborators
+		SET
+			user_id = @user_id,
+			accepted_at = NOW(),
+			invite_token_hash = NULL
+		WHERE
+			id = @id
+			AND accepted_at IS NULL
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      collaboratorID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute accept invitation query for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	collaboratorItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_collaborators for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	return &collaboratorItem, nil
+}
+
+// UpdateCollaboratorRole changes the role of a collaborator on a resume owned by the user
+func (r *CollaboratorRepository) UpdateCollaboratorRole(ctx context.Context, userID string, resumeID, collaboratorID uuid.UUID, role collaborator.Role) (*collaborator.Collaborator, error) {
+	stmt := `
+		UPDATE resume_collaborators
+		SET
+			role = @role
+		WHERE
+			id = @id
+			AND resume_id = @resume_id
+			AND resume_id IN (` + ownedResumes + `)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":        collaboratorID,
+		"resume_id": resumeID,
+		"role":      role,
+		"user_id":   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update collaborator query for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	collaboratorItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[collaborator.Collaborator])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_collaborators for collaborator_id=%s user_id=%s: %w", collaboratorID.String(), userID, err)
+	}
+
+	return &collaboratorItem, nil
+}
+
+// DeleteCollaborator revokes a collaborator or pending invitation. The resume
+// owner can revoke anyone; a collaborator can only remove themselves.
+func (r *CollaboratorRepository) DeleteCollaborator(ctx context.Context, userID string, resumeID, collaboratorID uuid.UUID) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM resume_collaborators
+		WHERE id = @id
+		AND resume_id = @resume_id
+		AND (
+			user_id = @user_id
+			OR resume_id IN (`+ownedResumes+`)
+		)
+	`, pgx.NamedArgs{
+		"id":        collaboratorID,
+		"resume_id": resumeID,
+		"user_id":   userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete collaborator: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("collaborator not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,287 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/comment"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// commentAnchorTables maps the entity types comments can be anchored to onto
+// the tables holding them
+var commentAnchorTables = map[comment.EntityType]string{
+	comment.EntitySection:       "resume_sections",
+	comment.EntityEducation:     "education",
+	comment.EntityExperience:    "experience",
+	comment.EntityProject:       "projects",
+	comment.EntitySkill:         "skills",
+	comment.EntityCertification: "certifications",
+}
+
+// commentThreadFilters restricts comment lists by the state of the thread root,
+// joined as t
+var commentThreadFilters = map[string]string{
+	comment.FilterActive:   "t.archived_at IS NULL",
+	comment.FilterOpen:     "t.archived_at IS NULL AND t.resolved_at IS NULL",
+	comment.FilterResolved: "t.archived_at IS NULL AND t.resolved_at IS NOT NULL",
+	comment.FilterArchived: "t.archived_at IS NOT NULL",
+	comment.FilterAll:      "TRUE",
+}
+
+type CommentRepository struct {
+	server *server.Server
+}
+
+func NewCommentRepository(s *server.Server) *CommentRepository {
+	return &CommentRepository{server: s}
+}
+
+// AnchorExists reports whether the entity a comment is anchored to belongs to
+// the resume
+func (r *CommentRepository) AnchorExists(ctx context.Context, resumeID uuid.UUID, entityType comment.EntityType, entityID uuid.UUID) (bool, error) {
+	if entityType == comment.EntityResume {
+		return entityID == resumeID, nil
+	}
+
+	table, ok := commentAnchorTables[entityType]
+	if !ok {
+		return false, fmt.Errorf("unknown comment entity type: %s", entityType)
+	}
+
+	var exists bool
+	err := r.server.DB.Pool.QueryRow(ctx, `SELECT EXISTS (SELECT 1 FROM `+table+` WHERE id = @id AND resume_id = @resume_id)`, pgx.NamedArgs{
+		"id":        entityID,
+		"resume_id": resumeID,
+	}).Scan(&exists)
+	if err != nil {
+		return false, fmt.Errorf("failed to check comment anchor %s=%s: %w", entityType, entityID.String(), err)
+	}
+
+	return exists, nil
+}
+
+// CreateComment adds a comment to a resume the user may comment on
+func (r *CommentRepository) CreateComment(ctx context.Context, userID string, resumeID uuid.UUID, payload *comment.CreateCommentRequest) (*comment.Comment, error) {
+	stmt := `
+		INSERT INTO
+			resume_comments (
+				resume_id,
+				parent_id,
+				entity_type,
+				entity_id,
+				field_name,
+				author_id,
+				body
+			)
+		SELECT
+			r.id,
+			@parent_id,
+			@entity_type,
+			@entity_id,
+			@field_name,
+			@user_id,
+			@body
+		FROM
+			resumes r
+		WHERE
+			r.id=@resume_id
+			AND r.id IN (` + commentableResumes + `)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"resume_id":   resumeID,
+		"parent_id":   payload.ParentID,
+		"entity_type": payload.EntityType,
+		"entity_id":   payload.EntityID,
+		"field_name":  payload.FieldName,
+		"user_id":     userID,
+		"body":        payload.Body,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create comment query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_comments for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return &commentItem, nil
+}
+
+func (r *CommentRepository) GetCommentByID(ctx context.Context, userID string, commentID uuid.UUID) (*comment.Comment, error) {
+	stmt := `
+		SELECT
+			c.*
+		FROM
+			resume_comments c
+		WHERE
+			c.id=@id
+			AND c.resume_id IN (` + viewableResumes + `)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      commentID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get comment by id query for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_comments for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	return &commentItem, nil
+}
+
+// GetCommentsByResumeID lists the comments of every thread on a resume whose
+// root matches the filter, roots and replies in creation order
+func (r *CommentRepository) GetCommentsByResumeID(ctx context.Context, userID string, resumeID uuid.UUID, filter string) ([]comment.Comment, error) {
+	return r.getComments(ctx, filter, "", pgx.NamedArgs{
+		"resume_id": resumeID,
+		"user_id":   userID,
+	})
+}
+
+// GetCommentsByEntity lists the comments of every thread anchored to one entity
+// of a resume whose root matches the filter
+func (r *CommentRepository) GetCommentsByEntity(ctx context.Context, userID string, resumeID uuid.UUID, entityType comment.EntityType, entityID uuid.UUID, filter string) ([]comment.Comment, error) {
+	return r.getComments(ctx, filter, "AND c.entity_type=@entity_type AND c.entity_id=@entity_id", pgx.NamedArgs{
+		"resume_id":   resumeID,
+		"user_id":     userID,
+		"entity_type": entityType,
+		"entity_id":   entityID,
+	})
+}
+
+func (r *CommentRepository) getComments(ctx context.Context, filter string, conditions string, args pgx.NamedArgs) ([]comment.Comment, error) {
+	threadFilter, ok := commentThreadFilters[filter]
+	if !ok {
+		return nil, fmt.Errorf("unknown comment filter: %s", filter)
+	}
+
+	stmt := `
+		SELECT
+			c.*
+		FROM
+			resume_comments c
+		JOIN resume_comments t ON t.id = COALESCE(c.parent_id, c.id)
+		WHERE
+			c.resume_id=@resume_id
+			AND c.resume_id IN (` + viewableResumes + `)
+			AND ` + threadFilter + `
+			` + conditions + `
+		ORDER BY c.created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get comments query for resume_id=%v user_id=%v: %w", args["resume_id"], args["user_id"], err)
+	}
+
+	comments, err := pgx.CollectRows(rows, pgx.RowToStructByName[comment.Comment])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []comment.Comment{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:resume_comments for resume_id=%v user_id=%v: %w", args["resume_id"], args["user_id"], err)
+	}
+
+	return comments, nil
+}
+
+// UpdateCommentBody edits a comment written by the user
+func (r *CommentRepository) UpdateCommentBody(ctx context.Context, userID string, commentID uuid.UUID, body string) (*comment.Comment, error) {
+	stmt := `
+		UPDATE resume_comments
+		SET
+			body = @body
+		WHERE
+			id = @id
+			AND author_id = @user_id
+			AND resume_id IN (` + commentableResumes + `)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      commentID,
+		"body":    body,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update comment query for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_comments for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	return &commentItem, nil
+}
+
+// SetThreadResolved resolves or reopens the thread started by a root comment
+func (r *CommentRepository) SetThreadResolved(ctx context.Context, userID string, commentID uuid.UUID, resolved bool) (*comment.Comment, error) {
+	stmt := `
+		UPDATE resume_comments
+		SET
+			resolved_at = CASE WHEN @resolved THEN NOW() ELSE NULL END,
+			resolved_by = CASE WHEN @resolved THEN @user_id ELSE NULL END
+		WHERE
+			id = @id
+			AND parent_id IS NULL
+			AND resume_id IN (` + commentableResumes + `)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":       commentID,
+		"resolved": resolved,
+		"user_id":  userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute resolve comment query for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	commentItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[comment.Comment])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_comments for comment_id=%s user_id=%s: %w", commentID.String(), userID, err)
+	}
+
+	return &commentItem, nil
+}
+
+// DeleteComment removes a comment and its replies. Authors can delete their
+// own comments and the resume owner can delete any comment.
+func (r *CommentRepository) DeleteComment(ctx context.Context, userID string, commentID uuid.UUID) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM resume_comments
+		WHERE id = @id
+		AND (
+			(author_id = @user_id AND resume_id IN (`+commentableResumes+`))
+			OR resume_id IN (`+ownedResumes+`)
+		)
+	`, pgx.NamedArgs{
+		"id":      commentID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("comment not found")
+	}
+
+	return nil
+}
@@ -0,0 +1,315 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/dataexport"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type DataExportRepository struct {
+	server *server.Server
+}
+
+func NewDataExportRepository(s *server.Server) *DataExportRepository {
+	return &DataExportRepository{server: s}
+}
+
+// dataExportColumns are the columns of data_exports except the archive
+const dataExportColumns = `
+	id,
+	user_id,
+	status,
+	size_bytes,
+	error,
+	expires_at,
+	completed_at,
+	created_at,
+	updated_at
+`
+
+// ownedResumeIDs selects the IDs of every resume of @user_id, including those in
+// the trash
+const ownedResumeIDs = `SELECT id FROM resumes WHERE user_id = @user_id`
+
+// userDataExports select every row tied to a user, one file per table. Tables
+// that gain user data must be added here and to erasureSteps.
+var userDataExports = []struct {
+	file string
+	stmt string
+}{
+	{"profile.json", `SELECT * FROM users WHERE id = @user_id`},
+	{"resumes.json", `SELECT * FROM resumes WHERE user_id = @user_id ORDER BY created_at`},
+	{"resume_sections.json", `SELECT * FROM resume_sections WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"education.json", `SELECT * FROM education WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"experience.json", `SELECT * FROM experience WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"projects.json", `SELECT * FROM projects WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"skills.json", `SELECT * FROM skills WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"certifications.json", `SELECT * FROM certifications WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY resume_id, order_index`},
+	{"certification_reminders.json", `SELECT * FROM certification_reminders WHERE certification_id IN (SELECT id FROM certifications WHERE resume_id IN (` + ownedResumeIDs + `)) ORDER BY sent_at`},
+	{"library_education.json", `SELECT * FROM library_education WHERE user_id = @user_id ORDER BY created_at`},
+	{"library_experience.json", `SELECT * FROM library_experience WHERE user_id = @user_id ORDER BY created_at`},
+	{"library_projects.json", `SELECT * FROM library_projects WHERE user_id = @user_id ORDER BY created_at`},
+	{"library_skills.json", `SELECT * FROM library_skills WHERE user_id = @user_id ORDER BY created_at`},
+	{"library_certifications.json", `SELECT * FROM library_certifications WHERE user_id = @user_id ORDER BY created_at`},
+	{"collaborators.json", `SELECT id, resume_id, email, user_id, role, invited_by, accepted_at, created_at, updated_at FROM resume_collaborators WHERE user_id = @user_id OR invited_by = @user_id OR resume_id IN (` + ownedResumeIDs + `) ORDER BY created_at`},
+	{"comments.json", `SELECT * FROM resume_comments WHERE author_id = @user_id OR resume_id IN (` + ownedResumeIDs + `) ORDER BY created_at`},
+	{"organization_memberships.json", `SELECT * FROM organization_members WHERE user_id = @user_id ORDER BY created_at`},
+	{"organization_membership_removals.json", `SELECT * FROM organization_member_removals WHERE user_id = @user_id ORDER BY removed_at`},
+	{"reviews.json", `SELECT * FROM resume_reviews WHERE submitter_id = @user_id OR reviewer_id = @user_id OR resume_id IN (` + ownedResumeIDs + `) ORDER BY created_at`},
+	{"review_events.json", `SELECT * FROM resume_review_events WHERE actor_id = @user_id OR review_id IN (SELECT id FROM resume_reviews WHERE submitter_id = @user_id OR reviewer_id = @user_id OR resume_id IN (` + ownedResumeIDs + `)) ORDER BY created_at`},
+	{"audit_log.json", `SELECT * FROM audit_log WHERE actor_id = @user_id OR resume_id IN (` + ownedResumeIDs + `) ORDER BY created_at`},
+	{"webhook_endpoints.json", `SELECT id, user_id, url, description, event_types, active, created_at, updated_at FROM webhook_endpoints WHERE user_id = @user_id ORDER BY created_at`},
+	{"webhook_deliveries.json", `SELECT * FROM webhook_deliveries WHERE endpoint_id IN (SELECT id FROM webhook_endpoints WHERE user_id = @user_id) ORDER BY created_at`},
+	{"notification_preferences.json", `SELECT * FROM notification_preferences WHERE user_id = @user_id`},
+	{"email_deliveries.json", `SELECT * FROM email_deliveries WHERE user_id = @user_id ORDER BY created_at`},
+	{"resume_exports.json", `SELECT ` + resumeExportColumns + ` FROM resume_exports WHERE resume_id IN (` + ownedResumeIDs + `) ORDER BY created_at`},
+	{"data_exports.json", `SELECT ` + dataExportColumns + ` FROM data_exports WHERE user_id = @user_id ORDER BY created_at`},
+}
+
+// GetActiveResumeIDs lists the IDs of the user's resumes that are not in the
+// trash
+func (r *DataExportRepository) GetActiveResumeIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			id
+		FROM
+			resumes
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NULL
+		ORDER BY created_at ASC
+	`, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get active resume ids query for user_id=%s: %w", userID, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resumes for user_id=%s: %w", userID, err)
+	}
+
+	return ids, nil
+}
+
+// CreateExport starts a data export for the user. While an export is pending
+// or running it is returned instead of starting another.
+func (r *DataExportRepository) CreateExport(ctx context.Context, userID string) (*dataexport.DataExport, error) {
+	stmt := `
+		INSERT INTO
+			data_exports (user_id)
+		VALUES
+			(@user_id)
+		ON CONFLICT (user_id) WHERE status IN ('pending', 'running')
+		DO UPDATE SET status = data_exports.status
+		RETURNING
+	` + dataExportColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create data export query for user_id=%s: %w", userID, err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dataexport.DataExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:data_exports for user_id=%s: %w", userID, err)
+	}
+
+	return &export, nil
+}
+
+// GetExportByID returns one of the user's data exports
+func (r *DataExportRepository) GetExportByID(ctx context.Context, userID string, exportID uuid.UUID) (*dataexport.DataExport, error) {
+	stmt := `
+		SELECT
+	` + dataExportColumns + `
+		FROM
+			data_exports
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      exportID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get data export by id query for export_id=%s user_id=%s: %w", exportID.String(), userID, err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dataexport.DataExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:data_exports for export_id=%s user_id=%s: %w", exportID.String(), userID, err)
+	}
+
+	return &export, nil
+}
+
+// StartExport marks a pending export as running and returns it; exports that
+// are already finished are returned unchanged. It is used by the export job
+// and is not scoped to a user.
+func (r *DataExportRepository) StartExport(ctx context.Context, exportID uuid.UUID) (*dataexport.DataExport, error) {
+	stmt := `
+		UPDATE data_exports
+		SET
+			status = CASE WHEN status = 'pending' THEN 'running' ELSE status END
+		WHERE
+			id = @id
+		RETURNING
+	` + dataExportColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": exportID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute start data export query for export_id=%s: %w", exportID.String(), err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[dataexport.DataExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:data_exports for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return &export, nil
+}
+
+// CompleteExport stores the archive of a running export until it expires
+func (r *DataExportRepository) CompleteExport(ctx context.Context, exportID uuid.UUID, archive []byte, expiresAt time.Time) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE data_exports
+		SET
+			status = 'ready',
+			archive = @archive,
+			size_bytes = @size_bytes,
+			error = NULL,
+			expires_at = @expires_at,
+			completed_at = NOW()
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":         exportID,
+		"archive":    archive,
+		"size_bytes": len(archive),
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete data export for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return nil
+}
+
+// FailExport records why an export could not be built
+func (r *DataExportRepository) FailExport(ctx context.Context, exportID uuid.UUID, message string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE data_exports
+		SET
+			status = 'failed',
+			error = @error,
+			completed_at = NOW()
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":    exportID,
+		"error": message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fail data export for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return nil
+}
+
+// GetArchive returns a ready export with its archive. It is used for signed
+// downloads and is not scoped to a user.
+func (r *DataExportRepository) GetArchive(ctx context.Context, exportID uuid.UUID) (*dataexport.DataExport, []byte, error) {
+	stmt := `
+		SELECT
+	` + dataExportColumns + `,
+			archive
+		FROM
+			data_exports
+		WHERE
+			id=@id
+			AND status = 'ready'
+	`
+
+	var archive []byte
+	export := dataexport.DataExport{}
+	err := r.server.DB.Pool.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"id": exportID,
+	}).Scan(
+		&export.ID,
+		&export.UserID,
+		&export.Status,
+		&export.SizeBytes,
+		&export.Error,
+		&export.ExpiresAt,
+		&export.CompletedAt,
+		&export.CreatedAt,
+		&export.UpdatedAt,
+		&archive,
+	)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get data export archive for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return &export, archive, nil
+}
+
+// PurgeExpiredExports removes exports whose download link has expired
+func (r *DataExportRepository) PurgeExpiredExports(ctx context.Context, now time.Time) (int64, error) {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM data_exports
+		WHERE expires_at IS NOT NULL AND expires_at < @now
+	`, pgx.NamedArgs{
+		"now": now,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge data exports expired before %s: %w", now.Format(time.RFC3339), err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// CollectUserData reads every row tied to the user from one consistent
+// snapshot of the database
+func (r *DataExportRepository) CollectUserData(ctx context.Context, userID string) ([]dataexport.File, error) {
+	tx, err := r.server.DB.Pool.BeginTx(ctx, pgx.TxOptions{
+		IsoLevel:   pgx.RepeatableRead,
+		AccessMode: pgx.ReadOnly,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	files := make([]dataexport.File, 0, len(userDataExports))
+	for _, e := range userDataExports {
+		var data []byte
+		err := tx.QueryRow(ctx, `
+			SELECT
+				COALESCE(jsonb_agg(to_jsonb(t)), '[]'::jsonb)
+			FROM
+				(`+e.stmt+`) t
+		`, pgx.NamedArgs{
+			"user_id": userID,
+		}).Scan(&data)
+		if err != nil {
+			return nil, fmt.Errorf("failed to collect %s for user_id=%s: %w", e.file, userID, err)
+		}
+
+		files = append(files, dataexport.File{Name: e.file, Data: data})
+	}
+
+	return files, nil
+}
@@ -0,0 +1,378 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/education"
+)
+
+func (r *EducationRepository) CreateLibraryEducation(ctx context.Context, userID string, payload *education.CreateLibraryEducationRequest) (*education.LibraryEducation, error) {
+	stmt := `
+		INSERT INTO
+			library_education (
+				user_id,
+				institution,
+				degree,
+				field_of_study,
+				start_date,
+				end_date,
+				grade,
+				description
+			)
+		VALUES
+			(
+				@user_id,
+				@institution,
+				@degree,
+				@field_of_study,
+				@start_date,
+				@end_date,
+				@grade,
+				@description
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":        userID,
+		"institution":    payload.Institution,
+		"degree":         payload.Degree,
+		"field_of_study": payload.FieldOfStudy,
+		"start_date":     payload.StartDate,
+		"end_date":       payload.EndDate,
+		"grade":          payload.Grade,
+		"description":    payload.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create library education query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[education.LibraryEducation])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_education for user_id=%s: %w", userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *EducationRepository) GetLibraryEducationByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*education.LibraryEducation, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_education
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      libraryItemID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library education by id query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[education.LibraryEducation])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_education for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *EducationRepository) GetLibraryEducationByUserID(ctx context.Context, userID string) ([]education.LibraryEducation, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_education
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library education by user query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItems, err := pgx.CollectRows(rows, pgx.RowToStructByName[education.LibraryEducation])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []education.LibraryEducation{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:library_education for user_id=%s: %w", userID, err)
+	}
+
+	return libraryItems, nil
+}
+
+func (r *EducationRepository) UpdateLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time, payload *education.UpdateLibraryEducationRequest) (*education.LibraryEducation, error) {
+	stmt := `UPDATE library_education SET `
+	args := pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"expected_updated_at": expectedUpdatedAt,
+	}
+	setClauses := []string{}
+
+	if payload.Institution != nil {
+		setClauses = append(setClauses, "institution = @institution")
+		args["institution"] = *payload.Institution
+	}
+	if payload.Degree != nil {
+		setClauses = append(setClauses, "degree = @degree")
+		args["degree"] = *payload.Degree
+	}
+	if payload.FieldOfStudy != nil {
+		setClauses = append(setClauses, "field_of_study = @field_of_study")
+		args["field_of_study"] = *payload.FieldOfStudy
+	}
+	if payload.StartDate != nil {
+		setClauses = append(setClauses, "start_date = @start_date")
+		args["start_date"] = *payload.StartDate
+	}
+	if payload.EndDate != nil {
+		setClauses = append(setClauses, "end_date = @end_date")
+		args["end_date"] = *payload.EndDate
+	}
+	if payload.Grade != nil {
+		setClauses = append(setClauses, "grade = @grade")
+		args["grade"] = *payload.Grade
+	}
+	if payload.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *payload.Description
+	}
+
+	if len(setClauses) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND updated_at = @expected_updated_at RETURNING *`
+
+	args["user_id"] = userID
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update library education query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[education.LibraryEducation])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_education for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+// DeleteLibraryEducation removes a library item. Resume rows linked to it keep their
+// current content: inherited values are copied into the rows before the link is dropped.
+func (r *EducationRepository) DeleteLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE education e
+		SET
+			institution = CASE WHEN 'institution' = ANY(e.overridden_fields) THEN e.institution ELSE l.institution END,
+			degree = CASE WHEN 'degree' = ANY(e.overridden_fields) THEN e.degree ELSE l.degree END,
+			field_of_study = CASE WHEN 'field_of_study' = ANY(e.overridden_fields) THEN e.field_of_study ELSE l.field_of_study END,
+			start_date = CASE WHEN 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END,
+			end_date = CASE WHEN 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END,
+			grade = CASE WHEN 'grade' = ANY(e.overridden_fields) THEN e.grade ELSE l.grade END,
+			description = CASE WHEN 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM library_education l
+		WHERE e.library_item_id = l.id
+		AND l.id = @id
+		AND l.user_id = @user_id
+		AND l.updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach education from library_item_id=%s: %w", libraryItemID.String(), err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		DELETE FROM library_education
+		WHERE id = @id
+		AND user_id = @user_id
+		AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete library education: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("library education not found: %w", pgx.ErrNoRows)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LinkLibraryEducation adds a library item to a resume as an education row with no overrides.
+func (r *EducationRepository) LinkLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *education.LinkLibraryEducationRequest) (*education.Education, error) {
+	var educationID uuid.UUID
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		INSERT INTO
+			education (resume_id, library_item_id, order_index)
+		SELECT
+			@resume_id,
+			l.id,
+			@order_index
+		FROM
+			library_education l
+		WHERE
+			l.id=@library_item_id
+			AND l.user_id=@user_id
+			AND @resume_id IN (`+ownedResumes+`)
+		RETURNING
+		id
+	`, pgx.NamedArgs{
+		"resume_id":       payload.ResumeID,
+		"library_item_id": libraryItemID,
+		"order_index":     payload.OrderIndex,
+		"user_id":         userID,
+	}).Scan(&educationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library education library_item_id=%s to resume_id=%s: %w", libraryItemID.String(), payload.ResumeID.String(), err)
+	}
+
+	return r.GetEducationByID(ctx, userID, educationID)
+}
+
+// PromoteEducation copies a resume education row into the career library and links the
+// row to the new library item, so its content is inherited from then on.
+func (r *EducationRepository) PromoteEducation(ctx context.Context, userID string, educationID uuid.UUID) (*education.LibraryEducation, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			library_education (
+				user_id,
+				institution,
+				degree,
+				field_of_study,
+				start_date,
+				end_date,
+				grade,
+				description
+			)
+		SELECT
+			@user_id,
+			e.institution,
+			e.degree,
+			e.field_of_study,
+			e.start_date,
+			e.end_date,
+			e.grade,
+			e.description
+		FROM
+			education e
+		WHERE
+			e.id=@id
+			AND e.library_item_id IS NULL
+			AND e.resume_id IN (`+ownedResumes+`)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"id":      educationID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute promote education query for education_id=%s user_id=%s: %w", educationID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[education.LibraryEducation])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_education for education_id=%s user_id=%s: %w", educationID.String(), userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE education
+		SET
+			library_item_id = @library_item_id,
+			institution = NULL,
+			degree = NULL,
+			field_of_study = NULL,
+			start_date = NULL,
+			end_date = NULL,
+			grade = NULL,
+			description = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":              educationID,
+		"library_item_id": libraryItem.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link education to library item for education_id=%s: %w", educationID.String(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &libraryItem, nil
+}
+
+// ResetEducationOverrides clears every per-resume override on a linked education row so
+// it shows the library item's content again.
+func (r *EducationRepository) ResetEducationOverrides(ctx context.Context, userID string, educationID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE education
+		SET
+			institution = NULL,
+			degree = NULL,
+			field_of_study = NULL,
+			start_date = NULL,
+			end_date = NULL,
+			grade = NULL,
+			description = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+		AND library_item_id IS NOT NULL
+		AND `+resolvedUnchanged("education", "library_education")+`
+		AND resume_id IN (`+editableResumes+`)
+	`, pgx.NamedArgs{
+		"id":                  educationID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset education overrides: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("linked education not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
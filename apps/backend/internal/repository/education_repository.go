@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -78,11 +79,10 @@ func (r *EducationRepository) GetEducationByID(ctx context.Context, userID strin
 		SELECT
 			e.*
 		FROM
-			education e
-		JOIN resumes r ON e.resume_id = r.id
+			education_resolved e
 		WHERE
 			e.id=@id
-			AND r.user_id=@user_id
+			AND e.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -106,11 +106,10 @@ func (r *EducationRepository) GetEducationByResumeID(ctx context.Context, userID
 		SELECT
 			e.*
 		FROM
-			education e
-		JOIN resumes r ON e.resume_id = r.id
+			education_resolved e
 		WHERE
 			e.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND e.resume_id IN (` + viewableResumes + `)
 		ORDER BY e.order_index ASC
 	`
 
@@ -133,52 +132,69 @@ func (r *EducationRepository) GetEducationByResumeID(ctx context.Context, userID
 	return educationItems, nil
 }
 
-func (r *EducationRepository) UpdateEducation(ctx context.Context, userID string, educationID uuid.UUID, payload *education.UpdateEducationRequest) (*education.Education, error) {
+func (r *EducationRepository) UpdateEducation(ctx context.Context, userID string, educationID uuid.UUID, expectedUpdatedAt time.Time, payload *education.UpdateEducationRequest) (*education.Education, error) {
 	stmt := `UPDATE education SET `
 	args := pgx.NamedArgs{
-		"id": educationID,
+		"id":                  educationID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
+	overridden := []string{}
 
 	if payload.Institution != nil {
 		setClauses = append(setClauses, "institution = @institution")
 		args["institution"] = *payload.Institution
+		overridden = append(overridden, "institution")
 	}
 	if payload.Degree != nil {
 		setClauses = append(setClauses, "degree = @degree")
 		args["degree"] = *payload.Degree
+		overridden = append(overridden, "degree")
 	}
 	if payload.FieldOfStudy != nil {
 		setClauses = append(setClauses, "field_of_study = @field_of_study")
 		args["field_of_study"] = *payload.FieldOfStudy
+		overridden = append(overridden, "field_of_study")
 	}
 	if payload.StartDate != nil {
 		setClauses = append(setClauses, "start_date = @start_date")
 		args["start_date"] = *payload.StartDate
+		overridden = append(overridden, "start_date")
 	}
 	if payload.EndDate != nil {
 		setClauses = append(setClauses, "end_date = @end_date")
 		args["end_date"] = *payload.EndDate
+		overridden = append(overridden, "end_date")
 	}
 	if payload.Grade != nil {
 		setClauses = append(setClauses, "grade = @grade")
 		args["grade"] = *payload.Grade
+		overridden = append(overridden, "grade")
 	}
 	if payload.Description != nil {
 		setClauses = append(setClauses, "description = @description")
 		args["description"] = *payload.Description
+		overridden = append(overridden, "description")
 	}
 	if payload.OrderIndex != nil {
 		setClauses = append(setClauses, "order_index = @order_index")
 		args["order_index"] = *payload.OrderIndex
 	}
 
+	for _, column := range clearedColumns("education", payload.Clear) {
+		setClauses = append(setClauses, column+" = NULL")
+		overridden = append(overridden, column)
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
 	if len(setClauses) == 0 {
 		return nil, fmt.Errorf("no fields to update")
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND ` + resolvedUnchanged("education", "library_education") + ` AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -192,11 +208,16 @@ func (r *EducationRepository) UpdateEducation(ctx context.Context, userID string
 		return nil, fmt.Errorf("failed to collect row from table:education for education_id=%s user_id=%s: %w", educationID.String(), userID, err)
 	}
 
+	// Linked rows only store their overrides, so read back the resolved values
+	if educationItem.LibraryItemID != nil {
+		return r.GetEducationByID(ctx, userID, educationID)
+	}
+
 	return &educationItem, nil
 }
 
 func (r *EducationRepository) BulkUpdateEducationOrder(ctx context.Context, userID string, payload *education.BulkUpdateEducationRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -207,7 +228,7 @@ func (r *EducationRepository) BulkUpdateEducationOrder(ctx context.Context, user
 			UPDATE education 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          educationUpdate.ID,
 			"order_index": educationUpdate.OrderIndex,
@@ -221,21 +242,23 @@ func (r *EducationRepository) BulkUpdateEducationOrder(ctx context.Context, user
 	return tx.Commit(ctx)
 }
 
-func (r *EducationRepository) DeleteEducation(ctx context.Context, userID string, educationID uuid.UUID) error {
+func (r *EducationRepository) DeleteEducation(ctx context.Context, userID string, educationID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM education
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND `+resolvedUnchanged("education", "library_education")+`
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      educationID,
-		"user_id": userID,
+		"id":                  educationID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete education: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("education not found")
+		return fmt.Errorf("education not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
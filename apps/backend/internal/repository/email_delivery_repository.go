@@ -0,0 +1,202 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/emaildelivery"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type EmailDeliveryRepository struct {
+	server *server.Server
+}
+
+func NewEmailDeliveryRepository(s *server.Server) *EmailDeliveryRepository {
+	return &EmailDeliveryRepository{server: s}
+}
+
+// StartAttempt records a send attempt of the delivery with the idempotency
+// key, creating the delivery on its first attempt. It returns false without
+// recording anything when the delivery was already sent.
+func (r *EmailDeliveryRepository) StartAttempt(ctx context.Context, idempotencyKey string, userID *string, template, recipient, provider string) (bool, error) {
+	stmt := `
+		INSERT INTO
+			email_deliveries (
+				idempotency_key,
+				user_id,
+				template,
+				recipient,
+				provider,
+				attempts,
+				last_attempt_at
+			)
+		VALUES
+			(
+				@idempotency_key,
+				@user_id,
+				@template,
+				@recipient,
+				@provider,
+				1,
+				NOW()
+			)
+		ON CONFLICT (idempotency_key) DO UPDATE
+		SET
+			status = 'pending',
+			recipient = EXCLUDED.recipient,
+			provider = EXCLUDED.provider,
+			attempts = email_deliveries.attempts + 1,
+			last_attempt_at = NOW()
+		WHERE
+			email_deliveries.status IN ('pending', 'failed')
+		RETURNING
+			id
+	`
+
+	var id string
+	err := r.server.DB.Pool.QueryRow(ctx, stmt, pgx.NamedArgs{
+		"idempotency_key": idempotencyKey,
+		"user_id":         userID,
+		"template":        template,
+		"recipient":       recipient,
+		"provider":        provider,
+	}).Scan(&id)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to record attempt of email delivery idempotency_key=%s: %w", idempotencyKey, err)
+	}
+
+	return true, nil
+}
+
+// RecordSent marks a delivery as accepted by the provider
+func (r *EmailDeliveryRepository) RecordSent(ctx context.Context, idempotencyKey, providerMessageID string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE email_deliveries
+		SET
+			status = 'sent',
+			provider_message_id = NULLIF(@provider_message_id, ''),
+			error = NULL,
+			sent_at = NOW()
+		WHERE
+			idempotency_key = @idempotency_key
+			AND status = 'pending'
+	`, pgx.NamedArgs{
+		"idempotency_key":     idempotencyKey,
+		"provider_message_id": providerMessageID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record sent email delivery idempotency_key=%s: %w", idempotencyKey, err)
+	}
+
+	return nil
+}
+
+// RecordFailure marks the attempt of a delivery as failed
+func (r *EmailDeliveryRepository) RecordFailure(ctx context.Context, idempotencyKey, sendErr string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE email_deliveries
+		SET
+			status = 'failed',
+			error = @error
+		WHERE
+			idempotency_key = @idempotency_key
+			AND status = 'pending'
+	`, pgx.NamedArgs{
+		"idempotency_key": idempotencyKey,
+		"error":           sendErr,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record failed email delivery idempotency_key=%s: %w", idempotencyKey, err)
+	}
+
+	return nil
+}
+
+// UpdateProviderStatus applies a status reported by the provider to the
+// delivery it sent as providerMessageID. Delivered only follows sent, while a
+// bounce or complaint can follow delivery. It returns false when no delivery
+// changed.
+func (r *EmailDeliveryRepository) UpdateProviderStatus(ctx context.Context, provider, providerMessageID string, status emaildelivery.Status, reason *string) (bool, error) {
+	tag, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE email_deliveries
+		SET
+			status = @status,
+			error = COALESCE(@error, error),
+			delivered_at = CASE WHEN @status = 'delivered' THEN NOW() ELSE delivered_at END
+		WHERE
+			provider = @provider
+			AND provider_message_id = @provider_message_id
+			AND (
+				(@status = 'delivered' AND status = 'sent')
+				OR (@status IN ('bounced', 'complained') AND status IN ('sent', 'delivered'))
+			)
+	`, pgx.NamedArgs{
+		"provider":            provider,
+		"provider_message_id": providerMessageID,
+		"status":              status,
+		"error":               reason,
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to update status of email delivery provider=%s provider_message_id=%s: %w", provider, providerMessageID, err)
+	}
+
+	return tag.RowsAffected() > 0, nil
+}
+
+// GetDeliveriesByUser returns the emails sent to the user, newest first
+func (r *EmailDeliveryRepository) GetDeliveriesByUser(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[emaildelivery.Delivery], error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			email_deliveries
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at DESC, id DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+		"limit":   limit,
+		"offset":  (page - 1) * limit,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get email deliveries query for user_id=%s: %w", userID, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[emaildelivery.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:email_deliveries for user_id=%s: %w", userID, err)
+	}
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			COUNT(*)
+		FROM
+			email_deliveries
+		WHERE
+			user_id=@user_id
+	`, pgx.NamedArgs{
+		"user_id": userID,
+	}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of email deliveries for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[emaildelivery.Delivery]{
+		Data:       deliveries,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
@@ -0,0 +1,498 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/erasure"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type ErasureRepository struct {
+	server *server.Server
+}
+
+func NewErasureRepository(s *server.Server) *ErasureRepository {
+	return &ErasureRepository{server: s}
+}
+
+// erasureBatchSize is the number of rows each erasure transaction changes
+const erasureBatchSize = 500
+
+// erasureSteps delete or anonymize everything tied to @user_id, @batch_size
+// rows at a time. Every statement only matches rows it has not handled yet, so
+// an erasure can be retried from the start. Rows on other users' resumes and
+// in the audit log are kept with the user replaced by @anonymous_id; they are
+// handled first because they are found through the user's resumes. Resumes go
+// last but one so the rows that reference them are removed by ON DELETE
+// CASCADE. Tables that gain user data must be added here and to
+// userDataExports.
+var erasureSteps = []struct {
+	table  string
+	action string
+	stmt   string
+}{
+	{"audit_log", "anonymized", `
+		UPDATE audit_log
+		SET
+			actor_id = CASE WHEN actor_id = @user_id THEN @anonymous_id ELSE actor_id END,
+			ip_address = CASE WHEN actor_id = @user_id THEN NULL ELSE ip_address END,
+			before = NULL,
+			after = NULL
+		WHERE id IN (
+			SELECT id FROM audit_log
+			WHERE actor_id = @user_id
+				OR (resume_id IN (` + ownedResumeIDs + `) AND (before IS NOT NULL OR after IS NOT NULL))
+			LIMIT @batch_size
+		)
+	`},
+	{"resume_comments", "anonymized", `
+		UPDATE resume_comments
+		SET
+			author_id = @anonymous_id,
+			body = '[deleted]'
+		WHERE id IN (
+			SELECT id FROM resume_comments
+			WHERE author_id = @user_id AND resume_id NOT IN (` + ownedResumeIDs + `)
+			LIMIT @batch_size
+		)
+	`},
+	{"resume_comments", "anonymized", `
+		UPDATE resume_comments
+		SET
+			resolved_by = @anonymous_id
+		WHERE id IN (
+			SELECT id FROM resume_comments
+			WHERE resolved_by = @user_id AND resume_id NOT IN (` + ownedResumeIDs + `)
+			LIMIT @batch_size
+		)
+	`},
+	{"resume_collaborators", "anonymized", `
+		UPDATE resume_collaborators
+		SET
+			invited_by = @anonymous_id
+		WHERE id IN (
+			SELECT id FROM resume_collaborators
+			WHERE invited_by = @user_id AND resume_id NOT IN (` + ownedResumeIDs + `)
+			LIMIT @batch_size
+		)
+	`},
+	// Open reviews assigned to the user are withdrawn so the resume can be
+	// submitted to another reviewer
+	{"resume_reviews", "anonymized", `
+		UPDATE resume_reviews
+		SET
+			reviewer_id = @anonymous_id,
+			status = CASE WHEN status IN ('submitted', 'in_review') THEN 'withdrawn' ELSE status END,
+			withdrawn_at = CASE WHEN status IN ('submitted', 'in_review') THEN NOW() ELSE withdrawn_at END
+		WHERE id IN (
+			SELECT id FROM resume_reviews
+			WHERE reviewer_id = @user_id AND resume_id NOT IN (` + ownedResumeIDs + `)
+			LIMIT @batch_size
+		)
+	`},
+	{"resume_reviews", "anonymized", `
+		UPDATE resume_reviews
+		SET
+			submitter_id = @anonymous_id,
+			message = NULL
+		WHERE id IN (
+			SELECT id FROM resume_reviews
+			WHERE submitter_id = @user_id AND resume_id NOT IN (` + ownedResumeIDs + `)
+			LIMIT @batch_size
+		)
+	`},
+	{"resume_review_events", "anonymized", `
+		UPDATE resume_review_events
+		SET
+			actor_id = @anonymous_id,
+			note = NULL
+		WHERE id IN (
+			SELECT id FROM resume_review_events
+			WHERE actor_id = @user_id
+				AND review_id NOT IN (SELECT id FROM resume_reviews WHERE resume_id IN (` + ownedResumeIDs + `))
+			LIMIT @batch_size
+		)
+	`},
+	{"webhook_endpoints", "deleted", `
+		DELETE FROM webhook_endpoints
+		WHERE id IN (SELECT id FROM webhook_endpoints WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"notification_preferences", "deleted", `DELETE FROM notification_preferences WHERE user_id = @user_id`},
+	{"email_deliveries", "deleted", `
+		DELETE FROM email_deliveries
+		WHERE id IN (SELECT id FROM email_deliveries WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"data_exports", "deleted", `
+		DELETE FROM data_exports
+		WHERE id IN (SELECT id FROM data_exports WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	// Entries on other users' resumes that link one of the user's library
+	// items would lose every field they inherit, so they keep the content
+	// they show and are detached before the library is deleted
+	{"experience", "detached", detachLibraryLinksStmt("experience", "library_experience",
+		"company", "position", "start_date", "end_date", "location", "description")},
+	{"education", "detached", detachLibraryLinksStmt("education", "library_education",
+		"institution", "degree", "field_of_study", "start_date", "end_date", "grade", "description")},
+	{"projects", "detached", detachLibraryLinksStmt("projects", "library_projects",
+		"name", "role", "description", "link", "technologies")},
+	{"skills", "detached", detachLibraryLinksStmt("skills", "library_skills",
+		"name", "level", "category")},
+	{"certifications", "detached", detachLibraryLinksStmt("certifications", "library_certifications",
+		"name", "organization", "issue_date", "expiry_date", "credential_id", "credential_url")},
+	{"library_education", "deleted", `
+		DELETE FROM library_education
+		WHERE id IN (SELECT id FROM library_education WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"library_experience", "deleted", `
+		DELETE FROM library_experience
+		WHERE id IN (SELECT id FROM library_experience WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"library_projects", "deleted", `
+		DELETE FROM library_projects
+		WHERE id IN (SELECT id FROM library_projects WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"library_skills", "deleted", `
+		DELETE FROM library_skills
+		WHERE id IN (SELECT id FROM library_skills WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"library_certifications", "deleted", `
+		DELETE FROM library_certifications
+		WHERE id IN (SELECT id FROM library_certifications WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"resume_collaborators", "deleted", `
+		DELETE FROM resume_collaborators
+		WHERE id IN (SELECT id FROM resume_collaborators WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"organization_members", "deleted", `
+		DELETE FROM organization_members
+		WHERE ctid IN (SELECT ctid FROM organization_members WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"organization_member_removals", "deleted", `
+		DELETE FROM organization_member_removals
+		WHERE ctid IN (SELECT ctid FROM organization_member_removals WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	// Events about the user's resumes and reviews, including those raised by
+	// the steps above, are no longer delivered
+	{"outbox_events", "deleted", `
+		DELETE FROM outbox_events
+		WHERE id IN (
+			SELECT id FROM outbox_events
+			WHERE payload->>'resume_id' IN (SELECT id::TEXT FROM resumes WHERE user_id = @user_id)
+				OR payload->>'reviewer_id' = @user_id
+				OR payload->>'submitter_id' = @user_id
+			LIMIT @batch_size
+		)
+	`},
+	{"resumes", "deleted", `
+		DELETE FROM resumes
+		WHERE id IN (SELECT id FROM resumes WHERE user_id = @user_id LIMIT @batch_size)
+	`},
+	{"users", "deleted", `DELETE FROM users WHERE id = @user_id`},
+}
+
+// erasureColumns are the columns of account_erasures
+const erasureColumns = `
+	id,
+	user_id,
+	source,
+	status,
+	rows_affected,
+	cancelled_jobs,
+	error,
+	completed_at,
+	created_at,
+	updated_at
+`
+
+// CreateErasure starts an erasure of the user's account. While an erasure is
+// pending or running it is returned instead of starting another.
+func (r *ErasureRepository) CreateErasure(ctx context.Context, userID string, source erasure.Source) (*erasure.Erasure, error) {
+	stmt := `
+		INSERT INTO
+			account_erasures (user_id, source)
+		VALUES
+			(@user_id, @source)
+		ON CONFLICT (user_id) WHERE status IN ('pending', 'running')
+		DO UPDATE SET status = account_erasures.status
+		RETURNING
+	` + erasureColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+		"source":  source,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create erasure query for user_id=%s: %w", userID, err)
+	}
+
+	item, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[erasure.Erasure])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:account_erasures for user_id=%s: %w", userID, err)
+	}
+
+	return &item, nil
+}
+
+// GetErasureByID returns the receipt of one of the user's erasures
+func (r *ErasureRepository) GetErasureByID(ctx context.Context, userID string, erasureID uuid.UUID) (*erasure.Erasure, error) {
+	stmt := `
+		SELECT
+	` + erasureColumns + `
+		FROM
+			account_erasures
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      erasureID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get erasure by id query for erasure_id=%s user_id=%s: %w", erasureID.String(), userID, err)
+	}
+
+	item, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[erasure.Erasure])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:account_erasures for erasure_id=%s user_id=%s: %w", erasureID.String(), userID, err)
+	}
+
+	return &item, nil
+}
+
+// StartErasure marks a pending erasure as running and returns it; erasures
+// that are already finished are returned unchanged. It is used by the erasure job and
+// is not scoped to a user.
+func (r *ErasureRepository) StartErasure(ctx context.Context, erasureID uuid.UUID) (*erasure.Erasure, error) {
+	stmt := `
+		UPDATE account_erasures
+		SET
+			status = CASE WHEN status = 'pending' THEN 'running' ELSE status END
+		WHERE
+			id = @id
+		RETURNING
+	` + erasureColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": erasureID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute start erasure query for erasure_id=%s: %w", erasureID.String(), err)
+	}
+
+	item, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[erasure.Erasure])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:account_erasures for erasure_id=%s: %w", erasureID.String(), err)
+	}
+
+	return &item, nil
+}
+
+// GetUserEmail returns the cached email of a user, or nil when the user has
+// no cached profile
+func (r *ErasureRepository) GetUserEmail(ctx context.Context, userID string) (*string, error) {
+	var email *string
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			email
+		FROM
+			users
+		WHERE
+			id=@user_id
+	`, pgx.NamedArgs{
+		"user_id": userID,
+	}).Scan(&email)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get email for user_id=%s: %w", userID, err)
+	}
+
+	return email, nil
+}
+
+// GetDataExportIDs lists the IDs of the user's data exports
+func (r *ErasureRepository) GetDataExportIDs(ctx context.Context, userID string) ([]uuid.UUID, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			id
+		FROM
+			data_exports
+		WHERE
+			user_id=@user_id
+	`, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get data export ids query for user_id=%s: %w", userID, err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:data_exports for user_id=%s: %w", userID, err)
+	}
+
+	return ids, nil
+}
+
+// RecordCancelledJobs adds to the number of jobs the erasure cancelled
+func (r *ErasureRepository) RecordCancelledJobs(ctx context.Context, erasureID uuid.UUID, cancelled int) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE account_erasures
+		SET
+			cancelled_jobs = cancelled_jobs + @cancelled
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":        erasureID,
+		"cancelled": cancelled,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record cancelled jobs for erasure_id=%s: %w", erasureID.String(), err)
+	}
+
+	return nil
+}
+
+// detachLibraryLinksStmt returns the erasure step that copies the resolved
+// values of columns into rows of table linked to the user's library items on
+// resumes the user does not own, then drops the link
+func detachLibraryLinksStmt(table, libraryTable string, columns ...string) string {
+	setClauses := make([]string, 0, len(columns))
+	for _, column := range columns {
+		setClauses = append(setClauses, fmt.Sprintf(
+			"%[1]s = CASE WHEN '%[1]s' = ANY(t.overridden_fields) THEN t.%[1]s ELSE l.%[1]s END", column))
+	}
+
+	return `
+		UPDATE ` + table + ` t
+		SET
+			` + strings.Join(setClauses, ",\n\t\t\t") + `,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM ` + libraryTable + ` l
+		WHERE t.library_item_id = l.id
+			AND t.id IN (
+				SELECT linked.id FROM ` + table + ` linked
+				JOIN ` + libraryTable + ` item ON linked.library_item_id = item.id
+				WHERE item.user_id = @user_id AND linked.resume_id NOT IN (` + ownedResumeIDs + `)
+				LIMIT @batch_size
+			)
+	`
+}
+
+// EraseUserData runs every erasure step for the user in batches. Each batch
+// is its own transaction and adds the rows it changed to the receipt, so an
+// erasure that fails partway keeps its progress.
+func (r *ErasureRepository) EraseUserData(ctx context.Context, erasureID uuid.UUID, userID string) error {
+	for _, s := range erasureSteps {
+		for {
+			affected, err := r.eraseBatch(ctx, erasureID, userID, s.table+"."+s.action, s.stmt)
+			if err != nil {
+				return fmt.Errorf("failed to erase table:%s for user_id=%s: %w", s.table, userID, err)
+			}
+			if affected < erasureBatchSize {
+				break
+			}
+		}
+	}
+
+	return nil
+}
+
+// CompleteErasure marks an erasure as completed
+func (r *ErasureRepository) CompleteErasure(ctx context.Context, erasureID uuid.UUID) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE account_erasures
+		SET
+			status = 'completed',
+			error = NULL,
+			completed_at = NOW()
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id": erasureID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete erasure for erasure_id=%s: %w", erasureID.String(), err)
+	}
+
+	return nil
+}
+
+// FailErasure records why an erasure could not finish. The rows already
+// erased stay erased; requesting another erasure picks up the rest.
+func (r *ErasureRepository) FailErasure(ctx context.Context, erasureID uuid.UUID, message string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE account_erasures
+		SET
+			status = 'failed',
+			error = @error
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":    erasureID,
+		"error": message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fail erasure for erasure_id=%s: %w", erasureID.String(), err)
+	}
+
+	return nil
+}
+
+func (r *ErasureRepository) eraseBatch(ctx context.Context, erasureID uuid.UUID, userID, key, stmt string) (int64, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Allows this transaction to anonymize the append-only audit log
+	if _, err := tx.Exec(ctx, `SELECT set_config('resumify.audit_erasure', 'on', true)`); err != nil {
+		return 0, fmt.Errorf("failed to enable audit log erasure: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, stmt, pgx.NamedArgs{
+		"user_id":      userID,
+		"anonymous_id": erasure.AnonymousUserID,
+		"batch_size":   erasureBatchSize,
+	})
+	if err != nil {
+		return 0, err
+	}
+
+	affected := result.RowsAffected()
+	if affected == 0 {
+		return 0, nil
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE account_erasures
+		SET
+			rows_affected = jsonb_set(
+				rows_affected,
+				ARRAY[@key::TEXT],
+				to_jsonb(COALESCE((rows_affected->>@key::TEXT)::BIGINT, 0) + @affected)
+			)
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":       erasureID,
+		"key":      key,
+		"affected": affected,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to record erased rows: %w", err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return affected, nil
+}
@@ -0,0 +1,366 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+)
+
+func (r *ExperienceRepository) CreateLibraryExperience(ctx context.Context, userID string, payload *experience.CreateLibraryExperienceRequest) (*experience.LibraryExperience, error) {
+	stmt := `
+		INSERT INTO
+			library_experience (
+				user_id,
+				company,
+				position,
+				start_date,
+				end_date,
+				location,
+				description
+			)
+		VALUES
+			(
+				@user_id,
+				@company,
+				@position,
+				@start_date,
+				@end_date,
+				@location,
+				@description
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":     userID,
+		"company":     payload.Company,
+		"position":    payload.Position,
+		"start_date":  payload.StartDate,
+		"end_date":    payload.EndDate,
+		"location":    payload.Location,
+		"description": payload.Description,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create library experience query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[experience.LibraryExperience])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_experience for user_id=%s: %w", userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *ExperienceRepository) GetLibraryExperienceByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*experience.LibraryExperience, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_experience
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      libraryItemID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library experience by id query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[experience.LibraryExperience])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_experience for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *ExperienceRepository) GetLibraryExperienceByUserID(ctx context.Context, userID string) ([]experience.LibraryExperience, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_experience
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library experience by user query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItems, err := pgx.CollectRows(rows, pgx.RowToStructByName[experience.LibraryExperience])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []experience.LibraryExperience{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:library_experience for user_id=%s: %w", userID, err)
+	}
+
+	return libraryItems, nil
+}
+
+func (r *ExperienceRepository) UpdateLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time, payload *experience.UpdateLibraryExperienceRequest) (*experience.LibraryExperience, error) {
+	stmt := `UPDATE library_experience SET `
+	args := pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"expected_updated_at": expectedUpdatedAt,
+	}
+	setClauses := []string{}
+
+	if payload.Company != nil {
+		setClauses = append(setClauses, "company = @company")
+		args["company"] = *payload.Company
+	}
+	if payload.Position != nil {
+		setClauses = append(setClauses, "position = @position")
+		args["position"] = *payload.Position
+	}
+	if payload.StartDate != nil {
+		setClauses = append(setClauses, "start_date = @start_date")
+		args["start_date"] = *payload.StartDate
+	}
+	if payload.EndDate != nil {
+		setClauses = append(setClauses, "end_date = @end_date")
+		args["end_date"] = *payload.EndDate
+	}
+	if payload.Location != nil {
+		setClauses = append(setClauses, "location = @location")
+		args["location"] = *payload.Location
+	}
+	if payload.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *payload.Description
+	}
+
+	if len(setClauses) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND updated_at = @expected_updated_at RETURNING *`
+
+	args["user_id"] = userID
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update library experience query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[experience.LibraryExperience])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_experience for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+// DeleteLibraryExperience removes a library item. Resume rows linked to it keep their
+// current content: inherited values are copied into the rows before the link is dropped.
+func (r *ExperienceRepository) DeleteLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE experience e
+		SET
+			company = CASE WHEN 'company' = ANY(e.overridden_fields) THEN e.company ELSE l.company END,
+			position = CASE WHEN 'position' = ANY(e.overridden_fields) THEN e.position ELSE l.position END,
+			start_date = CASE WHEN 'start_date' = ANY(e.overridden_fields) THEN e.start_date ELSE l.start_date END,
+			end_date = CASE WHEN 'end_date' = ANY(e.overridden_fields) THEN e.end_date ELSE l.end_date END,
+			location = CASE WHEN 'location' = ANY(e.overridden_fields) THEN e.location ELSE l.location END,
+			description = CASE WHEN 'description' = ANY(e.overridden_fields) THEN e.description ELSE l.description END,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM library_experience l
+		WHERE e.library_item_id = l.id
+		AND l.id = @id
+		AND l.user_id = @user_id
+		AND l.updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach experience from library_item_id=%s: %w", libraryItemID.String(), err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		DELETE FROM library_experience
+		WHERE id = @id
+		AND user_id = @user_id
+		AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete library experience: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("library experience not found: %w", pgx.ErrNoRows)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LinkLibraryExperience adds a library item to a resume as an experience row with no overrides.
+func (r *ExperienceRepository) LinkLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *experience.LinkLibraryExperienceRequest) (*experience.Experience, error) {
+	var experienceID uuid.UUID
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		INSERT INTO
+			experience (resume_id, library_item_id, order_index)
+		SELECT
+			@resume_id,
+			l.id,
+			@order_index
+		FROM
+			library_experience l
+		WHERE
+			l.id=@library_item_id
+			AND l.user_id=@user_id
+			AND @resume_id IN (`+ownedResumes+`)
+		RETURNING
+		id
+	`, pgx.NamedArgs{
+		"resume_id":       payload.ResumeID,
+		"library_item_id": libraryItemID,
+		"order_index":     payload.OrderIndex,
+		"user_id":         userID,
+	}).Scan(&experienceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library experience library_item_id=%s to resume_id=%s: %w", libraryItemID.String(), payload.ResumeID.String(), err)
+	}
+
+	return r.GetExperienceByID(ctx, userID, experienceID)
+}
+
+// PromoteExperience copies a resume experience row into the career library and links the
+// row to the new library item, so its content is inherited from then on.
+func (r *ExperienceRepository) PromoteExperience(ctx context.Context, userID string, experienceID uuid.UUID) (*experience.LibraryExperience, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			library_experience (
+				user_id,
+				company,
+				position,
+				start_date,
+				end_date,
+				location,
+				description
+			)
+		SELECT
+			@user_id,
+			e.company,
+			e.position,
+			e.start_date,
+			e.end_date,
+			e.location,
+			e.description
+		FROM
+			experience e
+		WHERE
+			e.id=@id
+			AND e.library_item_id IS NULL
+			AND e.resume_id IN (`+ownedResumes+`)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"id":      experienceID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute promote experience query for experience_id=%s user_id=%s: %w", experienceID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[experience.LibraryExperience])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_experience for experience_id=%s user_id=%s: %w", experienceID.String(), userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE experience
+		SET
+			library_item_id = @library_item_id,
+			company = NULL,
+			position = NULL,
+			start_date = NULL,
+			end_date = NULL,
+			location = NULL,
+			description = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":              experienceID,
+		"library_item_id": libraryItem.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link experience to library item for experience_id=%s: %w", experienceID.String(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &libraryItem, nil
+}
+
+// ResetExperienceOverrides clears every per-resume override on a linked experience row so
+// it shows the library item's content again.
+func (r *ExperienceRepository) ResetExperienceOverrides(ctx context.Context, userID string, experienceID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE experience
+		SET
+			company = NULL,
+			position = NULL,
+			start_date = NULL,
+			end_date = NULL,
+			location = NULL,
+			description = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+		AND library_item_id IS NOT NULL
+		AND `+resolvedUnchanged("experience", "library_experience")+`
+		AND resume_id IN (`+editableResumes+`)
+	`, pgx.NamedArgs{
+		"id":                  experienceID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset experience overrides: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("linked experience not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
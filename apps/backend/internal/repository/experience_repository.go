@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -75,11 +76,10 @@ func (r *ExperienceRepository) GetExperienceByID(ctx context.Context, userID str
 		SELECT
 			e.*
 		FROM
-			experience e
-		JOIN resumes r ON e.resume_id = r.id
+			experience_resolved e
 		WHERE
 			e.id=@id
-			AND r.user_id=@user_id
+			AND e.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -103,11 +103,10 @@ func (r *ExperienceRepository) GetExperienceByResumeID(ctx context.Context, user
 		SELECT
 			e.*
 		FROM
-			experience e
-		JOIN resumes r ON e.resume_id = r.id
+			experience_resolved e
 		WHERE
 			e.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND e.resume_id IN (` + viewableResumes + `)
 		ORDER BY e.order_index ASC
 	`
 
@@ -130,48 +129,64 @@ func (r *ExperienceRepository) GetExperienceByResumeID(ctx context.Context, user
 	return experienceItems, nil
 }
 
-func (r *ExperienceRepository) UpdateExperience(ctx context.Context, userID string, experienceID uuid.UUID, payload *experience.UpdateExperienceRequest) (*experience.Experience, error) {
+func (r *ExperienceRepository) UpdateExperience(ctx context.Context, userID string, experienceID uuid.UUID, expectedUpdatedAt time.Time, payload *experience.UpdateExperienceRequest) (*experience.Experience, error) {
 	stmt := `UPDATE experience SET `
 	args := pgx.NamedArgs{
-		"id": experienceID,
+		"id":                  experienceID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
+	overridden := []string{}
 
 	if payload.Company != nil {
 		setClauses = append(setClauses, "company = @company")
 		args["company"] = *payload.Company
+		overridden = append(overridden, "company")
 	}
 	if payload.Position != nil {
 		setClauses = append(setClauses, "position = @position")
 		args["position"] = *payload.Position
+		overridden = append(overridden, "position")
 	}
 	if payload.StartDate != nil {
 		setClauses = append(setClauses, "start_date = @start_date")
 		args["start_date"] = *payload.StartDate
+		overridden = append(overridden, "start_date")
 	}
 	if payload.EndDate != nil {
 		setClauses = append(setClauses, "end_date = @end_date")
 		args["end_date"] = *payload.EndDate
+		overridden = append(overridden, "end_date")
 	}
 	if payload.Location != nil {
 		setClauses = append(setClauses, "location = @location")
 		args["location"] = *payload.Location
+		overridden = append(overridden, "location")
 	}
 	if payload.Description != nil {
 		setClauses = append(setClauses, "description = @description")
 		args["description"] = *payload.Description
+		overridden = append(overridden, "description")
 	}
 	if payload.OrderIndex != nil {
 		setClauses = append(setClauses, "order_index = @order_index")
 		args["order_index"] = *payload.OrderIndex
 	}
 
+	for _, column := range clearedColumns("experience", payload.Clear) {
+		setClauses = append(setClauses, column+" = NULL")
+		overridden = append(overridden, column)
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
 	if len(setClauses) == 0 {
 		return nil, fmt.Errorf("no fields to update")
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND ` + resolvedUnchanged("experience", "library_experience") + ` AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -185,11 +200,16 @@ func (r *ExperienceRepository) UpdateExperience(ctx context.Context, userID stri
 		return nil, fmt.Errorf("failed to collect row from table:experience for experience_id=%s user_id=%s: %w", experienceID.String(), userID, err)
 	}
 
+	// Linked rows only store their overrides, so read back the resolved values
+	if experienceItem.LibraryItemID != nil {
+		return r.GetExperienceByID(ctx, userID, experienceID)
+	}
+
 	return &experienceItem, nil
 }
 
 func (r *ExperienceRepository) BulkUpdateExperienceOrder(ctx context.Context, userID string, payload *experience.BulkUpdateExperienceRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -200,7 +220,7 @@ func (r *ExperienceRepository) BulkUpdateExperienceOrder(ctx context.Context, us
 			UPDATE experience 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          experienceUpdate.ID,
 			"order_index": experienceUpdate.OrderIndex,
@@ -214,21 +234,23 @@ func (r *ExperienceRepository) BulkUpdateExperienceOrder(ctx context.Context, us
 	return tx.Commit(ctx)
 }
 
-func (r *ExperienceRepository) DeleteExperience(ctx context.Context, userID string, experienceID uuid.UUID) error {
+func (r *ExperienceRepository) DeleteExperience(ctx context.Context, userID string, experienceID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM experience
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND `+resolvedUnchanged("experience", "library_experience")+`
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      experienceID,
-		"user_id": userID,
+		"id":                  experienceID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete experience: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("experience not found")
+		return fmt.Errorf("experience not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
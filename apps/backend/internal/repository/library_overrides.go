@@ -0,0 +1,76 @@
+package repository
+
+import "github.com/jackc/pgx/v5"
+
+// clearableColumns maps the field names accepted in an update's clear list to
+// the content columns of each library-linked item table
+var clearableColumns = map[string]map[string]string{
+	"experience": {
+		"company":     "company",
+		"position":    "position",
+		"startDate":   "start_date",
+		"endDate":     "end_date",
+		"location":    "location",
+		"description": "description",
+	},
+	"education": {
+		"institution":  "institution",
+		"degree":       "degree",
+		"fieldOfStudy": "field_of_study",
+		"startDate":    "start_date",
+		"endDate":      "end_date",
+		"grade":        "grade",
+		"description":  "description",
+	},
+	"projects": {
+		"name":         "name",
+		"role":         "role",
+		"description":  "description",
+		"link":         "link",
+		"technologies": "technologies",
+	},
+	"skills": {
+		"name":     "name",
+		"level":    "level",
+		"category": "category",
+	},
+	"certifications": {
+		"name":          "name",
+		"organization":  "organization",
+		"issueDate":     "issue_date",
+		"expiryDate":    "expiry_date",
+		"credentialId":  "credential_id",
+		"credentialUrl": "credential_url",
+	},
+}
+
+// clearedColumns returns the columns of table named by an update's clear list
+func clearedColumns(table string, fields []string) []string {
+	columns := make([]string, 0, len(fields))
+	for _, field := range fields {
+		if column, ok := clearableColumns[table][field]; ok {
+			columns = append(columns, column)
+		}
+	}
+	return columns
+}
+
+// overrideClause returns the SET clause that records columns as overridden on
+// a library-linked row, so the resolved view shows the row's own value for
+// them, NULL included. Unlinked rows keep an empty list.
+func overrideClause(args pgx.NamedArgs, columns []string) string {
+	args["overridden_fields"] = columns
+	return `overridden_fields = CASE
+		WHEN library_item_id IS NULL THEN '{}'
+		ELSE ARRAY(SELECT DISTINCT f FROM unnest(overridden_fields || @overridden_fields::TEXT[]) AS f ORDER BY f)
+	END`
+}
+
+// resolvedUnchanged returns the If-Match precondition for a write to a
+// library-linked item table: the row, resolved with its library item as the
+// entity tag was, must still have been last modified at @expected_updated_at
+func resolvedUnchanged(table, libraryTable string) string {
+	return `GREATEST(` + table + `.updated_at, (
+			SELECT l.updated_at FROM ` + libraryTable + ` l WHERE l.id = ` + table + `.library_item_id
+		)) = @expected_updated_at`
+}
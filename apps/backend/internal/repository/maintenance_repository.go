@@ -0,0 +1,168 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// itemTables are the tables holding the ordered items of resume sections. The
+// name of each table is also the name of the section it backs.
+var itemTables = []string{"education", "experience", "projects", "skills", "certifications"}
+
+type MaintenanceRepository struct {
+	server *server.Server
+}
+
+func NewMaintenanceRepository(s *server.Server) *MaintenanceRepository {
+	return &MaintenanceRepository{server: s}
+}
+
+// CompactOrderIndexes renumbers the items of every resume from 1 in their
+// current order, closing the gaps left by deleted items. Only rows whose
+// position changes are written, and they keep their updated_at and raise no
+// domain events. It returns the number of rows renumbered.
+func (r *MaintenanceRepository) CompactOrderIndexes(ctx context.Context) (int64, error) {
+	var compacted int64
+	for _, table := range itemTables {
+		affected, err := r.compactTable(ctx, table)
+		if err != nil {
+			return compacted, fmt.Errorf("failed to compact order indexes of table:%s: %w", table, err)
+		}
+		compacted += affected
+	}
+
+	return compacted, nil
+}
+
+func (r *MaintenanceRepository) compactTable(ctx context.Context, table string) (int64, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Keeps the entity tags of the renumbered items and skips their domain events
+	if _, err := tx.Exec(ctx, `SELECT set_config('resumify.order_compaction', 'on', true)`); err != nil {
+		return 0, fmt.Errorf("failed to enable order compaction: %w", err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		UPDATE `+table+` t
+		SET
+			order_index = ranked.position
+		FROM
+			(
+				SELECT
+					id,
+					ROW_NUMBER() OVER (
+						PARTITION BY
+							resume_id
+						ORDER BY
+							order_index ASC NULLS LAST,
+							created_at ASC,
+							id ASC
+					) AS position
+				FROM
+					`+table+`
+			) ranked
+		WHERE
+			t.id = ranked.id
+			AND t.order_index IS DISTINCT FROM ranked.position
+	`)
+	if err != nil {
+		return 0, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return 0, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return result.RowsAffected(), nil
+}
+
+// GetEmptySectionIDs lists the sections of resumes that are not in the trash
+// which back an item table but have no items
+func (r *MaintenanceRepository) GetEmptySectionIDs(ctx context.Context) ([]uuid.UUID, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			s.id
+		FROM
+			resume_sections s
+			JOIN resumes r ON r.id = s.resume_id
+		WHERE
+			r.deleted_at IS NULL
+			AND (
+				(
+					s.name = 'education'
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							education i
+						WHERE
+							i.resume_id = s.resume_id
+					)
+				)
+				OR (
+					s.name = 'experience'
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							experience i
+						WHERE
+							i.resume_id = s.resume_id
+					)
+				)
+				OR (
+					s.name = 'projects'
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							projects i
+						WHERE
+							i.resume_id = s.resume_id
+					)
+				)
+				OR (
+					s.name = 'skills'
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							skills i
+						WHERE
+							i.resume_id = s.resume_id
+					)
+				)
+				OR (
+					s.name = 'certifications'
+					AND NOT EXISTS (
+						SELECT
+							1
+						FROM
+							certifications i
+						WHERE
+							i.resume_id = s.resume_id
+					)
+				)
+			)
+		ORDER BY
+			s.created_at ASC
+	`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get empty sections query: %w", err)
+	}
+
+	ids, err := pgx.CollectRows(rows, pgx.RowTo[uuid.UUID])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resume_sections: %w", err)
+	}
+
+	return ids, nil
+}
@@ -0,0 +1,88 @@
+package repository
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompactOrderIndexes(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewMaintenanceRepository(testServer)
+
+	var resumeID uuid.UUID
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `INSERT INTO resumes (user_id, title) VALUES ('user_1', 'Resume') RETURNING id`).Scan(&resumeID))
+
+	// Deleted items left gaps; the items were last edited a while ago
+	for _, orderIndex := range []int{2, 5, 9} {
+		_, err := testDB.Pool.Exec(ctx, `INSERT INTO skills (resume_id, name, order_index) VALUES ($1, 'Go', $2)`, resumeID, orderIndex)
+		require.NoError(t, err)
+	}
+	_, err := testDB.Pool.Exec(ctx, `UPDATE skills SET updated_at = $1`, time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC))
+	require.NoError(t, err)
+
+	type skill struct {
+		orderIndex int
+		updatedAt  time.Time
+	}
+	skills := func(t *testing.T) []skill {
+		t.Helper()
+
+		rows, err := testDB.Pool.Query(ctx, `SELECT order_index, updated_at FROM skills WHERE resume_id = $1 ORDER BY order_index`, resumeID)
+		require.NoError(t, err)
+		defer rows.Close()
+
+		var result []skill
+		for rows.Next() {
+			var s skill
+			require.NoError(t, rows.Scan(&s.orderIndex, &s.updatedAt))
+			result = append(result, s)
+		}
+		require.NoError(t, rows.Err())
+		return result
+	}
+	outboxEvents := func(t *testing.T) int {
+		t.Helper()
+
+		var n int
+		require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM outbox_events`).Scan(&n))
+		return n
+	}
+
+	before := skills(t)
+	eventsBefore := outboxEvents(t)
+
+	compacted, err := repo.CompactOrderIndexes(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), compacted)
+
+	after := skills(t)
+	require.Len(t, after, 3)
+	for i, s := range after {
+		assert.Equal(t, i+1, s.orderIndex)
+		// The entity tags derived from updated_at stay valid
+		assert.True(t, before[i].updatedAt.Equal(s.updatedAt), "updated_at changed from %s to %s", before[i].updatedAt, s.updatedAt)
+	}
+	assert.Equal(t, eventsBefore, outboxEvents(t), "compaction raised domain events")
+
+	// Compacted items are not written again
+	compacted, err = repo.CompactOrderIndexes(ctx)
+	require.NoError(t, err)
+	assert.Zero(t, compacted)
+
+	// Regular edits still bump updated_at
+	_, err = testDB.Pool.Exec(ctx, `UPDATE skills SET name = 'Rust' WHERE resume_id = $1`, resumeID)
+	require.NoError(t, err)
+	for i, s := range skills(t) {
+		assert.True(t, s.updatedAt.After(before[i].updatedAt))
+	}
+	assert.Greater(t, outboxEvents(t), eventsBefore)
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/notification"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type NotificationRepository struct {
+	server *server.Server
+}
+
+func NewNotificationRepository(s *server.Server) *NotificationRepository {
+	return &NotificationRepository{server: s}
+}
+
+// GetPreferences returns the user's notification preferences, or
+// pgx.ErrNoRows when the user never changed them
+func (r *NotificationRepository) GetPreferences(ctx context.Context, userID string) (*notification.Preferences, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			notification_preferences
+		WHERE
+			user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Conn(ctx).Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get notification preferences query for user_id=%s: %w", userID, err)
+	}
+
+	preferences, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[notification.Preferences])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:notification_preferences for user_id=%s: %w", userID, err)
+	}
+
+	return &preferences, nil
+}
+
+// SavePreferences stores every category of the user's preferences
+func (r *NotificationRepository) SavePreferences(ctx context.Context, preferences *notification.Preferences) (*notification.Preferences, error) {
+	stmt := `
+		INSERT INTO
+			notification_preferences (
+				user_id,
+				reminders,
+				review_requests,
+				share_view_digests,
+				product_news
+			)
+		VALUES
+			(
+				@user_id,
+				@reminders,
+				@review_requests,
+				@share_view_digests,
+				@product_news
+			)
+		ON CONFLICT (user_id) DO UPDATE
+		SET
+			reminders = EXCLUDED.reminders,
+			review_requests = EXCLUDED.review_requests,
+			share_view_digests = EXCLUDED.share_view_digests,
+			product_news = EXCLUDED.product_news
+		RETURNING
+			*
+	`
+
+	rows, err := r.server.DB.Conn(ctx).Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":            preferences.UserID,
+		"reminders":          preferences.Reminders,
+		"review_requests":    preferences.ReviewRequests,
+		"share_view_digests": preferences.ShareViewDigests,
+		"product_news":       preferences.ProductNews,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute save notification preferences query for user_id=%s: %w", preferences.UserID, err)
+	}
+
+	saved, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[notification.Preferences])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:notification_preferences for user_id=%s: %w", preferences.UserID, err)
+	}
+
+	return &saved, nil
+}
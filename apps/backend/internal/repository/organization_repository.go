@@ -0,0 +1,364 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/organization"
+	"github.com/recreatedev/Resumify/internal/model/resume"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type OrganizationRepository struct {
+	server *server.Server
+}
+
+func NewOrganizationRepository(s *server.Server) *OrganizationRepository {
+	return &OrganizationRepository{server: s}
+}
+
+// GetMember returns the user's membership of an organization
+func (r *OrganizationRepository) GetMember(ctx context.Context, organizationID, userID string) (*organization.Member, error) {
+	rows, err := r.server.DB.Conn(ctx).Query(ctx, `
+		SELECT
+			*
+		FROM
+			organization_members
+		WHERE
+			organization_id = @organization_id
+			AND user_id = @user_id
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get organization member query for organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	member, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[organization.Member])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:organization_members for organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return &member, nil
+}
+
+// AddMember records the user's membership of an organization unless it was
+// removed through a webhook. It never changes an existing membership.
+func (r *OrganizationRepository) AddMember(ctx context.Context, organizationID, userID, orgRole string, resumeRole collaborator.Role) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		INSERT INTO
+			organization_members (
+				organization_id,
+				user_id,
+				org_role,
+				resume_role
+			)
+		SELECT
+			@organization_id,
+			@user_id,
+			@org_role,
+			@resume_role
+		WHERE
+			NOT EXISTS (
+				SELECT
+					1
+				FROM
+					organization_member_removals
+				WHERE
+					organization_id = @organization_id
+					AND user_id = @user_id
+			)
+		ON CONFLICT (organization_id, user_id) DO NOTHING
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+		"org_role":        orgRole,
+		"resume_role":     resumeRole,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add organization member organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return nil
+}
+
+// UpsertMember records the user's membership of an organization and clears
+// any earlier removal of it. Unchanged memberships are left untouched.
+func (r *OrganizationRepository) UpsertMember(ctx context.Context, organizationID, userID, orgRole string, resumeRole collaborator.Role) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		DELETE FROM organization_member_removals
+		WHERE organization_id = @organization_id AND user_id = @user_id
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to clear organization member removal organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	_, err = r.server.DB.Conn(ctx).Exec(ctx, `
+		INSERT INTO
+			organization_members (
+				organization_id,
+				user_id,
+				org_role,
+				resume_role
+			)
+		VALUES
+			(
+				@organization_id,
+				@user_id,
+				@org_role,
+				@resume_role
+			)
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET
+			org_role = EXCLUDED.org_role,
+			resume_role = EXCLUDED.resume_role
+		WHERE
+			(organization_members.org_role, organization_members.resume_role)
+			IS DISTINCT FROM (EXCLUDED.org_role, EXCLUDED.resume_role)
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+		"org_role":        orgRole,
+		"resume_role":     resumeRole,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to upsert organization member organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return nil
+}
+
+// DeleteMember removes the user's membership of an organization
+func (r *OrganizationRepository) DeleteMember(ctx context.Context, organizationID, userID string) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		DELETE FROM organization_members
+		WHERE organization_id = @organization_id AND user_id = @user_id
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete organization member organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return nil
+}
+
+// RecordMemberRemoval records that the user was removed from an organization,
+// so AddMember does not bring the membership back
+func (r *OrganizationRepository) RecordMemberRemoval(ctx context.Context, organizationID, userID string) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		INSERT INTO
+			organization_member_removals (organization_id, user_id)
+		VALUES
+			(@organization_id, @user_id)
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET
+			removed_at = NOW()
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record organization member removal organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return nil
+}
+
+// UpdateMember changes the roles of an existing membership. Unlike
+// UpsertMember it never adds a member, so a late update cannot bring back a
+// membership that was already removed.
+func (r *OrganizationRepository) UpdateMember(ctx context.Context, organizationID, userID, orgRole string, resumeRole collaborator.Role) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		UPDATE organization_members
+		SET
+			org_role = @org_role,
+			resume_role = @resume_role
+		WHERE
+			organization_id = @organization_id
+			AND user_id = @user_id
+			AND (org_role, resume_role) IS DISTINCT FROM (@org_role, @resume_role)
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+		"user_id":         userID,
+		"org_role":        orgRole,
+		"resume_role":     resumeRole,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update organization member organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return nil
+}
+
+// DeleteOrganizationMembers removes every membership of an organization and
+// records the removals
+func (r *OrganizationRepository) DeleteOrganizationMembers(ctx context.Context, organizationID string) error {
+	_, err := r.server.DB.Conn(ctx).Exec(ctx, `
+		WITH
+			removed AS (
+				DELETE FROM organization_members
+				WHERE organization_id = @organization_id
+				RETURNING
+					organization_id,
+					user_id
+			)
+		INSERT INTO
+			organization_member_removals (organization_id, user_id)
+		SELECT
+			organization_id,
+			user_id
+		FROM
+			removed
+		ON CONFLICT (organization_id, user_id) DO UPDATE
+		SET
+			removed_at = NOW()
+	`, pgx.NamedArgs{
+		"organization_id": organizationID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete organization members organization_id=%s: %w", organizationID, err)
+	}
+
+	return nil
+}
+
+// CreateOrganizationResume creates a resume owned by the user inside an organization
+func (r *OrganizationRepository) CreateOrganizationResume(ctx context.Context, userID, organizationID string, payload *resume.CreateResumeRequest) (*resume.Resume, error) {
+	stmt := `
+		INSERT INTO
+			resumes (
+				user_id,
+				organization_id,
+				title,
+				theme
+			)
+		VALUES
+			(
+				@user_id,
+				@organization_id,
+				@title,
+				@theme
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":         userID,
+		"organization_id": organizationID,
+		"title":           payload.Title,
+		"theme":           payload.Theme,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create organization resume query for user_id=%s organization_id=%s: %w", userID, organizationID, err)
+	}
+
+	resumeItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resumes for user_id=%s organization_id=%s: %w", userID, organizationID, err)
+	}
+
+	return &resumeItem, nil
+}
+
+// GetOrganizationResumes returns the organization's active resumes the user can
+// see, with the user's role on each
+func (r *OrganizationRepository) GetOrganizationResumes(ctx context.Context, userID, organizationID string, page, limit int) (*model.PaginatedResponse[resume.SharedResume], error) {
+	stmt := `
+		SELECT
+			r.*,
+			CASE WHEN r.user_id = @user_id THEN 'owner' ELSE m.resume_role END AS role
+		FROM
+			resumes r
+		LEFT JOIN organization_members m ON m.organization_id = r.organization_id AND m.user_id = @user_id
+		WHERE
+			r.organization_id=@organization_id
+			AND r.deleted_at IS NULL
+			AND (r.user_id = @user_id OR m.user_id IS NOT NULL)
+		ORDER BY r.updated_at DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	args := pgx.NamedArgs{
+		"user_id":         userID,
+		"organization_id": organizationID,
+		"limit":           limit,
+		"offset":          (page - 1) * limit,
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get organization resumes query for organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	resumes, err := pgx.CollectRows(rows, pgx.RowToStructByName[resume.SharedResume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resumes for organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			resumes r
+		LEFT JOIN organization_members m ON m.organization_id = r.organization_id AND m.user_id = @user_id
+		WHERE
+			r.organization_id=@organization_id
+			AND r.deleted_at IS NULL
+			AND (r.user_id = @user_id OR m.user_id IS NOT NULL)
+	`
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, pgx.NamedArgs{
+		"user_id":         userID,
+		"organization_id": organizationID,
+	}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of organization resumes for organization_id=%s user_id=%s: %w", organizationID, userID, err)
+	}
+
+	return &model.PaginatedResponse[resume.SharedResume]{
+		Data:       resumes,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// SetResumeOrganization moves a resume owned by the user into an organization,
+// or back to the user's personal workspace when organizationID is nil
+func (r *OrganizationRepository) SetResumeOrganization(ctx context.Context, userID string, resumeID uuid.UUID, organizationID *string) (*resume.Resume, error) {
+	stmt := `
+		UPDATE resumes
+		SET organization_id = @organization_id
+		WHERE id = @id AND user_id = @user_id AND deleted_at IS NULL
+		RETURNING *
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":              resumeID,
+		"user_id":         userID,
+		"organization_id": organizationID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute set resume organization query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	resumeItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resumes for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return &resumeItem, nil
+}
@@ -0,0 +1,148 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/outbox"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type OutboxRepository struct {
+	server *server.Server
+}
+
+func NewOutboxRepository(s *server.Server) *OutboxRepository {
+	return &OutboxRepository{server: s}
+}
+
+// AddEvent writes a domain event to the outbox. Call it with the context of
+// the transaction making the change so the event is committed with it.
+func (r *OutboxRepository) AddEvent(ctx context.Context, eventType string, payload any) error {
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s event payload: %w", eventType, err)
+	}
+
+	_, err = r.server.DB.Conn(ctx).Exec(ctx, `
+		INSERT INTO
+			outbox_events (event_type, payload)
+		VALUES
+			(@event_type, @payload)
+	`, pgx.NamedArgs{
+		"event_type": eventType,
+		"payload":    encoded,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to insert outbox event event_type=%s: %w", eventType, err)
+	}
+
+	return nil
+}
+
+// DispatchPendingEvents hands up to limit pending events to dispatch, oldest
+// first, and marks the ones it accepted as dispatched. The events stay locked
+// until the batch is done so concurrent dispatchers skip them; failed events
+// are kept pending with their error and retried by the next run, until their
+// maxAttempts-th failure dead-letters them. It returns the number of events
+// dispatched and dead-lettered.
+func (r *OutboxRepository) DispatchPendingEvents(ctx context.Context, limit, maxAttempts int, dispatch func(ctx context.Context, event *outbox.Event) error) (int, int, error) {
+	dispatched, deadLettered := 0, 0
+
+	err := r.server.DB.InTx(ctx, func(ctx context.Context) error {
+		rows, err := r.server.DB.Conn(ctx).Query(ctx, `
+			SELECT
+				o.*
+			FROM
+				outbox_events o
+			WHERE
+				o.dispatched_at IS NULL
+				AND o.dead_lettered_at IS NULL
+			ORDER BY o.created_at ASC
+			LIMIT @limit
+			FOR UPDATE SKIP LOCKED
+		`, pgx.NamedArgs{
+			"limit": limit,
+		})
+		if err != nil {
+			return fmt.Errorf("failed to execute get pending outbox events query: %w", err)
+		}
+
+		events, err := pgx.CollectRows(rows, pgx.RowToStructByName[outbox.Event])
+		if err != nil {
+			return fmt.Errorf("failed to collect rows from table:outbox_events: %w", err)
+		}
+
+		for _, event := range events {
+			if dispatchErr := dispatch(ctx, &event); dispatchErr != nil {
+				var deadLetteredAt *time.Time
+				err = r.server.DB.Conn(ctx).QueryRow(ctx, `
+					UPDATE outbox_events
+					SET
+						attempts = attempts + 1,
+						last_error = @last_error,
+						dead_lettered_at = CASE
+							WHEN attempts + 1 >= @max_attempts THEN NOW()
+						END
+					WHERE
+						id = @id
+					RETURNING
+						dead_lettered_at
+				`, pgx.NamedArgs{
+					"id":           event.ID,
+					"last_error":   dispatchErr.Error(),
+					"max_attempts": maxAttempts,
+				}).Scan(&deadLetteredAt)
+				if err != nil {
+					return fmt.Errorf("failed to record outbox dispatch failure for event_id=%s: %w", event.ID.String(), err)
+				}
+				if deadLetteredAt != nil {
+					deadLettered++
+				}
+				continue
+			}
+
+			_, err = r.server.DB.Conn(ctx).Exec(ctx, `
+				UPDATE outbox_events
+				SET
+					attempts = attempts + 1,
+					last_error = NULL,
+					dispatched_at = NOW()
+				WHERE
+					id = @id
+			`, pgx.NamedArgs{
+				"id": event.ID,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to mark outbox event_id=%s as dispatched: %w", event.ID.String(), err)
+			}
+			dispatched++
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, 0, err
+	}
+
+	return dispatched, deadLettered, nil
+}
+
+// PruneDispatchedEvents deletes the events dispatched before the cutoff.
+// Pending and dead-lettered events are kept.
+func (r *OutboxRepository) PruneDispatchedEvents(ctx context.Context, before time.Time) (int64, error) {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM outbox_events
+		WHERE dispatched_at IS NOT NULL AND dispatched_at < @before
+	`, pgx.NamedArgs{
+		"before": before,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune outbox events dispatched before %s: %w", before.Format(time.RFC3339), err)
+	}
+
+	return result.RowsAffected(), nil
+}
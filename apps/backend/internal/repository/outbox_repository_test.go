@@ -0,0 +1,100 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/model/outbox"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOutboxDeadLettersFailingEvents(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewOutboxRepository(testServer)
+
+	require.NoError(t, repo.AddEvent(ctx, "event:test.failing", map[string]string{"id": "1"}))
+
+	failing := func(ctx context.Context, event *outbox.Event) error {
+		return errors.New("queue unavailable")
+	}
+
+	// The event is retried until its third failure
+	for attempt := 1; attempt <= 3; attempt++ {
+		dispatched, deadLettered, err := repo.DispatchPendingEvents(ctx, 10, 3, failing)
+		require.NoError(t, err)
+		assert.Zero(t, dispatched)
+		if attempt < 3 {
+			assert.Zero(t, deadLettered, "attempt %d", attempt)
+		} else {
+			assert.Equal(t, 1, deadLettered)
+		}
+	}
+
+	var event outbox.Event
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT attempts, last_error, dead_lettered_at FROM outbox_events`).Scan(&event.Attempts, &event.LastError, &event.DeadLetteredAt))
+	assert.Equal(t, 3, event.Attempts)
+	require.NotNil(t, event.LastError)
+	assert.Equal(t, "queue unavailable", *event.LastError)
+	assert.NotNil(t, event.DeadLetteredAt)
+
+	// Dead-lettered events are no longer handed out
+	calls := 0
+	_, _, err := repo.DispatchPendingEvents(ctx, 10, 3, func(ctx context.Context, event *outbox.Event) error {
+		calls++
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Zero(t, calls)
+}
+
+func TestOutboxPruneDispatchedEvents(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repo := NewOutboxRepository(testServer)
+	now := time.Now()
+
+	insert := func(t *testing.T, dispatchedAt, deadLetteredAt *time.Time) uuid.UUID {
+		t.Helper()
+
+		var id uuid.UUID
+		require.NoError(t, testDB.Pool.QueryRow(ctx, `
+			INSERT INTO outbox_events (event_type, payload, dispatched_at, dead_lettered_at)
+			VALUES ('event:test', '{}', $1, $2)
+			RETURNING id
+		`, dispatchedAt, deadLetteredAt).Scan(&id))
+		return id
+	}
+
+	old := now.Add(-8 * 24 * time.Hour)
+	recent := now.Add(-time.Hour)
+
+	insert(t, &old, nil)
+	recentID := insert(t, &recent, nil)
+	pendingID := insert(t, nil, nil)
+	deadLetteredID := insert(t, nil, &old)
+
+	pruned, err := repo.PruneDispatchedEvents(ctx, now.Add(-7*24*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), pruned)
+
+	rows, err := testDB.Pool.Query(ctx, `SELECT id FROM outbox_events`)
+	require.NoError(t, err)
+	var remaining []uuid.UUID
+	for rows.Next() {
+		var id uuid.UUID
+		require.NoError(t, rows.Scan(&id))
+		remaining = append(remaining, id)
+	}
+	require.NoError(t, rows.Err())
+	assert.ElementsMatch(t, []uuid.UUID{recentID, pendingID, deadLetteredID}, remaining)
+}
@@ -0,0 +1,354 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/project"
+)
+
+func (r *ProjectRepository) CreateLibraryProject(ctx context.Context, userID string, payload *project.CreateLibraryProjectRequest) (*project.LibraryProject, error) {
+	stmt := `
+		INSERT INTO
+			library_projects (
+				user_id,
+				name,
+				role,
+				description,
+				link,
+				technologies
+			)
+		VALUES
+			(
+				@user_id,
+				@name,
+				@role,
+				@description,
+				@link,
+				@technologies
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":      userID,
+		"name":         payload.Name,
+		"role":         payload.Role,
+		"description":  payload.Description,
+		"link":         payload.Link,
+		"technologies": payload.Technologies,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create library project query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[project.LibraryProject])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_projects for user_id=%s: %w", userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *ProjectRepository) GetLibraryProjectByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*project.LibraryProject, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_projects
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      libraryItemID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library project by id query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[project.LibraryProject])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_projects for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *ProjectRepository) GetLibraryProjectsByUserID(ctx context.Context, userID string) ([]project.LibraryProject, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_projects
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library project by user query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItems, err := pgx.CollectRows(rows, pgx.RowToStructByName[project.LibraryProject])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []project.LibraryProject{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:library_projects for user_id=%s: %w", userID, err)
+	}
+
+	return libraryItems, nil
+}
+
+func (r *ProjectRepository) UpdateLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time, payload *project.UpdateLibraryProjectRequest) (*project.LibraryProject, error) {
+	stmt := `UPDATE library_projects SET `
+	args := pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"expected_updated_at": expectedUpdatedAt,
+	}
+	setClauses := []string{}
+
+	if payload.Name != nil {
+		setClauses = append(setClauses, "name = @name")
+		args["name"] = *payload.Name
+	}
+	if payload.Role != nil {
+		setClauses = append(setClauses, "role = @role")
+		args["role"] = *payload.Role
+	}
+	if payload.Description != nil {
+		setClauses = append(setClauses, "description = @description")
+		args["description"] = *payload.Description
+	}
+	if payload.Link != nil {
+		setClauses = append(setClauses, "link = @link")
+		args["link"] = *payload.Link
+	}
+	if payload.Technologies != nil {
+		setClauses = append(setClauses, "technologies = @technologies")
+		args["technologies"] = payload.Technologies
+	}
+
+	if len(setClauses) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND updated_at = @expected_updated_at RETURNING *`
+
+	args["user_id"] = userID
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update library project query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[project.LibraryProject])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_projects for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+// DeleteLibraryProject removes a library item. Resume rows linked to it keep their
+// current content: inherited values are copied into the rows before the link is dropped.
+func (r *ProjectRepository) DeleteLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE projects p
+		SET
+			name = CASE WHEN 'name' = ANY(p.overridden_fields) THEN p.name ELSE l.name END,
+			role = CASE WHEN 'role' = ANY(p.overridden_fields) THEN p.role ELSE l.role END,
+			description = CASE WHEN 'description' = ANY(p.overridden_fields) THEN p.description ELSE l.description END,
+			link = CASE WHEN 'link' = ANY(p.overridden_fields) THEN p.link ELSE l.link END,
+			technologies = CASE WHEN 'technologies' = ANY(p.overridden_fields) THEN p.technologies ELSE l.technologies END,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM library_projects l
+		WHERE p.library_item_id = l.id
+		AND l.id = @id
+		AND l.user_id = @user_id
+		AND l.updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach projects from library_item_id=%s: %w", libraryItemID.String(), err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		DELETE FROM library_projects
+		WHERE id = @id
+		AND user_id = @user_id
+		AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete library project: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("library project not found: %w", pgx.ErrNoRows)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LinkLibraryProject adds a library item to a resume as a project row with no overrides.
+func (r *ProjectRepository) LinkLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *project.LinkLibraryProjectRequest) (*project.Project, error) {
+	var projectID uuid.UUID
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		INSERT INTO
+			projects (resume_id, library_item_id, order_index)
+		SELECT
+			@resume_id,
+			l.id,
+			@order_index
+		FROM
+			library_projects l
+		WHERE
+			l.id=@library_item_id
+			AND l.user_id=@user_id
+			AND @resume_id IN (`+ownedResumes+`)
+		RETURNING
+		id
+	`, pgx.NamedArgs{
+		"resume_id":       payload.ResumeID,
+		"library_item_id": libraryItemID,
+		"order_index":     payload.OrderIndex,
+		"user_id":         userID,
+	}).Scan(&projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library project library_item_id=%s to resume_id=%s: %w", libraryItemID.String(), payload.ResumeID.String(), err)
+	}
+
+	return r.GetProjectByID(ctx, userID, projectID)
+}
+
+// PromoteProject copies a resume project row into the career library and links the
+// row to the new library item, so its content is inherited from then on.
+func (r *ProjectRepository) PromoteProject(ctx context.Context, userID string, projectID uuid.UUID) (*project.LibraryProject, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			library_projects (
+				user_id,
+				name,
+				role,
+				description,
+				link,
+				technologies
+			)
+		SELECT
+			@user_id,
+			p.name,
+			p.role,
+			p.description,
+			p.link,
+			p.technologies
+		FROM
+			projects p
+		WHERE
+			p.id=@id
+			AND p.library_item_id IS NULL
+			AND p.resume_id IN (`+ownedResumes+`)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"id":      projectID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute promote project query for project_id=%s user_id=%s: %w", projectID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[project.LibraryProject])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_projects for project_id=%s user_id=%s: %w", projectID.String(), userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE projects
+		SET
+			library_item_id = @library_item_id,
+			name = NULL,
+			role = NULL,
+			description = NULL,
+			link = NULL,
+			technologies = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":              projectID,
+		"library_item_id": libraryItem.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link project to library item for project_id=%s: %w", projectID.String(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &libraryItem, nil
+}
+
+// ResetProjectOverrides clears every per-resume override on a linked project row so
+// it shows the library item's content again.
+func (r *ProjectRepository) ResetProjectOverrides(ctx context.Context, userID string, projectID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE projects
+		SET
+			name = NULL,
+			role = NULL,
+			description = NULL,
+			link = NULL,
+			technologies = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+		AND library_item_id IS NOT NULL
+		AND `+resolvedUnchanged("projects", "library_projects")+`
+		AND resume_id IN (`+editableResumes+`)
+	`, pgx.NamedArgs{
+		"id":                  projectID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset project overrides: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("linked project not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
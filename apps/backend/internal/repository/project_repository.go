@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -72,11 +73,10 @@ func (r *ProjectRepository) GetProjectByID(ctx context.Context, userID string, p
 		SELECT
 			p.*
 		FROM
-			projects p
-		JOIN resumes r ON p.resume_id = r.id
+			projects_resolved p
 		WHERE
 			p.id=@id
-			AND r.user_id=@user_id
+			AND p.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -100,11 +100,10 @@ func (r *ProjectRepository) GetProjectsByResumeID(ctx context.Context, userID st
 		SELECT
 			p.*
 		FROM
-			projects p
-		JOIN resumes r ON p.resume_id = r.id
+			projects_resolved p
 		WHERE
 			p.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND p.resume_id IN (` + viewableResumes + `)
 		ORDER BY p.order_index ASC
 	`
 
@@ -127,44 +126,59 @@ func (r *ProjectRepository) GetProjectsByResumeID(ctx context.Context, userID st
 	return projectItems, nil
 }
 
-func (r *ProjectRepository) UpdateProject(ctx context.Context, userID string, projectID uuid.UUID, payload *project.UpdateProjectRequest) (*project.Project, error) {
+func (r *ProjectRepository) UpdateProject(ctx context.Context, userID string, projectID uuid.UUID, expectedUpdatedAt time.Time, payload *project.UpdateProjectRequest) (*project.Project, error) {
 	stmt := `UPDATE projects SET `
 	args := pgx.NamedArgs{
-		"id": projectID,
+		"id":                  projectID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
+	overridden := []string{}
 
 	if payload.Name != nil {
 		setClauses = append(setClauses, "name = @name")
 		args["name"] = *payload.Name
+		overridden = append(overridden, "name")
 	}
 	if payload.Role != nil {
 		setClauses = append(setClauses, "role = @role")
 		args["role"] = *payload.Role
+		overridden = append(overridden, "role")
 	}
 	if payload.Description != nil {
 		setClauses = append(setClauses, "description = @description")
 		args["description"] = *payload.Description
+		overridden = append(overridden, "description")
 	}
 	if payload.Link != nil {
 		setClauses = append(setClauses, "link = @link")
 		args["link"] = *payload.Link
+		overridden = append(overridden, "link")
 	}
 	if payload.Technologies != nil {
 		setClauses = append(setClauses, "technologies = @technologies")
 		args["technologies"] = payload.Technologies
+		overridden = append(overridden, "technologies")
 	}
 	if payload.OrderIndex != nil {
 		setClauses = append(setClauses, "order_index = @order_index")
 		args["order_index"] = *payload.OrderIndex
 	}
 
+	for _, column := range clearedColumns("projects", payload.Clear) {
+		setClauses = append(setClauses, column+" = NULL")
+		overridden = append(overridden, column)
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
 	if len(setClauses) == 0 {
 		return nil, fmt.Errorf("no fields to update")
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND ` + resolvedUnchanged("projects", "library_projects") + ` AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -178,11 +192,16 @@ func (r *ProjectRepository) UpdateProject(ctx context.Context, userID string, pr
 		return nil, fmt.Errorf("failed to collect row from table:projects for project_id=%s user_id=%s: %w", projectID.String(), userID, err)
 	}
 
+	// Linked rows only store their overrides, so read back the resolved values
+	if projectItem.LibraryItemID != nil {
+		return r.GetProjectByID(ctx, userID, projectID)
+	}
+
 	return &projectItem, nil
 }
 
 func (r *ProjectRepository) BulkUpdateProjectOrder(ctx context.Context, userID string, payload *project.BulkUpdateProjectsRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -193,7 +212,7 @@ func (r *ProjectRepository) BulkUpdateProjectOrder(ctx context.Context, userID s
 			UPDATE projects 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          projectUpdate.ID,
 			"order_index": projectUpdate.OrderIndex,
@@ -207,21 +226,23 @@ func (r *ProjectRepository) BulkUpdateProjectOrder(ctx context.Context, userID s
 	return tx.Commit(ctx)
 }
 
-func (r *ProjectRepository) DeleteProject(ctx context.Context, userID string, projectID uuid.UUID) error {
+func (r *ProjectRepository) DeleteProject(ctx context.Context, userID string, projectID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM projects
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND `+resolvedUnchanged("projects", "library_projects")+`
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      projectID,
-		"user_id": userID,
+		"id":                  projectID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("project not found")
+		return fmt.Errorf("project not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
@@ -10,6 +10,20 @@ type Repositories struct {
 	Project       *ProjectRepository
 	Skill         *SkillRepository
 	Certification *CertificationRepository
+	Collaborator  *CollaboratorRepository
+	Comment       *CommentRepository
+	Organization  *OrganizationRepository
+	Review        *ReviewRepository
+	Audit         *AuditRepository
+	Outbox        *OutboxRepository
+	Webhook       *WebhookRepository
+	User          *UserRepository
+	DataExport    *DataExportRepository
+	Erasure       *ErasureRepository
+	EmailDelivery *EmailDeliveryRepository
+	Notification  *NotificationRepository
+	Maintenance   *MaintenanceRepository
+	ResumeExport  *ResumeExportRepository
 }
 
 func NewRepositories(s *server.Server) *Repositories {
@@ -21,5 +35,19 @@ func NewRepositories(s *server.Server) *Repositories {
 		Project:       NewProjectRepository(s),
 		Skill:         NewSkillRepository(s),
 		Certification: NewCertificationRepository(s),
+		Collaborator:  NewCollaboratorRepository(s),
+		Comment:       NewCommentRepository(s),
+		Organization:  NewOrganizationRepository(s),
+		Review:        NewReviewRepository(s),
+		Audit:         NewAuditRepository(s),
+		Outbox:        NewOutboxRepository(s),
+		Webhook:       NewWebhookRepository(s),
+		User:          NewUserRepository(s),
+		DataExport:    NewDataExportRepository(s),
+		Erasure:       NewErasureRepository(s),
+		EmailDelivery: NewEmailDeliveryRepository(s),
+		Notification:  NewNotificationRepository(s),
+		Maintenance:   NewMaintenanceRepository(s),
+		ResumeExport:  NewResumeExportRepository(s),
 	}
 }
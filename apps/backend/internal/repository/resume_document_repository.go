@@ -0,0 +1,384 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/composite"
+	"github.com/recreatedev/Resumify/internal/model/education"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+	"github.com/recreatedev/Resumify/internal/model/project"
+	"github.com/recreatedev/Resumify/internal/model/section"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+)
+
+// documentChange is a single entry of a saved document collection: the stored
+// row it updates (nil for new entries) and the columns that differ from it
+type documentChange struct {
+	id      *uuid.UUID
+	columns map[string]any
+}
+
+// SaveResumeDocument applies a whole-document save in one transaction. Every
+// collection present in the payload is diffed against the stored rows; only
+// columns whose value changed are written, so library-linked entries do not
+// gain overrides for values they already inherit. Every column written to a
+// linked entry is recorded as overridden.
+func (r *ResumeRepository) SaveResumeDocument(ctx context.Context, userID string, resumeID uuid.UUID, expectedUpdatedAt time.Time, payload *composite.SaveResumeDocumentRequest) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	// Lock the resume so concurrent saves of the same document are serialized,
+	// and only if it is still the version the If-Match check was made against
+	var lockedID uuid.UUID
+	err = tx.QueryRow(ctx, `
+		SELECT
+			id
+		FROM
+			resumes
+		WHERE
+			id=@id
+			AND updated_at=@expected_updated_at
+			AND id IN (`+editableResumes+`)
+		FOR UPDATE
+	`, pgx.NamedArgs{
+		"id":                  resumeID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	}).Scan(&lockedID)
+	if err != nil {
+		return fmt.Errorf("failed to lock resume for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	// The resume row is always written so its updated_at, and with it the
+	// document's entity tag, moves on every save
+	_, err = tx.Exec(ctx, `
+		UPDATE resumes
+		SET
+			title = COALESCE(@title, title),
+			theme = COALESCE(@theme, theme)
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":    resumeID,
+		"title": payload.Title,
+		"theme": payload.Theme,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to update resume for resume_id=%s: %w", resumeID.String(), err)
+	}
+
+	if payload.Sections != nil {
+		stored, err := collectDocumentRows[section.ResumeSection](ctx, tx, "resume_sections", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Sections))
+		for i, item := range payload.Sections {
+			current := findDocumentRow(stored, item.ID, func(s section.ResumeSection) uuid.UUID { return s.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "name", item.Name, &current.Name)
+			setIfChanged(columns, "display_name", item.DisplayName, current.DisplayName)
+			setIfChanged(columns, "is_visible", item.IsVisible, &current.IsVisible)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "resume_sections", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	if payload.Education != nil {
+		stored, err := collectDocumentRows[education.Education](ctx, tx, "education_resolved", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Education))
+		for i, item := range payload.Education {
+			current := findDocumentRow(stored, item.ID, func(e education.Education) uuid.UUID { return e.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "institution", item.Institution, current.Institution)
+			setIfChanged(columns, "degree", item.Degree, current.Degree)
+			setIfChanged(columns, "field_of_study", item.FieldOfStudy, current.FieldOfStudy)
+			setIfDateChanged(columns, "start_date", item.StartDate, current.StartDate)
+			setIfDateChanged(columns, "end_date", item.EndDate, current.EndDate)
+			setIfChanged(columns, "grade", item.Grade, current.Grade)
+			setIfChanged(columns, "description", item.Description, current.Description)
+			setCleared(columns, "education", item.Clear)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "education", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	if payload.Experience != nil {
+		stored, err := collectDocumentRows[experience.Experience](ctx, tx, "experience_resolved", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Experience))
+		for i, item := range payload.Experience {
+			current := findDocumentRow(stored, item.ID, func(e experience.Experience) uuid.UUID { return e.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "company", item.Company, current.Company)
+			setIfChanged(columns, "position", item.Position, current.Position)
+			setIfDateChanged(columns, "start_date", item.StartDate, current.StartDate)
+			setIfDateChanged(columns, "end_date", item.EndDate, current.EndDate)
+			setIfChanged(columns, "location", item.Location, current.Location)
+			setIfChanged(columns, "description", item.Description, current.Description)
+			setCleared(columns, "experience", item.Clear)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "experience", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	if payload.Projects != nil {
+		stored, err := collectDocumentRows[project.Project](ctx, tx, "projects_resolved", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Projects))
+		for i, item := range payload.Projects {
+			current := findDocumentRow(stored, item.ID, func(p project.Project) uuid.UUID { return p.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "name", item.Name, current.Name)
+			setIfChanged(columns, "role", item.Role, current.Role)
+			setIfChanged(columns, "description", item.Description, current.Description)
+			setIfChanged(columns, "link", item.Link, current.Link)
+			if item.Technologies != nil && !slices.Equal(item.Technologies, current.Technologies) {
+				columns["technologies"] = item.Technologies
+			}
+			setCleared(columns, "projects", item.Clear)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "projects", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	if payload.Skills != nil {
+		stored, err := collectDocumentRows[skill.Skill](ctx, tx, "skills_resolved", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Skills))
+		for i, item := range payload.Skills {
+			current := findDocumentRow(stored, item.ID, func(s skill.Skill) uuid.UUID { return s.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "name", item.Name, current.Name)
+			setIfChanged(columns, "level", item.Level, current.Level)
+			setIfChanged(columns, "category", item.Category, current.Category)
+			setCleared(columns, "skills", item.Clear)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "skills", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	if payload.Certifications != nil {
+		stored, err := collectDocumentRows[certification.Certification](ctx, tx, "certifications_resolved", resumeID)
+		if err != nil {
+			return err
+		}
+		changes := make([]documentChange, len(payload.Certifications))
+		for i, item := range payload.Certifications {
+			current := findDocumentRow(stored, item.ID, func(c certification.Certification) uuid.UUID { return c.ID })
+			columns := map[string]any{}
+			setIfChanged(columns, "name", item.Name, current.Name)
+			setIfChanged(columns, "organization", item.Organization, current.Organization)
+			setIfDateChanged(columns, "issue_date", item.IssueDate, current.IssueDate)
+			setIfDateChanged(columns, "expiry_date", item.ExpiryDate, current.ExpiryDate)
+			setIfChanged(columns, "credential_id", item.CredentialID, current.CredentialID)
+			setIfChanged(columns, "credential_url", item.CredentialURL, current.CredentialURL)
+			setCleared(columns, "certifications", item.Clear)
+			setOrderIfChanged(columns, item.ID, i, current.OrderIndex)
+			changes[i] = documentChange{id: item.ID, columns: columns}
+		}
+		if err := applyDocumentChanges(ctx, tx, "certifications", resumeID, changes); err != nil {
+			return err
+		}
+	}
+
+	return tx.Commit(ctx)
+}
+
+func collectDocumentRows[T any](ctx context.Context, tx pgx.Tx, table string, resumeID uuid.UUID) ([]T, error) {
+	rows, err := tx.Query(ctx, `SELECT * FROM `+table+` WHERE resume_id = @resume_id`, pgx.NamedArgs{
+		"resume_id": resumeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute load document query for table:%s resume_id=%s: %w", table, resumeID.String(), err)
+	}
+
+	items, err := pgx.CollectRows(rows, pgx.RowToStructByName[T])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:%s for resume_id=%s: %w", table, resumeID.String(), err)
+	}
+
+	return items, nil
+}
+
+// findDocumentRow returns the stored row with the given ID, or a zero row for
+// new entries so every submitted value counts as a change
+func findDocumentRow[T any](stored []T, id *uuid.UUID, idOf func(T) uuid.UUID) T {
+	var zero T
+	if id == nil {
+		return zero
+	}
+	for _, item := range stored {
+		if idOf(item) == *id {
+			return item
+		}
+	}
+	return zero
+}
+
+func setIfChanged[T comparable](columns map[string]any, column string, next, current *T) {
+	if next == nil {
+		return
+	}
+	if current == nil || *next != *current {
+		columns[column] = *next
+	}
+}
+
+func setIfDateChanged(columns map[string]any, column string, next, current *time.Time) {
+	if next == nil {
+		return
+	}
+	if current == nil || !next.Equal(*current) {
+		columns[column] = *next
+	}
+}
+
+// setCleared empties the columns an entry lists to clear. They are written
+// even when already empty, so a library-linked entry stops inheriting them.
+func setCleared(columns map[string]any, table string, fields []string) {
+	for _, column := range clearedColumns(table, fields) {
+		columns[column] = nil
+	}
+}
+
+// setOrderIfChanged numbers entries from 1, like the per-item create endpoints
+// that append at the end of a collection
+func setOrderIfChanged(columns map[string]any, id *uuid.UUID, position, current int) {
+	orderIndex := position + 1
+	if id == nil || orderIndex != current {
+		columns["order_index"] = orderIndex
+	}
+}
+
+// applyDocumentChanges deletes stored rows missing from the document, then
+// creates and updates the submitted entries
+func applyDocumentChanges(ctx context.Context, tx pgx.Tx, table string, resumeID uuid.UUID, changes []documentChange) error {
+	keepIDs := []uuid.UUID{}
+	for _, change := range changes {
+		if change.id != nil {
+			keepIDs = append(keepIDs, *change.id)
+		}
+	}
+
+	_, err := tx.Exec(ctx, `DELETE FROM `+table+` WHERE resume_id = @resume_id AND NOT (id = ANY(@keep_ids))`, pgx.NamedArgs{
+		"resume_id": resumeID,
+		"keep_ids":  keepIDs,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete removed rows from table:%s for resume_id=%s: %w", table, resumeID.String(), err)
+	}
+
+	for _, change := range changes {
+		if change.id == nil {
+			if err := insertDocumentRow(ctx, tx, table, resumeID, change.columns); err != nil {
+				return err
+			}
+			continue
+		}
+		if len(change.columns) == 0 {
+			continue
+		}
+		if err := updateDocumentRow(ctx, tx, table, resumeID, *change.id, change.columns); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func insertDocumentRow(ctx context.Context, tx pgx.Tx, table string, resumeID uuid.UUID, columns map[string]any) error {
+	names := []string{"resume_id"}
+	values := []string{"@resume_id"}
+	args := pgx.NamedArgs{
+		"resume_id": resumeID,
+	}
+	for _, column := range sortedColumns(columns) {
+		names = append(names, column)
+		values = append(values, "@"+column)
+		args[column] = columns[column]
+	}
+
+	stmt := `INSERT INTO ` + table + ` (` + strings.Join(names, ", ") + `) VALUES (` + strings.Join(values, ", ") + `)`
+	if _, err := tx.Exec(ctx, stmt, args); err != nil {
+		return fmt.Errorf("failed to insert row into table:%s for resume_id=%s: %w", table, resumeID.String(), err)
+	}
+
+	return nil
+}
+
+// updateDocumentRow updates one row of a document table, scoped to the resume
+// being saved so IDs from other resumes cannot be touched
+func updateDocumentRow(ctx context.Context, tx pgx.Tx, table string, resumeID, id uuid.UUID, columns map[string]any) error {
+	setClauses := []string{}
+	args := pgx.NamedArgs{
+		"id":        id,
+		"resume_id": resumeID,
+	}
+	overridden := []string{}
+	for _, column := range sortedColumns(columns) {
+		setClauses = append(setClauses, column+" = @"+column)
+		args[column] = columns[column]
+		if _, linkable := clearableColumns[table]; linkable && column != "order_index" {
+			overridden = append(overridden, column)
+		}
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
+	stmt := `UPDATE ` + table + ` SET ` + strings.Join(setClauses, ", ") + ` WHERE id = @id AND resume_id = @resume_id`
+	result, err := tx.Exec(ctx, stmt, args)
+	if err != nil {
+		return fmt.Errorf("failed to update row in table:%s for id=%s: %w", table, id.String(), err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("failed to update row in table:%s for id=%s: %w", table, id.String(), pgx.ErrNoRows)
+	}
+
+	return nil
+}
+
+func sortedColumns(columns map[string]any) []string {
+	names := make([]string, 0, len(columns))
+	for column := range columns {
+		names = append(names, column)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,219 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/resumeexport"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type ResumeExportRepository struct {
+	server *server.Server
+}
+
+func NewResumeExportRepository(s *server.Server) *ResumeExportRepository {
+	return &ResumeExportRepository{server: s}
+}
+
+// resumeExportColumns are the columns of resume_exports except the content
+const resumeExportColumns = `
+	id,
+	resume_id,
+	format,
+	document_hash,
+	status,
+	size_bytes,
+	error,
+	expires_at,
+	completed_at,
+	created_at,
+	updated_at
+`
+
+// CreateExport queues a render of the resume document with the given hash.
+// When the document was already rendered to the format, or is being rendered,
+// that export is returned with its expiry extended instead.
+func (r *ResumeExportRepository) CreateExport(ctx context.Context, resumeID uuid.UUID, format resumeexport.Format, documentHash string, expiresAt time.Time) (*resumeexport.ResumeExport, error) {
+	stmt := `
+		INSERT INTO
+			resume_exports (resume_id, format, document_hash, expires_at)
+		VALUES
+			(@resume_id, @format, @document_hash, @expires_at)
+		ON CONFLICT (resume_id, format, document_hash) WHERE status <> 'failed'
+		DO UPDATE SET expires_at = GREATEST(resume_exports.expires_at, EXCLUDED.expires_at)
+		RETURNING
+	` + resumeExportColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"resume_id":     resumeID,
+		"format":        format,
+		"document_hash": documentHash,
+		"expires_at":    expiresAt,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create resume export query for resume_id=%s format=%s: %w", resumeID.String(), format, err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resumeexport.ResumeExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_exports for resume_id=%s format=%s: %w", resumeID.String(), format, err)
+	}
+
+	return &export, nil
+}
+
+// GetExportByID returns a resume export. It is not scoped to a user; callers
+// authorize access to the export's resume. Exports of purged resumes are not
+// found.
+func (r *ResumeExportRepository) GetExportByID(ctx context.Context, exportID uuid.UUID) (*resumeexport.ResumeExport, error) {
+	stmt := `
+		SELECT
+	` + resumeExportColumns + `
+		FROM
+			resume_exports
+		WHERE
+			id=@id
+			AND resume_id IS NOT NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": exportID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get resume export by id query for export_id=%s: %w", exportID.String(), err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resumeexport.ResumeExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_exports for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return &export, nil
+}
+
+// GetResumeOwnerID returns the owner of an export's resume, whose view of the
+// resume is rendered
+func (r *ResumeExportRepository) GetResumeOwnerID(ctx context.Context, exportID uuid.UUID) (string, error) {
+	var ownerID string
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			r.user_id
+		FROM
+			resume_exports e
+			JOIN resumes r ON r.id = e.resume_id
+		WHERE
+			e.id=@id
+	`, pgx.NamedArgs{
+		"id": exportID,
+	}).Scan(&ownerID)
+	if err != nil {
+		return "", fmt.Errorf("failed to get resume owner for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return ownerID, nil
+}
+
+// StartExport marks a queued export as running and returns it; exports that
+// are already finished are returned unchanged. It is used by the export job
+// and is not scoped to a user.
+func (r *ResumeExportRepository) StartExport(ctx context.Context, exportID uuid.UUID) (*resumeexport.ResumeExport, error) {
+	stmt := `
+		UPDATE resume_exports
+		SET
+			status = CASE WHEN status = 'queued' THEN 'running' ELSE status END
+		WHERE
+			id = @id
+			AND resume_id IS NOT NULL
+		RETURNING
+	` + resumeExportColumns
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": exportID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute start resume export query for export_id=%s: %w", exportID.String(), err)
+	}
+
+	export, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resumeexport.ResumeExport])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_exports for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return &export, nil
+}
+
+// CompleteExport records that the rendered content of a running export was
+// stored and keeps it until it expires
+func (r *ResumeExportRepository) CompleteExport(ctx context.Context, exportID uuid.UUID, sizeBytes int64, expiresAt time.Time) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE resume_exports
+		SET
+			status = 'done',
+			size_bytes = @size_bytes,
+			error = NULL,
+			expires_at = GREATEST(expires_at, @expires_at),
+			completed_at = NOW()
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":         exportID,
+		"size_bytes": sizeBytes,
+		"expires_at": expiresAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to complete resume export for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return nil
+}
+
+// FailExport records why a resume could not be rendered
+func (r *ResumeExportRepository) FailExport(ctx context.Context, exportID uuid.UUID, message string) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE resume_exports
+		SET
+			status = 'failed',
+			error = @error,
+			completed_at = NOW()
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":    exportID,
+		"error": message,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to fail resume export for export_id=%s: %w", exportID.String(), err)
+	}
+
+	return nil
+}
+
+// PurgeExpiredExports removes exports that expired or whose resume was purged
+// and returns them, so their stored content can be deleted too
+func (r *ResumeExportRepository) PurgeExpiredExports(ctx context.Context, now time.Time) ([]resumeexport.Blob, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		DELETE FROM resume_exports
+		WHERE
+			(expires_at IS NOT NULL AND expires_at < @now)
+			OR resume_id IS NULL
+		RETURNING
+			id,
+			format
+	`, pgx.NamedArgs{
+		"now": now,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to purge resume exports expired before %s: %w", now.Format(time.RFC3339), err)
+	}
+
+	blobs, err := pgx.CollectRows(rows, pgx.RowToStructByName[resumeexport.Blob])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resume_exports: %w", err)
+	}
+
+	return blobs, nil
+}
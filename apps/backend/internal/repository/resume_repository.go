@@ -5,10 +5,12 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/resume"
 	"github.com/recreatedev/Resumify/internal/server"
 )
@@ -64,7 +66,7 @@ func (r *ResumeRepository) GetResumeByID(ctx context.Context, userID string, res
 			resumes
 		WHERE
 			id=@id
-			AND user_id=@user_id
+			AND id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -91,6 +93,7 @@ func (r *ResumeRepository) GetResumes(ctx context.Context, userID string, page,
 			resumes
 		WHERE
 			user_id=@user_id
+			AND deleted_at IS NULL
 		ORDER BY created_at DESC
 		LIMIT @limit OFFSET @offset
 	`
@@ -128,6 +131,7 @@ func (r *ResumeRepository) GetResumes(ctx context.Context, userID string, page,
 			resumes
 		WHERE
 			user_id=@user_id
+			AND deleted_at IS NULL
 	`
 
 	var total int
@@ -145,11 +149,12 @@ func (r *ResumeRepository) GetResumes(ctx context.Context, userID string, page,
 	}, nil
 }
 
-func (r *ResumeRepository) UpdateResume(ctx context.Context, userID string, resumeID uuid.UUID, payload *resume.UpdateResumeRequest) (*resume.Resume, error) {
+func (r *ResumeRepository) UpdateResume(ctx context.Context, userID string, resumeID uuid.UUID, expectedUpdatedAt time.Time, payload *resume.UpdateResumeRequest) (*resume.Resume, error) {
 	stmt := `UPDATE resumes SET `
 	args := pgx.NamedArgs{
-		"id":      resumeID,
-		"user_id": userID,
+		"id":                  resumeID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
 
@@ -167,7 +172,7 @@ func (r *ResumeRepository) UpdateResume(ctx context.Context, userID string, resu
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND user_id = @user_id RETURNING *`
+	stmt += ` WHERE id = @id AND updated_at = @expected_updated_at AND id IN (` + editableResumes + `) RETURNING *`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
 	if err != nil {
@@ -182,21 +187,248 @@ func (r *ResumeRepository) UpdateResume(ctx context.Context, userID string, resu
 	return &resumeItem, nil
 }
 
-func (r *ResumeRepository) DeleteResume(ctx context.Context, userID string, resumeID uuid.UUID) error {
-	result, err := r.server.DB.Pool.Exec(ctx, `
-		DELETE FROM resumes
-		WHERE id = @id AND user_id = @user_id
+// GetResumeRole returns the user's strongest role on an active resume. Owners
+// and collaborators may also reach the resume through an organization.
+func (r *ResumeRepository) GetResumeRole(ctx context.Context, userID string, resumeID uuid.UUID) (collaborator.Role, error) {
+	var role collaborator.Role
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		SELECT
+			role
+		FROM
+			resume_access
+		WHERE
+			resume_id=@resume_id
+			AND user_id=@user_id
+		ORDER BY
+			CASE role
+				WHEN 'owner' THEN 4
+				WHEN 'editor' THEN 3
+				WHEN 'commenter' THEN 2
+				ELSE 1
+			END DESC
+		LIMIT 1
 	`, pgx.NamedArgs{
-		"id":      resumeID,
+		"resume_id": resumeID,
+		"user_id":   userID,
+	}).Scan(&role)
+	if err != nil {
+		return "", fmt.Errorf("failed to get resume role for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return role, nil
+}
+
+// GetSharedResumes returns the active resumes the user collaborates on, with
+// the user's role on each
+func (r *ResumeRepository) GetSharedResumes(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[resume.SharedResume], error) {
+	stmt := `
+		SELECT
+			r.*,
+			c.role
+		FROM
+			resume_collaborators c
+		JOIN resumes r ON r.id = c.resume_id
+		WHERE
+			c.user_id=@user_id
+			AND c.accepted_at IS NOT NULL
+			AND r.deleted_at IS NULL
+		ORDER BY r.updated_at DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	args := pgx.NamedArgs{
 		"user_id": userID,
+		"limit":   limit,
+		"offset":  (page - 1) * limit,
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get shared resumes query for user_id=%s: %w", userID, err)
+	}
+
+	resumes, err := pgx.CollectRows(rows, pgx.RowToStructByName[resume.SharedResume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resumes for user_id=%s: %w", userID, err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			resume_collaborators c
+		JOIN resumes r ON r.id = c.resume_id
+		WHERE
+			c.user_id=@user_id
+			AND c.accepted_at IS NOT NULL
+			AND r.deleted_at IS NULL
+	`
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, pgx.NamedArgs{"user_id": userID}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of shared resumes for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[resume.SharedResume]{
+		Data:       resumes,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// DeleteResume moves a resume to the trash. Related rows are kept until the
+// resume is purged.
+func (r *ResumeRepository) DeleteResume(ctx context.Context, userID string, resumeID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE resumes
+		SET deleted_at = NOW()
+		WHERE id = @id AND user_id = @user_id AND deleted_at IS NULL AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  resumeID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete resume: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("resume not found")
+		return fmt.Errorf("resume not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
 }
+
+func (r *ResumeRepository) GetDeletedResumeByID(ctx context.Context, userID string, resumeID uuid.UUID) (*resume.Resume, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			resumes
+		WHERE
+			id=@id
+			AND user_id=@user_id
+			AND deleted_at IS NOT NULL
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      resumeID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get deleted resume by id query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	resumeItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resumes for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return &resumeItem, nil
+}
+
+func (r *ResumeRepository) GetDeletedResumes(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[resume.Resume], error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			resumes
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NOT NULL
+		ORDER BY deleted_at DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	args := pgx.NamedArgs{
+		"user_id": userID,
+		"limit":   limit,
+		"offset":  (page - 1) * limit,
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get deleted resumes query for user_id=%s: %w", userID, err)
+	}
+
+	resumes, err := pgx.CollectRows(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return &model.PaginatedResponse[resume.Resume]{
+				Data:       []resume.Resume{},
+				Page:       page,
+				Limit:      limit,
+				Total:      0,
+				TotalPages: 0,
+			}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:resumes for user_id=%s: %w", userID, err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			resumes
+		WHERE
+			user_id=@user_id
+			AND deleted_at IS NOT NULL
+	`
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, pgx.NamedArgs{"user_id": userID}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of deleted resumes for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[resume.Resume]{
+		Data:       resumes,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+func (r *ResumeRepository) RestoreResume(ctx context.Context, userID string, resumeID uuid.UUID) (*resume.Resume, error) {
+	stmt := `
+		UPDATE resumes
+		SET deleted_at = NULL
+		WHERE id = @id AND user_id = @user_id AND deleted_at IS NOT NULL
+		RETURNING *
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      resumeID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute restore resume query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	resumeItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resumes for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return &resumeItem, nil
+}
+
+// PurgeDeletedResumes permanently removes resumes trashed before the cutoff.
+// Related rows are removed by the ON DELETE CASCADE constraints.
+func (r *ResumeRepository) PurgeDeletedResumes(ctx context.Context, cutoff time.Time) (int64, error) {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM resumes
+		WHERE deleted_at IS NOT NULL AND deleted_at < @cutoff
+	`, pgx.NamedArgs{
+		"cutoff": cutoff,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge deleted resumes before %s: %w", cutoff.Format(time.RFC3339), err)
+	}
+
+	return result.RowsAffected(), nil
+}
@@ -0,0 +1,331 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/review"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// visibleReviews limits reviews to the ones the user submitted, was asked to
+// review, or can see through the resume
+var visibleReviews = `(rv.submitter_id = @user_id OR rv.reviewer_id = @user_id OR rv.resume_id IN (` + viewableResumes + `))`
+
+type ReviewRepository struct {
+	server *server.Server
+}
+
+func NewReviewRepository(s *server.Server) *ReviewRepository {
+	return &ReviewRepository{server: s}
+}
+
+// CreateReview submits a version of a resume for review and records the
+// submission in the review history
+func (r *ReviewRepository) CreateReview(ctx context.Context, userID string, resumeID uuid.UUID, payload *review.SubmitReviewRequest, resumeVersion string, snapshot []byte) (*review.Review, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			resume_reviews (
+				resume_id,
+				submitter_id,
+				reviewer_id,
+				status,
+				message,
+				resume_version,
+				snapshot
+			)
+		VALUES
+			(
+				@resume_id,
+				@user_id,
+				@reviewer_id,
+				@status,
+				@message,
+				@resume_version,
+				@snapshot
+			)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"resume_id":      resumeID,
+		"user_id":        userID,
+		"reviewer_id":    payload.ReviewerID,
+		"status":         review.StatusSubmitted,
+		"message":        payload.Message,
+		"resume_version": resumeVersion,
+		"snapshot":       snapshot,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create review query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_reviews for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	if err := insertReviewEvent(ctx, tx, reviewItem.ID, nil, review.StatusSubmitted, userID, payload.Message); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit review submission: %w", err)
+	}
+
+	return &reviewItem, nil
+}
+
+func (r *ReviewRepository) GetReviewByID(ctx context.Context, userID string, reviewID uuid.UUID) (*review.Review, error) {
+	stmt := `
+		SELECT
+			rv.*
+		FROM
+			resume_reviews rv
+		WHERE
+			rv.id=@id
+			AND ` + visibleReviews + `
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      reviewID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get review by id query for review_id=%s user_id=%s: %w", reviewID.String(), userID, err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_reviews for review_id=%s user_id=%s: %w", reviewID.String(), userID, err)
+	}
+
+	return &reviewItem, nil
+}
+
+// GetReviewsByResumeID lists the reviews of a resume, newest first
+func (r *ReviewRepository) GetReviewsByResumeID(ctx context.Context, userID string, resumeID uuid.UUID) ([]review.Review, error) {
+	stmt := `
+		SELECT
+			rv.*
+		FROM
+			resume_reviews rv
+		WHERE
+			rv.resume_id=@resume_id
+			AND ` + visibleReviews + `
+		ORDER BY rv.submitted_at DESC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"resume_id": resumeID,
+		"user_id":   userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get reviews by resume query for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	reviews, err := pgx.CollectRows(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []review.Review{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:resume_reviews for resume_id=%s user_id=%s: %w", resumeID.String(), userID, err)
+	}
+
+	return reviews, nil
+}
+
+// GetReviewQueue lists the reviews assigned to the user in the given statuses,
+// oldest submission first
+func (r *ReviewRepository) GetReviewQueue(ctx context.Context, userID string, statuses []review.Status, page, limit int) (*model.PaginatedResponse[review.Review], error) {
+	stmt := `
+		SELECT
+			rv.*
+		FROM
+			resume_reviews rv
+		WHERE
+			rv.reviewer_id=@user_id
+			AND rv.status = ANY(@statuses)
+		ORDER BY rv.submitted_at ASC
+		LIMIT @limit OFFSET @offset
+	`
+
+	statusNames := make([]string, len(statuses))
+	for i, status := range statuses {
+		statusNames[i] = string(status)
+	}
+
+	args := pgx.NamedArgs{
+		"user_id":  userID,
+		"statuses": statusNames,
+		"limit":    limit,
+		"offset":   (page - 1) * limit,
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get review queue query for user_id=%s: %w", userID, err)
+	}
+
+	reviews, err := pgx.CollectRows(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:resume_reviews for user_id=%s: %w", userID, err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			resume_reviews rv
+		WHERE
+			rv.reviewer_id=@user_id
+			AND rv.status = ANY(@statuses)
+	`
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, pgx.NamedArgs{
+		"user_id":  userID,
+		"statuses": statusNames,
+	}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of review queue for user_id=%s: %w", userID, err)
+	}
+
+	return &model.PaginatedResponse[review.Review]{
+		Data:       reviews,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// GetReviewEvents returns the status history of a review in order
+func (r *ReviewRepository) GetReviewEvents(ctx context.Context, reviewID uuid.UUID) ([]review.Event, error) {
+	rows, err := r.server.DB.Pool.Query(ctx, `
+		SELECT
+			*
+		FROM
+			resume_review_events
+		WHERE
+			review_id=@review_id
+		ORDER BY created_at ASC
+	`, pgx.NamedArgs{
+		"review_id": reviewID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get review events query for review_id=%s: %w", reviewID.String(), err)
+	}
+
+	reviewEvents, err := pgx.CollectRows(rows, pgx.RowToStructByName[review.Event])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []review.Event{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:resume_review_events for review_id=%s: %w", reviewID.String(), err)
+	}
+
+	return reviewEvents, nil
+}
+
+// TransitionReview moves a review from one status to another and records the
+// change. It fails with pgx.ErrNoRows when the review is no longer in the from
+// status. A resubmission replaces the pinned resume version.
+func (r *ReviewRepository) TransitionReview(ctx context.Context, userID string, reviewID uuid.UUID, from, to review.Status, note *string, resumeVersion string, snapshot []byte) (*review.Review, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	args := pgx.NamedArgs{
+		"id":   reviewID,
+		"from": from,
+		"to":   to,
+	}
+
+	var timestamps string
+	switch to {
+	case review.StatusSubmitted:
+		timestamps = "submitted_at = NOW(), started_at = NULL, decided_at = NULL, resume_version = @resume_version, snapshot = @snapshot"
+		args["resume_version"] = resumeVersion
+		args["snapshot"] = snapshot
+	case review.StatusInReview:
+		timestamps = "started_at = NOW()"
+	case review.StatusChangesRequested, review.StatusApproved:
+		timestamps = "decided_at = NOW()"
+	case review.StatusWithdrawn:
+		timestamps = "withdrawn_at = NOW()"
+	}
+
+	rows, err := tx.Query(ctx, `
+		UPDATE resume_reviews
+		SET
+			status = @to,
+			`+timestamps+`
+		WHERE
+			id = @id
+			AND status = @from
+		RETURNING
+		*
+	`, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute transition review query for review_id=%s: %w", reviewID.String(), err)
+	}
+
+	reviewItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[review.Review])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resume_reviews for review_id=%s: %w", reviewID.String(), err)
+	}
+
+	if err := insertReviewEvent(ctx, tx, reviewID, &from, to, userID, note); err != nil {
+		return nil, err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit review transition: %w", err)
+	}
+
+	return &reviewItem, nil
+}
+
+func insertReviewEvent(ctx context.Context, tx pgx.Tx, reviewID uuid.UUID, from *review.Status, to review.Status, actorID string, note *string) error {
+	_, err := tx.Exec(ctx, `
+		INSERT INTO
+			resume_review_events (
+				review_id,
+				from_status,
+				to_status,
+				actor_id,
+				note
+			)
+		VALUES
+			(
+				@review_id,
+				@from_status,
+				@to_status,
+				@actor_id,
+				@note
+			)
+	`, pgx.NamedArgs{
+		"review_id":   reviewID,
+		"from_status": from,
+		"to_status":   to,
+		"actor_id":    actorID,
+		"note":        note,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record review event for review_id=%s: %w", reviewID.String(), err)
+	}
+
+	return nil
+}
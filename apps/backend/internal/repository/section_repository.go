@@ -5,6 +5,7 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
@@ -67,10 +68,9 @@ func (r *ResumeSectionRepository) GetSectionByID(ctx context.Context, userID str
 			rs.*
 		FROM
 			resume_sections rs
-		JOIN resumes r ON rs.resume_id = r.id
 		WHERE
 			rs.id=@id
-			AND r.user_id=@user_id
+			AND rs.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -95,10 +95,9 @@ func (r *ResumeSectionRepository) GetSectionsByResumeID(ctx context.Context, use
 			rs.*
 		FROM
 			resume_sections rs
-		JOIN resumes r ON rs.resume_id = r.id
 		WHERE
 			rs.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND rs.resume_id IN (` + viewableResumes + `)
 		ORDER BY rs.order_index ASC
 	`
 
@@ -121,10 +120,11 @@ func (r *ResumeSectionRepository) GetSectionsByResumeID(ctx context.Context, use
 	return sections, nil
 }
 
-func (r *ResumeSectionRepository) UpdateSection(ctx context.Context, userID string, sectionID uuid.UUID, payload *section.UpdateSectionRequest) (*section.ResumeSection, error) {
+func (r *ResumeSectionRepository) UpdateSection(ctx context.Context, userID string, sectionID uuid.UUID, expectedUpdatedAt time.Time, payload *section.UpdateSectionRequest) (*section.ResumeSection, error) {
 	stmt := `UPDATE resume_sections SET `
 	args := pgx.NamedArgs{
-		"id": sectionID,
+		"id":                  sectionID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
 
@@ -150,7 +150,7 @@ func (r *ResumeSectionRepository) UpdateSection(ctx context.Context, userID stri
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND updated_at = @expected_updated_at AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -168,7 +168,7 @@ func (r *ResumeSectionRepository) UpdateSection(ctx context.Context, userID stri
 }
 
 func (r *ResumeSectionRepository) BulkUpdateSectionOrder(ctx context.Context, userID string, payload *section.BulkUpdateSectionsRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -179,7 +179,7 @@ func (r *ResumeSectionRepository) BulkUpdateSectionOrder(ctx context.Context, us
 			UPDATE resume_sections 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          sectionUpdate.ID,
 			"order_index": sectionUpdate.OrderIndex,
@@ -193,21 +193,23 @@ func (r *ResumeSectionRepository) BulkUpdateSectionOrder(ctx context.Context, us
 	return tx.Commit(ctx)
 }
 
-func (r *ResumeSectionRepository) DeleteSection(ctx context.Context, userID string, sectionID uuid.UUID) error {
+func (r *ResumeSectionRepository) DeleteSection(ctx context.Context, userID string, sectionID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM resume_sections
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND updated_at = @expected_updated_at
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      sectionID,
-		"user_id": userID,
+		"id":                  sectionID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete section: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("section not found")
+		return fmt.Errorf("section not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
@@ -0,0 +1,330 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+)
+
+func (r *SkillRepository) CreateLibrarySkill(ctx context.Context, userID string, payload *skill.CreateLibrarySkillRequest) (*skill.LibrarySkill, error) {
+	stmt := `
+		INSERT INTO
+			library_skills (
+				user_id,
+				name,
+				level,
+				category
+			)
+		VALUES
+			(
+				@user_id,
+				@name,
+				@level,
+				@category
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":  userID,
+		"name":     payload.Name,
+		"level":    payload.Level,
+		"category": payload.Category,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create library skill query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[skill.LibrarySkill])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_skills for user_id=%s: %w", userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *SkillRepository) GetLibrarySkillByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*skill.LibrarySkill, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_skills
+		WHERE
+			id=@id
+			AND user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      libraryItemID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library skill by id query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[skill.LibrarySkill])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_skills for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+func (r *SkillRepository) GetLibrarySkillsByUserID(ctx context.Context, userID string) ([]skill.LibrarySkill, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			library_skills
+		WHERE
+			user_id=@user_id
+		ORDER BY created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get library skill by user query for user_id=%s: %w", userID, err)
+	}
+
+	libraryItems, err := pgx.CollectRows(rows, pgx.RowToStructByName[skill.LibrarySkill])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []skill.LibrarySkill{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:library_skills for user_id=%s: %w", userID, err)
+	}
+
+	return libraryItems, nil
+}
+
+func (r *SkillRepository) UpdateLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time, payload *skill.UpdateLibrarySkillRequest) (*skill.LibrarySkill, error) {
+	stmt := `UPDATE library_skills SET `
+	args := pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"expected_updated_at": expectedUpdatedAt,
+	}
+	setClauses := []string{}
+
+	if payload.Name != nil {
+		setClauses = append(setClauses, "name = @name")
+		args["name"] = *payload.Name
+	}
+	if payload.Level != nil {
+		setClauses = append(setClauses, "level = @level")
+		args["level"] = *payload.Level
+	}
+	if payload.Category != nil {
+		setClauses = append(setClauses, "category = @category")
+		args["category"] = *payload.Category
+	}
+
+	if len(setClauses) == 0 {
+		return nil, fmt.Errorf("no fields to update")
+	}
+
+	stmt += strings.Join(setClauses, ", ")
+	stmt += ` WHERE id = @id AND user_id = @user_id AND updated_at = @expected_updated_at RETURNING *`
+
+	args["user_id"] = userID
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update library skill query for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[skill.LibrarySkill])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_skills for library_item_id=%s user_id=%s: %w", libraryItemID.String(), userID, err)
+	}
+
+	return &libraryItem, nil
+}
+
+// DeleteLibrarySkill removes a library item. Resume rows linked to it keep their
+// current content: inherited values are copied into the rows before the link is dropped.
+func (r *SkillRepository) DeleteLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, expectedUpdatedAt time.Time) error {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	_, err = tx.Exec(ctx, `
+		UPDATE skills s
+		SET
+			name = CASE WHEN 'name' = ANY(s.overridden_fields) THEN s.name ELSE l.name END,
+			level = CASE WHEN 'level' = ANY(s.overridden_fields) THEN s.level ELSE l.level END,
+			category = CASE WHEN 'category' = ANY(s.overridden_fields) THEN s.category ELSE l.category END,
+			overridden_fields = '{}',
+			library_item_id = NULL
+		FROM library_skills l
+		WHERE s.library_item_id = l.id
+		AND l.id = @id
+		AND l.user_id = @user_id
+		AND l.updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to detach skills from library_item_id=%s: %w", libraryItemID.String(), err)
+	}
+
+	result, err := tx.Exec(ctx, `
+		DELETE FROM library_skills
+		WHERE id = @id
+		AND user_id = @user_id
+		AND updated_at = @expected_updated_at
+	`, pgx.NamedArgs{
+		"id":                  libraryItemID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete library skill: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("library skill not found: %w", pgx.ErrNoRows)
+	}
+
+	return tx.Commit(ctx)
+}
+
+// LinkLibrarySkill adds a library item to a resume as a skill row with no overrides.
+func (r *SkillRepository) LinkLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *skill.LinkLibrarySkillRequest) (*skill.Skill, error) {
+	var skillID uuid.UUID
+	err := r.server.DB.Pool.QueryRow(ctx, `
+		INSERT INTO
+			skills (resume_id, library_item_id, order_index)
+		SELECT
+			@resume_id,
+			l.id,
+			@order_index
+		FROM
+			library_skills l
+		WHERE
+			l.id=@library_item_id
+			AND l.user_id=@user_id
+			AND @resume_id IN (`+ownedResumes+`)
+		RETURNING
+		id
+	`, pgx.NamedArgs{
+		"resume_id":       payload.ResumeID,
+		"library_item_id": libraryItemID,
+		"order_index":     payload.OrderIndex,
+		"user_id":         userID,
+	}).Scan(&skillID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library skill library_item_id=%s to resume_id=%s: %w", libraryItemID.String(), payload.ResumeID.String(), err)
+	}
+
+	return r.GetSkillByID(ctx, userID, skillID)
+}
+
+// PromoteSkill copies a resume skill row into the career library and links the
+// row to the new library item, so its content is inherited from then on.
+func (r *SkillRepository) PromoteSkill(ctx context.Context, userID string, skillID uuid.UUID) (*skill.LibrarySkill, error) {
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to begin transaction: %w", err)
+	}
+	defer tx.Rollback(ctx)
+
+	rows, err := tx.Query(ctx, `
+		INSERT INTO
+			library_skills (
+				user_id,
+				name,
+				level,
+				category
+			)
+		SELECT
+			@user_id,
+			s.name,
+			s.level,
+			s.category
+		FROM
+			skills s
+		WHERE
+			s.id=@id
+			AND s.library_item_id IS NULL
+			AND s.resume_id IN (`+ownedResumes+`)
+		RETURNING
+		*
+	`, pgx.NamedArgs{
+		"id":      skillID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute promote skill query for skill_id=%s user_id=%s: %w", skillID.String(), userID, err)
+	}
+
+	libraryItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[skill.LibrarySkill])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:library_skills for skill_id=%s user_id=%s: %w", skillID.String(), userID, err)
+	}
+
+	_, err = tx.Exec(ctx, `
+		UPDATE skills
+		SET
+			library_item_id = @library_item_id,
+			name = NULL,
+			level = NULL,
+			category = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+	`, pgx.NamedArgs{
+		"id":              skillID,
+		"library_item_id": libraryItem.ID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to link skill to library item for skill_id=%s: %w", skillID.String(), err)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("failed to commit transaction: %w", err)
+	}
+
+	return &libraryItem, nil
+}
+
+// ResetSkillOverrides clears every per-resume override on a linked skill row so
+// it shows the library item's content again.
+func (r *SkillRepository) ResetSkillOverrides(ctx context.Context, userID string, skillID uuid.UUID, expectedUpdatedAt time.Time) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE skills
+		SET
+			name = NULL,
+			level = NULL,
+			category = NULL,
+			overridden_fields = '{}'
+		WHERE id = @id
+		AND library_item_id IS NOT NULL
+		AND `+resolvedUnchanged("skills", "library_skills")+`
+		AND resume_id IN (`+editableResumes+`)
+	`, pgx.NamedArgs{
+		"id":                  skillID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to reset skill overrides: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("linked skill not found: %w", pgx.ErrNoRows)
+	}
+
+	return nil
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/google/uuid"
 	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
 	"github.com/recreatedev/Resumify/internal/model/skill"
 	"github.com/recreatedev/Resumify/internal/server"
 )
@@ -66,11 +68,10 @@ func (r *SkillRepository) GetSkillByID(ctx context.Context, userID string, skill
 		SELECT
 			s.*
 		FROM
-			skills s
-		JOIN resumes r ON s.resume_id = r.id
+			skills_resolved s
 		WHERE
 			s.id=@id
-			AND r.user_id=@user_id
+			AND s.resume_id IN (` + viewableResumes + `)
 	`
 
 	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
@@ -94,11 +95,10 @@ func (r *SkillRepository) GetSkillsByResumeID(ctx context.Context, userID string
 		SELECT
 			s.*
 		FROM
-			skills s
-		JOIN resumes r ON s.resume_id = r.id
+			skills_resolved s
 		WHERE
 			s.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND s.resume_id IN (` + viewableResumes + `)
 		ORDER BY s.order_index ASC
 	`
 
@@ -126,11 +126,10 @@ func (r *SkillRepository) GetSkillsByCategory(ctx context.Context, userID string
 		SELECT
 			s.*
 		FROM
-			skills s
-		JOIN resumes r ON s.resume_id = r.id
+			skills_resolved s
 		WHERE
 			s.resume_id=@resume_id
-			AND r.user_id=@user_id
+			AND s.resume_id IN (` + viewableResumes + `)
 		ORDER BY s.category ASC, s.order_index ASC
 	`
 
@@ -160,12 +159,17 @@ func (r *SkillRepository) GetSkillsByCategory(ctx context.Context, userID string
 
 		// Convert Skill to SkillResponse
 		skillResponse := skill.SkillResponse{
-			ID:         skillItem.ID.String(),
-			ResumeID:   skillItem.ResumeID,
-			Name:       skillItem.Name,
-			Level:      skillItem.Level,
-			Category:   skillItem.Category,
-			OrderIndex: skillItem.OrderIndex,
+			ID:               skillItem.ID.String(),
+			ResumeID:         skillItem.ResumeID,
+			LibraryItemID:    skillItem.LibraryItemID,
+			OverriddenFields: skillItem.OverriddenFields,
+			Name:             skillItem.Name,
+			Level:            skillItem.Level,
+			Category:         skillItem.Category,
+			OrderIndex:       skillItem.OrderIndex,
+			CreatedAt:        skillItem.CreatedAt.Format(time.RFC3339),
+			UpdatedAt:        skillItem.UpdatedAt.Format(time.RFC3339),
+			ETag:             etag.Format(skillItem.UpdatedAt),
 		}
 
 		categoryMap[category] = append(categoryMap[category], skillResponse)
@@ -183,36 +187,49 @@ func (r *SkillRepository) GetSkillsByCategory(ctx context.Context, userID string
 	return result, nil
 }
 
-func (r *SkillRepository) UpdateSkill(ctx context.Context, userID string, skillID uuid.UUID, payload *skill.UpdateSkillRequest) (*skill.Skill, error) {
+func (r *SkillRepository) UpdateSkill(ctx context.Context, userID string, skillID uuid.UUID, expectedUpdatedAt time.Time, payload *skill.UpdateSkillRequest) (*skill.Skill, error) {
 	stmt := `UPDATE skills SET `
 	args := pgx.NamedArgs{
-		"id": skillID,
+		"id":                  skillID,
+		"expected_updated_at": expectedUpdatedAt,
 	}
 	setClauses := []string{}
+	overridden := []string{}
 
 	if payload.Name != nil {
 		setClauses = append(setClauses, "name = @name")
 		args["name"] = *payload.Name
+		overridden = append(overridden, "name")
 	}
 	if payload.Level != nil {
 		setClauses = append(setClauses, "level = @level")
 		args["level"] = *payload.Level
+		overridden = append(overridden, "level")
 	}
 	if payload.Category != nil {
 		setClauses = append(setClauses, "category = @category")
 		args["category"] = *payload.Category
+		overridden = append(overridden, "category")
 	}
 	if payload.OrderIndex != nil {
 		setClauses = append(setClauses, "order_index = @order_index")
 		args["order_index"] = *payload.OrderIndex
 	}
 
+	for _, column := range clearedColumns("skills", payload.Clear) {
+		setClauses = append(setClauses, column+" = NULL")
+		overridden = append(overridden, column)
+	}
+	if len(overridden) > 0 {
+		setClauses = append(setClauses, overrideClause(args, overridden))
+	}
+
 	if len(setClauses) == 0 {
 		return nil, fmt.Errorf("no fields to update")
 	}
 
 	stmt += strings.Join(setClauses, ", ")
-	stmt += ` WHERE id = @id AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id) RETURNING *`
+	stmt += ` WHERE id = @id AND ` + resolvedUnchanged("skills", "library_skills") + ` AND resume_id IN (` + editableResumes + `) RETURNING *`
 
 	args["user_id"] = userID
 
@@ -226,11 +243,16 @@ func (r *SkillRepository) UpdateSkill(ctx context.Context, userID string, skillI
 		return nil, fmt.Errorf("failed to collect row from table:skills for skill_id=%s user_id=%s: %w", skillID.String(), userID, err)
 	}
 
+	// Linked rows only store their overrides, so read back the resolved values
+	if skillItem.LibraryItemID != nil {
+		return r.GetSkillByID(ctx, userID, skillID)
+	}
+
 	return &skillItem, nil
 }
 
 func (r *SkillRepository) BulkUpdateSkillOrder(ctx context.Context, userID string, payload *skill.BulkUpdateSkillsRequest) error {
-	tx, err := r.server.DB.Pool.Begin(ctx)
+	tx, err := r.server.DB.Conn(ctx).Begin(ctx)
 	if err != nil {
 		return fmt.Errorf("failed to begin transaction: %w", err)
 	}
@@ -241,7 +263,7 @@ func (r *SkillRepository) BulkUpdateSkillOrder(ctx context.Context, userID strin
 			UPDATE skills 
 			SET order_index = @order_index
 			WHERE id = @id 
-			AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+			AND resume_id IN (`+editableResumes+`)
 		`, pgx.NamedArgs{
 			"id":          skillUpdate.ID,
 			"order_index": skillUpdate.OrderIndex,
@@ -255,21 +277,23 @@ func (r *SkillRepository) BulkUpdateSkillOrder(ctx context.Context, userID strin
 	return tx.Commit(ctx)
 }
 
-func (r *SkillRepository) DeleteSkill(ctx context.Context, userID string, skillID uuid.UUID) error {
+func (r *SkillRepository) DeleteSkill(ctx context.Context, userID string, skillID uuid.UUID, expectedUpdatedAt time.Time) error {
 	result, err := r.server.DB.Pool.Exec(ctx, `
 		DELETE FROM skills
-		WHERE id = @id 
-		AND resume_id IN (SELECT id FROM resumes WHERE user_id = @user_id)
+		WHERE id = @id
+		AND `+resolvedUnchanged("skills", "library_skills")+`
+		AND resume_id IN (`+editableResumes+`)
 	`, pgx.NamedArgs{
-		"id":      skillID,
-		"user_id": userID,
+		"id":                  skillID,
+		"user_id":             userID,
+		"expected_updated_at": expectedUpdatedAt,
 	})
 	if err != nil {
 		return fmt.Errorf("failed to delete skill: %w", err)
 	}
 
 	if result.RowsAffected() == 0 {
-		return fmt.Errorf("skill not found")
+		return fmt.Errorf("skill not found: %w", pgx.ErrNoRows)
 	}
 
 	return nil
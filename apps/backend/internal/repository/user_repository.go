@@ -0,0 +1,96 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/user"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type UserRepository struct {
+	server *server.Server
+}
+
+func NewUserRepository(s *server.Server) *UserRepository {
+	return &UserRepository{server: s}
+}
+
+// UpsertUser stores the profile of a Clerk user, replacing the cached copy
+func (r *UserRepository) UpsertUser(ctx context.Context, profile *user.ClerkUserData) (*user.User, error) {
+	stmt := `
+		INSERT INTO
+			users (
+				id,
+				email,
+				verified_emails,
+				first_name,
+				last_name,
+				image_url
+			)
+		VALUES
+			(
+				@id,
+				@email,
+				@verified_emails,
+				@first_name,
+				@last_name,
+				@image_url
+			)
+		ON CONFLICT (id) DO UPDATE
+		SET
+			email = EXCLUDED.email,
+			verified_emails = EXCLUDED.verified_emails,
+			first_name = EXCLUDED.first_name,
+			last_name = EXCLUDED.last_name,
+			image_url = EXCLUDED.image_url
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":         profile.ID,
+		"email":           profile.PrimaryEmail(),
+		"verified_emails": profile.VerifiedEmails(),
+		"first_name":      profile.FirstName,
+		"last_name":       profile.LastName,
+		"image_url":       profile.ImageURL,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute upsert user query for user_id=%s: %w", profile.ID, err)
+	}
+
+	userItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[user.User])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:users for user_id=%s: %w", profile.ID, err)
+	}
+
+	return &userItem, nil
+}
+
+// GetUserByID returns the cached profile of a Clerk user
+func (r *UserRepository) GetUserByID(ctx context.Context, userID string) (*user.User, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			users
+		WHERE
+			id = @id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get user by id query for user_id=%s: %w", userID, err)
+	}
+
+	userItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[user.User])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:users for user_id=%s: %w", userID, err)
+	}
+
+	return &userItem, nil
+}
@@ -0,0 +1,442 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/resume"
+	"github.com/recreatedev/Resumify/internal/model/webhook"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type WebhookRepository struct {
+	server *server.Server
+}
+
+func NewWebhookRepository(s *server.Server) *WebhookRepository {
+	return &WebhookRepository{server: s}
+}
+
+// CreateEndpoint registers a webhook endpoint for the user
+func (r *WebhookRepository) CreateEndpoint(ctx context.Context, userID string, secret string, payload *webhook.CreateEndpointRequest) (*webhook.Endpoint, error) {
+	stmt := `
+		INSERT INTO
+			webhook_endpoints (
+				user_id,
+				url,
+				description,
+				secret,
+				event_types
+			)
+		VALUES
+			(
+				@user_id,
+				@url,
+				@description,
+				@secret,
+				@event_types
+			)
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":     userID,
+		"url":         payload.URL,
+		"description": payload.Description,
+		"secret":      secret,
+		"event_types": payload.EventTypes,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create webhook endpoint query for user_id=%s: %w", userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for user_id=%s: %w", userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+// GetEndpointByID returns one of the user's webhook endpoints
+func (r *WebhookRepository) GetEndpointByID(ctx context.Context, userID string, endpointID uuid.UUID) (*webhook.Endpoint, error) {
+	stmt := `
+		SELECT
+			e.*
+		FROM
+			webhook_endpoints e
+		WHERE
+			e.id=@id
+			AND e.user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":      endpointID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook endpoint by id query for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+// GetEndpointsByUserID lists the user's webhook endpoints
+func (r *WebhookRepository) GetEndpointsByUserID(ctx context.Context, userID string) ([]webhook.Endpoint, error) {
+	stmt := `
+		SELECT
+			e.*
+		FROM
+			webhook_endpoints e
+		WHERE
+			e.user_id=@user_id
+		ORDER BY e.created_at ASC
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id": userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook endpoints query for user_id=%s: %w", userID, err)
+	}
+
+	endpoints, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []webhook.Endpoint{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_endpoints for user_id=%s: %w", userID, err)
+	}
+
+	return endpoints, nil
+}
+
+// GetSubscribedEndpoints lists the active endpoints of the user that receive
+// events of the given type
+func (r *WebhookRepository) GetSubscribedEndpoints(ctx context.Context, userID string, eventType webhook.EventType) ([]webhook.Endpoint, error) {
+	stmt := `
+		SELECT
+			e.*
+		FROM
+			webhook_endpoints e
+		WHERE
+			e.user_id=@user_id
+			AND e.active
+			AND @event_type = ANY(e.event_types)
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"user_id":    userID,
+		"event_type": eventType,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get subscribed webhook endpoints query for user_id=%s: %w", userID, err)
+	}
+
+	endpoints, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return []webhook.Endpoint{}, nil
+		}
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_endpoints for user_id=%s: %w", userID, err)
+	}
+
+	return endpoints, nil
+}
+
+// UpdateEndpoint changes one of the user's webhook endpoints
+func (r *WebhookRepository) UpdateEndpoint(ctx context.Context, userID string, endpointID uuid.UUID, payload *webhook.UpdateEndpointRequest) (*webhook.Endpoint, error) {
+	stmt := `
+		UPDATE webhook_endpoints
+		SET
+			url = COALESCE(@url, url),
+			description = COALESCE(@description, description),
+			event_types = COALESCE(@event_types, event_types),
+			active = COALESCE(@active, active)
+		WHERE
+			id = @id
+			AND user_id = @user_id
+		RETURNING
+		*
+	`
+
+	args := pgx.NamedArgs{
+		"id":          endpointID,
+		"user_id":     userID,
+		"url":         payload.URL,
+		"description": payload.Description,
+		"event_types": nil,
+		"active":      payload.Active,
+	}
+	if payload.EventTypes != nil {
+		args["event_types"] = payload.EventTypes
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute update webhook endpoint query for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	endpoint, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Endpoint])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_endpoints for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	return &endpoint, nil
+}
+
+// DeleteEndpoint removes one of the user's webhook endpoints with its delivery log
+func (r *WebhookRepository) DeleteEndpoint(ctx context.Context, userID string, endpointID uuid.UUID) error {
+	result, err := r.server.DB.Pool.Exec(ctx, `
+		DELETE FROM webhook_endpoints
+		WHERE id = @id
+		AND user_id = @user_id
+	`, pgx.NamedArgs{
+		"id":      endpointID,
+		"user_id": userID,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	if result.RowsAffected() == 0 {
+		return fmt.Errorf("webhook endpoint not found")
+	}
+
+	return nil
+}
+
+// CreateDelivery records that an event is to be delivered to an endpoint. An
+// event is fanned out to an endpoint once, so fanning it out again returns the
+// existing delivery. Redeliveries always create a new one.
+func (r *WebhookRepository) CreateDelivery(ctx context.Context, endpointID, eventID uuid.UUID, eventType webhook.EventType, payload []byte, redeliveryOf *uuid.UUID) (*webhook.Delivery, error) {
+	stmt := `
+		INSERT INTO
+			webhook_deliveries (
+				endpoint_id,
+				event_id,
+				event_type,
+				payload,
+				redelivery_of
+			)
+		VALUES
+			(
+				@endpoint_id,
+				@event_id,
+				@event_type,
+				@payload,
+				@redelivery_of
+			)
+		ON CONFLICT (endpoint_id, event_id) WHERE redelivery_of IS NULL
+		DO UPDATE SET event_type = EXCLUDED.event_type
+		RETURNING
+		*
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"endpoint_id":   endpointID,
+		"event_id":      eventID,
+		"event_type":    eventType,
+		"payload":       payload,
+		"redelivery_of": redeliveryOf,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute create webhook delivery query for endpoint_id=%s event_id=%s: %w", endpointID.String(), eventID.String(), err)
+	}
+
+	delivery, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_deliveries for endpoint_id=%s event_id=%s: %w", endpointID.String(), eventID.String(), err)
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveryByID returns a delivery of one of the user's endpoints
+func (r *WebhookRepository) GetDeliveryByID(ctx context.Context, userID string, endpointID, deliveryID uuid.UUID) (*webhook.Delivery, error) {
+	stmt := `
+		SELECT
+			d.*
+		FROM
+			webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE
+			d.id=@id
+			AND d.endpoint_id=@endpoint_id
+			AND e.user_id=@user_id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id":          deliveryID,
+		"endpoint_id": endpointID,
+		"user_id":     userID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook delivery by id query for delivery_id=%s user_id=%s: %w", deliveryID.String(), userID, err)
+	}
+
+	delivery, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_deliveries for delivery_id=%s user_id=%s: %w", deliveryID.String(), userID, err)
+	}
+
+	return &delivery, nil
+}
+
+// GetDeliveryTarget returns a delivery with the endpoint it goes to. It is
+// used by the delivery job and is not scoped to a user.
+func (r *WebhookRepository) GetDeliveryTarget(ctx context.Context, deliveryID uuid.UUID) (*webhook.DeliveryTarget, error) {
+	stmt := `
+		SELECT
+			d.*,
+			e.url,
+			e.secret,
+			e.active
+		FROM
+			webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE
+			d.id=@id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": deliveryID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook delivery target query for delivery_id=%s: %w", deliveryID.String(), err)
+	}
+
+	target, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[webhook.DeliveryTarget])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:webhook_deliveries for delivery_id=%s: %w", deliveryID.String(), err)
+	}
+
+	return &target, nil
+}
+
+// GetDeliveriesByEndpoint returns the delivery log of one of the user's
+// endpoints, newest first
+func (r *WebhookRepository) GetDeliveriesByEndpoint(ctx context.Context, userID string, endpointID uuid.UUID, page, limit int) (*model.PaginatedResponse[webhook.Delivery], error) {
+	stmt := `
+		SELECT
+			d.*
+		FROM
+			webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE
+			d.endpoint_id=@endpoint_id
+			AND e.user_id=@user_id
+		ORDER BY d.created_at DESC, d.id DESC
+		LIMIT @limit OFFSET @offset
+	`
+
+	args := pgx.NamedArgs{
+		"endpoint_id": endpointID,
+		"user_id":     userID,
+		"limit":       limit,
+		"offset":      (page - 1) * limit,
+	}
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, args)
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get webhook deliveries query for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	deliveries, err := pgx.CollectRows(rows, pgx.RowToStructByName[webhook.Delivery])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect rows from table:webhook_deliveries for endpoint_id=%s user_id=%s: %w", endpointID.String(), userID, err)
+	}
+
+	countStmt := `
+		SELECT
+			COUNT(*)
+		FROM
+			webhook_deliveries d
+		JOIN webhook_endpoints e ON e.id = d.endpoint_id
+		WHERE
+			d.endpoint_id=@endpoint_id
+			AND e.user_id=@user_id
+	`
+
+	var total int
+	err = r.server.DB.Pool.QueryRow(ctx, countStmt, pgx.NamedArgs{
+		"endpoint_id": endpointID,
+		"user_id":     userID,
+	}).Scan(&total)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get total count of webhook deliveries for endpoint_id=%s: %w", endpointID.String(), err)
+	}
+
+	return &model.PaginatedResponse[webhook.Delivery]{
+		Data:       deliveries,
+		Page:       page,
+		Limit:      limit,
+		Total:      total,
+		TotalPages: (total + limit - 1) / limit,
+	}, nil
+}
+
+// RecordAttempt stores the outcome of a delivery attempt
+func (r *WebhookRepository) RecordAttempt(ctx context.Context, deliveryID uuid.UUID, attempt *webhook.Attempt) error {
+	_, err := r.server.DB.Pool.Exec(ctx, `
+		UPDATE webhook_deliveries
+		SET
+			status = @status,
+			attempts = attempts + 1,
+			response_status = @response_status,
+			response_body = @response_body,
+			error = @error,
+			last_attempt_at = NOW(),
+			delivered_at = CASE WHEN @status = 'succeeded' THEN NOW() ELSE delivered_at END
+		WHERE
+			id = @id
+	`, pgx.NamedArgs{
+		"id":              deliveryID,
+		"status":          attempt.Status,
+		"response_status": attempt.ResponseStatus,
+		"response_body":   attempt.ResponseBody,
+		"error":           attempt.Error,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to record webhook delivery attempt for delivery_id=%s: %w", deliveryID.String(), err)
+	}
+
+	return nil
+}
+
+// GetEventResume returns the resume a domain event is about, including resumes
+// in the trash. It is used when fanning events out and is not scoped to a user.
+func (r *WebhookRepository) GetEventResume(ctx context.Context, resumeID uuid.UUID) (*resume.Resume, error) {
+	stmt := `
+		SELECT
+			*
+		FROM
+			resumes
+		WHERE
+			id=@id
+	`
+
+	rows, err := r.server.DB.Pool.Query(ctx, stmt, pgx.NamedArgs{
+		"id": resumeID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to execute get event resume query for resume_id=%s: %w", resumeID.String(), err)
+	}
+
+	resumeItem, err := pgx.CollectOneRow(rows, pgx.RowToStructByName[resume.Resume])
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect row from table:resumes for resume_id=%s: %w", resumeID.String(), err)
+	}
+
+	return &resumeItem, nil
+}
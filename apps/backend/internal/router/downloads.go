@@ -0,0 +1,16 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/handler"
+)
+
+func registerDownloadRoutes(r *echo.Echo, h *handler.Handlers) {
+	downloads := r.Group("/downloads")
+
+	// Data exports, linked from the email sent when they are ready
+	downloads.GET("/data-exports/:id", h.DataExport.DownloadExport)
+
+	// Blobs of the local storage backend, linked by storage.SignedURL
+	downloads.GET("/storage/*", h.Storage.DownloadBlob)
+}
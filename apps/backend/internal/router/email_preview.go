@@ -0,0 +1,14 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/handler"
+)
+
+func registerEmailPreviewRoutes(r *echo.Echo, h *handler.Handlers) {
+	emails := r.Group("/dev/emails")
+
+	emails.GET("", h.EmailPreview.ListTemplates)
+	emails.GET("/:template", h.EmailPreview.PreviewHTML)
+	emails.GET("/:template/text", h.EmailPreview.PreviewText)
+}
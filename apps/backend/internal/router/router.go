@@ -54,6 +54,20 @@ func NewRouter(s *server.Server, h *handler.Handlers, services *service.Services
 	// register system routes
 	registerSystemRoutes(router, h)
 
+	// register webhooks sent to the app, authenticated by their signatures
+	registerIncomingWebhookRoutes(router, h, middlewares.Auth)
+
+	// register downloads authorized by signed links
+	registerDownloadRoutes(router, h)
+
+	// register unsubscribe links, authorized by their signed tokens
+	registerUnsubscribeRoutes(router, h)
+
+	// register email template previews, only served locally
+	if s.Config.Primary.Env == "local" {
+		registerEmailPreviewRoutes(router, h)
+	}
+
 	// register versioned routes
 	v1.RegisterRoutes(router, h, s, services)
 
@@ -0,0 +1,12 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/handler"
+)
+
+func registerUnsubscribeRoutes(r *echo.Echo, h *handler.Handlers) {
+	// Unsubscribe links from email footers and List-Unsubscribe headers,
+	// authorized by their signed token
+	r.POST("/unsubscribe/:token", h.Notification.Unsubscribe)
+}
@@ -4,6 +4,7 @@ import (
 	"github.com/labstack/echo/v4"
 	"github.com/recreatedev/Resumify/internal/handler"
 	"github.com/recreatedev/Resumify/internal/middleware"
+	orgmodel "github.com/recreatedev/Resumify/internal/model/organization"
 	"github.com/recreatedev/Resumify/internal/server"
 	"github.com/recreatedev/Resumify/internal/service"
 )
@@ -11,9 +12,11 @@ import (
 func RegisterRoutes(e *echo.Echo, h *handler.Handlers, s *server.Server, services *service.Services) {
 	v1 := e.Group("/api/v1")
 
-	// Apply authentication middleware to all v1 routes
+	// Apply authentication middleware to all v1 routes, then mirror the
+	// active organization membership used to authorize organization resumes
 	authMiddleware := middleware.NewAuthMiddleware(s)
-	v1.Use(authMiddleware.RequireAuth)
+	organizationMiddleware := middleware.NewOrganizationMiddleware(s, services.Organization)
+	v1.Use(authMiddleware.RequireAuth, organizationMiddleware.SyncMembership)
 
 	// Resume routes
 	registerResumeRoutes(v1, h)
@@ -35,6 +38,30 @@ func RegisterRoutes(e *echo.Echo, h *handler.Handlers, s *server.Server, service
 
 	// Section routes
 	registerSectionRoutes(v1, h)
+
+	// Collaborator routes
+	registerCollaboratorRoutes(v1, h)
+
+	// Comment routes
+	registerCommentRoutes(v1, h)
+
+	// Organization workspace routes
+	registerOrganizationRoutes(v1, h, authMiddleware)
+
+	// Review workflow routes
+	registerReviewRoutes(v1, h)
+
+	// Audit log routes
+	registerAuditRoutes(v1, h)
+
+	// Outgoing webhook routes
+	registerWebhookRoutes(v1, h)
+
+	// Resume export routes
+	registerResumeExportRoutes(v1, h)
+
+	// Account data routes
+	registerAccountRoutes(v1, h)
 }
 
 func registerResumeRoutes(g *echo.Group, h *handler.Handlers) {
@@ -47,9 +74,18 @@ func registerResumeRoutes(g *echo.Group, h *handler.Handlers) {
 	resumes.PUT("/:id", h.Resume.UpdateResume)
 	resumes.DELETE("/:id", h.Resume.DeleteResume)
 
+	// Resumes shared with the user
+	resumes.GET("/shared", h.Resume.GetSharedResumes)
+
+	// Trash operations
+	resumes.GET("/trash", h.Resume.GetDeletedResumes)
+	resumes.POST("/:id/restore", h.Resume.RestoreResume)
+
 	// Resume operations
 	resumes.POST("/:id/duplicate", h.Resume.DuplicateResume)
 	resumes.GET("/:id/sections", h.Resume.GetResumeWithSections)
+	resumes.PUT("/:id/document", h.Resume.SaveResumeDocument)
+	resumes.GET("/:id/events", h.Resume.StreamResumeEvents)
 }
 
 func registerEducationRoutes(g *echo.Group, h *handler.Handlers) {
@@ -64,9 +100,22 @@ func registerEducationRoutes(g *echo.Group, h *handler.Handlers) {
 	// Education bulk operations
 	educations.PUT("/order", h.Education.BulkUpdateEducationOrder)
 
+	// Career library links
+	educations.POST("/:id/promote", h.Education.PromoteEducation)
+	educations.DELETE("/:id/overrides", h.Education.ResetEducationOverrides)
+
 	// Resume-specific education routes
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/educations", h.Education.GetEducationByResumeID)
+
+	// Career library education routes
+	library := g.Group("/library/educations")
+	library.POST("", h.Education.CreateLibraryEducation)
+	library.GET("", h.Education.GetLibraryEducationByUserID)
+	library.GET("/:id", h.Education.GetLibraryEducationByID)
+	library.PUT("/:id", h.Education.UpdateLibraryEducation)
+	library.DELETE("/:id", h.Education.DeleteLibraryEducation)
+	library.POST("/:id/link", h.Education.LinkLibraryEducation)
 }
 
 func registerExperienceRoutes(g *echo.Group, h *handler.Handlers) {
@@ -81,9 +130,22 @@ func registerExperienceRoutes(g *echo.Group, h *handler.Handlers) {
 	// Experience bulk operations
 	experiences.PUT("/order", h.Experience.BulkUpdateExperienceOrder)
 
+	// Career library links
+	experiences.POST("/:id/promote", h.Experience.PromoteExperience)
+	experiences.DELETE("/:id/overrides", h.Experience.ResetExperienceOverrides)
+
 	// Resume-specific experience routes
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/experiences", h.Experience.GetExperienceByResumeID)
+
+	// Career library experience routes
+	library := g.Group("/library/experiences")
+	library.POST("", h.Experience.CreateLibraryExperience)
+	library.GET("", h.Experience.GetLibraryExperienceByUserID)
+	library.GET("/:id", h.Experience.GetLibraryExperienceByID)
+	library.PUT("/:id", h.Experience.UpdateLibraryExperience)
+	library.DELETE("/:id", h.Experience.DeleteLibraryExperience)
+	library.POST("/:id/link", h.Experience.LinkLibraryExperience)
 }
 
 func registerProjectRoutes(g *echo.Group, h *handler.Handlers) {
@@ -98,9 +160,22 @@ func registerProjectRoutes(g *echo.Group, h *handler.Handlers) {
 	// Project bulk operations
 	projects.PUT("/order", h.Project.BulkUpdateProjectOrder)
 
+	// Career library links
+	projects.POST("/:id/promote", h.Project.PromoteProject)
+	projects.DELETE("/:id/overrides", h.Project.ResetProjectOverrides)
+
 	// Resume-specific project routes
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/projects", h.Project.GetProjectsByResumeID)
+
+	// Career library project routes
+	library := g.Group("/library/projects")
+	library.POST("", h.Project.CreateLibraryProject)
+	library.GET("", h.Project.GetLibraryProjectsByUserID)
+	library.GET("/:id", h.Project.GetLibraryProjectByID)
+	library.PUT("/:id", h.Project.UpdateLibraryProject)
+	library.DELETE("/:id", h.Project.DeleteLibraryProject)
+	library.POST("/:id/link", h.Project.LinkLibraryProject)
 }
 
 func registerSkillRoutes(g *echo.Group, h *handler.Handlers) {
@@ -115,10 +190,23 @@ func registerSkillRoutes(g *echo.Group, h *handler.Handlers) {
 	// Skill bulk operations
 	skills.PUT("/order", h.Skill.BulkUpdateSkillOrder)
 
+	// Career library links
+	skills.POST("/:id/promote", h.Skill.PromoteSkill)
+	skills.DELETE("/:id/overrides", h.Skill.ResetSkillOverrides)
+
 	// Resume-specific skill routes
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/skills", h.Skill.GetSkillsByResumeID)
 	resumes.GET("/:resumeId/skills/category", h.Skill.GetSkillsByCategory)
+
+	// Career library skill routes
+	library := g.Group("/library/skills")
+	library.POST("", h.Skill.CreateLibrarySkill)
+	library.GET("", h.Skill.GetLibrarySkillsByUserID)
+	library.GET("/:id", h.Skill.GetLibrarySkillByID)
+	library.PUT("/:id", h.Skill.UpdateLibrarySkill)
+	library.DELETE("/:id", h.Skill.DeleteLibrarySkill)
+	library.POST("/:id/link", h.Skill.LinkLibrarySkill)
 }
 
 func registerCertificationRoutes(g *echo.Group, h *handler.Handlers) {
@@ -133,9 +221,22 @@ func registerCertificationRoutes(g *echo.Group, h *handler.Handlers) {
 	// Certification bulk operations
 	certifications.PUT("/order", h.Certification.BulkUpdateCertificationOrder)
 
+	// Career library links
+	certifications.POST("/:id/promote", h.Certification.PromoteCertification)
+	certifications.DELETE("/:id/overrides", h.Certification.ResetCertificationOverrides)
+
 	// Resume-specific certification routes
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/certifications", h.Certification.GetCertificationsByResumeID)
+
+	// Career library certification routes
+	library := g.Group("/library/certifications")
+	library.POST("", h.Certification.CreateLibraryCertification)
+	library.GET("", h.Certification.GetLibraryCertificationsByUserID)
+	library.GET("/:id", h.Certification.GetLibraryCertificationByID)
+	library.PUT("/:id", h.Certification.UpdateLibraryCertification)
+	library.DELETE("/:id", h.Certification.DeleteLibraryCertification)
+	library.POST("/:id/link", h.Certification.LinkLibraryCertification)
 }
 
 func registerSectionRoutes(g *echo.Group, h *handler.Handlers) {
@@ -154,3 +255,114 @@ func registerSectionRoutes(g *echo.Group, h *handler.Handlers) {
 	resumes := g.Group("/resumes")
 	resumes.GET("/:resumeId/sections", h.Section.GetSectionsByResumeID)
 }
+
+func registerCollaboratorRoutes(g *echo.Group, h *handler.Handlers) {
+	// Resume sharing, managed by the owner
+	resumes := g.Group("/resumes")
+	resumes.GET("/:id/collaborators", h.Collaborator.GetCollaborators)
+	resumes.POST("/:id/collaborators", h.Collaborator.InviteCollaborator)
+	resumes.PUT("/:id/collaborators/:collaboratorId", h.Collaborator.UpdateCollaboratorRole)
+	resumes.DELETE("/:id/collaborators/:collaboratorId", h.Collaborator.RevokeCollaborator)
+
+	// Invitation acceptance by the invitee
+	invitations := g.Group("/invitations")
+	invitations.POST("/:token/accept", h.Collaborator.AcceptInvitation)
+}
+
+func registerCommentRoutes(g *echo.Group, h *handler.Handlers) {
+	// Comment threads on a resume and on its entities
+	resumes := g.Group("/resumes")
+	resumes.GET("/:id/comments", h.Comment.GetCommentsByResumeID)
+	resumes.POST("/:id/comments", h.Comment.CreateComment)
+	resumes.GET("/:id/comments/:entityType/:entityId", h.Comment.GetCommentsByEntity)
+
+	comments := g.Group("/comments")
+	comments.PUT("/:id", h.Comment.UpdateComment)
+	comments.DELETE("/:id", h.Comment.DeleteComment)
+	comments.POST("/:id/resolve", h.Comment.ResolveComment)
+	comments.POST("/:id/unresolve", h.Comment.UnresolveComment)
+}
+
+func registerOrganizationRoutes(g *echo.Group, h *handler.Handlers, auth *middleware.AuthMiddleware) {
+	// Resumes of the active organization
+	organization := g.Group("/organization", auth.RequireOrganization)
+	organization.GET("/resumes", h.Resume.GetOrganizationResumes)
+	organization.POST("/resumes", h.Resume.CreateOrganizationResume, auth.RequirePermission(orgmodel.PermissionResumesCreate))
+
+	// Moving resumes between the personal workspace and the active organization
+	resumes := g.Group("/resumes")
+	resumes.PUT("/:id/organization", h.Resume.MoveResumeToOrganization, auth.RequirePermission(orgmodel.PermissionResumesCreate))
+	resumes.DELETE("/:id/organization", h.Resume.RemoveResumeFromOrganization)
+}
+
+func registerReviewRoutes(g *echo.Group, h *handler.Handlers) {
+	// Submitting a resume and its review history
+	resumes := g.Group("/resumes")
+	resumes.GET("/:id/reviews", h.Review.GetReviewsByResumeID)
+	resumes.POST("/:id/reviews", h.Review.SubmitReview)
+
+	reviews := g.Group("/reviews")
+
+	// Reviews assigned to the current user
+	reviews.GET("/queue", h.Review.GetReviewQueue)
+	reviews.GET("/:id", h.Review.GetReview)
+
+	// Reviewer transitions
+	reviews.POST("/:id/start", h.Review.StartReview)
+	reviews.POST("/:id/request-changes", h.Review.RequestChanges)
+	reviews.POST("/:id/approve", h.Review.ApproveReview)
+
+	// Submitter transitions
+	reviews.POST("/:id/resubmit", h.Review.ResubmitReview)
+	reviews.POST("/:id/withdraw", h.Review.WithdrawReview)
+}
+
+func registerAuditRoutes(g *echo.Group, h *handler.Handlers) {
+	// The current user's changes, or a resume's full history with ?resumeId=
+	g.GET("/audit", h.Audit.GetAuditLog)
+}
+
+func registerWebhookRoutes(g *echo.Group, h *handler.Handlers) {
+	webhooks := g.Group("/webhooks")
+
+	// Webhook endpoint CRUD operations
+	webhooks.POST("", h.Webhook.CreateEndpoint)
+	webhooks.GET("", h.Webhook.GetEndpoints)
+	webhooks.GET("/:id", h.Webhook.GetEndpoint)
+	webhooks.PUT("/:id", h.Webhook.UpdateEndpoint)
+	webhooks.DELETE("/:id", h.Webhook.DeleteEndpoint)
+
+	// Delivery log and manual redelivery
+	webhooks.GET("/:id/deliveries", h.Webhook.GetDeliveries)
+	webhooks.POST("/:id/deliveries/:deliveryId/redeliver", h.Webhook.RedeliverWebhook)
+}
+
+func registerResumeExportRoutes(g *echo.Group, h *handler.Handlers) {
+	// Rendering a resume to a download format in the background
+	resumes := g.Group("/resumes")
+	resumes.POST("/:id/exports", h.ResumeExport.RequestExport)
+
+	// Export progress and the rendered result
+	exports := g.Group("/exports")
+	exports.GET("/:id", h.ResumeExport.GetExport)
+	exports.GET("/:id/download", h.ResumeExport.DownloadExport)
+}
+
+func registerAccountRoutes(g *echo.Group, h *handler.Handlers) {
+	account := g.Group("/account")
+
+	// Exports of everything the user owns
+	account.POST("/data-exports", h.DataExport.RequestExport)
+	account.GET("/data-exports/:id", h.DataExport.GetExport)
+
+	// Erasure of everything the user owns, with its receipt
+	account.POST("/erasures", h.Erasure.RequestErasure)
+	account.GET("/erasures/:id", h.Erasure.GetErasure)
+
+	// Log of the emails sent to the user
+	account.GET("/email-deliveries", h.EmailDelivery.GetDeliveries)
+
+	// Optional emails the user wants
+	account.GET("/notification-preferences", h.Notification.GetPreferences)
+	account.PUT("/notification-preferences", h.Notification.UpdatePreferences)
+}
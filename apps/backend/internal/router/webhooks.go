@@ -0,0 +1,17 @@
+package router
+
+import (
+	"github.com/labstack/echo/v4"
+	"github.com/recreatedev/Resumify/internal/handler"
+	"github.com/recreatedev/Resumify/internal/middleware"
+)
+
+func registerIncomingWebhookRoutes(r *echo.Echo, h *handler.Handlers, auth *middleware.AuthMiddleware) {
+	webhooks := r.Group("/webhooks")
+
+	// Clerk user lifecycle events, signed through Svix
+	webhooks.POST("/clerk", h.ClerkWebhook.HandleEvent, auth.RequireClerkWebhook)
+
+	// Resend email delivery status, signed through Svix
+	webhooks.POST("/resend", h.EmailDelivery.HandleResendEvent, auth.RequireResendWebhook)
+}
@@ -8,11 +8,14 @@ import (
 	"time"
 
 	"github.com/newrelic/go-agent/v3/integrations/nrredis-v9"
+	"github.com/newrelic/go-agent/v3/newrelic"
 	"github.com/redis/go-redis/v9"
 	"github.com/rs/zerolog"
 	"github.com/recreatedev/Resumify/internal/config"
 	"github.com/recreatedev/Resumify/internal/database"
+	"github.com/recreatedev/Resumify/internal/lib/events"
 	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/lib/storage"
 	loggerPkg "github.com/recreatedev/Resumify/internal/logger"
 )
 
@@ -24,6 +27,8 @@ type Server struct {
 	Redis         *redis.Client
 	httpServer    *http.Server
 	Job           *job.JobService
+	Events        *events.Broker
+	Storage       storage.Storage
 }
 
 func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.LoggerService) (*Server, error) {
@@ -35,6 +40,9 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 	// Redis client with New Relic integration
 	redisClient := redis.NewClient(&redis.Options{
 		Addr: cfg.Redis.Address,
+		// Commands give up when their context is done, which bounds publishing
+		// resume events
+		ContextTimeoutEnabled: true,
 	})
 
 	// Add New Relic Redis hooks if available
@@ -51,14 +59,18 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 		// Don't fail startup if Redis is unavailable
 	}
 
-	// job service
-	jobService := job.NewJobService(logger, cfg)
-	jobService.InitHandlers(cfg, logger)
+	blobStorage, err := storage.New(&cfg.Storage, cfg.Auth.SecretKey, cfg.Server.PublicURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to initialize storage: %w", err)
+	}
 
-	// Start job server
-	if err := jobService.Start(); err != nil {
-		return nil, err
+	// job service; started by the caller once all task handlers are registered
+	var newRelicApp *newrelic.Application
+	if loggerService != nil {
+		newRelicApp = loggerService.GetApplication()
 	}
+	jobService := job.NewJobService(logger, cfg, newRelicApp)
+	jobService.InitHandlers(cfg, logger)
 
 	server := &Server{
 		Config:        cfg,
@@ -67,6 +79,8 @@ func New(cfg *config.Config, logger *zerolog.Logger, loggerService *loggerPkg.Lo
 		DB:            db,
 		Redis:         redisClient,
 		Job:           jobService,
+		Events:        events.NewBroker(redisClient, logger),
+		Storage:       blobStorage,
 	}
 
 	// Start metrics collection
@@ -98,18 +112,29 @@ func (s *Server) Start() error {
 	return s.httpServer.ListenAndServe()
 }
 
+// Shutdown stops the HTTP server, when there is one, then the job server,
+// which lets running tasks finish, and publishes the queued resume events
+// before the database is closed
 func (s *Server) Shutdown(ctx context.Context) error {
-	if err := s.httpServer.Shutdown(ctx); err != nil {
-		return fmt.Errorf("failed to shutdown HTTP server: %w", err)
-	}
-
-	if err := s.DB.Close(); err != nil {
-		return fmt.Errorf("failed to close database connection: %w", err)
+	if s.httpServer != nil {
+		if err := s.httpServer.Shutdown(ctx); err != nil {
+			return fmt.Errorf("failed to shutdown HTTP server: %w", err)
+		}
 	}
 
 	if s.Job != nil {
 		s.Job.Stop()
 	}
 
+	if s.Events != nil {
+		if err := s.Events.Close(ctx); err != nil {
+			return fmt.Errorf("failed to close event broker: %w", err)
+		}
+	}
+
+	if err := s.DB.Close(); err != nil {
+		return fmt.Errorf("failed to close database connection: %w", err)
+	}
+
 	return nil
 }
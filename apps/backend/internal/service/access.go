@@ -0,0 +1,32 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/repository"
+)
+
+// authorizeResume checks that the user holds at least the required role on an
+// active resume. Resumes the user cannot see at all are reported as not found
+// so their existence is not revealed.
+func authorizeResume(ctx context.Context, resumeRepo *repository.ResumeRepository, userID string, resumeID uuid.UUID, required collaborator.Role) (collaborator.Role, error) {
+	role, err := resumeRepo.GetResumeRole(ctx, userID, resumeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return "", errs.NewNotFoundError("resume not found", false, nil)
+		}
+		return "", fmt.Errorf("failed to get resume role: %w", err)
+	}
+
+	if !role.Includes(required) {
+		return "", errs.NewForbiddenError(fmt.Sprintf("this action requires the %s role on the resume", required), false)
+	}
+
+	return role, nil
+}
@@ -0,0 +1,161 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/lib/requestinfo"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// auditLog appends the changes made through a service to the audit log
+type auditLog struct {
+	server    *server.Server
+	auditRepo *repository.AuditRepository
+}
+
+func newAuditLog(s *server.Server, repos *repository.Repositories) auditLog {
+	return auditLog{
+		server:    s,
+		auditRepo: repos.Audit,
+	}
+}
+
+// Record appends a change to the audit log. Like event publishing it is best
+// effort: the change has already been committed, so failures are logged and
+// never returned.
+func (a auditLog) Record(ctx context.Context, record audit.Record) {
+	entry := &audit.Entry{
+		ActorID:    record.ActorID,
+		ResumeID:   record.ResumeID,
+		EntityType: record.EntityType,
+		EntityID:   record.EntityID,
+		Action:     record.Action,
+	}
+
+	var err error
+	if entry.Before, err = marshalAuditState(record.Before); err == nil {
+		entry.After, err = marshalAuditState(record.After)
+	}
+	if err != nil {
+		a.server.Logger.Error().
+			Err(err).
+			Str("entity_type", record.EntityType).
+			Str("action", record.Action).
+			Msg("failed to marshal audit entry")
+		return
+	}
+
+	info := requestinfo.FromContext(ctx)
+	if info.RequestID != "" {
+		entry.RequestID = &info.RequestID
+	}
+	if info.IPAddress != "" {
+		entry.IPAddress = &info.IPAddress
+	}
+
+	if err := a.auditRepo.CreateEntry(ctx, entry); err != nil {
+		a.server.Logger.Error().
+			Err(err).
+			Str("actor_id", record.ActorID).
+			Str("entity_type", record.EntityType).
+			Str("action", record.Action).
+			Msg("failed to record audit entry")
+	}
+}
+
+func marshalAuditState(state any) (json.RawMessage, error) {
+	if state == nil {
+		return nil, nil
+	}
+	return json.Marshal(state)
+}
+
+type AuditService struct {
+	server     *server.Server
+	auditRepo  *repository.AuditRepository
+	resumeRepo *repository.ResumeRepository
+}
+
+func NewAuditService(s *server.Server, repos *repository.Repositories) *AuditService {
+	return &AuditService{
+		server:     s,
+		auditRepo:  repos.Audit,
+		resumeRepo: repos.Resume,
+	}
+}
+
+// GetEntriesByActor lists the changes made by the user
+func (s *AuditService) GetEntriesByActor(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[audit.EntryResponse], error) {
+	page, limit = normalizeAuditPagination(page, limit)
+
+	result, err := s.auditRepo.GetEntriesByActor(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	return s.convertToEntriesResponse(result), nil
+}
+
+// GetEntriesByResume lists the changes made to a resume by anyone
+func (s *AuditService) GetEntriesByResume(ctx context.Context, userID string, resumeID uuid.UUID, page, limit int) (*model.PaginatedResponse[audit.EntryResponse], error) {
+	// Only the owner may read the full history of the resume
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	page, limit = normalizeAuditPagination(page, limit)
+
+	result, err := s.auditRepo.GetEntriesByResume(ctx, resumeID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get audit entries: %w", err)
+	}
+
+	return s.convertToEntriesResponse(result), nil
+}
+
+// Helper methods
+
+func normalizeAuditPagination(page, limit int) (int, int) {
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+	return page, limit
+}
+
+func (s *AuditService) convertToEntriesResponse(result *model.PaginatedResponse[audit.Entry]) *model.PaginatedResponse[audit.EntryResponse] {
+	responses := make([]audit.EntryResponse, len(result.Data))
+	for i, entry := range result.Data {
+		responses[i] = audit.EntryResponse{
+			ID:         entry.ID.String(),
+			ActorID:    entry.ActorID,
+			ResumeID:   entry.ResumeID,
+			EntityType: entry.EntityType,
+			EntityID:   entry.EntityID,
+			Action:     entry.Action,
+			Before:     entry.Before,
+			After:      entry.After,
+			RequestID:  entry.RequestID,
+			IPAddress:  entry.IPAddress,
+			CreatedAt:  entry.CreatedAt.Format(time.RFC3339),
+		}
+	}
+
+	return &model.PaginatedResponse[audit.EntryResponse]{
+		Data:       responses,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}
+}
@@ -2,12 +2,19 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/url"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
 	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
 )
@@ -16,6 +23,7 @@ type CertificationService struct {
 	server            *server.Server
 	certificationRepo *repository.CertificationRepository
 	resumeRepo        *repository.ResumeRepository
+	auditLog          auditLog
 }
 
 func NewCertificationService(s *server.Server, repos *repository.Repositories) *CertificationService {
@@ -23,54 +31,33 @@ func NewCertificationService(s *server.Server, repos *repository.Repositories) *
 		server:            s,
 		certificationRepo: repos.Certification,
 		resumeRepo:        repos.Resume,
+		auditLog:          newAuditLog(s, repos),
 	}
 }
 
 // CreateCertification creates a new certification entry
 func (s *CertificationService) CreateCertification(ctx context.Context, userID string, payload *certification.CreateCertificationRequest) (*certification.CertificationResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
-	}
-
-	// Business logic: Validate date ranges
-	if payload.IssueDate != nil && payload.ExpiryDate != nil {
-		if payload.IssueDate.After(*payload.ExpiryDate) {
-			return nil, errs.NewBadRequestError(
-				"issue date cannot be after expiry date",
-				false, nil, nil, nil,
-			)
-		}
-	}
-
-	// Business logic: Validate credential URL if provided
-	if payload.CredentialURL != nil && *payload.CredentialURL != "" {
-		if _, err := url.Parse(*payload.CredentialURL); err != nil {
-			return nil, errs.NewBadRequestError(
-				"invalid credential URL",
-				false, nil, nil, nil,
-			)
-		}
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate certification entries
+	// Business logic: Check the certification against the resume's other certifications
 	existingCertifications, err := s.certificationRepo.GetCertificationsByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing certifications: %w", err)
 	}
 
-	// Check for duplicates based on name and organization
-	for _, existing := range existingCertifications {
-		if *existing.Name == *payload.Name && *existing.Organization == *payload.Organization {
-			return nil, errs.NewBadRequestError(
-				"certification with same name and organization already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := certification.Certification{
+		Name:          payload.Name,
+		Organization:  payload.Organization,
+		IssueDate:     payload.IssueDate,
+		ExpiryDate:    payload.ExpiryDate,
+		CredentialURL: payload.CredentialURL,
+	}
+	if violation := checkCertification(entry, existingCertifications); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -86,6 +73,15 @@ func (s *CertificationService) CreateCertification(ctx context.Context, userID s
 
 	// Convert to response DTO
 	response := s.convertToCertificationResponse(certificationItem)
+	s.server.Events.Publish(ctx, certificationItem.ResumeID, events.EntityCertification, events.ActionCreated, &certificationItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &certificationItem.ResumeID,
+		EntityType: events.EntityCertification,
+		EntityID:   &certificationItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -129,7 +125,7 @@ func (s *CertificationService) GetCertificationsByResumeID(ctx context.Context,
 }
 
 // UpdateCertification updates a certification entry
-func (s *CertificationService) UpdateCertification(ctx context.Context, userID string, certificationID uuid.UUID, payload *certification.UpdateCertificationRequest) (*certification.CertificationResponse, error) {
+func (s *CertificationService) UpdateCertification(ctx context.Context, userID string, certificationID uuid.UUID, ifMatch string, payload *certification.UpdateCertificationRequest) (*certification.CertificationResponse, error) {
 	// Check if certification exists and belongs to user
 	existingCertification, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
 	if err != nil {
@@ -139,93 +135,76 @@ func (s *CertificationService) UpdateCertification(ctx context.Context, userID s
 		return nil, fmt.Errorf("failed to get existing certification: %w", err)
 	}
 
-	// Business logic: Validate date ranges
-	if payload.IssueDate != nil && payload.ExpiryDate != nil {
-		if payload.IssueDate.After(*payload.ExpiryDate) {
-			return nil, errs.NewBadRequestError(
-				"issue date cannot be after expiry date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.IssueDate != nil && existingCertification.ExpiryDate != nil {
-		if payload.IssueDate.After(*existingCertification.ExpiryDate) {
-			return nil, errs.NewBadRequestError(
-				"issue date cannot be after expiry date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.ExpiryDate != nil && existingCertification.IssueDate != nil {
-		if existingCertification.IssueDate.After(*payload.ExpiryDate) {
-			return nil, errs.NewBadRequestError(
-				"issue date cannot be after expiry date",
-				false, nil, nil, nil,
-			)
-		}
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingCertification.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
 	}
 
-	// Business logic: Validate credential URL if provided
-	if payload.CredentialURL != nil && *payload.CredentialURL != "" {
-		if _, err := url.Parse(*payload.CredentialURL); err != nil {
-			return nil, errs.NewBadRequestError(
-				"invalid credential URL",
-				false, nil, nil, nil,
-			)
-		}
+	// Reject writes based on a stale copy of the certification
+	if err := etag.Check(ifMatch, existingCertification.UpdatedAt); err != nil {
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate certification names (excluding current certification)
-	if (payload.Name != nil && *payload.Name != *existingCertification.Name) ||
-		(payload.Organization != nil && *payload.Organization != *existingCertification.Organization) {
-
-		certifications, err := s.certificationRepo.GetCertificationsByResumeID(ctx, userID, existingCertification.ResumeID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check existing certifications: %w", err)
-		}
-
-		name := *existingCertification.Name
-		organization := *existingCertification.Organization
-
-		if payload.Name != nil {
-			name = *payload.Name
-		}
-		if payload.Organization != nil {
-			organization = *payload.Organization
-		}
+	// Business logic: Check the updated certification against the resume's other certifications
+	certifications, err := s.certificationRepo.GetCertificationsByResumeID(ctx, userID, existingCertification.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing certifications: %w", err)
+	}
 
-		for _, cert := range certifications {
-			if cert.ID != certificationID && *cert.Name == name && *cert.Organization == organization {
-				return nil, errs.NewBadRequestError(
-					"certification with same name and organization already exists",
-					false, nil, nil, nil,
-				)
-			}
-		}
+	others := slices.DeleteFunc(certifications, func(c certification.Certification) bool { return c.ID == certificationID })
+	if violation := checkCertification(applyCertificationUpdate(*existingCertification, payload), others); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Update certification in repository
-	updatedCertification, err := s.certificationRepo.UpdateCertification(ctx, userID, certificationID, payload)
+	updatedCertification, err := s.certificationRepo.UpdateCertification(ctx, userID, certificationID, existingCertification.UpdatedAt, payload)
 	if err != nil {
+		// The certification changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update certification: %w", err)
 	}
 
-	return s.convertToCertificationResponse(updatedCertification), nil
+	response := s.convertToCertificationResponse(updatedCertification)
+	s.server.Events.Publish(ctx, updatedCertification.ResumeID, events.EntityCertification, events.ActionUpdated, &updatedCertification.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedCertification.ResumeID,
+		EntityType: events.EntityCertification,
+		EntityID:   &updatedCertification.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToCertificationResponse(existingCertification),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateCertificationOrder updates the order of multiple certification entries
 func (s *CertificationService) BulkUpdateCertificationOrder(ctx context.Context, userID string, payload *certification.BulkUpdateCertificationsRequest) error {
 	// Validate that all certification entries belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, certUpdate := range payload.Certifications {
 		certificationID, err := uuid.Parse(certUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid certification ID", false, nil, nil, nil)
 		}
-		_, err = s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
+		existingCertification, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:certifications" {
 				return errs.NewNotFoundError("certification not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify certification ownership: %w", err)
 		}
+		resumeIDs[existingCertification.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -234,13 +213,24 @@ func (s *CertificationService) BulkUpdateCertificationOrder(ctx context.Context,
 		return fmt.Errorf("failed to update certification order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntityCertification, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntityCertification,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteCertification deletes a certification entry
-func (s *CertificationService) DeleteCertification(ctx context.Context, userID string, certificationID uuid.UUID) error {
+func (s *CertificationService) DeleteCertification(ctx context.Context, userID string, certificationID uuid.UUID, ifMatch string) error {
 	// Check if certification exists and belongs to user
-	_, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
+	existingCertification, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:certifications" {
 			return errs.NewNotFoundError("certification not found", false, nil)
@@ -248,12 +238,36 @@ func (s *CertificationService) DeleteCertification(ctx context.Context, userID s
 		return fmt.Errorf("failed to get existing certification: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingCertification.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the certification
+	if err := etag.Check(ifMatch, existingCertification.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete certification
-	err = s.certificationRepo.DeleteCertification(ctx, userID, certificationID)
+	err = s.certificationRepo.DeleteCertification(ctx, userID, certificationID, existingCertification.UpdatedAt)
 	if err != nil {
+		// The certification changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete certification: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingCertification.ResumeID, events.EntityCertification, events.ActionDeleted, &certificationID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingCertification.ResumeID,
+		EntityType: events.EntityCertification,
+		EntityID:   &certificationID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToCertificationResponse(existingCertification),
+	})
+
 	return nil
 }
 
@@ -261,13 +275,18 @@ func (s *CertificationService) DeleteCertification(ctx context.Context, userID s
 
 func (s *CertificationService) convertToCertificationResponse(certificationItem *certification.Certification) *certification.CertificationResponse {
 	response := &certification.CertificationResponse{
-		ID:            certificationItem.ID.String(),
-		ResumeID:      certificationItem.ResumeID,
-		Name:          certificationItem.Name,
-		Organization:  certificationItem.Organization,
-		CredentialID:  certificationItem.CredentialID,
-		CredentialURL: certificationItem.CredentialURL,
-		OrderIndex:    certificationItem.OrderIndex,
+		ID:               certificationItem.ID.String(),
+		ResumeID:         certificationItem.ResumeID,
+		LibraryItemID:    certificationItem.LibraryItemID,
+		OverriddenFields: certificationItem.OverriddenFields,
+		Name:             certificationItem.Name,
+		Organization:     certificationItem.Organization,
+		CredentialID:     certificationItem.CredentialID,
+		CredentialURL:    certificationItem.CredentialURL,
+		OrderIndex:       certificationItem.OrderIndex,
+		CreatedAt:        certificationItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        certificationItem.UpdatedAt.Format(time.RFC3339),
+		ETag:             etag.Format(certificationItem.UpdatedAt),
 	}
 
 	// Handle optional date fields
@@ -277,6 +296,7 @@ func (s *CertificationService) convertToCertificationResponse(certificationItem
 	if certificationItem.ExpiryDate != nil {
 		response.ExpiryDate = certificationItem.ExpiryDate
 	}
+	response.Status = certification.ComputeStatus(certificationItem.ExpiryDate, time.Now(), s.server.Config.CertReminder.ExpiringWithinDays())
 
 	return response
 }
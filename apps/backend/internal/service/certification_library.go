@@ -0,0 +1,354 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+)
+
+// CreateLibraryCertification adds a certification entry to the user's career library
+func (s *CertificationService) CreateLibraryCertification(ctx context.Context, userID string, payload *certification.CreateLibraryCertificationRequest) (*certification.LibraryCertificationResponse, error) {
+	// Business logic: Validate date ranges
+	if payload.IssueDate != nil && payload.ExpiryDate != nil {
+		if payload.IssueDate.After(*payload.ExpiryDate) {
+			return nil, errs.NewBadRequestError(
+				"issue date cannot be after expiry date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	// Business logic: Validate URL if provided
+	if payload.CredentialURL != nil && *payload.CredentialURL != "" {
+		if _, err := url.Parse(*payload.CredentialURL); err != nil {
+			return nil, errs.NewBadRequestError(
+				"invalid credential URL",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	libraryItem, err := s.certificationRepo.CreateLibraryCertification(ctx, userID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library certification: %w", err)
+	}
+
+	response := s.convertToLibraryCertificationResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryCertification,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetLibraryCertificationByID retrieves a career library certification entry by ID
+func (s *CertificationService) GetLibraryCertificationByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*certification.LibraryCertificationResponse, error) {
+	libraryItem, err := s.certificationRepo.GetLibraryCertificationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_certifications" {
+			return nil, errs.NewNotFoundError("library certification not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library certification: %w", err)
+	}
+
+	return s.convertToLibraryCertificationResponse(libraryItem), nil
+}
+
+// GetLibraryCertificationsByUserID retrieves all certifications in the user's career library
+func (s *CertificationService) GetLibraryCertificationsByUserID(ctx context.Context, userID string) ([]certification.LibraryCertificationResponse, error) {
+	libraryItems, err := s.certificationRepo.GetLibraryCertificationsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library certifications: %w", err)
+	}
+
+	// Convert to response DTOs
+	responses := make([]certification.LibraryCertificationResponse, len(libraryItems))
+	for i, item := range libraryItems {
+		responses[i] = *s.convertToLibraryCertificationResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// UpdateLibraryCertification updates a career library certification entry; every resume linked to it
+// picks up the change for fields it has not overridden
+func (s *CertificationService) UpdateLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string, payload *certification.UpdateLibraryCertificationRequest) (*certification.LibraryCertificationResponse, error) {
+	// Check if library item exists and belongs to user
+	existingItem, err := s.certificationRepo.GetLibraryCertificationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_certifications" {
+			return nil, errs.NewNotFoundError("library certification not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing library certification: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library certification
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Validate date ranges
+	if payload.IssueDate != nil && payload.ExpiryDate != nil {
+		if payload.IssueDate.After(*payload.ExpiryDate) {
+			return nil, errs.NewBadRequestError(
+				"issue date cannot be after expiry date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.IssueDate != nil && existingItem.ExpiryDate != nil {
+		if payload.IssueDate.After(*existingItem.ExpiryDate) {
+			return nil, errs.NewBadRequestError(
+				"issue date cannot be after expiry date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.ExpiryDate != nil && existingItem.IssueDate != nil {
+		if existingItem.IssueDate.After(*payload.ExpiryDate) {
+			return nil, errs.NewBadRequestError(
+				"issue date cannot be after expiry date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	// Business logic: Validate URL if provided
+	if payload.CredentialURL != nil && *payload.CredentialURL != "" {
+		if _, err := url.Parse(*payload.CredentialURL); err != nil {
+			return nil, errs.NewBadRequestError(
+				"invalid credential URL",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	updatedItem, err := s.certificationRepo.UpdateLibraryCertification(ctx, userID, libraryItemID, existingItem.UpdatedAt, payload)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to update library certification: %w", err)
+	}
+
+	response := s.convertToLibraryCertificationResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryCertification,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToLibraryCertificationResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteLibraryCertification removes a career library certification entry. Linked resume entries keep
+// their current content and become standalone.
+func (s *CertificationService) DeleteLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string) error {
+	existingItem, err := s.certificationRepo.GetLibraryCertificationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_certifications" {
+			return errs.NewNotFoundError("library certification not found", false, nil)
+		}
+		return fmt.Errorf("failed to get existing library certification: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library certification
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.certificationRepo.DeleteLibraryCertification(ctx, userID, libraryItemID, existingItem.UpdatedAt)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
+		return fmt.Errorf("failed to delete library certification: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryCertification,
+		EntityID:   &libraryItemID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToLibraryCertificationResponse(existingItem),
+	})
+
+	return nil
+}
+
+// LinkLibraryCertification adds a career library certification entry to a resume
+func (s *CertificationService) LinkLibraryCertification(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *certification.LinkLibraryCertificationRequest) (*certification.CertificationResponse, error) {
+	// Only the owner may link library items, since a collaborator's library
+	// is not theirs to edit or delete
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify library item belongs to user
+	_, err = s.certificationRepo.GetLibraryCertificationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_certifications" {
+			return nil, errs.NewNotFoundError("library certification not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library certification: %w", err)
+	}
+
+	// Set default order index if not provided
+	if payload.OrderIndex == 0 {
+		existingItems, err := s.certificationRepo.GetCertificationsByResumeID(ctx, userID, payload.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing certifications: %w", err)
+		}
+		payload.OrderIndex = len(existingItems) + 1
+	}
+
+	linkedItem, err := s.certificationRepo.LinkLibraryCertification(ctx, userID, libraryItemID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library certification: %w", err)
+	}
+
+	response := s.convertToCertificationResponse(linkedItem)
+	s.server.Events.Publish(ctx, linkedItem.ResumeID, events.EntityCertification, events.ActionCreated, &linkedItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &linkedItem.ResumeID,
+		EntityType: events.EntityCertification,
+		EntityID:   &linkedItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// PromoteCertification moves a resume certification entry into the career library and links the
+// entry to it
+func (s *CertificationService) PromoteCertification(ctx context.Context, userID string, certificationID uuid.UUID) (*certification.LibraryCertificationResponse, error) {
+	existingItem, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:certifications" {
+			return nil, errs.NewNotFoundError("certification not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing certification: %w", err)
+	}
+
+	// Only the owner may promote: the entry would otherwise move into a
+	// collaborator's library, out of the owner's control
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Linked entries already live in the library
+	if existingItem.LibraryItemID != nil {
+		return nil, errs.NewBadRequestError(
+			"certification is already linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	libraryItem, err := s.certificationRepo.PromoteCertification(ctx, userID, certificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote certification: %w", err)
+	}
+
+	response := s.convertToLibraryCertificationResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryCertification,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// ResetCertificationOverrides drops the per-resume overrides of a linked certification entry
+func (s *CertificationService) ResetCertificationOverrides(ctx context.Context, userID string, certificationID uuid.UUID, ifMatch string) (*certification.CertificationResponse, error) {
+	existingItem, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:certifications" {
+			return nil, errs.NewNotFoundError("certification not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing certification: %w", err)
+	}
+
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the certification
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if existingItem.LibraryItemID == nil {
+		return nil, errs.NewBadRequestError(
+			"certification is not linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	err = s.certificationRepo.ResetCertificationOverrides(ctx, userID, certificationID, existingItem.UpdatedAt)
+	if err != nil {
+		// The certification changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to reset certification overrides: %w", err)
+	}
+
+	resetItem, err := s.certificationRepo.GetCertificationByID(ctx, userID, certificationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certification: %w", err)
+	}
+
+	response := s.convertToCertificationResponse(resetItem)
+	s.server.Events.Publish(ctx, resetItem.ResumeID, events.EntityCertification, events.ActionUpdated, &resetItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resetItem.ResumeID,
+		EntityType: events.EntityCertification,
+		EntityID:   &resetItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToCertificationResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+func (s *CertificationService) convertToLibraryCertificationResponse(libraryItem *certification.LibraryCertification) *certification.LibraryCertificationResponse {
+	return &certification.LibraryCertificationResponse{
+		ID:            libraryItem.ID.String(),
+		Name:          libraryItem.Name,
+		Organization:  libraryItem.Organization,
+		IssueDate:     libraryItem.IssueDate,
+		ExpiryDate:    libraryItem.ExpiryDate,
+		CredentialID:  libraryItem.CredentialID,
+		CredentialURL: libraryItem.CredentialURL,
+		CreatedAt:     libraryItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     libraryItem.UpdatedAt.Format(time.RFC3339),
+		ETag:          etag.Format(libraryItem.UpdatedAt),
+	}
+}
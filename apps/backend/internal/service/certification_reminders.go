@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/email"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+)
+
+// SendExpiryReminders emails every user whose certifications reach one of the
+// reminder windows, one email per user. Reminders are recorded in the same
+// transaction that queues the email, so each is sent once. It returns the
+// number of emails queued.
+func (s *CertificationService) SendExpiryReminders(ctx context.Context) (int, error) {
+	today := time.Now().UTC().Truncate(24 * time.Hour)
+
+	reminders, err := s.certificationRepo.GetDueExpiryReminders(ctx, s.server.Config.CertReminder.WindowDays, today)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get due expiry reminders: %w", err)
+	}
+
+	// Reminders are ordered by user
+	byUser := make(map[string][]certification.ExpiryReminder)
+	var userIDs []string
+	for _, reminder := range reminders {
+		if _, ok := byUser[reminder.UserID]; !ok {
+			userIDs = append(userIDs, reminder.UserID)
+		}
+		byUser[reminder.UserID] = append(byUser[reminder.UserID], reminder)
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		userReminders := byUser[userID]
+
+		err := s.server.DB.InTx(ctx, func(ctx context.Context) error {
+			if err := s.certificationRepo.RecordExpiryReminders(ctx, userReminders); err != nil {
+				return err
+			}
+
+			task, err := job.NewCertificationExpiryEmailTask(userID, s.expiringCertifications(userReminders, today))
+			if err != nil {
+				return fmt.Errorf("failed to create certification expiry email task: %w", err)
+			}
+
+			// Keyed by day, so a run repeated after a failed commit does not
+			// send the email twice
+			return s.server.Job.EnqueueOnce(ctx, userID+":"+today.Format(time.DateOnly)+":"+job.TaskCertificationExpiry, task)
+		})
+		if err != nil {
+			return sent, fmt.Errorf("failed to send expiry reminder for user_id=%s: %w", userID, err)
+		}
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (s *CertificationService) expiringCertifications(reminders []certification.ExpiryReminder, today time.Time) []email.ExpiringCertification {
+	frontendURL := strings.TrimRight(s.server.Config.Server.FrontendURL, "/")
+
+	certifications := make([]email.ExpiringCertification, len(reminders))
+	for i, reminder := range reminders {
+		name := "Untitled certification"
+		if reminder.Name != nil && *reminder.Name != "" {
+			name = *reminder.Name
+		}
+		organization := ""
+		if reminder.Organization != nil {
+			organization = *reminder.Organization
+		}
+
+		certifications[i] = email.ExpiringCertification{
+			Name:         name,
+			Organization: organization,
+			ResumeTitle:  reminder.ResumeTitle,
+			ResumeURL:    fmt.Sprintf("%s/resumes/%s", frontendURL, reminder.ResumeID.String()),
+			ExpiresOn:    reminder.ExpiryDate.Format("January 2, 2006"),
+			DaysLeft:     int(reminder.ExpiryDate.UTC().Truncate(24*time.Hour).Sub(today).Hours() / 24),
+		}
+	}
+
+	return certifications
+}
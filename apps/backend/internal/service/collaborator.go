@@ -0,0 +1,286 @@
+package service
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type CollaboratorService struct {
+	server           *server.Server
+	collaboratorRepo *repository.CollaboratorRepository
+	resumeRepo       *repository.ResumeRepository
+	userRepo         *repository.UserRepository
+	auditLog         auditLog
+}
+
+func NewCollaboratorService(s *server.Server, repos *repository.Repositories) *CollaboratorService {
+	return &CollaboratorService{
+		server:           s,
+		collaboratorRepo: repos.Collaborator,
+		resumeRepo:       repos.Resume,
+		userRepo:         repos.User,
+		auditLog:         newAuditLog(s, repos),
+	}
+}
+
+// InviteCollaborator invites someone by email to work on a resume and emails
+// them a single-use invitation link
+func (s *CollaboratorService) InviteCollaborator(ctx context.Context, userID string, resumeID uuid.UUID, payload *collaborator.InviteCollaboratorRequest) (*collaborator.CollaboratorResponse, error) {
+	// Only the owner may share the resume
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	resumeItem, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	// Business logic: Check for an existing invitation to the same email
+	existingCollaborators, err := s.collaboratorRepo.GetCollaboratorsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing collaborators: %w", err)
+	}
+
+	for _, existing := range existingCollaborators {
+		if strings.EqualFold(existing.Email, payload.Email) {
+			return nil, errs.NewBadRequestError(
+				"this email has already been invited to the resume",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	token, tokenHash, err := newInviteToken()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate invitation token: %w", err)
+	}
+
+	collaboratorItem, err := s.collaboratorRepo.CreateInvitation(ctx, userID, resumeID, tokenHash, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation: %w", err)
+	}
+
+	acceptURL := fmt.Sprintf("%s/invitations/%s", strings.TrimRight(s.server.Config.Server.FrontendURL, "/"), token)
+	task, err := job.NewCollaboratorInviteEmailTask(payload.Email, resumeItem.Title, string(payload.Role), acceptURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create invitation email task: %w", err)
+	}
+
+	// The invitation stands even if the email cannot be queued; the owner can
+	// revoke it and invite again
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil {
+		s.server.Logger.Error().
+			Err(err).
+			Str("resume_id", resumeID.String()).
+			Str("collaborator_id", collaboratorItem.ID.String()).
+			Msg("failed to enqueue collaborator invite email")
+	}
+
+	response := s.convertToCollaboratorResponse(collaboratorItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: audit.EntityCollaborator,
+		EntityID:   &collaboratorItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetCollaborators lists the collaborators and pending invitations of a resume
+func (s *CollaboratorService) GetCollaborators(ctx context.Context, userID string, resumeID uuid.UUID) ([]collaborator.CollaboratorResponse, error) {
+	// Only the owner may manage sharing
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	collaborators, err := s.collaboratorRepo.GetCollaboratorsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get collaborators: %w", err)
+	}
+
+	responses := make([]collaborator.CollaboratorResponse, len(collaborators))
+	for i, item := range collaborators {
+		responses[i] = *s.convertToCollaboratorResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// AcceptInvitation grants the invited role to the user redeeming the token,
+// who must have verified the invited email address
+func (s *CollaboratorService) AcceptInvitation(ctx context.Context, userID string, token string) (*collaborator.CollaboratorResponse, error) {
+	invitation, err := s.collaboratorRepo.GetInvitationByTokenHash(ctx, hashInviteToken(token))
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("invitation not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get invitation: %w", err)
+	}
+
+	// Business logic: Only a user who verified the invited address can redeem
+	// the invitation, so a forwarded or leaked link grants nothing
+	invitee, err := s.userRepo.GetUserByID(ctx, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to get user: %w", err)
+	}
+	if invitee == nil || !slices.Contains(invitee.VerifiedEmails, strings.ToLower(invitation.Email)) {
+		return nil, errs.NewForbiddenError("this invitation was sent to an email address you have not verified", false)
+	}
+
+	// Business logic: The owner and existing collaborators already have access
+	_, err = s.resumeRepo.GetResumeRole(ctx, userID, invitation.ResumeID)
+	if err == nil {
+		return nil, errs.NewBadRequestError(
+			"you already have access to this resume",
+			false, nil, nil, nil,
+		)
+	}
+	if !errors.Is(err, pgx.ErrNoRows) {
+		return nil, fmt.Errorf("failed to check resume access: %w", err)
+	}
+
+	acceptedItem, err := s.collaboratorRepo.AcceptInvitation(ctx, userID, invitation.ID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("invitation not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to accept invitation: %w", err)
+	}
+
+	response := s.convertToCollaboratorResponse(acceptedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &acceptedItem.ResumeID,
+		EntityType: audit.EntityCollaborator,
+		EntityID:   &acceptedItem.ID,
+		Action:     audit.ActionAccepted,
+		Before:     s.convertToCollaboratorResponse(invitation),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// UpdateCollaboratorRole changes the role of a collaborator or pending invitation
+func (s *CollaboratorService) UpdateCollaboratorRole(ctx context.Context, userID string, resumeID, collaboratorID uuid.UUID, payload *collaborator.UpdateCollaboratorRequest) (*collaborator.CollaboratorResponse, error) {
+	// Only the owner may manage sharing
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	existingItem, err := s.collaboratorRepo.GetCollaboratorByID(ctx, userID, resumeID, collaboratorID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("collaborator not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get collaborator: %w", err)
+	}
+
+	updatedItem, err := s.collaboratorRepo.UpdateCollaboratorRole(ctx, userID, resumeID, collaboratorID, payload.Role)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("collaborator not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to update collaborator: %w", err)
+	}
+
+	response := s.convertToCollaboratorResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: audit.EntityCollaborator,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToCollaboratorResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// RevokeCollaborator removes a collaborator or withdraws a pending invitation.
+// Collaborators may also use it to leave a resume shared with them.
+func (s *CollaboratorService) RevokeCollaborator(ctx context.Context, userID string, resumeID, collaboratorID uuid.UUID) error {
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleViewer); err != nil {
+		return err
+	}
+
+	err := s.collaboratorRepo.DeleteCollaborator(ctx, userID, resumeID, collaboratorID)
+	if err != nil {
+		if err.Error() == "collaborator not found" {
+			return errs.NewNotFoundError("collaborator not found", false, nil)
+		}
+		return fmt.Errorf("failed to revoke collaborator: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: audit.EntityCollaborator,
+		EntityID:   &collaboratorID,
+		Action:     events.ActionDeleted,
+	})
+
+	return nil
+}
+
+// Helper methods
+
+func (s *CollaboratorService) convertToCollaboratorResponse(collaboratorItem *collaborator.Collaborator) *collaborator.CollaboratorResponse {
+	response := &collaborator.CollaboratorResponse{
+		ID:        collaboratorItem.ID.String(),
+		ResumeID:  collaboratorItem.ResumeID,
+		Email:     collaboratorItem.Email,
+		UserID:    collaboratorItem.UserID,
+		Role:      collaboratorItem.Role,
+		Status:    collaborator.StatusPending,
+		InvitedBy: collaboratorItem.InvitedBy,
+		CreatedAt: collaboratorItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: collaboratorItem.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if collaboratorItem.AcceptedAt != nil {
+		acceptedAt := collaboratorItem.AcceptedAt.Format(time.RFC3339)
+		response.AcceptedAt = &acceptedAt
+		response.Status = collaborator.StatusAccepted
+	}
+
+	return response
+}
+
+// newInviteToken returns a random invitation token and the hash stored for it
+func newInviteToken() (string, string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", "", err
+	}
+	token := base64.RawURLEncoding.EncodeToString(buf)
+	return token, hashInviteToken(token), nil
+}
+
+func hashInviteToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,70 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/repository"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAcceptInvitationRequiresTheInvitedEmail(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	collaboratorService := NewCollaboratorService(testServer, repository.NewRepositories(testServer))
+
+	var resumeID uuid.UUID
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `INSERT INTO resumes (user_id, title) VALUES ('user_owner', 'Resume') RETURNING id`).Scan(&resumeID))
+
+	token := "invite-token"
+	_, err := testDB.Pool.Exec(ctx, `
+		INSERT INTO resume_collaborators (resume_id, email, role, invited_by, invite_token_hash)
+		VALUES ($1, 'Ada@Example.com', 'editor', 'user_owner', $2)
+	`, resumeID, hashInviteToken(token))
+	require.NoError(t, err)
+
+	_, err = testDB.Pool.Exec(ctx, `
+		INSERT INTO users (id, email, verified_emails)
+		VALUES
+			('user_mallory', 'mallory@example.com', '{mallory@example.com}'),
+			('user_unverified', 'ada@example.com', '{}'),
+			('user_ada', 'ada@work.example.com', '{ada@work.example.com,ada@example.com}')
+	`)
+	require.NoError(t, err)
+
+	forbidden := func(t *testing.T, userID string) {
+		t.Helper()
+
+		_, err := collaboratorService.AcceptInvitation(ctx, userID, token)
+		var httpErr *errs.HTTPError
+		require.True(t, errors.As(err, &httpErr), "got %v", err)
+		assert.Equal(t, http.StatusForbidden, httpErr.Status)
+	}
+
+	// A forwarded link does not work for someone else
+	forbidden(t, "user_mallory")
+	// Nor for an address the user has not verified
+	forbidden(t, "user_unverified")
+	// Nor for a user whose profile has not been synced from Clerk yet
+	forbidden(t, "user_unknown")
+
+	var accepted int
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM resume_collaborators WHERE accepted_at IS NOT NULL`).Scan(&accepted))
+	assert.Zero(t, accepted)
+
+	// The invited address matches regardless of case
+	response, err := collaboratorService.AcceptInvitation(ctx, "user_ada", token)
+	require.NoError(t, err)
+	assert.Equal(t, collaborator.StatusAccepted, response.Status)
+	require.NotNil(t, response.UserID)
+	assert.Equal(t, "user_ada", *response.UserID)
+}
@@ -0,0 +1,303 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/comment"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type CommentService struct {
+	server      *server.Server
+	commentRepo *repository.CommentRepository
+	resumeRepo  *repository.ResumeRepository
+	auditLog    auditLog
+}
+
+func NewCommentService(s *server.Server, repos *repository.Repositories) *CommentService {
+	return &CommentService{
+		server:      s,
+		commentRepo: repos.Comment,
+		resumeRepo:  repos.Resume,
+		auditLog:    newAuditLog(s, repos),
+	}
+}
+
+// CreateComment starts a thread on a resume entity or replies to one
+func (s *CommentService) CreateComment(ctx context.Context, userID string, resumeID uuid.UUID, payload *comment.CreateCommentRequest) (*comment.CommentResponse, error) {
+	// Verify the user can comment on the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleCommenter)
+	if err != nil {
+		return nil, err
+	}
+
+	if payload.ParentID != nil {
+		// Business logic: Replies join the thread of the parent and share its anchor
+		parent, err := s.commentRepo.GetCommentByID(ctx, userID, *payload.ParentID)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return nil, errs.NewNotFoundError("parent comment not found", false, nil)
+			}
+			return nil, fmt.Errorf("failed to get parent comment: %w", err)
+		}
+		if parent.ResumeID != resumeID {
+			return nil, errs.NewBadRequestError("parent comment belongs to another resume", false, nil, nil, nil)
+		}
+		if parent.ArchivedAt != nil {
+			return nil, errs.NewBadRequestError("cannot reply to an archived comment", false, nil, nil, nil)
+		}
+		if parent.ParentID != nil {
+			payload.ParentID = parent.ParentID
+		}
+		payload.EntityType = &parent.EntityType
+		payload.EntityID = &parent.EntityID
+		payload.FieldName = parent.FieldName
+	} else {
+		// Business logic: The anchored entity must belong to the resume
+		exists, err := s.commentRepo.AnchorExists(ctx, resumeID, *payload.EntityType, *payload.EntityID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check comment anchor: %w", err)
+		}
+		if !exists {
+			return nil, errs.NewBadRequestError(
+				fmt.Sprintf("%s %s not found on this resume", *payload.EntityType, payload.EntityID.String()),
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	commentItem, err := s.commentRepo.CreateComment(ctx, userID, resumeID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create comment: %w", err)
+	}
+
+	response := s.convertToCommentResponse(commentItem)
+	s.server.Events.Publish(ctx, commentItem.ResumeID, events.EntityComment, events.ActionCreated, &commentItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &commentItem.ResumeID,
+		EntityType: events.EntityComment,
+		EntityID:   &commentItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetCommentsByResumeID lists the comment threads of a resume
+func (s *CommentService) GetCommentsByResumeID(ctx context.Context, userID string, resumeID uuid.UUID, filter string) ([]comment.CommentResponse, error) {
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.GetCommentsByResumeID(ctx, userID, resumeID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return s.buildThreads(comments), nil
+}
+
+// GetCommentsByEntity lists the comment threads anchored to one resume entity
+func (s *CommentService) GetCommentsByEntity(ctx context.Context, userID string, resumeID uuid.UUID, entityType comment.EntityType, entityID uuid.UUID, filter string) ([]comment.CommentResponse, error) {
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleViewer)
+	if err != nil {
+		return nil, err
+	}
+
+	comments, err := s.commentRepo.GetCommentsByEntity(ctx, userID, resumeID, entityType, entityID, filter)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get comments: %w", err)
+	}
+
+	return s.buildThreads(comments), nil
+}
+
+// UpdateComment edits the body of a comment. Only its author may edit it.
+func (s *CommentService) UpdateComment(ctx context.Context, userID string, commentID uuid.UUID, payload *comment.UpdateCommentRequest) (*comment.CommentResponse, error) {
+	existingComment, err := s.getWritableComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingComment.AuthorID != userID {
+		return nil, errs.NewForbiddenError("only the author can edit a comment", false)
+	}
+
+	updatedComment, err := s.commentRepo.UpdateCommentBody(ctx, userID, commentID, payload.Body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	response := s.convertToCommentResponse(updatedComment)
+	s.server.Events.Publish(ctx, updatedComment.ResumeID, events.EntityComment, events.ActionUpdated, &updatedComment.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedComment.ResumeID,
+		EntityType: events.EntityComment,
+		EntityID:   &updatedComment.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToCommentResponse(existingComment),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// SetCommentResolved resolves or reopens the thread started by a comment
+func (s *CommentService) SetCommentResolved(ctx context.Context, userID string, commentID uuid.UUID, resolved bool) (*comment.CommentResponse, error) {
+	existingComment, err := s.getWritableComment(ctx, userID, commentID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business logic: Threads are resolved as a whole through their first comment
+	if existingComment.ParentID != nil {
+		return nil, errs.NewBadRequestError("only the first comment of a thread can be resolved", false, nil, nil, nil)
+	}
+
+	updatedComment, err := s.commentRepo.SetThreadResolved(ctx, userID, commentID, resolved)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update comment: %w", err)
+	}
+
+	response := s.convertToCommentResponse(updatedComment)
+	s.server.Events.Publish(ctx, updatedComment.ResumeID, events.EntityComment, events.ActionUpdated, &updatedComment.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedComment.ResumeID,
+		EntityType: events.EntityComment,
+		EntityID:   &updatedComment.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToCommentResponse(existingComment),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteComment deletes a comment and its replies. Authors may delete their own
+// comments and the resume owner may delete any comment.
+func (s *CommentService) DeleteComment(ctx context.Context, userID string, commentID uuid.UUID) error {
+	existingComment, err := s.commentRepo.GetCommentByID(ctx, userID, commentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return errs.NewNotFoundError("comment not found", false, nil)
+		}
+		return fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	if existingComment.AuthorID != userID {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingComment.ResumeID, collaborator.RoleOwner); err != nil {
+			return err
+		}
+	}
+
+	err = s.commentRepo.DeleteComment(ctx, userID, commentID)
+	if err != nil {
+		if err.Error() == "comment not found" {
+			return errs.NewNotFoundError("comment not found", false, nil)
+		}
+		return fmt.Errorf("failed to delete comment: %w", err)
+	}
+
+	s.server.Events.Publish(ctx, existingComment.ResumeID, events.EntityComment, events.ActionDeleted, &commentID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingComment.ResumeID,
+		EntityType: events.EntityComment,
+		EntityID:   &commentID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToCommentResponse(existingComment),
+	})
+
+	return nil
+}
+
+// Helper methods
+
+// getWritableComment returns a comment the user may act on: the user must be
+// able to comment on the resume and the thread must not be archived
+func (s *CommentService) getWritableComment(ctx context.Context, userID string, commentID uuid.UUID) (*comment.Comment, error) {
+	existingComment, err := s.commentRepo.GetCommentByID(ctx, userID, commentID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("comment not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get comment: %w", err)
+	}
+
+	_, err = authorizeResume(ctx, s.resumeRepo, userID, existingComment.ResumeID, collaborator.RoleCommenter)
+	if err != nil {
+		return nil, err
+	}
+
+	if existingComment.ArchivedAt != nil {
+		return nil, errs.NewBadRequestError("archived comments are read only", false, nil, nil, nil)
+	}
+
+	return existingComment, nil
+}
+
+// buildThreads nests replies under their root comment. Comments arrive in
+// creation order, so roots precede their replies.
+func (s *CommentService) buildThreads(comments []comment.Comment) []comment.CommentResponse {
+	threads := []comment.CommentResponse{}
+	rootIndex := make(map[uuid.UUID]int)
+
+	for i := range comments {
+		response := s.convertToCommentResponse(&comments[i])
+		if comments[i].ParentID == nil {
+			rootIndex[comments[i].ID] = len(threads)
+			threads = append(threads, *response)
+			continue
+		}
+
+		if index, ok := rootIndex[*comments[i].ParentID]; ok {
+			threads[index].Replies = append(threads[index].Replies, *response)
+		}
+	}
+
+	return threads
+}
+
+func (s *CommentService) convertToCommentResponse(commentItem *comment.Comment) *comment.CommentResponse {
+	response := &comment.CommentResponse{
+		ID:         commentItem.ID.String(),
+		ResumeID:   commentItem.ResumeID,
+		ParentID:   commentItem.ParentID,
+		EntityType: commentItem.EntityType,
+		EntityID:   commentItem.EntityID,
+		FieldName:  commentItem.FieldName,
+		AuthorID:   commentItem.AuthorID,
+		Body:       commentItem.Body,
+		Resolved:   commentItem.ResolvedAt != nil,
+		ResolvedBy: commentItem.ResolvedBy,
+		Archived:   commentItem.ArchivedAt != nil,
+		CreatedAt:  commentItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:  commentItem.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if commentItem.ResolvedAt != nil {
+		resolvedAt := commentItem.ResolvedAt.Format(time.RFC3339)
+		response.ResolvedAt = &resolvedAt
+	}
+	if commentItem.ArchivedAt != nil {
+		archivedAt := commentItem.ArchivedAt.Format(time.RFC3339)
+		response.ArchivedAt = &archivedAt
+	}
+
+	return response
+}
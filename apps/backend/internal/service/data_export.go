@@ -0,0 +1,253 @@
+package service
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/lib/render"
+	"github.com/recreatedev/Resumify/internal/lib/signedurl"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/dataexport"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type DataExportService struct {
+	server         *server.Server
+	dataExportRepo *repository.DataExportRepository
+	resumeService  *ResumeService
+	auditLog       auditLog
+	signer         *signedurl.Signer
+}
+
+func NewDataExportService(s *server.Server, repos *repository.Repositories, resumeService *ResumeService) *DataExportService {
+	return &DataExportService{
+		server:         s,
+		dataExportRepo: repos.DataExport,
+		resumeService:  resumeService,
+		auditLog:       newAuditLog(s, repos),
+		signer:         signedurl.NewSigner(s.Config.Auth.SecretKey),
+	}
+}
+
+// RequestExport starts building a copy of everything the user owns. While an
+// export is being built, requesting another returns it.
+func (s *DataExportService) RequestExport(ctx context.Context, userID string) (*dataexport.DataExportResponse, error) {
+	export, err := s.dataExportRepo.CreateExport(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data export: %w", err)
+	}
+
+	task, err := job.NewBuildDataExportTask(export.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create data export task: %w", err)
+	}
+
+	// The task ID is the export ID, so a conflict means the export is
+	// already queued
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil, fmt.Errorf("failed to enqueue data export task: %w", err)
+	}
+
+	response := s.convertToDataExportResponse(export)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityDataExport,
+		EntityID:   &export.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetExport reports the progress of one of the user's data exports
+func (s *DataExportService) GetExport(ctx context.Context, userID string, exportID uuid.UUID) (*dataexport.DataExportResponse, error) {
+	export, err := s.dataExportRepo.GetExportByID(ctx, userID, exportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("data export not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return s.convertToDataExportResponse(export), nil
+}
+
+// BuildExport collects the user's data into a zip archive, stores it and
+// emails the user a signed download link. A retry after the archive was
+// stored only makes sure the email is queued; final marks the last attempt.
+func (s *DataExportService) BuildExport(ctx context.Context, exportID uuid.UUID, final bool) error {
+	export, err := s.dataExportRepo.StartExport(ctx, exportID)
+	if err != nil {
+		// The export expired and was purged
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to start data export: %w", err)
+	}
+
+	switch export.Status {
+	case dataexport.StatusFailed:
+		return nil
+	case dataexport.StatusRunning:
+		archive, err := s.buildArchive(ctx, export.UserID)
+		if err != nil {
+			if final {
+				if failErr := s.dataExportRepo.FailExport(ctx, exportID, err.Error()); failErr != nil {
+					return failErr
+				}
+			}
+			return err
+		}
+
+		expiresAt := time.Now().Add(time.Duration(s.server.Config.DataExport.RetentionHours) * time.Hour).Truncate(time.Second)
+		if err := s.dataExportRepo.CompleteExport(ctx, exportID, archive, expiresAt); err != nil {
+			return err
+		}
+		export.ExpiresAt = &expiresAt
+	}
+
+	task, err := job.NewDataExportReadyEmailTask(
+		export.UserID,
+		s.downloadURL(exportID, *export.ExpiresAt),
+		export.ExpiresAt.UTC().Format("January 2, 2006 at 3:04 PM MST"),
+	)
+	if err != nil {
+		return fmt.Errorf("failed to create data export ready email task: %w", err)
+	}
+
+	return s.server.Job.EnqueueOnce(ctx, exportID.String()+":"+job.TaskDataExportReady, task)
+}
+
+// DownloadExport returns the archive of a ready export to the holder of a
+// signed download link
+func (s *DataExportService) DownloadExport(ctx context.Context, exportID uuid.UUID, expires, signature string) ([]byte, error) {
+	err := s.signer.Verify(s.downloadPath(exportID), expires, signature, time.Now())
+	if err != nil {
+		if errors.Is(err, signedurl.ErrExpired) {
+			return nil, errs.NewForbiddenError("download link has expired", false)
+		}
+		return nil, errs.NewForbiddenError("invalid download link", false)
+	}
+
+	_, archive, err := s.dataExportRepo.GetArchive(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("data export not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get data export: %w", err)
+	}
+
+	return archive, nil
+}
+
+// PurgeExpiredExports removes exports whose download link has expired
+func (s *DataExportService) PurgeExpiredExports(ctx context.Context) (int64, error) {
+	purged, err := s.dataExportRepo.PurgeExpiredExports(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired data exports: %w", err)
+	}
+
+	return purged, nil
+}
+
+// Helper methods
+
+// buildArchive zips the user's rows as one JSON file per table together with
+// each active resume rendered as HTML
+func (s *DataExportService) buildArchive(ctx context.Context, userID string) ([]byte, error) {
+	files, err := s.dataExportRepo.CollectUserData(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to collect user data: %w", err)
+	}
+
+	resumeIDs, err := s.dataExportRepo.GetActiveResumeIDs(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resumes: %w", err)
+	}
+
+	var buf bytes.Buffer
+	archive := zip.NewWriter(&buf)
+
+	for _, file := range files {
+		w, err := archive.Create("data/" + file.Name)
+		if err != nil {
+			return nil, fmt.Errorf("failed to add %s to data export: %w", file.Name, err)
+		}
+		if _, err := w.Write(file.Data); err != nil {
+			return nil, fmt.Errorf("failed to write %s to data export: %w", file.Name, err)
+		}
+	}
+
+	for _, resumeID := range resumeIDs {
+		document, err := s.resumeService.getResumeDocument(ctx, userID, resumeID)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := render.HTML(document)
+		if err != nil {
+			return nil, err
+		}
+
+		w, err := archive.Create("resumes/" + resumeID.String() + ".html")
+		if err != nil {
+			return nil, fmt.Errorf("failed to add resume %s to data export: %w", resumeID.String(), err)
+		}
+		if _, err := w.Write(page); err != nil {
+			return nil, fmt.Errorf("failed to write resume %s to data export: %w", resumeID.String(), err)
+		}
+	}
+
+	if err := archive.Close(); err != nil {
+		return nil, fmt.Errorf("failed to finish data export archive: %w", err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+func (s *DataExportService) downloadPath(exportID uuid.UUID) string {
+	return "/downloads/data-exports/" + exportID.String()
+}
+
+func (s *DataExportService) downloadURL(exportID uuid.UUID, expiresAt time.Time) string {
+	path := s.downloadPath(exportID)
+	return fmt.Sprintf("%s%s?%s", strings.TrimRight(s.server.Config.Server.PublicURL, "/"), path, s.signer.Sign(path, expiresAt))
+}
+
+func (s *DataExportService) convertToDataExportResponse(export *dataexport.DataExport) *dataexport.DataExportResponse {
+	response := &dataexport.DataExportResponse{
+		ID:        export.ID.String(),
+		Status:    export.Status,
+		SizeBytes: export.SizeBytes,
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+
+	if export.ExpiresAt != nil {
+		expiresAt := export.ExpiresAt.Format(time.RFC3339)
+		response.ExpiresAt = &expiresAt
+	}
+	if export.Status == dataexport.StatusReady && export.ExpiresAt != nil {
+		downloadURL := s.downloadURL(export.ID, *export.ExpiresAt)
+		response.DownloadURL = &downloadURL
+	}
+	if export.CompletedAt != nil {
+		completedAt := export.CompletedAt.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+
+	return response
+}
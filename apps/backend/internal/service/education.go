@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/education"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
@@ -16,6 +23,7 @@ type EducationService struct {
 	server        *server.Server
 	educationRepo *repository.EducationRepository
 	resumeRepo    *repository.ResumeRepository
+	auditLog      auditLog
 }
 
 func NewEducationService(s *server.Server, repos *repository.Repositories) *EducationService {
@@ -23,44 +31,32 @@ func NewEducationService(s *server.Server, repos *repository.Repositories) *Educ
 		server:        s,
 		educationRepo: repos.Education,
 		resumeRepo:    repos.Resume,
+		auditLog:      newAuditLog(s, repos),
 	}
 }
 
 // CreateEducation creates a new education entry
 func (s *EducationService) CreateEducation(ctx context.Context, userID string, payload *education.CreateEducationRequest) (*education.EducationResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
+		return nil, err
 	}
 
-	// Business logic: Validate date ranges
-	if payload.StartDate != nil && payload.EndDate != nil {
-		if payload.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	}
-
-	// Business logic: Check for duplicate education entries
+	// Business logic: Check the entry against the resume's other education entries
 	existingEducations, err := s.educationRepo.GetEducationByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing education: %w", err)
 	}
 
-	// Check for duplicates based on institution and degree
-	for _, existing := range existingEducations {
-		if existing.Institution == payload.Institution && existing.Degree == payload.Degree {
-			return nil, errs.NewBadRequestError(
-				"education entry with same institution and degree already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := education.Education{
+		Institution: payload.Institution,
+		Degree:      payload.Degree,
+		StartDate:   payload.StartDate,
+		EndDate:     payload.EndDate,
+	}
+	if violation := checkEducation(entry, existingEducations); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -76,6 +72,15 @@ func (s *EducationService) CreateEducation(ctx context.Context, userID string, p
 
 	// Convert to response DTO
 	response := s.convertToEducationResponse(educationItem)
+	s.server.Events.Publish(ctx, educationItem.ResumeID, events.EntityEducation, events.ActionCreated, &educationItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &educationItem.ResumeID,
+		EntityType: events.EntityEducation,
+		EntityID:   &educationItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -119,7 +124,7 @@ func (s *EducationService) GetEducationByResumeID(ctx context.Context, userID st
 }
 
 // UpdateEducation updates an education entry
-func (s *EducationService) UpdateEducation(ctx context.Context, userID string, educationID uuid.UUID, payload *education.UpdateEducationRequest) (*education.EducationResponse, error) {
+func (s *EducationService) UpdateEducation(ctx context.Context, userID string, educationID uuid.UUID, ifMatch string, payload *education.UpdateEducationRequest) (*education.EducationResponse, error) {
 	// Check if education exists and belongs to user
 	existingEducation, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
 	if err != nil {
@@ -129,54 +134,76 @@ func (s *EducationService) UpdateEducation(ctx context.Context, userID string, e
 		return nil, fmt.Errorf("failed to get existing education: %w", err)
 	}
 
-	// Business logic: Validate date ranges
-	if payload.StartDate != nil && payload.EndDate != nil {
-		if payload.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.StartDate != nil && existingEducation.EndDate != nil {
-		if payload.StartDate.After(*existingEducation.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.EndDate != nil && existingEducation.StartDate != nil {
-		if existingEducation.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingEducation.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the education
+	if err := etag.Check(ifMatch, existingEducation.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Check the updated entry against the resume's other education entries
+	educations, err := s.educationRepo.GetEducationByResumeID(ctx, userID, existingEducation.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing education: %w", err)
+	}
+
+	others := slices.DeleteFunc(educations, func(e education.Education) bool { return e.ID == educationID })
+	if violation := checkEducation(applyEducationUpdate(*existingEducation, payload), others); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Update education in repository
-	updatedEducation, err := s.educationRepo.UpdateEducation(ctx, userID, educationID, payload)
+	updatedEducation, err := s.educationRepo.UpdateEducation(ctx, userID, educationID, existingEducation.UpdatedAt, payload)
 	if err != nil {
+		// The education changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update education: %w", err)
 	}
 
-	return s.convertToEducationResponse(updatedEducation), nil
+	response := s.convertToEducationResponse(updatedEducation)
+	s.server.Events.Publish(ctx, updatedEducation.ResumeID, events.EntityEducation, events.ActionUpdated, &updatedEducation.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedEducation.ResumeID,
+		EntityType: events.EntityEducation,
+		EntityID:   &updatedEducation.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToEducationResponse(existingEducation),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateEducationOrder updates the order of multiple education entries
 func (s *EducationService) BulkUpdateEducationOrder(ctx context.Context, userID string, payload *education.BulkUpdateEducationRequest) error {
 	// Validate that all education entries belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, eduUpdate := range payload.Education {
 		educationID, err := uuid.Parse(eduUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid education ID", false, nil, nil, nil)
 		}
-		_, err = s.educationRepo.GetEducationByID(ctx, userID, educationID)
+		existingEducation, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:education" {
 				return errs.NewNotFoundError("education not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify education ownership: %w", err)
 		}
+		resumeIDs[existingEducation.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -185,13 +212,24 @@ func (s *EducationService) BulkUpdateEducationOrder(ctx context.Context, userID
 		return fmt.Errorf("failed to update education order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntityEducation, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntityEducation,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteEducation deletes an education entry
-func (s *EducationService) DeleteEducation(ctx context.Context, userID string, educationID uuid.UUID) error {
+func (s *EducationService) DeleteEducation(ctx context.Context, userID string, educationID uuid.UUID, ifMatch string) error {
 	// Check if education exists and belongs to user
-	_, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
+	existingEducation, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:education" {
 			return errs.NewNotFoundError("education not found", false, nil)
@@ -199,12 +237,36 @@ func (s *EducationService) DeleteEducation(ctx context.Context, userID string, e
 		return fmt.Errorf("failed to get existing education: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingEducation.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the education
+	if err := etag.Check(ifMatch, existingEducation.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete education
-	err = s.educationRepo.DeleteEducation(ctx, userID, educationID)
+	err = s.educationRepo.DeleteEducation(ctx, userID, educationID, existingEducation.UpdatedAt)
 	if err != nil {
+		// The education changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete education: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingEducation.ResumeID, events.EntityEducation, events.ActionDeleted, &educationID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingEducation.ResumeID,
+		EntityType: events.EntityEducation,
+		EntityID:   &educationID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToEducationResponse(existingEducation),
+	})
+
 	return nil
 }
 
@@ -212,16 +274,19 @@ func (s *EducationService) DeleteEducation(ctx context.Context, userID string, e
 
 func (s *EducationService) convertToEducationResponse(educationItem *education.Education) *education.EducationResponse {
 	response := &education.EducationResponse{
-		ID:           educationItem.ID.String(),
-		ResumeID:     educationItem.ResumeID,
-		Institution:  educationItem.Institution,
-		Degree:       educationItem.Degree,
-		FieldOfStudy: educationItem.FieldOfStudy,
-		Grade:        educationItem.Grade,
-		Description:  educationItem.Description,
-		OrderIndex:   educationItem.OrderIndex,
-		CreatedAt:    educationItem.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    educationItem.UpdatedAt.Format(time.RFC3339),
+		ID:               educationItem.ID.String(),
+		ResumeID:         educationItem.ResumeID,
+		LibraryItemID:    educationItem.LibraryItemID,
+		OverriddenFields: educationItem.OverriddenFields,
+		Institution:      educationItem.Institution,
+		Degree:           educationItem.Degree,
+		FieldOfStudy:     educationItem.FieldOfStudy,
+		Grade:            educationItem.Grade,
+		Description:      educationItem.Description,
+		OrderIndex:       educationItem.OrderIndex,
+		CreatedAt:        educationItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        educationItem.UpdatedAt.Format(time.RFC3339),
+		ETag:             etag.Format(educationItem.UpdatedAt),
 	}
 
 	// Handle optional date fields
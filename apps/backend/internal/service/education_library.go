@@ -0,0 +1,334 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/education"
+)
+
+// CreateLibraryEducation adds an education entry to the user's career library
+func (s *EducationService) CreateLibraryEducation(ctx context.Context, userID string, payload *education.CreateLibraryEducationRequest) (*education.LibraryEducationResponse, error) {
+	// Business logic: Validate date ranges
+	if payload.StartDate != nil && payload.EndDate != nil {
+		if payload.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	libraryItem, err := s.educationRepo.CreateLibraryEducation(ctx, userID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library education: %w", err)
+	}
+
+	response := s.convertToLibraryEducationResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryEducation,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetLibraryEducationByID retrieves a career library education entry by ID
+func (s *EducationService) GetLibraryEducationByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*education.LibraryEducationResponse, error) {
+	libraryItem, err := s.educationRepo.GetLibraryEducationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_education" {
+			return nil, errs.NewNotFoundError("library education not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library education: %w", err)
+	}
+
+	return s.convertToLibraryEducationResponse(libraryItem), nil
+}
+
+// GetLibraryEducationByUserID retrieves all education entries in the user's career library
+func (s *EducationService) GetLibraryEducationByUserID(ctx context.Context, userID string) ([]education.LibraryEducationResponse, error) {
+	libraryItems, err := s.educationRepo.GetLibraryEducationByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library education entries: %w", err)
+	}
+
+	// Convert to response DTOs
+	responses := make([]education.LibraryEducationResponse, len(libraryItems))
+	for i, item := range libraryItems {
+		responses[i] = *s.convertToLibraryEducationResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// UpdateLibraryEducation updates a career library education entry; every resume linked to it
+// picks up the change for fields it has not overridden
+func (s *EducationService) UpdateLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string, payload *education.UpdateLibraryEducationRequest) (*education.LibraryEducationResponse, error) {
+	// Check if library item exists and belongs to user
+	existingItem, err := s.educationRepo.GetLibraryEducationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_education" {
+			return nil, errs.NewNotFoundError("library education not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing library education: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library education
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Validate date ranges
+	if payload.StartDate != nil && payload.EndDate != nil {
+		if payload.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.StartDate != nil && existingItem.EndDate != nil {
+		if payload.StartDate.After(*existingItem.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.EndDate != nil && existingItem.StartDate != nil {
+		if existingItem.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	updatedItem, err := s.educationRepo.UpdateLibraryEducation(ctx, userID, libraryItemID, existingItem.UpdatedAt, payload)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to update library education: %w", err)
+	}
+
+	response := s.convertToLibraryEducationResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryEducation,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToLibraryEducationResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteLibraryEducation removes a career library education entry. Linked resume entries keep
+// their current content and become standalone.
+func (s *EducationService) DeleteLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string) error {
+	existingItem, err := s.educationRepo.GetLibraryEducationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_education" {
+			return errs.NewNotFoundError("library education not found", false, nil)
+		}
+		return fmt.Errorf("failed to get existing library education: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library education
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.educationRepo.DeleteLibraryEducation(ctx, userID, libraryItemID, existingItem.UpdatedAt)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
+		return fmt.Errorf("failed to delete library education: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryEducation,
+		EntityID:   &libraryItemID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToLibraryEducationResponse(existingItem),
+	})
+
+	return nil
+}
+
+// LinkLibraryEducation adds a career library education entry to a resume
+func (s *EducationService) LinkLibraryEducation(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *education.LinkLibraryEducationRequest) (*education.EducationResponse, error) {
+	// Only the owner may link library items, since a collaborator's library
+	// is not theirs to edit or delete
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify library item belongs to user
+	_, err = s.educationRepo.GetLibraryEducationByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_education" {
+			return nil, errs.NewNotFoundError("library education not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library education: %w", err)
+	}
+
+	// Set default order index if not provided
+	if payload.OrderIndex == 0 {
+		existingItems, err := s.educationRepo.GetEducationByResumeID(ctx, userID, payload.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing education entries: %w", err)
+		}
+		payload.OrderIndex = len(existingItems) + 1
+	}
+
+	linkedItem, err := s.educationRepo.LinkLibraryEducation(ctx, userID, libraryItemID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library education: %w", err)
+	}
+
+	response := s.convertToEducationResponse(linkedItem)
+	s.server.Events.Publish(ctx, linkedItem.ResumeID, events.EntityEducation, events.ActionCreated, &linkedItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &linkedItem.ResumeID,
+		EntityType: events.EntityEducation,
+		EntityID:   &linkedItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// PromoteEducation moves a resume education entry into the career library and links the
+// entry to it
+func (s *EducationService) PromoteEducation(ctx context.Context, userID string, educationID uuid.UUID) (*education.LibraryEducationResponse, error) {
+	existingItem, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:education" {
+			return nil, errs.NewNotFoundError("education not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing education: %w", err)
+	}
+
+	// Only the owner may promote: the entry would otherwise move into a
+	// collaborator's library, out of the owner's control
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Linked entries already live in the library
+	if existingItem.LibraryItemID != nil {
+		return nil, errs.NewBadRequestError(
+			"education is already linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	libraryItem, err := s.educationRepo.PromoteEducation(ctx, userID, educationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote education: %w", err)
+	}
+
+	response := s.convertToLibraryEducationResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryEducation,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// ResetEducationOverrides drops the per-resume overrides of a linked education entry
+func (s *EducationService) ResetEducationOverrides(ctx context.Context, userID string, educationID uuid.UUID, ifMatch string) (*education.EducationResponse, error) {
+	existingItem, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:education" {
+			return nil, errs.NewNotFoundError("education not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing education: %w", err)
+	}
+
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the education
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if existingItem.LibraryItemID == nil {
+		return nil, errs.NewBadRequestError(
+			"education is not linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	err = s.educationRepo.ResetEducationOverrides(ctx, userID, educationID, existingItem.UpdatedAt)
+	if err != nil {
+		// The education changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to reset education overrides: %w", err)
+	}
+
+	resetItem, err := s.educationRepo.GetEducationByID(ctx, userID, educationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education: %w", err)
+	}
+
+	response := s.convertToEducationResponse(resetItem)
+	s.server.Events.Publish(ctx, resetItem.ResumeID, events.EntityEducation, events.ActionUpdated, &resetItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resetItem.ResumeID,
+		EntityType: events.EntityEducation,
+		EntityID:   &resetItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToEducationResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+func (s *EducationService) convertToLibraryEducationResponse(libraryItem *education.LibraryEducation) *education.LibraryEducationResponse {
+	return &education.LibraryEducationResponse{
+		ID:           libraryItem.ID.String(),
+		Institution:  libraryItem.Institution,
+		Degree:       libraryItem.Degree,
+		FieldOfStudy: libraryItem.FieldOfStudy,
+		StartDate:    libraryItem.StartDate,
+		EndDate:      libraryItem.EndDate,
+		Grade:        libraryItem.Grade,
+		Description:  libraryItem.Description,
+		CreatedAt:    libraryItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    libraryItem.UpdatedAt.Format(time.RFC3339),
+		ETag:         etag.Format(libraryItem.UpdatedAt),
+	}
+}
@@ -0,0 +1,147 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/email"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/emaildelivery"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// EmailDeliveryService keeps the email delivery log. It is the email.DeliveryLog
+// of the job server's email client.
+type EmailDeliveryService struct {
+	server            *server.Server
+	emailDeliveryRepo *repository.EmailDeliveryRepository
+}
+
+func NewEmailDeliveryService(s *server.Server, repos *repository.Repositories) *EmailDeliveryService {
+	return &EmailDeliveryService{
+		server:            s,
+		emailDeliveryRepo: repos.EmailDelivery,
+	}
+}
+
+// StartAttempt records a send attempt before the email is sent
+func (s *EmailDeliveryService) StartAttempt(ctx context.Context, delivery *email.Delivery) error {
+	var userID *string
+	if delivery.UserID != "" {
+		userID = &delivery.UserID
+	}
+
+	started, err := s.emailDeliveryRepo.StartAttempt(ctx, delivery.IdempotencyKey, userID, string(delivery.Template), delivery.Recipient, delivery.Provider)
+	if err != nil {
+		return err
+	}
+	if !started {
+		return email.ErrAlreadySent
+	}
+
+	return nil
+}
+
+// RecordSent records that the provider accepted the email
+func (s *EmailDeliveryService) RecordSent(ctx context.Context, idempotencyKey, providerMessageID string) error {
+	return s.emailDeliveryRepo.RecordSent(ctx, idempotencyKey, providerMessageID)
+}
+
+// RecordFailure records why the email could not be sent
+func (s *EmailDeliveryService) RecordFailure(ctx context.Context, idempotencyKey string, sendErr error) error {
+	return s.emailDeliveryRepo.RecordFailure(ctx, idempotencyKey, sendErr.Error())
+}
+
+// GetDeliveries returns the emails sent to the user, newest first
+func (s *EmailDeliveryService) GetDeliveries(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[emaildelivery.DeliveryResponse], error) {
+	result, err := s.emailDeliveryRepo.GetDeliveriesByUser(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get email deliveries: %w", err)
+	}
+
+	responses := make([]emaildelivery.DeliveryResponse, len(result.Data))
+	for i := range result.Data {
+		responses[i] = *s.convertToDeliveryResponse(&result.Data[i])
+	}
+
+	return &model.PaginatedResponse[emaildelivery.DeliveryResponse]{
+		Data:       responses,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// HandleResendEvent applies a delivery status reported by a Resend webhook.
+// Events about emails that are not in the log, or that would move a delivery
+// back, are ignored so Resend's retries and out-of-order events are harmless.
+func (s *EmailDeliveryService) HandleResendEvent(ctx context.Context, event *emaildelivery.ResendEvent) error {
+	var (
+		status emaildelivery.Status
+		reason *string
+	)
+
+	switch event.Type {
+	case emaildelivery.ResendEmailDelivered:
+		status = emaildelivery.StatusDelivered
+	case emaildelivery.ResendEmailBounced:
+		status = emaildelivery.StatusBounced
+		if event.Data.Bounce != nil && event.Data.Bounce.Message != "" {
+			reason = &event.Data.Bounce.Message
+		}
+	case emaildelivery.ResendEmailComplained:
+		status = emaildelivery.StatusComplained
+	default:
+		return nil
+	}
+
+	if event.Data.EmailID == "" {
+		return nil
+	}
+
+	updated, err := s.emailDeliveryRepo.UpdateProviderStatus(ctx, email.TransportResend, event.Data.EmailID, status, reason)
+	if err != nil {
+		return err
+	}
+
+	if !updated {
+		s.server.Logger.Debug().
+			Str("type", event.Type).
+			Str("email_id", event.Data.EmailID).
+			Msg("Ignoring resend event for unknown or already updated email")
+	}
+
+	return nil
+}
+
+func (s *EmailDeliveryService) convertToDeliveryResponse(delivery *emaildelivery.Delivery) *emaildelivery.DeliveryResponse {
+	response := &emaildelivery.DeliveryResponse{
+		ID:                delivery.ID.String(),
+		Template:          delivery.Template,
+		Recipient:         delivery.Recipient,
+		Provider:          delivery.Provider,
+		ProviderMessageID: delivery.ProviderMessageID,
+		Status:            delivery.Status,
+		Error:             delivery.Error,
+		Attempts:          delivery.Attempts,
+		CreatedAt:         delivery.CreatedAt.Format(time.RFC3339),
+	}
+
+	if delivery.LastAttemptAt != nil {
+		lastAttemptAt := delivery.LastAttemptAt.Format(time.RFC3339)
+		response.LastAttemptAt = &lastAttemptAt
+	}
+	if delivery.SentAt != nil {
+		sentAt := delivery.SentAt.Format(time.RFC3339)
+		response.SentAt = &sentAt
+	}
+	if delivery.DeliveredAt != nil {
+		deliveredAt := delivery.DeliveredAt.Format(time.RFC3339)
+		response.DeliveredAt = &deliveredAt
+	}
+
+	return response
+}
@@ -0,0 +1,179 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/erasure"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type ErasureService struct {
+	server      *server.Server
+	erasureRepo *repository.ErasureRepository
+}
+
+func NewErasureService(s *server.Server, repos *repository.Repositories) *ErasureService {
+	return &ErasureService{
+		server:      s,
+		erasureRepo: repos.Erasure,
+	}
+}
+
+// RequestErasure starts erasing everything the user owns. While an erasure is
+// in progress, requesting another returns it.
+func (s *ErasureService) RequestErasure(ctx context.Context, userID string, source erasure.Source) (*erasure.ErasureResponse, error) {
+	item, err := s.erasureRepo.CreateErasure(ctx, userID, source)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create erasure: %w", err)
+	}
+
+	task, err := job.NewEraseAccountTask(item.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create erasure task: %w", err)
+	}
+
+	// The task ID is the erasure ID, so a conflict means the erasure is
+	// already queued
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return nil, fmt.Errorf("failed to enqueue erasure task: %w", err)
+	}
+
+	return s.convertToErasureResponse(item), nil
+}
+
+// GetErasure returns the receipt of one of the user's erasures
+func (s *ErasureService) GetErasure(ctx context.Context, userID string, erasureID uuid.UUID) (*erasure.ErasureResponse, error) {
+	item, err := s.erasureRepo.GetErasureByID(ctx, userID, erasureID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("erasure not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get erasure: %w", err)
+	}
+
+	return s.convertToErasureResponse(item), nil
+}
+
+// EraseAccount cancels the user's queued jobs and then deletes or anonymizes
+// every row tied to the user. Retries pick up where the last attempt stopped;
+// final marks the last attempt, after which the erasure is recorded as failed.
+func (s *ErasureService) EraseAccount(ctx context.Context, erasureID uuid.UUID, final bool) error {
+	item, err := s.erasureRepo.StartErasure(ctx, erasureID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to start erasure: %w", err)
+	}
+	if item.Status != erasure.StatusRunning {
+		return nil
+	}
+
+	err = s.eraseAccount(ctx, item)
+	if err != nil {
+		if final {
+			if failErr := s.erasureRepo.FailErasure(ctx, erasureID, err.Error()); failErr != nil {
+				return failErr
+			}
+		}
+		return err
+	}
+
+	return s.erasureRepo.CompleteErasure(ctx, erasureID)
+}
+
+// Helper methods
+
+func (s *ErasureService) eraseAccount(ctx context.Context, item *erasure.Erasure) error {
+	// Jobs are cancelled first, while the profile and exports they are
+	// matched by still exist
+	cancelled, err := s.cancelUserJobs(ctx, item.UserID)
+	if err != nil {
+		return err
+	}
+	if cancelled > 0 {
+		if err := s.erasureRepo.RecordCancelledJobs(ctx, item.ID, cancelled); err != nil {
+			return err
+		}
+	}
+
+	return s.erasureRepo.EraseUserData(ctx, item.ID, item.UserID)
+}
+
+// cancelUserJobs deletes the queued tasks that would act on the user's data
+// or send the user email
+func (s *ErasureService) cancelUserJobs(ctx context.Context, userID string) (int, error) {
+	email, err := s.erasureRepo.GetUserEmail(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	exportIDs, err := s.erasureRepo.GetDataExportIDs(ctx, userID)
+	if err != nil {
+		return 0, err
+	}
+	exports := make(map[string]bool, len(exportIDs))
+	for _, id := range exportIDs {
+		exports[id.String()] = true
+	}
+
+	cancelled, err := s.server.Job.CancelTasks(func(task *asynq.TaskInfo) bool {
+		if task.Type == job.TaskEraseAccount {
+			return false
+		}
+
+		// The fields of the task payloads that identify a user
+		var payload struct {
+			UserID   string `json:"user_id"`
+			To       string `json:"to"`
+			ExportID string `json:"export_id"`
+			Data     struct {
+				ReviewerID  string `json:"reviewer_id"`
+				SubmitterID string `json:"submitter_id"`
+			} `json:"data"`
+		}
+		if err := json.Unmarshal(task.Payload, &payload); err != nil {
+			return false
+		}
+
+		return payload.UserID == userID ||
+			payload.Data.ReviewerID == userID ||
+			payload.Data.SubmitterID == userID ||
+			exports[payload.ExportID] ||
+			(email != nil && payload.To == *email)
+	})
+	if err != nil {
+		return cancelled, fmt.Errorf("failed to cancel jobs: %w", err)
+	}
+
+	return cancelled, nil
+}
+
+func (s *ErasureService) convertToErasureResponse(item *erasure.Erasure) *erasure.ErasureResponse {
+	response := &erasure.ErasureResponse{
+		ID:            item.ID.String(),
+		Source:        item.Source,
+		Status:        item.Status,
+		RowsAffected:  item.RowsAffected,
+		CancelledJobs: item.CancelledJobs,
+		Error:         item.Error,
+		CreatedAt:     item.CreatedAt.Format(time.RFC3339),
+	}
+
+	if item.CompletedAt != nil {
+		completedAt := item.CompletedAt.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+
+	return response
+}
@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/experience"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
@@ -16,6 +23,7 @@ type ExperienceService struct {
 	server         *server.Server
 	experienceRepo *repository.ExperienceRepository
 	resumeRepo     *repository.ResumeRepository
+	auditLog       auditLog
 }
 
 func NewExperienceService(s *server.Server, repos *repository.Repositories) *ExperienceService {
@@ -23,44 +31,32 @@ func NewExperienceService(s *server.Server, repos *repository.Repositories) *Exp
 		server:         s,
 		experienceRepo: repos.Experience,
 		resumeRepo:     repos.Resume,
+		auditLog:       newAuditLog(s, repos),
 	}
 }
 
 // CreateExperience creates a new experience entry
 func (s *ExperienceService) CreateExperience(ctx context.Context, userID string, payload *experience.CreateExperienceRequest) (*experience.ExperienceResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
+		return nil, err
 	}
 
-	// Business logic: Validate date ranges
-	if payload.StartDate != nil && payload.EndDate != nil {
-		if payload.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	}
-
-	// Business logic: Check for duplicate experience entries
+	// Business logic: Check the entry against the resume's other experience entries
 	existingExperiences, err := s.experienceRepo.GetExperienceByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing experience: %w", err)
 	}
 
-	// Check for duplicates based on company and position
-	for _, existing := range existingExperiences {
-		if existing.Company == payload.Company && existing.Position == payload.Position {
-			return nil, errs.NewBadRequestError(
-				"experience entry with same company and position already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := experience.Experience{
+		Company:   payload.Company,
+		Position:  payload.Position,
+		StartDate: payload.StartDate,
+		EndDate:   payload.EndDate,
+	}
+	if violation := checkExperience(entry, existingExperiences); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -76,6 +72,15 @@ func (s *ExperienceService) CreateExperience(ctx context.Context, userID string,
 
 	// Convert to response DTO
 	response := s.convertToExperienceResponse(experienceItem)
+	s.server.Events.Publish(ctx, experienceItem.ResumeID, events.EntityExperience, events.ActionCreated, &experienceItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &experienceItem.ResumeID,
+		EntityType: events.EntityExperience,
+		EntityID:   &experienceItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -119,7 +124,7 @@ func (s *ExperienceService) GetExperienceByResumeID(ctx context.Context, userID
 }
 
 // UpdateExperience updates an experience entry
-func (s *ExperienceService) UpdateExperience(ctx context.Context, userID string, experienceID uuid.UUID, payload *experience.UpdateExperienceRequest) (*experience.ExperienceResponse, error) {
+func (s *ExperienceService) UpdateExperience(ctx context.Context, userID string, experienceID uuid.UUID, ifMatch string, payload *experience.UpdateExperienceRequest) (*experience.ExperienceResponse, error) {
 	// Check if experience exists and belongs to user
 	existingExperience, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
 	if err != nil {
@@ -129,54 +134,76 @@ func (s *ExperienceService) UpdateExperience(ctx context.Context, userID string,
 		return nil, fmt.Errorf("failed to get existing experience: %w", err)
 	}
 
-	// Business logic: Validate date ranges
-	if payload.StartDate != nil && payload.EndDate != nil {
-		if payload.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.StartDate != nil && existingExperience.EndDate != nil {
-		if payload.StartDate.After(*existingExperience.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
-	} else if payload.EndDate != nil && existingExperience.StartDate != nil {
-		if existingExperience.StartDate.After(*payload.EndDate) {
-			return nil, errs.NewBadRequestError(
-				"start date cannot be after end date",
-				false, nil, nil, nil,
-			)
-		}
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingExperience.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the experience
+	if err := etag.Check(ifMatch, existingExperience.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Check the updated entry against the resume's other experience entries
+	experiences, err := s.experienceRepo.GetExperienceByResumeID(ctx, userID, existingExperience.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing experience: %w", err)
+	}
+
+	others := slices.DeleteFunc(experiences, func(e experience.Experience) bool { return e.ID == experienceID })
+	if violation := checkExperience(applyExperienceUpdate(*existingExperience, payload), others); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Update experience in repository
-	updatedExperience, err := s.experienceRepo.UpdateExperience(ctx, userID, experienceID, payload)
+	updatedExperience, err := s.experienceRepo.UpdateExperience(ctx, userID, experienceID, existingExperience.UpdatedAt, payload)
 	if err != nil {
+		// The experience changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update experience: %w", err)
 	}
 
-	return s.convertToExperienceResponse(updatedExperience), nil
+	response := s.convertToExperienceResponse(updatedExperience)
+	s.server.Events.Publish(ctx, updatedExperience.ResumeID, events.EntityExperience, events.ActionUpdated, &updatedExperience.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedExperience.ResumeID,
+		EntityType: events.EntityExperience,
+		EntityID:   &updatedExperience.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToExperienceResponse(existingExperience),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateExperienceOrder updates the order of multiple experience entries
 func (s *ExperienceService) BulkUpdateExperienceOrder(ctx context.Context, userID string, payload *experience.BulkUpdateExperienceRequest) error {
 	// Validate that all experience entries belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, expUpdate := range payload.Experience {
 		experienceID, err := uuid.Parse(expUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid experience ID", false, nil, nil, nil)
 		}
-		_, err = s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
+		existingExperience, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:experience" {
 				return errs.NewNotFoundError("experience not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify experience ownership: %w", err)
 		}
+		resumeIDs[existingExperience.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -185,13 +212,24 @@ func (s *ExperienceService) BulkUpdateExperienceOrder(ctx context.Context, userI
 		return fmt.Errorf("failed to update experience order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntityExperience, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntityExperience,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteExperience deletes an experience entry
-func (s *ExperienceService) DeleteExperience(ctx context.Context, userID string, experienceID uuid.UUID) error {
+func (s *ExperienceService) DeleteExperience(ctx context.Context, userID string, experienceID uuid.UUID, ifMatch string) error {
 	// Check if experience exists and belongs to user
-	_, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
+	existingExperience, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:experience" {
 			return errs.NewNotFoundError("experience not found", false, nil)
@@ -199,12 +237,36 @@ func (s *ExperienceService) DeleteExperience(ctx context.Context, userID string,
 		return fmt.Errorf("failed to get existing experience: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingExperience.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the experience
+	if err := etag.Check(ifMatch, existingExperience.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete experience
-	err = s.experienceRepo.DeleteExperience(ctx, userID, experienceID)
+	err = s.experienceRepo.DeleteExperience(ctx, userID, experienceID, existingExperience.UpdatedAt)
 	if err != nil {
+		// The experience changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete experience: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingExperience.ResumeID, events.EntityExperience, events.ActionDeleted, &experienceID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingExperience.ResumeID,
+		EntityType: events.EntityExperience,
+		EntityID:   &experienceID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToExperienceResponse(existingExperience),
+	})
+
 	return nil
 }
 
@@ -212,15 +274,18 @@ func (s *ExperienceService) DeleteExperience(ctx context.Context, userID string,
 
 func (s *ExperienceService) convertToExperienceResponse(experienceItem *experience.Experience) *experience.ExperienceResponse {
 	response := &experience.ExperienceResponse{
-		ID:          experienceItem.ID.String(),
-		ResumeID:    experienceItem.ResumeID,
-		Company:     experienceItem.Company,
-		Position:    experienceItem.Position,
-		Location:    experienceItem.Location,
-		Description: experienceItem.Description,
-		OrderIndex:  experienceItem.OrderIndex,
-		CreatedAt:   experienceItem.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:   experienceItem.UpdatedAt.Format(time.RFC3339),
+		ID:               experienceItem.ID.String(),
+		ResumeID:         experienceItem.ResumeID,
+		LibraryItemID:    experienceItem.LibraryItemID,
+		OverriddenFields: experienceItem.OverriddenFields,
+		Company:          experienceItem.Company,
+		Position:         experienceItem.Position,
+		Location:         experienceItem.Location,
+		Description:      experienceItem.Description,
+		OrderIndex:       experienceItem.OrderIndex,
+		CreatedAt:        experienceItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        experienceItem.UpdatedAt.Format(time.RFC3339),
+		ETag:             etag.Format(experienceItem.UpdatedAt),
 	}
 
 	// Handle optional date fields
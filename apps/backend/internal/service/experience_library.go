@@ -0,0 +1,333 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+)
+
+// CreateLibraryExperience adds an experience entry to the user's career library
+func (s *ExperienceService) CreateLibraryExperience(ctx context.Context, userID string, payload *experience.CreateLibraryExperienceRequest) (*experience.LibraryExperienceResponse, error) {
+	// Business logic: Validate date ranges
+	if payload.StartDate != nil && payload.EndDate != nil {
+		if payload.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	libraryItem, err := s.experienceRepo.CreateLibraryExperience(ctx, userID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library experience: %w", err)
+	}
+
+	response := s.convertToLibraryExperienceResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryExperience,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetLibraryExperienceByID retrieves a career library experience entry by ID
+func (s *ExperienceService) GetLibraryExperienceByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*experience.LibraryExperienceResponse, error) {
+	libraryItem, err := s.experienceRepo.GetLibraryExperienceByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_experience" {
+			return nil, errs.NewNotFoundError("library experience not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library experience: %w", err)
+	}
+
+	return s.convertToLibraryExperienceResponse(libraryItem), nil
+}
+
+// GetLibraryExperienceByUserID retrieves all experience entries in the user's career library
+func (s *ExperienceService) GetLibraryExperienceByUserID(ctx context.Context, userID string) ([]experience.LibraryExperienceResponse, error) {
+	libraryItems, err := s.experienceRepo.GetLibraryExperienceByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library experience entries: %w", err)
+	}
+
+	// Convert to response DTOs
+	responses := make([]experience.LibraryExperienceResponse, len(libraryItems))
+	for i, item := range libraryItems {
+		responses[i] = *s.convertToLibraryExperienceResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// UpdateLibraryExperience updates a career library experience entry; every resume linked to it
+// picks up the change for fields it has not overridden
+func (s *ExperienceService) UpdateLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string, payload *experience.UpdateLibraryExperienceRequest) (*experience.LibraryExperienceResponse, error) {
+	// Check if library item exists and belongs to user
+	existingItem, err := s.experienceRepo.GetLibraryExperienceByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_experience" {
+			return nil, errs.NewNotFoundError("library experience not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing library experience: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library experience
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Validate date ranges
+	if payload.StartDate != nil && payload.EndDate != nil {
+		if payload.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.StartDate != nil && existingItem.EndDate != nil {
+		if payload.StartDate.After(*existingItem.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	} else if payload.EndDate != nil && existingItem.StartDate != nil {
+		if existingItem.StartDate.After(*payload.EndDate) {
+			return nil, errs.NewBadRequestError(
+				"start date cannot be after end date",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	updatedItem, err := s.experienceRepo.UpdateLibraryExperience(ctx, userID, libraryItemID, existingItem.UpdatedAt, payload)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to update library experience: %w", err)
+	}
+
+	response := s.convertToLibraryExperienceResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryExperience,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToLibraryExperienceResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteLibraryExperience removes a career library experience entry. Linked resume entries keep
+// their current content and become standalone.
+func (s *ExperienceService) DeleteLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string) error {
+	existingItem, err := s.experienceRepo.GetLibraryExperienceByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_experience" {
+			return errs.NewNotFoundError("library experience not found", false, nil)
+		}
+		return fmt.Errorf("failed to get existing library experience: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library experience
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.experienceRepo.DeleteLibraryExperience(ctx, userID, libraryItemID, existingItem.UpdatedAt)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
+		return fmt.Errorf("failed to delete library experience: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryExperience,
+		EntityID:   &libraryItemID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToLibraryExperienceResponse(existingItem),
+	})
+
+	return nil
+}
+
+// LinkLibraryExperience adds a career library experience entry to a resume
+func (s *ExperienceService) LinkLibraryExperience(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *experience.LinkLibraryExperienceRequest) (*experience.ExperienceResponse, error) {
+	// Only the owner may link library items, since a collaborator's library
+	// is not theirs to edit or delete
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify library item belongs to user
+	_, err = s.experienceRepo.GetLibraryExperienceByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_experience" {
+			return nil, errs.NewNotFoundError("library experience not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library experience: %w", err)
+	}
+
+	// Set default order index if not provided
+	if payload.OrderIndex == 0 {
+		existingItems, err := s.experienceRepo.GetExperienceByResumeID(ctx, userID, payload.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing experience entries: %w", err)
+		}
+		payload.OrderIndex = len(existingItems) + 1
+	}
+
+	linkedItem, err := s.experienceRepo.LinkLibraryExperience(ctx, userID, libraryItemID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library experience: %w", err)
+	}
+
+	response := s.convertToExperienceResponse(linkedItem)
+	s.server.Events.Publish(ctx, linkedItem.ResumeID, events.EntityExperience, events.ActionCreated, &linkedItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &linkedItem.ResumeID,
+		EntityType: events.EntityExperience,
+		EntityID:   &linkedItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// PromoteExperience moves a resume experience entry into the career library and links the
+// entry to it
+func (s *ExperienceService) PromoteExperience(ctx context.Context, userID string, experienceID uuid.UUID) (*experience.LibraryExperienceResponse, error) {
+	existingItem, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:experience" {
+			return nil, errs.NewNotFoundError("experience not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing experience: %w", err)
+	}
+
+	// Only the owner may promote: the entry would otherwise move into a
+	// collaborator's library, out of the owner's control
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Linked entries already live in the library
+	if existingItem.LibraryItemID != nil {
+		return nil, errs.NewBadRequestError(
+			"experience is already linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	libraryItem, err := s.experienceRepo.PromoteExperience(ctx, userID, experienceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote experience: %w", err)
+	}
+
+	response := s.convertToLibraryExperienceResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryExperience,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// ResetExperienceOverrides drops the per-resume overrides of a linked experience entry
+func (s *ExperienceService) ResetExperienceOverrides(ctx context.Context, userID string, experienceID uuid.UUID, ifMatch string) (*experience.ExperienceResponse, error) {
+	existingItem, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:experience" {
+			return nil, errs.NewNotFoundError("experience not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing experience: %w", err)
+	}
+
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the experience
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if existingItem.LibraryItemID == nil {
+		return nil, errs.NewBadRequestError(
+			"experience is not linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	err = s.experienceRepo.ResetExperienceOverrides(ctx, userID, experienceID, existingItem.UpdatedAt)
+	if err != nil {
+		// The experience changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to reset experience overrides: %w", err)
+	}
+
+	resetItem, err := s.experienceRepo.GetExperienceByID(ctx, userID, experienceID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experience: %w", err)
+	}
+
+	response := s.convertToExperienceResponse(resetItem)
+	s.server.Events.Publish(ctx, resetItem.ResumeID, events.EntityExperience, events.ActionUpdated, &resetItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resetItem.ResumeID,
+		EntityType: events.EntityExperience,
+		EntityID:   &resetItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToExperienceResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+func (s *ExperienceService) convertToLibraryExperienceResponse(libraryItem *experience.LibraryExperience) *experience.LibraryExperienceResponse {
+	return &experience.LibraryExperienceResponse{
+		ID:          libraryItem.ID.String(),
+		Company:     libraryItem.Company,
+		Position:    libraryItem.Position,
+		StartDate:   libraryItem.StartDate,
+		EndDate:     libraryItem.EndDate,
+		Location:    libraryItem.Location,
+		Description: libraryItem.Description,
+		CreatedAt:   libraryItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   libraryItem.UpdatedAt.Format(time.RFC3339),
+		ETag:        etag.Format(libraryItem.UpdatedAt),
+	}
+}
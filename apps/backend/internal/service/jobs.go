@@ -0,0 +1,188 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/webhook"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// registerJobHandlers wires background tasks that need service-layer access
+// into the job server. It must run before the job server is started.
+func registerJobHandlers(s *server.Server, services *Services) error {
+	if s.Job == nil {
+		return nil
+	}
+
+	s.Job.SetEmailDeliveryLog(services.EmailDelivery)
+	s.Job.SetEmailPreferences(services.Notification)
+
+	// Recurring jobs run on the schedules from the config
+	recurringJobs := []job.RecurringJob{
+		{
+			Cron: s.Config.Trash.PurgeCron,
+			Task: job.NewPurgeTrashTask(),
+			Run:  services.Resume.PurgeExpiredResumes,
+		},
+		{
+			Cron: s.Config.Outbox.DispatchCron,
+			Task: job.NewDispatchOutboxTask(),
+			Run: func(ctx context.Context) (int64, error) {
+				dispatched, err := services.Outbox.DispatchPendingEvents(ctx)
+				return int64(dispatched), err
+			},
+		},
+		{
+			Cron: s.Config.Outbox.PruneCron,
+			Task: job.NewPruneOutboxTask(),
+			Run:  services.Outbox.PruneDispatchedEvents,
+		},
+		{
+			Cron: s.Config.CertReminder.Cron,
+			Task: job.NewCertificationRemindersTask(),
+			Run: func(ctx context.Context) (int64, error) {
+				sent, err := services.Certification.SendExpiryReminders(ctx)
+				return int64(sent), err
+			},
+		},
+		{
+			Cron: s.Config.DataExport.PurgeCron,
+			Task: job.NewPurgeDataExportsTask(),
+			Run:  services.DataExport.PurgeExpiredExports,
+		},
+		{
+			Cron: s.Config.ResumeExport.PurgeCron,
+			Task: job.NewPurgeResumeExportsTask(),
+			Run:  services.ResumeExport.PurgeExpiredExports,
+		},
+		{
+			Cron: s.Config.Maintenance.PruneTasksCron,
+			Task: job.NewPruneCompletedTasksTask(),
+			Run:  services.Maintenance.PruneCompletedTasks,
+		},
+		{
+			Cron: s.Config.Maintenance.CompactOrderCron,
+			Task: job.NewCompactOrderIndexesTask(),
+			Run:  services.Maintenance.CompactOrderIndexes,
+		},
+		{
+			Cron: s.Config.Maintenance.IntegrityCheckCron,
+			Task: job.NewCheckSectionsTask(),
+			Run:  services.Maintenance.CheckSections,
+		},
+	}
+	for _, recurringJob := range recurringJobs {
+		if err := s.Job.RegisterRecurringJob(recurringJob); err != nil {
+			return fmt.Errorf("failed to schedule %s: %w", recurringJob.Task.Type(), err)
+		}
+	}
+
+	s.Job.HandleFunc(job.TaskEraseAccount, func(ctx context.Context, t *asynq.Task) error {
+		var p job.EraseAccountPayload
+		if err := json.Unmarshal(t.Payload(), &p); err != nil {
+			return fmt.Errorf("failed to unmarshal erase account payload: %w", err)
+		}
+
+		erasureID, err := uuid.Parse(p.ErasureID)
+		if err != nil {
+			return fmt.Errorf("invalid erasure id %q: %w", p.ErasureID, err)
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+		if err := services.Erasure.EraseAccount(ctx, erasureID, retried >= maxRetry); err != nil {
+			s.Logger.Error().Err(err).Str("task", t.Type()).Str("erasure_id", p.ErasureID).Msg("Failed to erase account")
+			return err
+		}
+
+		s.Logger.Info().
+			Str("task", t.Type()).
+			Str("erasure_id", p.ErasureID).
+			Msg("Erased account")
+		return nil
+	})
+
+	s.Job.HandleFunc(job.TaskBuildDataExport, func(ctx context.Context, t *asynq.Task) error {
+		var p job.BuildDataExportPayload
+		if err := json.Unmarshal(t.Payload(), &p); err != nil {
+			return fmt.Errorf("failed to unmarshal data export payload: %w", err)
+		}
+
+		exportID, err := uuid.Parse(p.ExportID)
+		if err != nil {
+			return fmt.Errorf("invalid data export id %q: %w", p.ExportID, err)
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+		if err := services.DataExport.BuildExport(ctx, exportID, retried >= maxRetry); err != nil {
+			s.Logger.Error().Err(err).Str("task", t.Type()).Str("export_id", p.ExportID).Msg("Failed to build data export")
+			return err
+		}
+		return nil
+	})
+
+	s.Job.HandleFunc(job.TaskRenderResumeExport, func(ctx context.Context, t *asynq.Task) error {
+		var p job.RenderResumeExportPayload
+		if err := json.Unmarshal(t.Payload(), &p); err != nil {
+			return fmt.Errorf("failed to unmarshal resume export payload: %w", err)
+		}
+
+		exportID, err := uuid.Parse(p.ExportID)
+		if err != nil {
+			return fmt.Errorf("invalid resume export id %q: %w", p.ExportID, err)
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+		if err := services.ResumeExport.RenderExport(ctx, exportID, retried >= maxRetry); err != nil {
+			s.Logger.Error().Err(err).Str("task", t.Type()).Str("export_id", p.ExportID).Msg("Failed to render resume export")
+			return err
+		}
+		return nil
+	})
+
+	// Resume events are fanned out to the webhook endpoints subscribed to them
+	s.Job.HandleFunc(job.EventResumeUpdated, job.EventHandler(func(ctx context.Context, eventID string, event job.ResumeChangedEvent) error {
+		return services.Webhook.FanOutResumeEvent(ctx, eventID, webhook.EventResumeUpdated, event)
+	}))
+	s.Job.HandleFunc(job.EventResumeDeleted, job.EventHandler(func(ctx context.Context, eventID string, event job.ResumeChangedEvent) error {
+		return services.Webhook.FanOutResumeEvent(ctx, eventID, webhook.EventResumeDeleted, event)
+	}))
+
+	s.Job.HandleFunc(job.TaskWebhookDelivery, func(ctx context.Context, t *asynq.Task) error {
+		var p job.WebhookDeliveryPayload
+		if err := json.Unmarshal(t.Payload(), &p); err != nil {
+			return fmt.Errorf("failed to unmarshal webhook delivery payload: %w", err)
+		}
+
+		deliveryID, err := uuid.Parse(p.DeliveryID)
+		if err != nil {
+			return fmt.Errorf("invalid webhook delivery id %q: %w", p.DeliveryID, err)
+		}
+
+		retried, _ := asynq.GetRetryCount(ctx)
+		maxRetry, _ := asynq.GetMaxRetry(ctx)
+
+		if err := services.Webhook.DeliverWebhook(ctx, deliveryID, retried >= maxRetry); err != nil {
+			s.Logger.Warn().
+				Err(err).
+				Str("task", t.Type()).
+				Str("delivery_id", p.DeliveryID).
+				Int("retried", retried).
+				Msg("Webhook delivery failed")
+			return err
+		}
+		return nil
+	})
+
+	return nil
+}
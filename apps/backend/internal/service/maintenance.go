@@ -0,0 +1,68 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type MaintenanceService struct {
+	server          *server.Server
+	maintenanceRepo *repository.MaintenanceRepository
+}
+
+func NewMaintenanceService(s *server.Server, repos *repository.Repositories) *MaintenanceService {
+	return &MaintenanceService{
+		server:          s,
+		maintenanceRepo: repos.Maintenance,
+	}
+}
+
+// PruneCompletedTasks deletes the completed task records older than the
+// configured retention from Redis
+func (s *MaintenanceService) PruneCompletedTasks(ctx context.Context) (int64, error) {
+	retention := time.Duration(s.server.Config.Maintenance.TaskRetentionHours) * time.Hour
+
+	pruned, err := s.server.Job.PruneCompletedTasks(retention)
+	if err != nil {
+		return int64(pruned), fmt.Errorf("failed to prune completed tasks: %w", err)
+	}
+
+	return int64(pruned), nil
+}
+
+// CompactOrderIndexes closes the order_index gaps left by deleted items
+func (s *MaintenanceService) CompactOrderIndexes(ctx context.Context) (int64, error) {
+	compacted, err := s.maintenanceRepo.CompactOrderIndexes(ctx)
+	if err != nil {
+		return compacted, fmt.Errorf("failed to compact order indexes: %w", err)
+	}
+
+	return compacted, nil
+}
+
+// CheckSections reports the sections that have no items. They are not
+// removed, an empty section may be one the user has yet to fill.
+func (s *MaintenanceService) CheckSections(ctx context.Context) (int64, error) {
+	sectionIDs, err := s.maintenanceRepo.GetEmptySectionIDs(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to check sections: %w", err)
+	}
+
+	if len(sectionIDs) > 0 {
+		ids := make([]string, len(sectionIDs))
+		for i, id := range sectionIDs {
+			ids[i] = id.String()
+		}
+
+		s.server.Logger.Warn().
+			Int("count", len(ids)).
+			Strs("section_ids", ids).
+			Msg("Found sections without items")
+	}
+
+	return int64(len(sectionIDs)), nil
+}
@@ -0,0 +1,166 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/email"
+	"github.com/recreatedev/Resumify/internal/model/notification"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+// NotificationService keeps the users' notification preferences. It is the
+// email.PreferenceChecker of the job server's email client.
+type NotificationService struct {
+	server           *server.Server
+	notificationRepo *repository.NotificationRepository
+	unsubscribe      *email.UnsubscribeSigner
+}
+
+func NewNotificationService(s *server.Server, repos *repository.Repositories) *NotificationService {
+	return &NotificationService{
+		server:           s,
+		notificationRepo: repos.Notification,
+		unsubscribe:      email.NewUnsubscribeSigner(s.Config.Auth.SecretKey),
+	}
+}
+
+// GetPreferences returns the user's notification preferences
+func (s *NotificationService) GetPreferences(ctx context.Context, userID string) (*notification.PreferencesResponse, error) {
+	preferences, err := s.getPreferences(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToPreferencesResponse(preferences), nil
+}
+
+// UpdatePreferences changes the categories set in the request
+func (s *NotificationService) UpdatePreferences(ctx context.Context, userID string, payload *notification.UpdatePreferencesRequest) (*notification.PreferencesResponse, error) {
+	var saved *notification.Preferences
+	err := s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		preferences, err := s.getPreferences(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		if payload.Reminders != nil {
+			preferences.Reminders = *payload.Reminders
+		}
+		if payload.ReviewRequests != nil {
+			preferences.ReviewRequests = *payload.ReviewRequests
+		}
+		if payload.ShareViewDigests != nil {
+			preferences.ShareViewDigests = *payload.ShareViewDigests
+		}
+		if payload.ProductNews != nil {
+			preferences.ProductNews = *payload.ProductNews
+		}
+
+		saved, err = s.notificationRepo.SavePreferences(ctx, preferences)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to update notification preferences: %w", err)
+	}
+
+	return s.convertToPreferencesResponse(saved), nil
+}
+
+// Unsubscribe opts the user of a signed unsubscribe token out of the token's
+// category, or out of every category when it has none. Using a token again
+// has no further effect.
+func (s *NotificationService) Unsubscribe(ctx context.Context, token string) (*notification.UnsubscribeResponse, error) {
+	userID, category, err := s.unsubscribe.Verify(token)
+	if err != nil {
+		return nil, errs.NewBadRequestError("invalid unsubscribe link", false, nil, nil, nil)
+	}
+
+	var saved *notification.Preferences
+	err = s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		preferences, err := s.getPreferences(ctx, userID)
+		if err != nil {
+			return err
+		}
+
+		switch category {
+		case email.CategoryReminders:
+			preferences.Reminders = false
+		case email.CategoryReviewRequests:
+			preferences.ReviewRequests = false
+		case email.CategoryShareViewDigests:
+			preferences.ShareViewDigests = false
+		case email.CategoryProductNews:
+			preferences.ProductNews = false
+		default:
+			preferences.Reminders = false
+			preferences.ReviewRequests = false
+			preferences.ShareViewDigests = false
+			preferences.ProductNews = false
+		}
+
+		saved, err = s.notificationRepo.SavePreferences(ctx, preferences)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to unsubscribe: %w", err)
+	}
+
+	response := &notification.UnsubscribeResponse{
+		Preferences: *s.convertToPreferencesResponse(saved),
+	}
+	if category != "" {
+		name := string(category)
+		response.Category = &name
+	}
+
+	return response, nil
+}
+
+// AllowsEmail tells whether the user wants the emails of the category
+func (s *NotificationService) AllowsEmail(ctx context.Context, userID string, category email.Category) (bool, error) {
+	preferences, err := s.getPreferences(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+
+	switch category {
+	case email.CategoryReminders:
+		return preferences.Reminders, nil
+	case email.CategoryReviewRequests:
+		return preferences.ReviewRequests, nil
+	case email.CategoryShareViewDigests:
+		return preferences.ShareViewDigests, nil
+	case email.CategoryProductNews:
+		return preferences.ProductNews, nil
+	default:
+		return true, nil
+	}
+}
+
+// getPreferences returns the stored preferences, or the defaults when the
+// user never changed them
+func (s *NotificationService) getPreferences(ctx context.Context, userID string) (*notification.Preferences, error) {
+	preferences, err := s.notificationRepo.GetPreferences(ctx, userID)
+	if errors.Is(err, pgx.ErrNoRows) {
+		return notification.DefaultPreferences(userID), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get notification preferences: %w", err)
+	}
+
+	return preferences, nil
+}
+
+func (s *NotificationService) convertToPreferencesResponse(preferences *notification.Preferences) *notification.PreferencesResponse {
+	return &notification.PreferencesResponse{
+		Reminders:        preferences.Reminders,
+		ReviewRequests:   preferences.ReviewRequests,
+		ShareViewDigests: preferences.ShareViewDigests,
+		ProductNews:      preferences.ProductNews,
+	}
+}
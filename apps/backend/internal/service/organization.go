@@ -0,0 +1,132 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/model/organization"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type OrganizationService struct {
+	server  *server.Server
+	orgRepo *repository.OrganizationRepository
+}
+
+func NewOrganizationService(s *server.Server, repos *repository.Repositories) *OrganizationService {
+	return &OrganizationService{
+		server:  s,
+		orgRepo: repos.Organization,
+	}
+}
+
+// SyncMembership mirrors the user's membership of their active organization
+// from the session claims, so the organization's resumes are authorized by the
+// role and permissions the user currently holds in Clerk. It only writes when
+// the membership is missing or its roles changed, and never recreates a
+// membership that a Clerk webhook removed; session claims can outlive it.
+func (s *OrganizationService) SyncMembership(ctx context.Context, userID, organizationID, orgRole string, permissions []string) error {
+	resumeRole := organization.ResumeRoleFor(orgRole, permissions)
+
+	member, err := s.orgRepo.GetMember(ctx, organizationID, userID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to get organization member: %w", err)
+	}
+
+	// Members without any resume permission get no access to the organization's resumes
+	if resumeRole == "" {
+		if member == nil {
+			return nil
+		}
+		if err := s.orgRepo.DeleteMember(ctx, organizationID, userID); err != nil {
+			return fmt.Errorf("failed to remove organization member: %w", err)
+		}
+		return nil
+	}
+
+	if member == nil {
+		if err := s.orgRepo.AddMember(ctx, organizationID, userID, orgRole, resumeRole); err != nil {
+			return fmt.Errorf("failed to add organization member: %w", err)
+		}
+		return nil
+	}
+
+	if member.OrgRole == orgRole && member.ResumeRole == resumeRole {
+		return nil
+	}
+
+	if err := s.orgRepo.UpdateMember(ctx, organizationID, userID, orgRole, resumeRole); err != nil {
+		return fmt.Errorf("failed to sync organization member: %w", err)
+	}
+
+	return nil
+}
+
+// AddMembership applies a membership created in Clerk. Unlike SyncMembership it
+// also restores a membership that was removed before.
+func (s *OrganizationService) AddMembership(ctx context.Context, userID, organizationID, orgRole string, permissions []string) error {
+	resumeRole := organization.ResumeRoleFor(orgRole, permissions)
+
+	err := s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		// Members without any resume permission get no access to the organization's resumes
+		if resumeRole == "" {
+			return s.orgRepo.DeleteMember(ctx, organizationID, userID)
+		}
+		return s.orgRepo.UpsertMember(ctx, organizationID, userID, orgRole, resumeRole)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to add organization member: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateMembership applies a role change reported by Clerk. Only memberships
+// already mirrored are changed; new ones are added by AddMembership and
+// SyncMembership.
+func (s *OrganizationService) UpdateMembership(ctx context.Context, userID, organizationID, orgRole string, permissions []string) error {
+	resumeRole := organization.ResumeRoleFor(orgRole, permissions)
+
+	if resumeRole == "" {
+		if err := s.orgRepo.DeleteMember(ctx, organizationID, userID); err != nil {
+			return fmt.Errorf("failed to remove organization member: %w", err)
+		}
+		return nil
+	}
+
+	if err := s.orgRepo.UpdateMember(ctx, organizationID, userID, orgRole, resumeRole); err != nil {
+		return fmt.Errorf("failed to update organization member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveMembership revokes the user's access to the organization's resumes
+// after they left or were removed from it. The removal is recorded so that
+// requests made with the user's earlier session claims do not restore it.
+func (s *OrganizationService) RemoveMembership(ctx context.Context, userID, organizationID string) error {
+	err := s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		if err := s.orgRepo.RecordMemberRemoval(ctx, organizationID, userID); err != nil {
+			return err
+		}
+		return s.orgRepo.DeleteMember(ctx, organizationID, userID)
+	})
+	if err != nil {
+		return fmt.Errorf("failed to remove organization member: %w", err)
+	}
+
+	return nil
+}
+
+// RemoveOrganization revokes every member's access to a deleted
+// organization's resumes
+func (s *OrganizationService) RemoveOrganization(ctx context.Context, organizationID string) error {
+	if err := s.orgRepo.DeleteOrganizationMembers(ctx, organizationID); err != nil {
+		return fmt.Errorf("failed to remove organization members: %w", err)
+	}
+
+	return nil
+}
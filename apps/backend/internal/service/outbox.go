@@ -0,0 +1,75 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/hibiken/asynq"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/outbox"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type OutboxService struct {
+	server     *server.Server
+	outboxRepo *repository.OutboxRepository
+}
+
+func NewOutboxService(s *server.Server, repos *repository.Repositories) *OutboxService {
+	return &OutboxService{
+		server:     s,
+		outboxRepo: repos.Outbox,
+	}
+}
+
+// DispatchPendingEvents moves a batch of committed domain events from the
+// outbox to the job queue. Delivery is at least once: an event whose dispatch
+// is not recorded is dispatched again, and handlers key their side effects by
+// event ID. Events that fail to dispatch Outbox.MaxAttempts times are
+// dead-lettered and left in the outbox with their last error.
+func (s *OutboxService) DispatchPendingEvents(ctx context.Context) (int, error) {
+	outboxConfig := s.server.Config.Outbox
+
+	dispatched, deadLettered, err := s.outboxRepo.DispatchPendingEvents(ctx, outboxConfig.BatchSize, outboxConfig.MaxAttempts, func(ctx context.Context, event *outbox.Event) error {
+		task, err := job.NewDomainEventTask(event.ID, event.EventType, event.Payload)
+		if err != nil {
+			return fmt.Errorf("failed to create %s task: %w", event.EventType, err)
+		}
+
+		// The task ID is the event ID, so a conflict means the event was
+		// already enqueued by an earlier run
+		if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return fmt.Errorf("failed to enqueue %s task: %w", event.EventType, err)
+		}
+
+		return nil
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to dispatch outbox events: %w", err)
+	}
+
+	if deadLettered > 0 {
+		s.server.Logger.Error().
+			Int("dead_lettered", deadLettered).
+			Int("max_attempts", outboxConfig.MaxAttempts).
+			Msg("Dead-lettered outbox events that failed to dispatch")
+	}
+
+	return dispatched, nil
+}
+
+// PruneDispatchedEvents deletes the events dispatched longer ago than the
+// outbox retention
+func (s *OutboxService) PruneDispatchedEvents(ctx context.Context) (int64, error) {
+	retention := time.Duration(s.server.Config.Outbox.RetentionHours) * time.Hour
+
+	pruned, err := s.outboxRepo.PruneDispatchedEvents(ctx, time.Now().Add(-retention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune outbox events: %w", err)
+	}
+
+	return pruned, nil
+}
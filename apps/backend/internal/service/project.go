@@ -2,12 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
-	"net/url"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/project"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
@@ -17,6 +23,7 @@ type ProjectService struct {
 	server      *server.Server
 	projectRepo *repository.ProjectRepository
 	resumeRepo  *repository.ResumeRepository
+	auditLog    auditLog
 }
 
 func NewProjectService(s *server.Server, repos *repository.Repositories) *ProjectService {
@@ -24,44 +31,27 @@ func NewProjectService(s *server.Server, repos *repository.Repositories) *Projec
 		server:      s,
 		projectRepo: repos.Project,
 		resumeRepo:  repos.Resume,
+		auditLog:    newAuditLog(s, repos),
 	}
 }
 
 // CreateProject creates a new project entry
 func (s *ProjectService) CreateProject(ctx context.Context, userID string, payload *project.CreateProjectRequest) (*project.ProjectResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
+		return nil, err
 	}
 
-	// Business logic: Validate URL if provided
-	if payload.Link != nil && *payload.Link != "" {
-		if _, err := url.Parse(*payload.Link); err != nil {
-			return nil, errs.NewBadRequestError(
-				"invalid project URL",
-				false, nil, nil, nil,
-			)
-		}
-	}
-
-	// Business logic: Check for duplicate project entries
+	// Business logic: Check the project against the resume's other projects
 	existingProjects, err := s.projectRepo.GetProjectsByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing projects: %w", err)
 	}
 
-	// Check for duplicates based on name
-	for _, existing := range existingProjects {
-		if existing.Name == payload.Name {
-			return nil, errs.NewBadRequestError(
-				"project with same name already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := project.Project{Name: payload.Name, Link: payload.Link}
+	if violation := checkProject(entry, existingProjects); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -77,6 +67,15 @@ func (s *ProjectService) CreateProject(ctx context.Context, userID string, paylo
 
 	// Convert to response DTO
 	response := s.convertToProjectResponse(projectItem)
+	s.server.Events.Publish(ctx, projectItem.ResumeID, events.EntityProject, events.ActionCreated, &projectItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &projectItem.ResumeID,
+		EntityType: events.EntityProject,
+		EntityID:   &projectItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -120,7 +119,7 @@ func (s *ProjectService) GetProjectsByResumeID(ctx context.Context, userID strin
 }
 
 // UpdateProject updates a project entry
-func (s *ProjectService) UpdateProject(ctx context.Context, userID string, projectID uuid.UUID, payload *project.UpdateProjectRequest) (*project.ProjectResponse, error) {
+func (s *ProjectService) UpdateProject(ctx context.Context, userID string, projectID uuid.UUID, ifMatch string, payload *project.UpdateProjectRequest) (*project.ProjectResponse, error) {
 	// Check if project exists and belongs to user
 	existingProject, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
 	if err != nil {
@@ -130,57 +129,76 @@ func (s *ProjectService) UpdateProject(ctx context.Context, userID string, proje
 		return nil, fmt.Errorf("failed to get existing project: %w", err)
 	}
 
-	// Business logic: Validate URL if provided
-	if payload.Link != nil && *payload.Link != "" {
-		if _, err := url.Parse(*payload.Link); err != nil {
-			return nil, errs.NewBadRequestError(
-				"invalid project URL",
-				false, nil, nil, nil,
-			)
-		}
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingProject.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate project names (excluding current project)
-	if payload.Name != nil && *payload.Name != *existingProject.Name {
-		projects, err := s.projectRepo.GetProjectsByResumeID(ctx, userID, existingProject.ResumeID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check existing projects: %w", err)
-		}
+	// Reject writes based on a stale copy of the project
+	if err := etag.Check(ifMatch, existingProject.UpdatedAt); err != nil {
+		return nil, err
+	}
 
-		for _, proj := range projects {
-			if proj.ID != projectID && *proj.Name == *payload.Name {
-				return nil, errs.NewBadRequestError(
-					"project with same name already exists",
-					false, nil, nil, nil,
-				)
-			}
-		}
+	// Business logic: Check the updated project against the resume's other projects
+	projects, err := s.projectRepo.GetProjectsByResumeID(ctx, userID, existingProject.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing projects: %w", err)
+	}
+
+	others := slices.DeleteFunc(projects, func(p project.Project) bool { return p.ID == projectID })
+	if violation := checkProject(applyProjectUpdate(*existingProject, payload), others); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Update project in repository
-	updatedProject, err := s.projectRepo.UpdateProject(ctx, userID, projectID, payload)
+	updatedProject, err := s.projectRepo.UpdateProject(ctx, userID, projectID, existingProject.UpdatedAt, payload)
 	if err != nil {
+		// The project changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update project: %w", err)
 	}
 
-	return s.convertToProjectResponse(updatedProject), nil
+	response := s.convertToProjectResponse(updatedProject)
+	s.server.Events.Publish(ctx, updatedProject.ResumeID, events.EntityProject, events.ActionUpdated, &updatedProject.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedProject.ResumeID,
+		EntityType: events.EntityProject,
+		EntityID:   &updatedProject.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToProjectResponse(existingProject),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateProjectOrder updates the order of multiple project entries
 func (s *ProjectService) BulkUpdateProjectOrder(ctx context.Context, userID string, payload *project.BulkUpdateProjectsRequest) error {
 	// Validate that all project entries belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, projUpdate := range payload.Projects {
 		projectID, err := uuid.Parse(projUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid project ID", false, nil, nil, nil)
 		}
-		_, err = s.projectRepo.GetProjectByID(ctx, userID, projectID)
+		existingProject, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:projects" {
 				return errs.NewNotFoundError("project not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify project ownership: %w", err)
 		}
+		resumeIDs[existingProject.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -189,13 +207,24 @@ func (s *ProjectService) BulkUpdateProjectOrder(ctx context.Context, userID stri
 		return fmt.Errorf("failed to update project order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntityProject, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntityProject,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteProject deletes a project entry
-func (s *ProjectService) DeleteProject(ctx context.Context, userID string, projectID uuid.UUID) error {
+func (s *ProjectService) DeleteProject(ctx context.Context, userID string, projectID uuid.UUID, ifMatch string) error {
 	// Check if project exists and belongs to user
-	_, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
+	existingProject, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:projects" {
 			return errs.NewNotFoundError("project not found", false, nil)
@@ -203,12 +232,36 @@ func (s *ProjectService) DeleteProject(ctx context.Context, userID string, proje
 		return fmt.Errorf("failed to get existing project: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingProject.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the project
+	if err := etag.Check(ifMatch, existingProject.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete project
-	err = s.projectRepo.DeleteProject(ctx, userID, projectID)
+	err = s.projectRepo.DeleteProject(ctx, userID, projectID, existingProject.UpdatedAt)
 	if err != nil {
+		// The project changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete project: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingProject.ResumeID, events.EntityProject, events.ActionDeleted, &projectID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingProject.ResumeID,
+		EntityType: events.EntityProject,
+		EntityID:   &projectID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToProjectResponse(existingProject),
+	})
+
 	return nil
 }
 
@@ -216,16 +269,19 @@ func (s *ProjectService) DeleteProject(ctx context.Context, userID string, proje
 
 func (s *ProjectService) convertToProjectResponse(projectItem *project.Project) *project.ProjectResponse {
 	response := &project.ProjectResponse{
-		ID:           projectItem.ID.String(),
-		ResumeID:     projectItem.ResumeID,
-		Name:         projectItem.Name,
-		Role:         projectItem.Role,
-		Description:  projectItem.Description,
-		Link:         projectItem.Link,
-		Technologies: projectItem.Technologies,
-		OrderIndex:   projectItem.OrderIndex,
-		CreatedAt:    projectItem.CreatedAt.Format(time.RFC3339),
-		UpdatedAt:    projectItem.UpdatedAt.Format(time.RFC3339),
+		ID:               projectItem.ID.String(),
+		ResumeID:         projectItem.ResumeID,
+		LibraryItemID:    projectItem.LibraryItemID,
+		OverriddenFields: projectItem.OverriddenFields,
+		Name:             projectItem.Name,
+		Role:             projectItem.Role,
+		Description:      projectItem.Description,
+		Link:             projectItem.Link,
+		Technologies:     projectItem.Technologies,
+		OrderIndex:       projectItem.OrderIndex,
+		CreatedAt:        projectItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        projectItem.UpdatedAt.Format(time.RFC3339),
+		ETag:             etag.Format(projectItem.UpdatedAt),
 	}
 
 	return response
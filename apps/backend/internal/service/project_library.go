@@ -0,0 +1,319 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/project"
+)
+
+// CreateLibraryProject adds a project entry to the user's career library
+func (s *ProjectService) CreateLibraryProject(ctx context.Context, userID string, payload *project.CreateLibraryProjectRequest) (*project.LibraryProjectResponse, error) {
+	// Business logic: Validate URL if provided
+	if payload.Link != nil && *payload.Link != "" {
+		if _, err := url.Parse(*payload.Link); err != nil {
+			return nil, errs.NewBadRequestError(
+				"invalid project URL",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	libraryItem, err := s.projectRepo.CreateLibraryProject(ctx, userID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library project: %w", err)
+	}
+
+	response := s.convertToLibraryProjectResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryProject,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetLibraryProjectByID retrieves a career library project entry by ID
+func (s *ProjectService) GetLibraryProjectByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*project.LibraryProjectResponse, error) {
+	libraryItem, err := s.projectRepo.GetLibraryProjectByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_projects" {
+			return nil, errs.NewNotFoundError("library project not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library project: %w", err)
+	}
+
+	return s.convertToLibraryProjectResponse(libraryItem), nil
+}
+
+// GetLibraryProjectsByUserID retrieves all projects in the user's career library
+func (s *ProjectService) GetLibraryProjectsByUserID(ctx context.Context, userID string) ([]project.LibraryProjectResponse, error) {
+	libraryItems, err := s.projectRepo.GetLibraryProjectsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library projects: %w", err)
+	}
+
+	// Convert to response DTOs
+	responses := make([]project.LibraryProjectResponse, len(libraryItems))
+	for i, item := range libraryItems {
+		responses[i] = *s.convertToLibraryProjectResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// UpdateLibraryProject updates a career library project entry; every resume linked to it
+// picks up the change for fields it has not overridden
+func (s *ProjectService) UpdateLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string, payload *project.UpdateLibraryProjectRequest) (*project.LibraryProjectResponse, error) {
+	// Check if library item exists and belongs to user
+	existingItem, err := s.projectRepo.GetLibraryProjectByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_projects" {
+			return nil, errs.NewNotFoundError("library project not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing library project: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library project
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Validate URL if provided
+	if payload.Link != nil && *payload.Link != "" {
+		if _, err := url.Parse(*payload.Link); err != nil {
+			return nil, errs.NewBadRequestError(
+				"invalid project URL",
+				false, nil, nil, nil,
+			)
+		}
+	}
+
+	updatedItem, err := s.projectRepo.UpdateLibraryProject(ctx, userID, libraryItemID, existingItem.UpdatedAt, payload)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to update library project: %w", err)
+	}
+
+	response := s.convertToLibraryProjectResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryProject,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToLibraryProjectResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteLibraryProject removes a career library project entry. Linked resume entries keep
+// their current content and become standalone.
+func (s *ProjectService) DeleteLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string) error {
+	existingItem, err := s.projectRepo.GetLibraryProjectByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_projects" {
+			return errs.NewNotFoundError("library project not found", false, nil)
+		}
+		return fmt.Errorf("failed to get existing library project: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library project
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.projectRepo.DeleteLibraryProject(ctx, userID, libraryItemID, existingItem.UpdatedAt)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
+		return fmt.Errorf("failed to delete library project: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryProject,
+		EntityID:   &libraryItemID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToLibraryProjectResponse(existingItem),
+	})
+
+	return nil
+}
+
+// LinkLibraryProject adds a career library project entry to a resume
+func (s *ProjectService) LinkLibraryProject(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *project.LinkLibraryProjectRequest) (*project.ProjectResponse, error) {
+	// Only the owner may link library items, since a collaborator's library
+	// is not theirs to edit or delete
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify library item belongs to user
+	_, err = s.projectRepo.GetLibraryProjectByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_projects" {
+			return nil, errs.NewNotFoundError("library project not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library project: %w", err)
+	}
+
+	// Set default order index if not provided
+	if payload.OrderIndex == 0 {
+		existingItems, err := s.projectRepo.GetProjectsByResumeID(ctx, userID, payload.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing projects: %w", err)
+		}
+		payload.OrderIndex = len(existingItems) + 1
+	}
+
+	linkedItem, err := s.projectRepo.LinkLibraryProject(ctx, userID, libraryItemID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library project: %w", err)
+	}
+
+	response := s.convertToProjectResponse(linkedItem)
+	s.server.Events.Publish(ctx, linkedItem.ResumeID, events.EntityProject, events.ActionCreated, &linkedItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &linkedItem.ResumeID,
+		EntityType: events.EntityProject,
+		EntityID:   &linkedItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// PromoteProject moves a resume project entry into the career library and links the
+// entry to it
+func (s *ProjectService) PromoteProject(ctx context.Context, userID string, projectID uuid.UUID) (*project.LibraryProjectResponse, error) {
+	existingItem, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:projects" {
+			return nil, errs.NewNotFoundError("project not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing project: %w", err)
+	}
+
+	// Only the owner may promote: the entry would otherwise move into a
+	// collaborator's library, out of the owner's control
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Linked entries already live in the library
+	if existingItem.LibraryItemID != nil {
+		return nil, errs.NewBadRequestError(
+			"project is already linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	libraryItem, err := s.projectRepo.PromoteProject(ctx, userID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote project: %w", err)
+	}
+
+	response := s.convertToLibraryProjectResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibraryProject,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// ResetProjectOverrides drops the per-resume overrides of a linked project entry
+func (s *ProjectService) ResetProjectOverrides(ctx context.Context, userID string, projectID uuid.UUID, ifMatch string) (*project.ProjectResponse, error) {
+	existingItem, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:projects" {
+			return nil, errs.NewNotFoundError("project not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing project: %w", err)
+	}
+
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the project
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if existingItem.LibraryItemID == nil {
+		return nil, errs.NewBadRequestError(
+			"project is not linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	err = s.projectRepo.ResetProjectOverrides(ctx, userID, projectID, existingItem.UpdatedAt)
+	if err != nil {
+		// The project changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to reset project overrides: %w", err)
+	}
+
+	resetItem, err := s.projectRepo.GetProjectByID(ctx, userID, projectID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get project: %w", err)
+	}
+
+	response := s.convertToProjectResponse(resetItem)
+	s.server.Events.Publish(ctx, resetItem.ResumeID, events.EntityProject, events.ActionUpdated, &resetItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resetItem.ResumeID,
+		EntityType: events.EntityProject,
+		EntityID:   &resetItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToProjectResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+func (s *ProjectService) convertToLibraryProjectResponse(libraryItem *project.LibraryProject) *project.LibraryProjectResponse {
+	return &project.LibraryProjectResponse{
+		ID:           libraryItem.ID.String(),
+		Name:         libraryItem.Name,
+		Role:         libraryItem.Role,
+		Description:  libraryItem.Description,
+		Link:         libraryItem.Link,
+		Technologies: libraryItem.Technologies,
+		CreatedAt:    libraryItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:    libraryItem.UpdatedAt.Format(time.RFC3339),
+		ETag:         etag.Format(libraryItem.UpdatedAt),
+	}
+}
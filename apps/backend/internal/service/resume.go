@@ -2,18 +2,27 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
 	"github.com/recreatedev/Resumify/internal/lib/email"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
 	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/resume"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
 )
 
+// maxResumesPerUser is the number of active (non-trashed) resumes a user may own
+const maxResumesPerUser = 10
+
 type ResumeService struct {
 	server         *server.Server
 	resumeRepo     *repository.ResumeRepository
@@ -23,7 +32,9 @@ type ResumeService struct {
 	projectRepo    *repository.ProjectRepository
 	skillRepo      *repository.SkillRepository
 	certRepo       *repository.CertificationRepository
+	orgRepo        *repository.OrganizationRepository
 	emailClient    *email.Client
+	auditLog       auditLog
 }
 
 func NewResumeService(s *server.Server, repos *repository.Repositories) *ResumeService {
@@ -36,24 +47,17 @@ func NewResumeService(s *server.Server, repos *repository.Repositories) *ResumeS
 		projectRepo:    repos.Project,
 		skillRepo:      repos.Skill,
 		certRepo:       repos.Certification,
+		orgRepo:        repos.Organization,
 		emailClient:    nil, // TODO: Initialize email client when available
+		auditLog:       newAuditLog(s, repos),
 	}
 }
 
 // CreateResume creates a new resume with business logic validation
 func (s *ResumeService) CreateResume(ctx context.Context, userID string, payload *resume.CreateResumeRequest) (*resume.ResumeResponse, error) {
 	// Business logic: Check if user has reached maximum resume limit
-	maxResumes := 10 // Configurable business rule
-	existingResumes, err := s.resumeRepo.GetResumes(ctx, userID, 1, maxResumes+1)
-	if err != nil {
-		return nil, fmt.Errorf("failed to check existing resumes: %w", err)
-	}
-
-	if existingResumes.Total >= maxResumes {
-		return nil, errs.NewBadRequestError(
-			fmt.Sprintf("maximum number of resumes (%d) reached", maxResumes),
-			false, nil, nil, nil,
-		)
+	if err := s.checkResumeLimit(ctx, userID); err != nil {
+		return nil, err
 	}
 
 	// Set default theme if not provided
@@ -70,9 +74,17 @@ func (s *ResumeService) CreateResume(ctx context.Context, userID string, payload
 	// Convert to response DTO
 	response := s.convertToResumeResponse(resumeItem)
 
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeItem.ID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
 	// TODO: Create default sections for new resume
 	// TODO: Send welcome email for first resume
-	// TODO: Log resume creation event
 
 	return response, nil
 }
@@ -122,8 +134,139 @@ func (s *ResumeService) GetResumes(ctx context.Context, userID string, page, lim
 	}, nil
 }
 
+// GetSharedResumes retrieves a paginated list of the resumes shared with the user
+func (s *ResumeService) GetSharedResumes(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[resume.SharedResumeResponse], error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	resumes, err := s.resumeRepo.GetSharedResumes(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get shared resumes: %w", err)
+	}
+
+	sharedResponses := make([]resume.SharedResumeResponse, len(resumes.Data))
+	for i, sharedItem := range resumes.Data {
+		sharedResponses[i] = resume.SharedResumeResponse{
+			ResumeSummaryResponse: s.convertToResumeSummaryResponse(&sharedItem.Resume),
+			OwnerID:               sharedItem.UserID,
+			Role:                  sharedItem.Role,
+		}
+	}
+
+	return &model.PaginatedResponse[resume.SharedResumeResponse]{
+		Data:       sharedResponses,
+		Page:       resumes.Page,
+		Limit:      resumes.Limit,
+		Total:      resumes.Total,
+		TotalPages: resumes.TotalPages,
+	}, nil
+}
+
+// GetOrganizationResumes retrieves a paginated list of the resumes in the
+// user's active organization
+func (s *ResumeService) GetOrganizationResumes(ctx context.Context, userID, organizationID string, page, limit int) (*model.PaginatedResponse[resume.SharedResumeResponse], error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	resumes, err := s.orgRepo.GetOrganizationResumes(ctx, userID, organizationID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get organization resumes: %w", err)
+	}
+
+	sharedResponses := make([]resume.SharedResumeResponse, len(resumes.Data))
+	for i, sharedItem := range resumes.Data {
+		sharedResponses[i] = resume.SharedResumeResponse{
+			ResumeSummaryResponse: s.convertToResumeSummaryResponse(&sharedItem.Resume),
+			OwnerID:               sharedItem.UserID,
+			Role:                  sharedItem.Role,
+		}
+	}
+
+	return &model.PaginatedResponse[resume.SharedResumeResponse]{
+		Data:       sharedResponses,
+		Page:       resumes.Page,
+		Limit:      resumes.Limit,
+		Total:      resumes.Total,
+		TotalPages: resumes.TotalPages,
+	}, nil
+}
+
+// CreateOrganizationResume creates a resume owned by the user inside their
+// active organization
+func (s *ResumeService) CreateOrganizationResume(ctx context.Context, userID, organizationID string, payload *resume.CreateResumeRequest) (*resume.ResumeResponse, error) {
+	// Business logic: Organization resumes count towards the owner's limit
+	if err := s.checkResumeLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	// Set default theme if not provided
+	if payload.Theme == "" {
+		payload.Theme = "default"
+	}
+
+	resumeItem, err := s.orgRepo.CreateOrganizationResume(ctx, userID, organizationID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create organization resume: %w", err)
+	}
+
+	response := s.convertToResumeResponse(resumeItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeItem.ID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// SetResumeOrganization moves one of the user's resumes into their active
+// organization, or back to their personal workspace when organizationID is nil
+func (s *ResumeService) SetResumeOrganization(ctx context.Context, userID string, resumeID uuid.UUID, organizationID *string) (*resume.ResumeResponse, error) {
+	// Only the owner may decide where the resume lives
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	existingResume, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get existing resume: %w", err)
+	}
+
+	updatedResume, err := s.orgRepo.SetResumeOrganization(ctx, userID, resumeID, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update resume organization: %w", err)
+	}
+
+	response := s.convertToResumeResponse(updatedResume)
+	s.server.Events.Publish(ctx, resumeID, events.EntityResume, events.ActionUpdated, &resumeID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToResumeResponse(existingResume),
+		After:      response,
+	})
+
+	return response, nil
+}
+
 // UpdateResume updates a resume with business logic validation
-func (s *ResumeService) UpdateResume(ctx context.Context, userID string, resumeID uuid.UUID, payload *resume.UpdateResumeRequest) (*resume.ResumeResponse, error) {
+func (s *ResumeService) UpdateResume(ctx context.Context, userID string, resumeID uuid.UUID, ifMatch string, payload *resume.UpdateResumeRequest) (*resume.ResumeResponse, error) {
 	// Check if resume exists and belongs to user
 	existingResume, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
 	if err != nil {
@@ -133,6 +276,16 @@ func (s *ResumeService) UpdateResume(ctx context.Context, userID string, resumeI
 		return nil, fmt.Errorf("failed to get existing resume: %w", err)
 	}
 
+	// Only editors may change the resume
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the resume
+	if err := etag.Check(ifMatch, existingResume.UpdatedAt); err != nil {
+		return nil, err
+	}
+
 	// Business logic: Validate theme transition
 	if payload.Theme != nil && *payload.Theme != existingResume.Theme {
 		if !s.isValidThemeTransition(existingResume.Theme, *payload.Theme) {
@@ -144,24 +297,39 @@ func (s *ResumeService) UpdateResume(ctx context.Context, userID string, resumeI
 	}
 
 	// Update resume in repository
-	updatedResume, err := s.resumeRepo.UpdateResume(ctx, userID, resumeID, payload)
+	updatedResume, err := s.resumeRepo.UpdateResume(ctx, userID, resumeID, existingResume.UpdatedAt, payload)
 	if err != nil {
+		// The resume changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update resume: %w", err)
 	}
 
 	// Convert to response DTO
 	response := s.convertToResumeResponse(updatedResume)
 
-	// TODO: Log resume update event
+	s.server.Events.Publish(ctx, resumeID, events.EntityResume, events.ActionUpdated, &resumeID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToResumeResponse(existingResume),
+		After:      response,
+	})
+
 	// TODO: Send notification if significant changes
 
 	return response, nil
 }
 
-// DeleteResume deletes a resume and all related data
-func (s *ResumeService) DeleteResume(ctx context.Context, userID string, resumeID uuid.UUID) error {
+// DeleteResume moves a resume to the trash. It can be restored until the
+// retention window elapses and the purge job removes it with all related data.
+func (s *ResumeService) DeleteResume(ctx context.Context, userID string, resumeID uuid.UUID, ifMatch string) error {
 	// Check if resume exists and belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
+	existingResume, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:resumes" {
 			return errs.NewNotFoundError("resume not found", false, nil)
@@ -169,21 +337,142 @@ func (s *ResumeService) DeleteResume(ctx context.Context, userID string, resumeI
 		return fmt.Errorf("failed to get existing resume: %w", err)
 	}
 
-	// TODO: Implement cascade deletion in a transaction
-	// For now, rely on database CASCADE DELETE constraints
+	// Only the owner may delete the resume
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleOwner); err != nil {
+		return err
+	}
 
-	// Delete resume (cascade will handle related data)
-	err = s.resumeRepo.DeleteResume(ctx, userID, resumeID)
+	// Reject writes based on a stale copy of the resume
+	if err := etag.Check(ifMatch, existingResume.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.resumeRepo.DeleteResume(ctx, userID, resumeID, existingResume.UpdatedAt)
 	if err != nil {
+		// The resume changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete resume: %w", err)
 	}
 
-	// TODO: Log resume deletion event
+	s.server.Events.Publish(ctx, resumeID, events.EntityResume, events.ActionDeleted, &resumeID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToResumeResponse(existingResume),
+	})
+
 	// TODO: Send deletion confirmation email
 
 	return nil
 }
 
+// GetDeletedResumes retrieves a paginated list of the user's trashed resumes
+func (s *ResumeService) GetDeletedResumes(ctx context.Context, userID string, page, limit int) (*model.PaginatedResponse[resume.ResumeSummaryResponse], error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	resumes, err := s.resumeRepo.GetDeletedResumes(ctx, userID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get deleted resumes: %w", err)
+	}
+
+	summaryResponses := make([]resume.ResumeSummaryResponse, len(resumes.Data))
+	for i, resumeItem := range resumes.Data {
+		summaryResponses[i] = s.convertToResumeSummaryResponse(&resumeItem)
+	}
+
+	return &model.PaginatedResponse[resume.ResumeSummaryResponse]{
+		Data:       summaryResponses,
+		Page:       resumes.Page,
+		Limit:      resumes.Limit,
+		Total:      resumes.Total,
+		TotalPages: resumes.TotalPages,
+	}, nil
+}
+
+// RestoreResume moves a resume out of the trash
+func (s *ResumeService) RestoreResume(ctx context.Context, userID string, resumeID uuid.UUID) (*resume.ResumeResponse, error) {
+	// Check if resume is in the user's trash
+	deletedResume, err := s.resumeRepo.GetDeletedResumeByID(ctx, userID, resumeID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("resume not found in trash", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get deleted resume: %w", err)
+	}
+
+	// Business logic: Restoring must not exceed the active resume limit
+	if err := s.checkResumeLimit(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	restoredResume, err := s.resumeRepo.RestoreResume(ctx, userID, resumeID)
+	if err != nil {
+		// The resume was restored or purged since it was read above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("resume not found in trash", false, nil)
+		}
+		return nil, fmt.Errorf("failed to restore resume: %w", err)
+	}
+
+	response := s.convertToResumeResponse(restoredResume)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: events.EntityResume,
+		EntityID:   &resumeID,
+		Action:     audit.ActionRestored,
+		Before:     s.convertToResumeResponse(deletedResume),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// SubscribeResumeEvents streams the changes made to a resume, replaying the
+// ones recorded after lastEventID first
+func (s *ResumeService) SubscribeResumeEvents(ctx context.Context, userID string, resumeID uuid.UUID, lastEventID string) (*events.Subscription, error) {
+	// Verify resume belongs to user
+	_, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:resumes" {
+			return nil, errs.NewNotFoundError("resume not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
+	}
+
+	subscription, err := s.server.Events.Subscribe(ctx, resumeID, lastEventID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to subscribe to resume events: %w", err)
+	}
+
+	return subscription, nil
+}
+
+// PurgeExpiredResumes permanently deletes resumes that have been in the trash
+// longer than the configured retention window
+func (s *ResumeService) PurgeExpiredResumes(ctx context.Context) (int64, error) {
+	retention := time.Duration(s.server.Config.Trash.RetentionDays) * 24 * time.Hour
+	cutoff := time.Now().Add(-retention)
+
+	purged, err := s.resumeRepo.PurgeDeletedResumes(ctx, cutoff)
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired resumes: %w", err)
+	}
+
+	return purged, nil
+}
+
 // DuplicateResume creates a copy of an existing resume
 func (s *ResumeService) DuplicateResume(ctx context.Context, userID string, resumeID uuid.UUID) (*resume.ResumeResponse, error) {
 	// Get original resume
@@ -207,10 +496,19 @@ func (s *ResumeService) DuplicateResume(ctx context.Context, userID string, resu
 		return nil, fmt.Errorf("failed to create duplicate resume: %w", err)
 	}
 
+	response := s.convertToResumeResponse(duplicateResume)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &duplicateResume.ID,
+		EntityType: events.EntityResume,
+		EntityID:   &duplicateResume.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
 	// TODO: Copy all sections and related data
-	// TODO: Log duplication event
 
-	return s.convertToResumeResponse(duplicateResume), nil
+	return response, nil
 }
 
 // GetResumeWithSections retrieves a resume with all its sections and data
@@ -239,23 +537,50 @@ func (s *ResumeService) GetResumeWithSections(ctx context.Context, userID string
 
 func (s *ResumeService) convertToResumeResponse(resumeItem *resume.Resume) *resume.ResumeResponse {
 	return &resume.ResumeResponse{
-		ID:        resumeItem.ID.String(),
-		UserID:    resumeItem.UserID,
-		Title:     resumeItem.Title,
-		Theme:     resumeItem.Theme,
-		CreatedAt: resumeItem.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: resumeItem.UpdatedAt.Format(time.RFC3339),
+		ID:             resumeItem.ID.String(),
+		UserID:         resumeItem.UserID,
+		OrganizationID: resumeItem.OrganizationID,
+		Title:          resumeItem.Title,
+		Theme:          resumeItem.Theme,
+		CreatedAt:      resumeItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      resumeItem.UpdatedAt.Format(time.RFC3339),
+		ETag:           etag.Format(resumeItem.UpdatedAt),
 	}
 }
 
 func (s *ResumeService) convertToResumeSummaryResponse(resumeItem *resume.Resume) resume.ResumeSummaryResponse {
-	return resume.ResumeSummaryResponse{
-		ID:        resumeItem.ID.String(),
-		Title:     resumeItem.Title,
-		Theme:     resumeItem.Theme,
-		CreatedAt: resumeItem.CreatedAt.Format(time.RFC3339),
-		UpdatedAt: resumeItem.UpdatedAt.Format(time.RFC3339),
+	response := resume.ResumeSummaryResponse{
+		ID:             resumeItem.ID.String(),
+		OrganizationID: resumeItem.OrganizationID,
+		Title:          resumeItem.Title,
+		Theme:          resumeItem.Theme,
+		CreatedAt:      resumeItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:      resumeItem.UpdatedAt.Format(time.RFC3339),
+		ETag:           etag.Format(resumeItem.UpdatedAt),
+	}
+
+	if resumeItem.DeletedAt != nil {
+		deletedAt := resumeItem.DeletedAt.Format(time.RFC3339)
+		response.DeletedAt = &deletedAt
+	}
+
+	return response
+}
+
+func (s *ResumeService) checkResumeLimit(ctx context.Context, userID string) error {
+	existingResumes, err := s.resumeRepo.GetResumes(ctx, userID, 1, maxResumesPerUser+1)
+	if err != nil {
+		return fmt.Errorf("failed to check existing resumes: %w", err)
+	}
+
+	if existingResumes.Total >= maxResumesPerUser {
+		return errs.NewBadRequestError(
+			fmt.Sprintf("maximum number of resumes (%d) reached", maxResumesPerUser),
+			false, nil, nil, nil,
+		)
 	}
+
+	return nil
 }
 
 func (s *ResumeService) isValidThemeTransition(fromTheme, toTheme string) bool {
@@ -0,0 +1,262 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/composite"
+	"github.com/recreatedev/Resumify/internal/model/education"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+	"github.com/recreatedev/Resumify/internal/model/project"
+	"github.com/recreatedev/Resumify/internal/model/section"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+	"github.com/recreatedev/Resumify/internal/validation"
+)
+
+// SaveResumeDocument saves a whole resume document in one transaction and
+// returns the stored result
+func (s *ResumeService) SaveResumeDocument(ctx context.Context, userID string, resumeID uuid.UUID, ifMatch string, payload *composite.SaveResumeDocumentRequest) (*composite.ResumeWithSections, error) {
+	current, err := s.getResumeDocument(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Only editors may change the resume
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject saves based on a stale copy of the document
+	if err := etag.Check(ifMatch, current.Resume.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: entries must belong to this resume and follow the same
+	// rules as entries saved one at a time
+	var fieldErrors []errs.FieldError
+
+	if payload.Sections != nil {
+		ids := make([]*uuid.UUID, len(payload.Sections))
+		entries := make([]section.ResumeSection, len(payload.Sections))
+		for i, item := range payload.Sections {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Sections, item.ID, func(s section.ResumeSection) uuid.UUID { return s.ID })
+			entries[i] = applySectionUpdate(stored, &item.UpdateSectionRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("sections", ids, current.Sections, func(s section.ResumeSection) uuid.UUID { return s.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("sections", entries, checkSection)...)
+	}
+
+	if payload.Education != nil {
+		ids := make([]*uuid.UUID, len(payload.Education))
+		entries := make([]education.Education, len(payload.Education))
+		for i, item := range payload.Education {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Education, item.ID, func(e education.Education) uuid.UUID { return e.ID })
+			entries[i] = applyEducationUpdate(stored, &item.UpdateEducationRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("education", ids, current.Education, func(e education.Education) uuid.UUID { return e.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("education", entries, checkEducation)...)
+	}
+
+	if payload.Experience != nil {
+		ids := make([]*uuid.UUID, len(payload.Experience))
+		entries := make([]experience.Experience, len(payload.Experience))
+		for i, item := range payload.Experience {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Experience, item.ID, func(e experience.Experience) uuid.UUID { return e.ID })
+			entries[i] = applyExperienceUpdate(stored, &item.UpdateExperienceRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("experience", ids, current.Experience, func(e experience.Experience) uuid.UUID { return e.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("experience", entries, checkExperience)...)
+	}
+
+	if payload.Projects != nil {
+		ids := make([]*uuid.UUID, len(payload.Projects))
+		entries := make([]project.Project, len(payload.Projects))
+		for i, item := range payload.Projects {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Projects, item.ID, func(p project.Project) uuid.UUID { return p.ID })
+			entries[i] = applyProjectUpdate(stored, &item.UpdateProjectRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("projects", ids, current.Projects, func(p project.Project) uuid.UUID { return p.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("projects", entries, checkProject)...)
+	}
+
+	if payload.Skills != nil {
+		ids := make([]*uuid.UUID, len(payload.Skills))
+		entries := make([]skill.Skill, len(payload.Skills))
+		for i, item := range payload.Skills {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Skills, item.ID, func(s skill.Skill) uuid.UUID { return s.ID })
+			entries[i] = applySkillUpdate(stored, &item.UpdateSkillRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("skills", ids, current.Skills, func(s skill.Skill) uuid.UUID { return s.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("skills", entries, checkSkill)...)
+	}
+
+	if payload.Certifications != nil {
+		ids := make([]*uuid.UUID, len(payload.Certifications))
+		entries := make([]certification.Certification, len(payload.Certifications))
+		for i, item := range payload.Certifications {
+			ids[i] = item.ID
+			stored := findStoredItem(current.Certifications, item.ID, func(c certification.Certification) uuid.UUID { return c.ID })
+			entries[i] = applyCertificationUpdate(stored, &item.UpdateCertificationRequest)
+		}
+		fieldErrors = append(fieldErrors, checkDocumentIDs("certifications", ids, current.Certifications, func(c certification.Certification) uuid.UUID { return c.ID })...)
+		fieldErrors = append(fieldErrors, checkDocumentEntries("certifications", entries, checkCertification)...)
+	}
+
+	if len(fieldErrors) > 0 {
+		return nil, errs.NewBadRequestError("Validation failed", true, nil, fieldErrors, nil)
+	}
+
+	err = s.resumeRepo.SaveResumeDocument(ctx, userID, resumeID, current.Resume.UpdatedAt, payload)
+	if err != nil {
+		// The resume changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to save resume document: %w", err)
+	}
+
+	document, err := s.getResumeDocument(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	s.server.Events.Publish(ctx, resumeID, events.EntityDocument, events.ActionUpdated, &resumeID, document)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resumeID,
+		EntityType: events.EntityDocument,
+		EntityID:   &resumeID,
+		Action:     events.ActionUpdated,
+		Before:     current,
+		After:      document,
+	})
+
+	return document, nil
+}
+
+// getResumeDocument loads a resume together with all of its sections and items
+func (s *ResumeService) getResumeDocument(ctx context.Context, userID string, resumeID uuid.UUID) (*composite.ResumeWithSections, error) {
+	resumeItem, err := s.resumeRepo.GetResumeByID(ctx, userID, resumeID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:resumes" {
+			return nil, errs.NewNotFoundError("resume not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	sections, err := s.sectionRepo.GetSectionsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get sections: %w", err)
+	}
+	educationItems, err := s.educationRepo.GetEducationByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get education entries: %w", err)
+	}
+	experienceItems, err := s.experienceRepo.GetExperienceByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get experience entries: %w", err)
+	}
+	projectItems, err := s.projectRepo.GetProjectsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get projects: %w", err)
+	}
+	skillItems, err := s.skillRepo.GetSkillsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skills: %w", err)
+	}
+	certificationItems, err := s.certRepo.GetCertificationsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get certifications: %w", err)
+	}
+
+	return &composite.ResumeWithSections{
+		Resume:         *resumeItem,
+		Sections:       sections,
+		Education:      educationItems,
+		Experience:     experienceItems,
+		Projects:       projectItems,
+		Skills:         skillItems,
+		Certifications: certificationItems,
+	}, nil
+}
+
+// checkDocumentIDs reports entries whose ID is not a stored entry of the resume
+// or appears more than once in the collection
+func checkDocumentIDs[T any](collection string, ids []*uuid.UUID, stored []T, idOf func(T) uuid.UUID) []errs.FieldError {
+	var fieldErrors []errs.FieldError
+
+	storedIDs := make(map[uuid.UUID]bool, len(stored))
+	for _, item := range stored {
+		storedIDs[idOf(item)] = true
+	}
+
+	seen := make(map[uuid.UUID]bool, len(ids))
+	for i, id := range ids {
+		if id == nil {
+			continue
+		}
+		if !storedIDs[*id] {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: validation.ItemPath(collection, i, "id"),
+				Error: "does not belong to this resume",
+			})
+			continue
+		}
+		if seen[*id] {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: validation.ItemPath(collection, i, "id"),
+				Error: "appears more than once",
+			})
+		}
+		seen[*id] = true
+	}
+
+	return fieldErrors
+}
+
+// checkDocumentEntries runs an entry rule over a submitted collection. The
+// collection replaces the stored one, so each entry is checked against the
+// entries submitted before it rather than against the stored entries.
+func checkDocumentEntries[T any](collection string, entries []T, check func(T, []T) *errs.FieldError) []errs.FieldError {
+	var fieldErrors []errs.FieldError
+
+	for i, entry := range entries {
+		if violation := check(entry, entries[:i]); violation != nil {
+			fieldErrors = append(fieldErrors, errs.FieldError{
+				Field: validation.ItemPath(collection, i, violation.Field),
+				Error: violation.Error,
+			})
+		}
+	}
+
+	return fieldErrors
+}
+
+// findStoredItem returns the stored entry a submitted entry updates, or the
+// zero entry for a new one
+func findStoredItem[T any](stored []T, id *uuid.UUID, idOf func(T) uuid.UUID) T {
+	var zero T
+	if id == nil {
+		return zero
+	}
+	for _, item := range stored {
+		if idOf(item) == *id {
+			return item
+		}
+	}
+	return zero
+}
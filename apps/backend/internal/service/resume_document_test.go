@@ -0,0 +1,88 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/model/composite"
+	"github.com/recreatedev/Resumify/internal/model/section"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+	"github.com/recreatedev/Resumify/internal/repository"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSaveResumeDocumentAppliesEntryRules(t *testing.T) {
+	testDB, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	repos := repository.NewRepositories(testServer)
+	resumeService := NewResumeService(testServer, repos)
+	skillService := NewSkillService(testServer, repos)
+	sectionService := NewSectionService(testServer, repos)
+
+	var resumeID, skillID uuid.UUID
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `INSERT INTO resumes (user_id, title) VALUES ('user_owner', 'Resume') RETURNING id`).Scan(&resumeID))
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `INSERT INTO skills (resume_id, name, order_index) VALUES ($1, 'Go', 1) RETURNING id`, resumeID).Scan(&skillID))
+
+	badRequest := func(t *testing.T, err error, message string) *errs.HTTPError {
+		t.Helper()
+
+		var httpErr *errs.HTTPError
+		require.True(t, errors.As(err, &httpErr), "got %v", err)
+		assert.Equal(t, http.StatusBadRequest, httpErr.Status)
+		if message != "" {
+			assert.Equal(t, message, httpErr.Message)
+		}
+		return httpErr
+	}
+
+	name := func(s string) *string { return &s }
+
+	t.Run("duplicate skill", func(t *testing.T) {
+		_, err := skillService.CreateSkill(ctx, "user_owner", &skill.CreateSkillRequest{
+			ResumeID: resumeID,
+			Name:     name("Go"),
+		})
+		badRequest(t, err, "skill with same name already exists")
+
+		_, err = resumeService.SaveResumeDocument(ctx, "user_owner", resumeID, "*", &composite.SaveResumeDocumentRequest{
+			Skills: []composite.DocumentSkill{
+				{ID: &skillID},
+				{UpdateSkillRequest: skill.UpdateSkillRequest{Name: name("Go")}},
+			},
+		})
+		httpErr := badRequest(t, err, "")
+		assert.Equal(t, []errs.FieldError{{Field: "skills[1].name", Error: "skill with same name already exists"}}, httpErr.Errors)
+	})
+
+	t.Run("invalid section name", func(t *testing.T) {
+		_, err := sectionService.CreateSection(ctx, "user_owner", &section.CreateSectionRequest{
+			ResumeID: resumeID,
+			Name:     "hobbies",
+		})
+		badRequest(t, err, "invalid section name. Must be one of: education, experience, projects, skills, certifications, summary, contact")
+
+		_, err = resumeService.SaveResumeDocument(ctx, "user_owner", resumeID, "*", &composite.SaveResumeDocumentRequest{
+			Sections: []composite.DocumentSection{
+				{UpdateSectionRequest: section.UpdateSectionRequest{Name: name("hobbies")}},
+			},
+		})
+		httpErr := badRequest(t, err, "")
+		require.Len(t, httpErr.Errors, 1)
+		assert.Equal(t, "sections[0].name", httpErr.Errors[0].Field)
+	})
+
+	// Neither path stored anything
+	var skills, sections int
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM skills WHERE resume_id = $1`, resumeID).Scan(&skills))
+	require.NoError(t, testDB.Pool.QueryRow(ctx, `SELECT COUNT(*) FROM resume_sections WHERE resume_id = $1`, resumeID).Scan(&sections))
+	assert.Equal(t, 1, skills)
+	assert.Zero(t, sections)
+}
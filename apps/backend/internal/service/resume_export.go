@@ -0,0 +1,279 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/lib/render"
+	"github.com/recreatedev/Resumify/internal/lib/storage"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/composite"
+	"github.com/recreatedev/Resumify/internal/model/resumeexport"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type ResumeExportService struct {
+	server           *server.Server
+	resumeExportRepo *repository.ResumeExportRepository
+	resumeRepo       *repository.ResumeRepository
+	resumeService    *ResumeService
+}
+
+func NewResumeExportService(s *server.Server, repos *repository.Repositories, resumeService *ResumeService) *ResumeExportService {
+	return &ResumeExportService{
+		server:           s,
+		resumeExportRepo: repos.ResumeExport,
+		resumeRepo:       repos.Resume,
+		resumeService:    resumeService,
+	}
+}
+
+// RequestExport queues a render of the resume in the format. Exports are keyed
+// by the document's content, which includes the modification time of the
+// resume and of every section and item, so an unchanged resume reuses the
+// export already rendered or being rendered.
+func (s *ResumeExportService) RequestExport(ctx context.Context, userID string, resumeID uuid.UUID, payload *resumeexport.CreateResumeExportRequest) (*resumeexport.ResumeExportResponse, error) {
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	document, err := s.resumeService.getResumeDocument(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	documentHash, err := hashDocument(document)
+	if err != nil {
+		return nil, err
+	}
+
+	export, err := s.resumeExportRepo.CreateExport(ctx, resumeID, payload.Format, documentHash, s.expiresAt())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create resume export: %w", err)
+	}
+
+	if export.Status == resumeexport.StatusQueued {
+		task, err := job.NewRenderResumeExportTask(export.ID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to create resume export task: %w", err)
+		}
+
+		// The task ID is the export ID, so a conflict means the export is
+		// already queued
+		if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+			return nil, fmt.Errorf("failed to enqueue resume export task: %w", err)
+		}
+	}
+
+	return s.convertToResumeExportResponse(export), nil
+}
+
+// GetExport reports the progress of an export of a resume the user can view
+func (s *ResumeExportService) GetExport(ctx context.Context, userID string, exportID uuid.UUID) (*resumeexport.ResumeExportResponse, error) {
+	export, err := s.getExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToResumeExportResponse(export), nil
+}
+
+// DownloadExport streams the rendered content of a done export from blob
+// storage
+func (s *ResumeExportService) DownloadExport(ctx context.Context, userID string, exportID uuid.UUID) (*model.Download, error) {
+	export, err := s.getExport(ctx, userID, exportID)
+	if err != nil {
+		return nil, err
+	}
+
+	if export.Status != resumeexport.StatusDone {
+		return nil, errs.NewBadRequestError(fmt.Sprintf("resume export is %s", export.Status), false, nil, nil, nil)
+	}
+
+	resumeItem, err := s.resumeRepo.GetResumeByID(ctx, userID, export.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	object, err := s.server.Storage.Get(ctx, export.Blob().StorageKey())
+	if err != nil {
+		// The export expired and was purged
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errs.NewNotFoundError("resume export not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get resume export content: %w", err)
+	}
+
+	return &model.Download{
+		Filename:    exportFilename(resumeItem.Title, export.Format),
+		ContentType: export.Format.ContentType(),
+		Size:        object.Size,
+		Content:     object.Content,
+	}, nil
+}
+
+// exportFilename names a downloaded export after the resume's title
+func exportFilename(title string, format resumeexport.Format) string {
+	title = strings.TrimSpace(strings.NewReplacer("/", "-", "\\", "-").Replace(title))
+	if title == "" {
+		title = "resume"
+	}
+	return title + "." + string(format)
+}
+
+// RenderExport renders a queued export from the owner's view of the resume
+// and stores it in blob storage. The current document is rendered, which may
+// be newer than the one the export was requested for. final marks the last
+// attempt.
+func (s *ResumeExportService) RenderExport(ctx context.Context, exportID uuid.UUID, final bool) error {
+	export, err := s.resumeExportRepo.StartExport(ctx, exportID)
+	if err != nil {
+		// The export expired and was purged
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to start resume export: %w", err)
+	}
+	if export.Status != resumeexport.StatusRunning {
+		return nil
+	}
+
+	content, err := s.renderExport(ctx, export)
+	if err == nil {
+		err = s.server.Storage.Put(ctx, export.Blob().StorageKey(), bytes.NewReader(content), int64(len(content)), export.Format.ContentType())
+	}
+	if err != nil {
+		// Client errors, such as the resume having been moved to the trash,
+		// are not retried
+		var httpErr *errs.HTTPError
+		if final || errors.As(err, &httpErr) {
+			if failErr := s.resumeExportRepo.FailExport(ctx, exportID, err.Error()); failErr != nil {
+				return failErr
+			}
+			return nil
+		}
+		return err
+	}
+
+	return s.resumeExportRepo.CompleteExport(ctx, exportID, int64(len(content)), s.expiresAt())
+}
+
+// PurgeExpiredExports removes exports that were not requested within the
+// retention period, or whose resume was purged, and their stored content
+func (s *ResumeExportService) PurgeExpiredExports(ctx context.Context) (int64, error) {
+	blobs, err := s.resumeExportRepo.PurgeExpiredExports(ctx, time.Now())
+	if err != nil {
+		return 0, fmt.Errorf("failed to purge expired resume exports: %w", err)
+	}
+
+	// The rows are gone either way, so a blob that cannot be deleted is
+	// logged rather than failing the purge
+	for _, blob := range blobs {
+		if err := s.server.Storage.Delete(ctx, blob.StorageKey()); err != nil {
+			s.server.Logger.Error().
+				Err(err).
+				Str("export_id", blob.ID.String()).
+				Msg("Failed to delete resume export content")
+		}
+	}
+
+	return int64(len(blobs)), nil
+}
+
+// Helper methods
+
+// getExport loads an export after checking the user can view its resume
+func (s *ResumeExportService) getExport(ctx context.Context, userID string, exportID uuid.UUID) (*resumeexport.ResumeExport, error) {
+	export, err := s.resumeExportRepo.GetExportByID(ctx, exportID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("resume export not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get resume export: %w", err)
+	}
+
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, export.ResumeID, collaborator.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	return export, nil
+}
+
+func (s *ResumeExportService) renderExport(ctx context.Context, export *resumeexport.ResumeExport) ([]byte, error) {
+	ownerID, err := s.resumeExportRepo.GetResumeOwnerID(ctx, export.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	document, err := s.resumeService.getResumeDocument(ctx, ownerID, export.ResumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	switch export.Format {
+	case resumeexport.FormatJSON:
+		content, err := json.MarshalIndent(document, "", "  ")
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode resume %s: %w", export.ResumeID.String(), err)
+		}
+		return content, nil
+	default:
+		return render.HTML(document)
+	}
+}
+
+func (s *ResumeExportService) expiresAt() time.Time {
+	return time.Now().Add(time.Duration(s.server.Config.ResumeExport.RetentionHours) * time.Hour).Truncate(time.Second)
+}
+
+func (s *ResumeExportService) convertToResumeExportResponse(export *resumeexport.ResumeExport) *resumeexport.ResumeExportResponse {
+	response := &resumeexport.ResumeExportResponse{
+		ID:        export.ID.String(),
+		ResumeID:  export.ResumeID.String(),
+		Format:    export.Format,
+		Status:    export.Status,
+		SizeBytes: export.SizeBytes,
+		Error:     export.Error,
+		CreatedAt: export.CreatedAt.Format(time.RFC3339),
+	}
+
+	if export.ExpiresAt != nil {
+		expiresAt := export.ExpiresAt.Format(time.RFC3339)
+		response.ExpiresAt = &expiresAt
+	}
+	if export.Status == resumeexport.StatusDone {
+		downloadURL := strings.TrimRight(s.server.Config.Server.PublicURL, "/") + "/api/v1/exports/" + export.ID.String() + "/download"
+		response.DownloadURL = &downloadURL
+	}
+	if export.CompletedAt != nil {
+		completedAt := export.CompletedAt.Format(time.RFC3339)
+		response.CompletedAt = &completedAt
+	}
+
+	return response
+}
+
+// hashDocument fingerprints the content of a resume document
+func hashDocument(document *composite.ResumeWithSections) (string, error) {
+	encoded, err := json.Marshal(document)
+	if err != nil {
+		return "", fmt.Errorf("failed to encode resume %s: %w", document.Resume.ID.String(), err)
+	}
+
+	sum := sha256.Sum256(encoded)
+	return hex.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,189 @@
+package service
+
+import (
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/model/certification"
+	"github.com/recreatedev/Resumify/internal/model/education"
+	"github.com/recreatedev/Resumify/internal/model/experience"
+	"github.com/recreatedev/Resumify/internal/model/project"
+	"github.com/recreatedev/Resumify/internal/model/section"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+)
+
+// The business rules for resume entries. Each check takes an entry as it will
+// be stored and the other entries of its resume, and reports the field that
+// breaks a rule. The per-entry endpoints and whole document saves both run
+// them, so a payload one path rejects the other rejects too.
+
+var validSectionNames = []string{"education", "experience", "projects", "skills", "certifications", "summary", "contact"}
+
+var validSkillLevels = []string{"Beginner", "Intermediate", "Advanced", "Expert"}
+
+// checkSection checks a section's name against the other sections of its resume
+func checkSection(entry section.ResumeSection, others []section.ResumeSection) *errs.FieldError {
+	if !slices.Contains(validSectionNames, entry.Name) {
+		return &errs.FieldError{
+			Field: "name",
+			Error: "invalid section name. Must be one of: education, experience, projects, skills, certifications, summary, contact",
+		}
+	}
+	for _, other := range others {
+		if other.Name == entry.Name {
+			return &errs.FieldError{Field: "name", Error: "section with same name already exists"}
+		}
+	}
+	return nil
+}
+
+// checkEducation checks an education entry against the other entries of its resume
+func checkEducation(entry education.Education, others []education.Education) *errs.FieldError {
+	if !datesInOrder(entry.StartDate, entry.EndDate) {
+		return &errs.FieldError{Field: "startdate", Error: "start date cannot be after end date"}
+	}
+	for _, other := range others {
+		if sameValue(other.Institution, entry.Institution) && sameValue(other.Degree, entry.Degree) {
+			return &errs.FieldError{Field: "institution", Error: "education entry with same institution and degree already exists"}
+		}
+	}
+	return nil
+}
+
+// checkExperience checks an experience entry against the other entries of its resume
+func checkExperience(entry experience.Experience, others []experience.Experience) *errs.FieldError {
+	if !datesInOrder(entry.StartDate, entry.EndDate) {
+		return &errs.FieldError{Field: "startdate", Error: "start date cannot be after end date"}
+	}
+	for _, other := range others {
+		if sameValue(other.Company, entry.Company) && sameValue(other.Position, entry.Position) {
+			return &errs.FieldError{Field: "company", Error: "experience entry with same company and position already exists"}
+		}
+	}
+	return nil
+}
+
+// checkProject checks a project entry against the other entries of its resume
+func checkProject(entry project.Project, others []project.Project) *errs.FieldError {
+	if !validURL(entry.Link) {
+		return &errs.FieldError{Field: "link", Error: "invalid project URL"}
+	}
+	for _, other := range others {
+		if sameValue(other.Name, entry.Name) {
+			return &errs.FieldError{Field: "name", Error: "project with same name already exists"}
+		}
+	}
+	return nil
+}
+
+// checkSkill checks a skill entry against the other entries of its resume
+func checkSkill(entry skill.Skill, others []skill.Skill) *errs.FieldError {
+	if entry.Level != nil && !slices.Contains(validSkillLevels, *entry.Level) {
+		return &errs.FieldError{Field: "level", Error: "invalid skill level. Must be one of: Beginner, Intermediate, Advanced, Expert"}
+	}
+	for _, other := range others {
+		if sameValue(other.Name, entry.Name) {
+			return &errs.FieldError{Field: "name", Error: "skill with same name already exists"}
+		}
+	}
+	return nil
+}
+
+// checkCertification checks a certification entry against the other entries of its resume
+func checkCertification(entry certification.Certification, others []certification.Certification) *errs.FieldError {
+	if !datesInOrder(entry.IssueDate, entry.ExpiryDate) {
+		return &errs.FieldError{Field: "issuedate", Error: "issue date cannot be after expiry date"}
+	}
+	if !validURL(entry.CredentialURL) {
+		return &errs.FieldError{Field: "credentialurl", Error: "invalid credential URL"}
+	}
+	for _, other := range others {
+		if sameValue(other.Name, entry.Name) && sameValue(other.Organization, entry.Organization) {
+			return &errs.FieldError{Field: "name", Error: "certification with same name and organization already exists"}
+		}
+	}
+	return nil
+}
+
+// ruleViolation turns a broken rule of a per-entry request into its error
+func ruleViolation(fieldError *errs.FieldError) error {
+	return errs.NewBadRequestError(fieldError.Error, false, nil, nil, nil)
+}
+
+func datesInOrder(start, end *time.Time) bool {
+	return start == nil || end == nil || !start.After(*end)
+}
+
+func validURL(link *string) bool {
+	if link == nil || *link == "" {
+		return true
+	}
+	_, err := url.Parse(*link)
+	return err == nil
+}
+
+// sameValue reports whether two optional fields hold the same value; an unset
+// field never matches
+func sameValue(a, b *string) bool {
+	return a != nil && b != nil && *a == *b
+}
+
+// updatedValue returns the value a field holds after an update that sends
+// value and empties the fields listed in clear
+func updatedValue[V any](stored, value *V, clear []string, field string) *V {
+	if value != nil {
+		return value
+	}
+	if slices.Contains(clear, field) {
+		return nil
+	}
+	return stored
+}
+
+// The apply functions return an entry as it will be stored after an update
+
+func applySectionUpdate(entry section.ResumeSection, payload *section.UpdateSectionRequest) section.ResumeSection {
+	if payload.Name != nil {
+		entry.Name = *payload.Name
+	}
+	return entry
+}
+
+func applyEducationUpdate(entry education.Education, payload *education.UpdateEducationRequest) education.Education {
+	entry.Institution = updatedValue(entry.Institution, payload.Institution, payload.Clear, "institution")
+	entry.Degree = updatedValue(entry.Degree, payload.Degree, payload.Clear, "degree")
+	entry.StartDate = updatedValue(entry.StartDate, payload.StartDate, payload.Clear, "startDate")
+	entry.EndDate = updatedValue(entry.EndDate, payload.EndDate, payload.Clear, "endDate")
+	return entry
+}
+
+func applyExperienceUpdate(entry experience.Experience, payload *experience.UpdateExperienceRequest) experience.Experience {
+	entry.Company = updatedValue(entry.Company, payload.Company, payload.Clear, "company")
+	entry.Position = updatedValue(entry.Position, payload.Position, payload.Clear, "position")
+	entry.StartDate = updatedValue(entry.StartDate, payload.StartDate, payload.Clear, "startDate")
+	entry.EndDate = updatedValue(entry.EndDate, payload.EndDate, payload.Clear, "endDate")
+	return entry
+}
+
+func applyProjectUpdate(entry project.Project, payload *project.UpdateProjectRequest) project.Project {
+	entry.Name = updatedValue(entry.Name, payload.Name, payload.Clear, "name")
+	entry.Link = updatedValue(entry.Link, payload.Link, payload.Clear, "link")
+	return entry
+}
+
+func applySkillUpdate(entry skill.Skill, payload *skill.UpdateSkillRequest) skill.Skill {
+	entry.Name = updatedValue(entry.Name, payload.Name, payload.Clear, "name")
+	entry.Level = updatedValue(entry.Level, payload.Level, payload.Clear, "level")
+	return entry
+}
+
+func applyCertificationUpdate(entry certification.Certification, payload *certification.UpdateCertificationRequest) certification.Certification {
+	entry.Name = updatedValue(entry.Name, payload.Name, payload.Clear, "name")
+	entry.Organization = updatedValue(entry.Organization, payload.Organization, payload.Clear, "organization")
+	entry.IssueDate = updatedValue(entry.IssueDate, payload.IssueDate, payload.Clear, "issueDate")
+	entry.ExpiryDate = updatedValue(entry.ExpiryDate, payload.ExpiryDate, payload.Clear, "expiryDate")
+	entry.CredentialURL = updatedValue(entry.CredentialURL, payload.CredentialURL, payload.Clear, "credentialUrl")
+	return entry
+}
@@ -0,0 +1,392 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/review"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type ReviewService struct {
+	server        *server.Server
+	reviewRepo    *repository.ReviewRepository
+	resumeRepo    *repository.ResumeRepository
+	outboxRepo    *repository.OutboxRepository
+	resumeService *ResumeService
+	auditLog      auditLog
+}
+
+func NewReviewService(s *server.Server, repos *repository.Repositories, resumeService *ResumeService) *ReviewService {
+	return &ReviewService{
+		server:        s,
+		reviewRepo:    repos.Review,
+		resumeRepo:    repos.Resume,
+		outboxRepo:    repos.Outbox,
+		resumeService: resumeService,
+		auditLog:      newAuditLog(s, repos),
+	}
+}
+
+// SubmitReview submits the current version of a resume to a reviewer
+func (s *ReviewService) SubmitReview(ctx context.Context, userID string, resumeID uuid.UUID, payload *review.SubmitReviewRequest) (*review.ReviewResponse, error) {
+	// Only editors may submit the resume for review
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Business logic: The reviewer must be someone else who can comment on the resume
+	if payload.ReviewerID == userID {
+		return nil, errs.NewBadRequestError("you cannot review your own submission", false, nil, nil, nil)
+	}
+	if err := s.checkReviewerAccess(ctx, payload.ReviewerID, resumeID); err != nil {
+		return nil, err
+	}
+
+	// Business logic: A resume has at most one review awaiting a decision
+	existingReviews, err := s.reviewRepo.GetReviewsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing reviews: %w", err)
+	}
+	for _, existing := range existingReviews {
+		if existing.Status.IsOpen() {
+			return nil, errs.NewBadRequestError("this resume already has a review in progress", false, nil, nil, nil)
+		}
+	}
+
+	resumeVersion, snapshot, title, err := s.snapshotResume(ctx, userID, resumeID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The reviewer is notified through the outbox, committed with the review
+	var reviewItem *review.Review
+	err = s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		reviewItem, err = s.reviewRepo.CreateReview(ctx, userID, resumeID, payload, resumeVersion, snapshot)
+		if err != nil {
+			return fmt.Errorf("failed to submit review: %w", err)
+		}
+		return s.addTransitionEvent(ctx, userID, reviewItem, title, payload.Message)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToReviewResponse(reviewItem, nil, false)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &reviewItem.ResumeID,
+		EntityType: audit.EntityReview,
+		EntityID:   &reviewItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetReview retrieves a review with the submitted resume version and its history
+func (s *ReviewService) GetReview(ctx context.Context, userID string, reviewID uuid.UUID) (*review.ReviewResponse, error) {
+	reviewItem, err := s.getReview(ctx, userID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	reviewEvents, err := s.reviewRepo.GetReviewEvents(ctx, reviewID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review history: %w", err)
+	}
+
+	return s.convertToReviewResponse(reviewItem, reviewEvents, true), nil
+}
+
+// GetReviewsByResumeID lists the reviews of a resume
+func (s *ReviewService) GetReviewsByResumeID(ctx context.Context, userID string, resumeID uuid.UUID) ([]review.ReviewResponse, error) {
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleViewer); err != nil {
+		return nil, err
+	}
+
+	reviews, err := s.reviewRepo.GetReviewsByResumeID(ctx, userID, resumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get reviews: %w", err)
+	}
+
+	responses := make([]review.ReviewResponse, len(reviews))
+	for i, reviewItem := range reviews {
+		responses[i] = *s.convertToReviewResponse(&reviewItem, nil, false)
+	}
+
+	return responses, nil
+}
+
+// GetReviewQueue retrieves the reviews assigned to the user. Without a status
+// it lists the reviews awaiting a decision.
+func (s *ReviewService) GetReviewQueue(ctx context.Context, userID string, status string, page, limit int) (*model.PaginatedResponse[review.ReviewResponse], error) {
+	// Validate pagination parameters
+	if page < 1 {
+		page = 1
+	}
+	if limit < 1 || limit > 100 {
+		limit = 20 // Default limit
+	}
+
+	statuses := []review.Status{review.StatusSubmitted, review.StatusInReview}
+	if status != "" {
+		statuses = []review.Status{review.Status(status)}
+	}
+
+	reviews, err := s.reviewRepo.GetReviewQueue(ctx, userID, statuses, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get review queue: %w", err)
+	}
+
+	responses := make([]review.ReviewResponse, len(reviews.Data))
+	for i, reviewItem := range reviews.Data {
+		responses[i] = *s.convertToReviewResponse(&reviewItem, nil, false)
+	}
+
+	return &model.PaginatedResponse[review.ReviewResponse]{
+		Data:       responses,
+		Page:       reviews.Page,
+		Limit:      reviews.Limit,
+		Total:      reviews.Total,
+		TotalPages: reviews.TotalPages,
+	}, nil
+}
+
+// TransitionReview moves a review to the next status of the workflow. The
+// state machine decides which transitions exist and which party may make them.
+func (s *ReviewService) TransitionReview(ctx context.Context, userID string, reviewID uuid.UUID, to review.Status, payload *review.TransitionReviewRequest) (*review.ReviewResponse, error) {
+	reviewItem, err := s.getReview(ctx, userID, reviewID)
+	if err != nil {
+		return nil, err
+	}
+
+	// Business logic: Validate the transition against the state machine
+	transition, ok := review.FindTransition(reviewItem.Status, to)
+	if !ok {
+		return nil, errs.NewBadRequestError(
+			fmt.Sprintf("a review cannot move from %s to %s", reviewItem.Status, to),
+			false, nil, nil, nil,
+		)
+	}
+
+	switch transition.Actor {
+	case review.ActorReviewer:
+		if userID != reviewItem.ReviewerID {
+			return nil, errs.NewForbiddenError("only the reviewer can make this change", false)
+		}
+		if err := s.checkReviewerAccess(ctx, userID, reviewItem.ResumeID); err != nil {
+			return nil, err
+		}
+	case review.ActorSubmitter:
+		if userID != reviewItem.SubmitterID {
+			return nil, errs.NewForbiddenError("only the submitter can make this change", false)
+		}
+	}
+
+	// Business logic: A resubmission pins the current version of the resume
+	var resumeVersion, title string
+	var snapshot []byte
+	if to == review.StatusSubmitted {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, reviewItem.ResumeID, collaborator.RoleEditor); err != nil {
+			return nil, err
+		}
+		resumeVersion, snapshot, title, err = s.snapshotResume(ctx, userID, reviewItem.ResumeID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The party who has to act next is notified through the outbox, committed
+	// with the status change
+	var updatedReview *review.Review
+	err = s.server.DB.InTx(ctx, func(ctx context.Context) error {
+		var err error
+		updatedReview, err = s.reviewRepo.TransitionReview(ctx, userID, reviewID, reviewItem.Status, to, payload.Note, resumeVersion, snapshot)
+		if err != nil {
+			if errors.Is(err, pgx.ErrNoRows) {
+				return errs.NewBadRequestError("the review was changed by someone else, reload it and try again", false, nil, nil, nil)
+			}
+			return fmt.Errorf("failed to update review: %w", err)
+		}
+		return s.addTransitionEvent(ctx, userID, updatedReview, title, payload.Note)
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	response := s.convertToReviewResponse(updatedReview, nil, false)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedReview.ResumeID,
+		EntityType: audit.EntityReview,
+		EntityID:   &updatedReview.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToReviewResponse(reviewItem, nil, false),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// Helper methods
+
+func (s *ReviewService) getReview(ctx context.Context, userID string, reviewID uuid.UUID) (*review.Review, error) {
+	reviewItem, err := s.reviewRepo.GetReviewByID(ctx, userID, reviewID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("review not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get review: %w", err)
+	}
+	return reviewItem, nil
+}
+
+// checkReviewerAccess requires the reviewer to be able to comment on the resume,
+// as a collaborator or through the resume's organization
+func (s *ReviewService) checkReviewerAccess(ctx context.Context, reviewerID string, resumeID uuid.UUID) error {
+	role, err := s.resumeRepo.GetResumeRole(ctx, reviewerID, resumeID)
+	if err != nil && !errors.Is(err, pgx.ErrNoRows) {
+		return fmt.Errorf("failed to check reviewer access: %w", err)
+	}
+
+	if err != nil || !role.Includes(collaborator.RoleCommenter) {
+		return errs.NewBadRequestError(
+			"the reviewer needs commenter access to the resume; invite them as a collaborator first",
+			false, nil, nil, nil,
+		)
+	}
+
+	return nil
+}
+
+// snapshotResume captures the current resume document, its version and title
+func (s *ReviewService) snapshotResume(ctx context.Context, userID string, resumeID uuid.UUID) (string, []byte, string, error) {
+	document, err := s.resumeService.getResumeDocument(ctx, userID, resumeID)
+	if err != nil {
+		return "", nil, "", err
+	}
+
+	snapshot, err := json.Marshal(document)
+	if err != nil {
+		return "", nil, "", fmt.Errorf("failed to encode resume snapshot: %w", err)
+	}
+
+	return document.EntityTag(), snapshot, document.Resume.Title, nil
+}
+
+// addTransitionEvent writes the domain event that notifies the party who has
+// to act next. Statuses nobody has to act on raise no event.
+func (s *ReviewService) addTransitionEvent(ctx context.Context, userID string, reviewItem *review.Review, title string, note *string) error {
+	var eventType string
+	switch reviewItem.Status {
+	case review.StatusSubmitted:
+		eventType = job.EventReviewSubmitted
+	case review.StatusChangesRequested, review.StatusApproved:
+		eventType = job.EventReviewDecided
+	default:
+		return nil
+	}
+
+	if title == "" {
+		resumeItem, err := s.resumeRepo.GetResumeByID(ctx, userID, reviewItem.ResumeID)
+		if err != nil {
+			return fmt.Errorf("failed to get resume for review notification: %w", err)
+		}
+		title = resumeItem.Title
+	}
+
+	var event any
+	if eventType == job.EventReviewSubmitted {
+		event = job.ReviewSubmittedEvent{
+			ReviewID:    reviewItem.ID.String(),
+			ReviewerID:  reviewItem.ReviewerID,
+			ResumeTitle: title,
+			Message:     valueOrEmpty(note),
+			ReviewURL:   s.reviewURL(reviewItem.ID),
+		}
+	} else {
+		event = job.ReviewDecidedEvent{
+			ReviewID:    reviewItem.ID.String(),
+			SubmitterID: reviewItem.SubmitterID,
+			ResumeTitle: title,
+			Decision:    strings.ReplaceAll(string(reviewItem.Status), "_", " "),
+			Note:        valueOrEmpty(note),
+			ReviewURL:   s.reviewURL(reviewItem.ID),
+		}
+	}
+
+	if err := s.outboxRepo.AddEvent(ctx, eventType, event); err != nil {
+		return fmt.Errorf("failed to add review notification event: %w", err)
+	}
+
+	return nil
+}
+
+func (s *ReviewService) reviewURL(reviewID uuid.UUID) string {
+	return fmt.Sprintf("%s/reviews/%s", strings.TrimRight(s.server.Config.Server.FrontendURL, "/"), reviewID.String())
+}
+
+func (s *ReviewService) convertToReviewResponse(reviewItem *review.Review, reviewEvents []review.Event, withSnapshot bool) *review.ReviewResponse {
+	response := &review.ReviewResponse{
+		ID:            reviewItem.ID.String(),
+		ResumeID:      reviewItem.ResumeID,
+		SubmitterID:   reviewItem.SubmitterID,
+		ReviewerID:    reviewItem.ReviewerID,
+		Status:        reviewItem.Status,
+		Message:       reviewItem.Message,
+		ResumeVersion: reviewItem.ResumeVersion,
+		SubmittedAt:   reviewItem.SubmittedAt.Format(time.RFC3339),
+		StartedAt:     formatOptionalTime(reviewItem.StartedAt),
+		DecidedAt:     formatOptionalTime(reviewItem.DecidedAt),
+		WithdrawnAt:   formatOptionalTime(reviewItem.WithdrawnAt),
+		CreatedAt:     reviewItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:     reviewItem.UpdatedAt.Format(time.RFC3339),
+	}
+
+	if withSnapshot {
+		response.Snapshot = reviewItem.Snapshot
+	}
+
+	for _, event := range reviewEvents {
+		response.History = append(response.History, review.EventResponse{
+			FromStatus: event.FromStatus,
+			ToStatus:   event.ToStatus,
+			ActorID:    event.ActorID,
+			Note:       event.Note,
+			CreatedAt:  event.CreatedAt.Format(time.RFC3339),
+		})
+	}
+
+	return response
+}
+
+func formatOptionalTime(t *time.Time) *string {
+	if t == nil {
+		return nil
+	}
+	formatted := t.Format(time.RFC3339)
+	return &formatted
+}
+
+func valueOrEmpty(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
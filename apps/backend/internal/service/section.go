@@ -2,11 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
 	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/section"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
@@ -16,6 +23,7 @@ type SectionService struct {
 	server      *server.Server
 	sectionRepo *repository.ResumeSectionRepository
 	resumeRepo  *repository.ResumeRepository
+	auditLog    auditLog
 }
 
 func NewSectionService(s *server.Server, repos *repository.Repositories) *SectionService {
@@ -23,50 +31,27 @@ func NewSectionService(s *server.Server, repos *repository.Repositories) *Sectio
 		server:      s,
 		sectionRepo: repos.Section,
 		resumeRepo:  repos.Resume,
+		auditLog:    newAuditLog(s, repos),
 	}
 }
 
 // CreateSection creates a new resume section
 func (s *SectionService) CreateSection(ctx context.Context, userID string, payload *section.CreateSectionRequest) (*section.SectionResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
-	}
-
-	// Business logic: Validate section name
-	validSections := []string{"education", "experience", "projects", "skills", "certifications", "summary", "contact"}
-	isValidSection := false
-	for _, validSection := range validSections {
-		if payload.Name == validSection {
-			isValidSection = true
-			break
-		}
-	}
-	if !isValidSection {
-		return nil, errs.NewBadRequestError(
-			"invalid section name. Must be one of: education, experience, projects, skills, certifications, summary, contact",
-			false, nil, nil, nil,
-		)
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate section names
+	// Business logic: Check the section against the resume's other sections
 	existingSections, err := s.sectionRepo.GetSectionsByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing sections: %w", err)
 	}
 
-	// Check for duplicates based on name
-	for _, existing := range existingSections {
-		if existing.Name == payload.Name {
-			return nil, errs.NewBadRequestError(
-				"section with same name already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := section.ResumeSection{Name: payload.Name}
+	if violation := checkSection(entry, existingSections); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -88,6 +73,15 @@ func (s *SectionService) CreateSection(ctx context.Context, userID string, paylo
 
 	// Convert to response DTO
 	response := s.convertToSectionResponse(sectionItem)
+	s.server.Events.Publish(ctx, sectionItem.ResumeID, events.EntitySection, events.ActionCreated, &sectionItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &sectionItem.ResumeID,
+		EntityType: events.EntitySection,
+		EntityID:   &sectionItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -131,7 +125,7 @@ func (s *SectionService) GetSectionsByResumeID(ctx context.Context, userID strin
 }
 
 // UpdateSection updates a section
-func (s *SectionService) UpdateSection(ctx context.Context, userID string, sectionID uuid.UUID, payload *section.UpdateSectionRequest) (*section.SectionResponse, error) {
+func (s *SectionService) UpdateSection(ctx context.Context, userID string, sectionID uuid.UUID, ifMatch string, payload *section.UpdateSectionRequest) (*section.SectionResponse, error) {
 	// Check if section exists and belongs to user
 	existingSection, err := s.sectionRepo.GetSectionByID(ctx, userID, sectionID)
 	if err != nil {
@@ -141,65 +135,78 @@ func (s *SectionService) UpdateSection(ctx context.Context, userID string, secti
 		return nil, fmt.Errorf("failed to get existing section: %w", err)
 	}
 
-	// Business logic: Validate section name if provided
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingSection.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the section
+	if err := etag.Check(ifMatch, existingSection.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Check the updated section against the resume's other sections
 	if payload.Name != nil {
-		validSections := []string{"education", "experience", "projects", "skills", "certifications", "summary", "contact"}
-		isValidSection := false
-		for _, validSection := range validSections {
-			if *payload.Name == validSection {
-				isValidSection = true
-				break
-			}
-		}
-		if !isValidSection {
-			return nil, errs.NewBadRequestError(
-				"invalid section name. Must be one of: education, experience, projects, skills, certifications, summary, contact",
-				false, nil, nil, nil,
-			)
+		sections, err := s.sectionRepo.GetSectionsByResumeID(ctx, userID, existingSection.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing sections: %w", err)
 		}
 
-		// Check for duplicate section names (excluding current section)
-		if *payload.Name != existingSection.Name {
-			sections, err := s.sectionRepo.GetSectionsByResumeID(ctx, userID, existingSection.ResumeID)
-			if err != nil {
-				return nil, fmt.Errorf("failed to check existing sections: %w", err)
-			}
-
-			for _, sec := range sections {
-				if sec.ID != sectionID && sec.Name == *payload.Name {
-					return nil, errs.NewBadRequestError(
-						"section with same name already exists",
-						false, nil, nil, nil,
-					)
-				}
-			}
+		others := slices.DeleteFunc(sections, func(sec section.ResumeSection) bool { return sec.ID == sectionID })
+		if violation := checkSection(applySectionUpdate(*existingSection, payload), others); violation != nil {
+			return nil, ruleViolation(violation)
 		}
 	}
 
 	// Update section in repository
-	updatedSection, err := s.sectionRepo.UpdateSection(ctx, userID, sectionID, payload)
+	updatedSection, err := s.sectionRepo.UpdateSection(ctx, userID, sectionID, existingSection.UpdatedAt, payload)
 	if err != nil {
+		// The section changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update section: %w", err)
 	}
 
-	return s.convertToSectionResponse(updatedSection), nil
+	response := s.convertToSectionResponse(updatedSection)
+	s.server.Events.Publish(ctx, updatedSection.ResumeID, events.EntitySection, events.ActionUpdated, &updatedSection.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedSection.ResumeID,
+		EntityType: events.EntitySection,
+		EntityID:   &updatedSection.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToSectionResponse(existingSection),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateSectionOrder updates the order of multiple sections
 func (s *SectionService) BulkUpdateSectionOrder(ctx context.Context, userID string, payload *section.BulkUpdateSectionsRequest) error {
 	// Validate that all sections belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, sectionUpdate := range payload.Sections {
 		sectionID, err := uuid.Parse(sectionUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid section ID", false, nil, nil, nil)
 		}
-		_, err = s.sectionRepo.GetSectionByID(ctx, userID, sectionID)
+		existingSection, err := s.sectionRepo.GetSectionByID(ctx, userID, sectionID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:resume_sections" {
 				return errs.NewNotFoundError("section not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify section ownership: %w", err)
 		}
+		resumeIDs[existingSection.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -208,13 +215,24 @@ func (s *SectionService) BulkUpdateSectionOrder(ctx context.Context, userID stri
 		return fmt.Errorf("failed to update section order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntitySection, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntitySection,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteSection deletes a section
-func (s *SectionService) DeleteSection(ctx context.Context, userID string, sectionID uuid.UUID) error {
+func (s *SectionService) DeleteSection(ctx context.Context, userID string, sectionID uuid.UUID, ifMatch string) error {
 	// Check if section exists and belongs to user
-	_, err := s.sectionRepo.GetSectionByID(ctx, userID, sectionID)
+	existingSection, err := s.sectionRepo.GetSectionByID(ctx, userID, sectionID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:resume_sections" {
 			return errs.NewNotFoundError("section not found", false, nil)
@@ -222,12 +240,36 @@ func (s *SectionService) DeleteSection(ctx context.Context, userID string, secti
 		return fmt.Errorf("failed to get existing section: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingSection.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the section
+	if err := etag.Check(ifMatch, existingSection.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete section
-	err = s.sectionRepo.DeleteSection(ctx, userID, sectionID)
+	err = s.sectionRepo.DeleteSection(ctx, userID, sectionID, existingSection.UpdatedAt)
 	if err != nil {
+		// The section changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete section: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingSection.ResumeID, events.EntitySection, events.ActionDeleted, &sectionID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingSection.ResumeID,
+		EntityType: events.EntitySection,
+		EntityID:   &sectionID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToSectionResponse(existingSection),
+	})
+
 	return nil
 }
 
@@ -243,6 +285,7 @@ func (s *SectionService) convertToSectionResponse(sectionItem *section.ResumeSec
 		OrderIndex:  sectionItem.OrderIndex,
 		CreatedAt:   sectionItem.CreatedAt.Format(time.RFC3339),
 		UpdatedAt:   sectionItem.UpdatedAt.Format(time.RFC3339),
+		ETag:        etag.Format(sectionItem.UpdatedAt),
 	}
 
 	return response
@@ -15,6 +15,21 @@ type Services struct {
 	Skill         *SkillService
 	Certification *CertificationService
 	Section       *SectionService
+	Collaborator  *CollaboratorService
+	Comment       *CommentService
+	Organization  *OrganizationService
+	Review        *ReviewService
+	Audit         *AuditService
+	Outbox        *OutboxService
+	Webhook       *WebhookService
+	User          *UserService
+	DataExport    *DataExportService
+	Erasure       *ErasureService
+	EmailDelivery *EmailDeliveryService
+	Notification  *NotificationService
+	Maintenance   *MaintenanceService
+	ResumeExport  *ResumeExportService
+	Storage       *StorageService
 	Job           *job.JobService
 }
 
@@ -27,8 +42,23 @@ func NewServices(s *server.Server, repos *repository.Repositories) (*Services, e
 	skillService := NewSkillService(s, repos)
 	certificationService := NewCertificationService(s, repos)
 	sectionService := NewSectionService(s, repos)
+	collaboratorService := NewCollaboratorService(s, repos)
+	commentService := NewCommentService(s, repos)
+	organizationService := NewOrganizationService(s, repos)
+	reviewService := NewReviewService(s, repos, resumeService)
+	auditService := NewAuditService(s, repos)
+	outboxService := NewOutboxService(s, repos)
+	webhookService := NewWebhookService(s, repos, resumeService)
+	erasureService := NewErasureService(s, repos)
+	userService := NewUserService(s, repos, erasureService, organizationService)
+	dataExportService := NewDataExportService(s, repos, resumeService)
+	emailDeliveryService := NewEmailDeliveryService(s, repos)
+	notificationService := NewNotificationService(s, repos)
+	maintenanceService := NewMaintenanceService(s, repos)
+	resumeExportService := NewResumeExportService(s, repos, resumeService)
+	storageService := NewStorageService(s)
 
-	return &Services{
+	services := &Services{
 		Job:           s.Job,
 		Auth:          authService,
 		Resume:        resumeService,
@@ -38,5 +68,26 @@ func NewServices(s *server.Server, repos *repository.Repositories) (*Services, e
 		Skill:         skillService,
 		Certification: certificationService,
 		Section:       sectionService,
-	}, nil
+		Collaborator:  collaboratorService,
+		Comment:       commentService,
+		Organization:  organizationService,
+		Review:        reviewService,
+		Audit:         auditService,
+		Outbox:        outboxService,
+		Webhook:       webhookService,
+		User:          userService,
+		DataExport:    dataExportService,
+		Erasure:       erasureService,
+		EmailDelivery: emailDeliveryService,
+		Notification:  notificationService,
+		Maintenance:   maintenanceService,
+		ResumeExport:  resumeExportService,
+		Storage:       storageService,
+	}
+
+	if err := registerJobHandlers(s, services); err != nil {
+		return nil, err
+	}
+
+	return services, nil
 }
@@ -2,10 +2,18 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"time"
 
 	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
 	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
 	"github.com/recreatedev/Resumify/internal/model/skill"
 	"github.com/recreatedev/Resumify/internal/repository"
 	"github.com/recreatedev/Resumify/internal/server"
@@ -15,6 +23,7 @@ type SkillService struct {
 	server     *server.Server
 	skillRepo  *repository.SkillRepository
 	resumeRepo *repository.ResumeRepository
+	auditLog   auditLog
 }
 
 func NewSkillService(s *server.Server, repos *repository.Repositories) *SkillService {
@@ -22,52 +31,27 @@ func NewSkillService(s *server.Server, repos *repository.Repositories) *SkillSer
 		server:     s,
 		skillRepo:  repos.Skill,
 		resumeRepo: repos.Resume,
+		auditLog:   newAuditLog(s, repos),
 	}
 }
 
 // CreateSkill creates a new skill entry
 func (s *SkillService) CreateSkill(ctx context.Context, userID string, payload *skill.CreateSkillRequest) (*skill.SkillResponse, error) {
-	// Verify resume belongs to user
-	_, err := s.resumeRepo.GetResumeByID(ctx, userID, payload.ResumeID)
+	// Verify the user can edit the resume
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleEditor)
 	if err != nil {
-		if err.Error() == "failed to collect row from table:resumes" {
-			return nil, errs.NewNotFoundError("resume not found", false, nil)
-		}
-		return nil, fmt.Errorf("failed to verify resume ownership: %w", err)
-	}
-
-	// Business logic: Validate skill level
-	if payload.Level != nil {
-		validLevels := []string{"Beginner", "Intermediate", "Advanced", "Expert"}
-		isValidLevel := false
-		for _, level := range validLevels {
-			if *payload.Level == level {
-				isValidLevel = true
-				break
-			}
-		}
-		if !isValidLevel {
-			return nil, errs.NewBadRequestError(
-				"invalid skill level. Must be one of: Beginner, Intermediate, Advanced, Expert",
-				false, nil, nil, nil,
-			)
-		}
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate skill entries
+	// Business logic: Check the skill against the resume's other skills
 	existingSkills, err := s.skillRepo.GetSkillsByResumeID(ctx, userID, payload.ResumeID)
 	if err != nil {
 		return nil, fmt.Errorf("failed to check existing skills: %w", err)
 	}
 
-	// Check for duplicates based on name
-	for _, existing := range existingSkills {
-		if *existing.Name == *payload.Name {
-			return nil, errs.NewBadRequestError(
-				"skill with same name already exists",
-				false, nil, nil, nil,
-			)
-		}
+	entry := skill.Skill{Name: payload.Name, Level: payload.Level}
+	if violation := checkSkill(entry, existingSkills); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Set default order index if not provided
@@ -89,6 +73,15 @@ func (s *SkillService) CreateSkill(ctx context.Context, userID string, payload *
 
 	// Convert to response DTO
 	response := s.convertToSkillResponse(skillItem)
+	s.server.Events.Publish(ctx, skillItem.ResumeID, events.EntitySkill, events.ActionCreated, &skillItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &skillItem.ResumeID,
+		EntityType: events.EntitySkill,
+		EntityID:   &skillItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
 
 	return response, nil
 }
@@ -151,7 +144,7 @@ func (s *SkillService) GetSkillsByCategory(ctx context.Context, userID string, r
 }
 
 // UpdateSkill updates a skill entry
-func (s *SkillService) UpdateSkill(ctx context.Context, userID string, skillID uuid.UUID, payload *skill.UpdateSkillRequest) (*skill.SkillResponse, error) {
+func (s *SkillService) UpdateSkill(ctx context.Context, userID string, skillID uuid.UUID, ifMatch string, payload *skill.UpdateSkillRequest) (*skill.SkillResponse, error) {
 	// Check if skill exists and belongs to user
 	existingSkill, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
 	if err != nil {
@@ -161,65 +154,76 @@ func (s *SkillService) UpdateSkill(ctx context.Context, userID string, skillID u
 		return nil, fmt.Errorf("failed to get existing skill: %w", err)
 	}
 
-	// Business logic: Validate skill level if provided
-	if payload.Level != nil {
-		validLevels := []string{"beginner", "intermediate", "advanced", "expert"}
-		isValidLevel := false
-		for _, level := range validLevels {
-			if *payload.Level == level {
-				isValidLevel = true
-				break
-			}
-		}
-		if !isValidLevel {
-			return nil, errs.NewBadRequestError(
-				"invalid skill level. Must be one of: beginner, intermediate, advanced, expert",
-				false, nil, nil, nil,
-			)
-		}
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingSkill.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
 	}
 
-	// Business logic: Check for duplicate skill names (excluding current skill)
-	if payload.Name != nil && *payload.Name != *existingSkill.Name {
-		skills, err := s.skillRepo.GetSkillsByResumeID(ctx, userID, existingSkill.ResumeID)
-		if err != nil {
-			return nil, fmt.Errorf("failed to check existing skills: %w", err)
-		}
+	// Reject writes based on a stale copy of the skill
+	if err := etag.Check(ifMatch, existingSkill.UpdatedAt); err != nil {
+		return nil, err
+	}
 
-		for _, sk := range skills {
-			if sk.ID != skillID && *sk.Name == *payload.Name {
-				return nil, errs.NewBadRequestError(
-					"skill with same name already exists",
-					false, nil, nil, nil,
-				)
-			}
-		}
+	// Business logic: Check the updated skill against the resume's other skills
+	skills, err := s.skillRepo.GetSkillsByResumeID(ctx, userID, existingSkill.ResumeID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to check existing skills: %w", err)
+	}
+
+	others := slices.DeleteFunc(skills, func(sk skill.Skill) bool { return sk.ID == skillID })
+	if violation := checkSkill(applySkillUpdate(*existingSkill, payload), others); violation != nil {
+		return nil, ruleViolation(violation)
 	}
 
 	// Update skill in repository
-	updatedSkill, err := s.skillRepo.UpdateSkill(ctx, userID, skillID, payload)
+	updatedSkill, err := s.skillRepo.UpdateSkill(ctx, userID, skillID, existingSkill.UpdatedAt, payload)
 	if err != nil {
+		// The skill changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
 		return nil, fmt.Errorf("failed to update skill: %w", err)
 	}
 
-	return s.convertToSkillResponse(updatedSkill), nil
+	response := s.convertToSkillResponse(updatedSkill)
+	s.server.Events.Publish(ctx, updatedSkill.ResumeID, events.EntitySkill, events.ActionUpdated, &updatedSkill.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &updatedSkill.ResumeID,
+		EntityType: events.EntitySkill,
+		EntityID:   &updatedSkill.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToSkillResponse(existingSkill),
+		After:      response,
+	})
+
+	return response, nil
 }
 
 // BulkUpdateSkillOrder updates the order of multiple skill entries
 func (s *SkillService) BulkUpdateSkillOrder(ctx context.Context, userID string, payload *skill.BulkUpdateSkillsRequest) error {
 	// Validate that all skill entries belong to the user
+	resumeIDs := make(map[uuid.UUID]bool)
 	for _, skillUpdate := range payload.Skills {
 		skillID, err := uuid.Parse(skillUpdate.ID)
 		if err != nil {
 			return errs.NewBadRequestError("invalid skill ID", false, nil, nil, nil)
 		}
-		_, err = s.skillRepo.GetSkillByID(ctx, userID, skillID)
+		existingSkill, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
 		if err != nil {
 			if err.Error() == "failed to collect row from table:skills" {
 				return errs.NewNotFoundError("skill not found", false, nil)
 			}
 			return fmt.Errorf("failed to verify skill ownership: %w", err)
 		}
+		resumeIDs[existingSkill.ResumeID] = true
+	}
+
+	// Only editors may reorder the resume's content
+	for resumeID := range resumeIDs {
+		if _, err := authorizeResume(ctx, s.resumeRepo, userID, resumeID, collaborator.RoleEditor); err != nil {
+			return err
+		}
 	}
 
 	// Update order in repository
@@ -228,13 +232,24 @@ func (s *SkillService) BulkUpdateSkillOrder(ctx context.Context, userID string,
 		return fmt.Errorf("failed to update skill order: %w", err)
 	}
 
+	for resumeID := range resumeIDs {
+		s.server.Events.Publish(ctx, resumeID, events.EntitySkill, events.ActionReordered, nil, payload)
+		s.auditLog.Record(ctx, audit.Record{
+			ActorID:    userID,
+			ResumeID:   &resumeID,
+			EntityType: events.EntitySkill,
+			Action:     events.ActionReordered,
+			After:      payload,
+		})
+	}
+
 	return nil
 }
 
 // DeleteSkill deletes a skill entry
-func (s *SkillService) DeleteSkill(ctx context.Context, userID string, skillID uuid.UUID) error {
+func (s *SkillService) DeleteSkill(ctx context.Context, userID string, skillID uuid.UUID, ifMatch string) error {
 	// Check if skill exists and belongs to user
-	_, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
+	existingSkill, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
 	if err != nil {
 		if err.Error() == "failed to collect row from table:skills" {
 			return errs.NewNotFoundError("skill not found", false, nil)
@@ -242,12 +257,36 @@ func (s *SkillService) DeleteSkill(ctx context.Context, userID string, skillID u
 		return fmt.Errorf("failed to get existing skill: %w", err)
 	}
 
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingSkill.ResumeID, collaborator.RoleEditor); err != nil {
+		return err
+	}
+
+	// Reject writes based on a stale copy of the skill
+	if err := etag.Check(ifMatch, existingSkill.UpdatedAt); err != nil {
+		return err
+	}
+
 	// Delete skill
-	err = s.skillRepo.DeleteSkill(ctx, userID, skillID)
+	err = s.skillRepo.DeleteSkill(ctx, userID, skillID, existingSkill.UpdatedAt)
 	if err != nil {
+		// The skill changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
 		return fmt.Errorf("failed to delete skill: %w", err)
 	}
 
+	s.server.Events.Publish(ctx, existingSkill.ResumeID, events.EntitySkill, events.ActionDeleted, &skillID, nil)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &existingSkill.ResumeID,
+		EntityType: events.EntitySkill,
+		EntityID:   &skillID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToSkillResponse(existingSkill),
+	})
+
 	return nil
 }
 
@@ -255,12 +294,17 @@ func (s *SkillService) DeleteSkill(ctx context.Context, userID string, skillID u
 
 func (s *SkillService) convertToSkillResponse(skillItem *skill.Skill) *skill.SkillResponse {
 	response := &skill.SkillResponse{
-		ID:         skillItem.ID.String(),
-		ResumeID:   skillItem.ResumeID,
-		Name:       skillItem.Name,
-		Level:      skillItem.Level,
-		Category:   skillItem.Category,
-		OrderIndex: skillItem.OrderIndex,
+		ID:               skillItem.ID.String(),
+		ResumeID:         skillItem.ResumeID,
+		LibraryItemID:    skillItem.LibraryItemID,
+		OverriddenFields: skillItem.OverriddenFields,
+		Name:             skillItem.Name,
+		Level:            skillItem.Level,
+		Category:         skillItem.Category,
+		OrderIndex:       skillItem.OrderIndex,
+		CreatedAt:        skillItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:        skillItem.UpdatedAt.Format(time.RFC3339),
+		ETag:             etag.Format(skillItem.UpdatedAt),
 	}
 
 	return response
@@ -0,0 +1,296 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/etag"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	"github.com/recreatedev/Resumify/internal/model/collaborator"
+	"github.com/recreatedev/Resumify/internal/model/skill"
+)
+
+// CreateLibrarySkill adds a skill entry to the user's career library
+func (s *SkillService) CreateLibrarySkill(ctx context.Context, userID string, payload *skill.CreateLibrarySkillRequest) (*skill.LibrarySkillResponse, error) {
+	libraryItem, err := s.skillRepo.CreateLibrarySkill(ctx, userID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create library skill: %w", err)
+	}
+
+	response := s.convertToLibrarySkillResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibrarySkill,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// GetLibrarySkillByID retrieves a career library skill entry by ID
+func (s *SkillService) GetLibrarySkillByID(ctx context.Context, userID string, libraryItemID uuid.UUID) (*skill.LibrarySkillResponse, error) {
+	libraryItem, err := s.skillRepo.GetLibrarySkillByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_skills" {
+			return nil, errs.NewNotFoundError("library skill not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library skill: %w", err)
+	}
+
+	return s.convertToLibrarySkillResponse(libraryItem), nil
+}
+
+// GetLibrarySkillsByUserID retrieves all skills in the user's career library
+func (s *SkillService) GetLibrarySkillsByUserID(ctx context.Context, userID string) ([]skill.LibrarySkillResponse, error) {
+	libraryItems, err := s.skillRepo.GetLibrarySkillsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get library skills: %w", err)
+	}
+
+	// Convert to response DTOs
+	responses := make([]skill.LibrarySkillResponse, len(libraryItems))
+	for i, item := range libraryItems {
+		responses[i] = *s.convertToLibrarySkillResponse(&item)
+	}
+
+	return responses, nil
+}
+
+// UpdateLibrarySkill updates a career library skill entry; every resume linked to it
+// picks up the change for fields it has not overridden
+func (s *SkillService) UpdateLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string, payload *skill.UpdateLibrarySkillRequest) (*skill.LibrarySkillResponse, error) {
+	// Check if library item exists and belongs to user
+	existingItem, err := s.skillRepo.GetLibrarySkillByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_skills" {
+			return nil, errs.NewNotFoundError("library skill not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing library skill: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library skill
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	updatedItem, err := s.skillRepo.UpdateLibrarySkill(ctx, userID, libraryItemID, existingItem.UpdatedAt, payload)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to update library skill: %w", err)
+	}
+
+	response := s.convertToLibrarySkillResponse(updatedItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibrarySkill,
+		EntityID:   &updatedItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToLibrarySkillResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteLibrarySkill removes a career library skill entry. Linked resume entries keep
+// their current content and become standalone.
+func (s *SkillService) DeleteLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, ifMatch string) error {
+	existingItem, err := s.skillRepo.GetLibrarySkillByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_skills" {
+			return errs.NewNotFoundError("library skill not found", false, nil)
+		}
+		return fmt.Errorf("failed to get existing library skill: %w", err)
+	}
+
+	// Reject writes based on a stale copy of the library skill
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return err
+	}
+
+	err = s.skillRepo.DeleteLibrarySkill(ctx, userID, libraryItemID, existingItem.UpdatedAt)
+	if err != nil {
+		// The library item changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return etag.NewStaleError()
+		}
+		return fmt.Errorf("failed to delete library skill: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibrarySkill,
+		EntityID:   &libraryItemID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToLibrarySkillResponse(existingItem),
+	})
+
+	return nil
+}
+
+// LinkLibrarySkill adds a career library skill entry to a resume
+func (s *SkillService) LinkLibrarySkill(ctx context.Context, userID string, libraryItemID uuid.UUID, payload *skill.LinkLibrarySkillRequest) (*skill.SkillResponse, error) {
+	// Only the owner may link library items, since a collaborator's library
+	// is not theirs to edit or delete
+	_, err := authorizeResume(ctx, s.resumeRepo, userID, payload.ResumeID, collaborator.RoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	// Verify library item belongs to user
+	_, err = s.skillRepo.GetLibrarySkillByID(ctx, userID, libraryItemID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:library_skills" {
+			return nil, errs.NewNotFoundError("library skill not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get library skill: %w", err)
+	}
+
+	// Set default order index if not provided
+	if payload.OrderIndex == 0 {
+		existingItems, err := s.skillRepo.GetSkillsByResumeID(ctx, userID, payload.ResumeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to check existing skills: %w", err)
+		}
+		payload.OrderIndex = len(existingItems) + 1
+	}
+
+	linkedItem, err := s.skillRepo.LinkLibrarySkill(ctx, userID, libraryItemID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to link library skill: %w", err)
+	}
+
+	response := s.convertToSkillResponse(linkedItem)
+	s.server.Events.Publish(ctx, linkedItem.ResumeID, events.EntitySkill, events.ActionCreated, &linkedItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &linkedItem.ResumeID,
+		EntityType: events.EntitySkill,
+		EntityID:   &linkedItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// PromoteSkill moves a resume skill entry into the career library and links the
+// entry to it
+func (s *SkillService) PromoteSkill(ctx context.Context, userID string, skillID uuid.UUID) (*skill.LibrarySkillResponse, error) {
+	existingItem, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:skills" {
+			return nil, errs.NewNotFoundError("skill not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing skill: %w", err)
+	}
+
+	// Only the owner may promote: the entry would otherwise move into a
+	// collaborator's library, out of the owner's control
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleOwner); err != nil {
+		return nil, err
+	}
+
+	// Business logic: Linked entries already live in the library
+	if existingItem.LibraryItemID != nil {
+		return nil, errs.NewBadRequestError(
+			"skill is already linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	libraryItem, err := s.skillRepo.PromoteSkill(ctx, userID, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to promote skill: %w", err)
+	}
+
+	response := s.convertToLibrarySkillResponse(libraryItem)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityLibrarySkill,
+		EntityID:   &libraryItem.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// ResetSkillOverrides drops the per-resume overrides of a linked skill entry
+func (s *SkillService) ResetSkillOverrides(ctx context.Context, userID string, skillID uuid.UUID, ifMatch string) (*skill.SkillResponse, error) {
+	existingItem, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
+	if err != nil {
+		if err.Error() == "failed to collect row from table:skills" {
+			return nil, errs.NewNotFoundError("skill not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get existing skill: %w", err)
+	}
+
+	// Only editors may change the resume's content
+	if _, err := authorizeResume(ctx, s.resumeRepo, userID, existingItem.ResumeID, collaborator.RoleEditor); err != nil {
+		return nil, err
+	}
+
+	// Reject writes based on a stale copy of the skill
+	if err := etag.Check(ifMatch, existingItem.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	if existingItem.LibraryItemID == nil {
+		return nil, errs.NewBadRequestError(
+			"skill is not linked to a library item",
+			false, nil, nil, nil,
+		)
+	}
+
+	err = s.skillRepo.ResetSkillOverrides(ctx, userID, skillID, existingItem.UpdatedAt)
+	if err != nil {
+		// The skill changed after the If-Match check above
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, etag.NewStaleError()
+		}
+		return nil, fmt.Errorf("failed to reset skill overrides: %w", err)
+	}
+
+	resetItem, err := s.skillRepo.GetSkillByID(ctx, userID, skillID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get skill: %w", err)
+	}
+
+	response := s.convertToSkillResponse(resetItem)
+	s.server.Events.Publish(ctx, resetItem.ResumeID, events.EntitySkill, events.ActionUpdated, &resetItem.ID, response)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		ResumeID:   &resetItem.ResumeID,
+		EntityType: events.EntitySkill,
+		EntityID:   &resetItem.ID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToSkillResponse(existingItem),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+func (s *SkillService) convertToLibrarySkillResponse(libraryItem *skill.LibrarySkill) *skill.LibrarySkillResponse {
+	return &skill.LibrarySkillResponse{
+		ID:        libraryItem.ID.String(),
+		Name:      libraryItem.Name,
+		Level:     libraryItem.Level,
+		Category:  libraryItem.Category,
+		CreatedAt: libraryItem.CreatedAt.Format(time.RFC3339),
+		UpdatedAt: libraryItem.UpdatedAt.Format(time.RFC3339),
+		ETag:      etag.Format(libraryItem.UpdatedAt),
+	}
+}
@@ -0,0 +1,55 @@
+package service
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"path"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/signedurl"
+	"github.com/recreatedev/Resumify/internal/lib/storage"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type StorageService struct {
+	server *server.Server
+}
+
+func NewStorageService(s *server.Server) *StorageService {
+	return &StorageService{server: s}
+}
+
+// DownloadBlob serves a blob of the local storage backend to the holder of a
+// signed link. Other backends serve their signed links themselves.
+func (s *StorageService) DownloadBlob(ctx context.Context, key, expires, signature string) (*model.Download, error) {
+	local, ok := s.server.Storage.(*storage.LocalStorage)
+	if !ok {
+		return nil, errs.NewNotFoundError("blob not found", false, nil)
+	}
+
+	err := local.VerifySignedURL(key, expires, signature, time.Now())
+	if err != nil {
+		if errors.Is(err, signedurl.ErrExpired) {
+			return nil, errs.NewForbiddenError("download link has expired", false)
+		}
+		return nil, errs.NewForbiddenError("invalid download link", false)
+	}
+
+	object, err := local.Get(ctx, key)
+	if err != nil {
+		if errors.Is(err, storage.ErrNotFound) {
+			return nil, errs.NewNotFoundError("blob not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get blob: %w", err)
+	}
+
+	return &model.Download{
+		Filename:    path.Base(key),
+		ContentType: object.ContentType,
+		Size:        object.Size,
+		Content:     object.Content,
+	}, nil
+}
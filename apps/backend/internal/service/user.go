@@ -0,0 +1,102 @@
+package service
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/model/erasure"
+	"github.com/recreatedev/Resumify/internal/model/user"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+type UserService struct {
+	server              *server.Server
+	userRepo            *repository.UserRepository
+	erasureService      *ErasureService
+	organizationService *OrganizationService
+}
+
+func NewUserService(s *server.Server, repos *repository.Repositories, erasureService *ErasureService, organizationService *OrganizationService) *UserService {
+	return &UserService{
+		server:              s,
+		userRepo:            repos.User,
+		erasureService:      erasureService,
+		organizationService: organizationService,
+	}
+}
+
+// HandleClerkEvent applies a verified Clerk webhook. Clerk retries webhooks
+// with the same ID, so the welcome email is keyed by it; a retried deletion
+// joins the erasure already in progress.
+func (s *UserService) HandleClerkEvent(ctx context.Context, webhookID string, event *user.ClerkEvent) error {
+	switch event.Type {
+	case user.ClerkUserCreated, user.ClerkUserUpdated:
+		var data user.ClerkUserData
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.ID == "" {
+			return errs.NewBadRequestError("invalid user data", false, nil, nil, nil)
+		}
+
+		profile, err := s.userRepo.UpsertUser(ctx, &data)
+		if err != nil {
+			return fmt.Errorf("failed to sync user: %w", err)
+		}
+
+		if event.Type != user.ClerkUserCreated || profile.Email == nil {
+			return nil
+		}
+
+		firstName := ""
+		if profile.FirstName != nil {
+			firstName = *profile.FirstName
+		}
+
+		task, err := job.NewWelcomeEmailTask(profile.ID, *profile.Email, firstName)
+		if err != nil {
+			return fmt.Errorf("failed to create welcome email task: %w", err)
+		}
+		return s.server.Job.EnqueueOnce(ctx, webhookID+":"+job.TaskWelcome, task)
+
+	case user.ClerkUserDeleted:
+		var data user.ClerkDeletedData
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.ID == "" {
+			return errs.NewBadRequestError("invalid user data", false, nil, nil, nil)
+		}
+
+		_, err := s.erasureService.RequestErasure(ctx, data.ID, erasure.SourceClerk)
+		return err
+
+	case user.ClerkMembershipCreated, user.ClerkMembershipUpdated, user.ClerkMembershipDeleted:
+		var data user.ClerkMembershipData
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.Organization.ID == "" || data.PublicUserData.UserID == "" {
+			return errs.NewBadRequestError("invalid organization membership data", false, nil, nil, nil)
+		}
+
+		switch event.Type {
+		case user.ClerkMembershipCreated:
+			return s.organizationService.AddMembership(ctx, data.PublicUserData.UserID, data.Organization.ID, data.Role, data.Permissions)
+		case user.ClerkMembershipUpdated:
+			return s.organizationService.UpdateMembership(ctx, data.PublicUserData.UserID, data.Organization.ID, data.Role, data.Permissions)
+		default:
+			return s.organizationService.RemoveMembership(ctx, data.PublicUserData.UserID, data.Organization.ID)
+		}
+
+	case user.ClerkOrganizationDeleted:
+		var data user.ClerkDeletedData
+		if err := json.Unmarshal(event.Data, &data); err != nil || data.ID == "" {
+			return errs.NewBadRequestError("invalid organization data", false, nil, nil, nil)
+		}
+
+		return s.organizationService.RemoveOrganization(ctx, data.ID)
+	}
+
+	// Other event types are acknowledged so Clerk does not retry them
+	s.server.Logger.Debug().
+		Str("webhook_id", webhookID).
+		Str("type", event.Type).
+		Msg("Ignoring Clerk webhook")
+	return nil
+}
@@ -0,0 +1,450 @@
+package service
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/hibiken/asynq"
+	"github.com/jackc/pgx/v5"
+	"github.com/recreatedev/Resumify/internal/errs"
+	"github.com/recreatedev/Resumify/internal/lib/events"
+	"github.com/recreatedev/Resumify/internal/lib/job"
+	"github.com/recreatedev/Resumify/internal/lib/webhook"
+	"github.com/recreatedev/Resumify/internal/model"
+	"github.com/recreatedev/Resumify/internal/model/audit"
+	webhookmodel "github.com/recreatedev/Resumify/internal/model/webhook"
+	"github.com/recreatedev/Resumify/internal/repository"
+	"github.com/recreatedev/Resumify/internal/server"
+)
+
+const (
+	// webhookTimeout bounds a single delivery attempt
+	webhookTimeout = 10 * time.Second
+	// webhookResponseLimit is how much of a receiver's response is kept in
+	// the delivery log
+	webhookResponseLimit = 1024
+)
+
+type WebhookService struct {
+	server        *server.Server
+	webhookRepo   *repository.WebhookRepository
+	resumeService *ResumeService
+	auditLog      auditLog
+	client        *http.Client
+}
+
+func NewWebhookService(s *server.Server, repos *repository.Repositories, resumeService *ResumeService) *WebhookService {
+	return &WebhookService{
+		server:        s,
+		webhookRepo:   repos.Webhook,
+		resumeService: resumeService,
+		auditLog:      newAuditLog(s, repos),
+		client:        webhook.NewHTTPClient(webhookTimeout, s.Config.Webhook.AllowPrivateTargets),
+	}
+}
+
+// webhookEnvelope is the body of every delivery
+type webhookEnvelope struct {
+	ID        string                 `json:"id"`
+	Type      webhookmodel.EventType `json:"type"`
+	CreatedAt string                 `json:"createdAt"`
+	Data      any                    `json:"data"`
+}
+
+// CreateEndpoint registers a webhook endpoint. The signing secret is only
+// returned here.
+func (s *WebhookService) CreateEndpoint(ctx context.Context, userID string, payload *webhookmodel.CreateEndpointRequest) (*webhookmodel.EndpointResponse, error) {
+	secret, err := newWebhookSecret()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate webhook secret: %w", err)
+	}
+
+	endpoint, err := s.webhookRepo.CreateEndpoint(ctx, userID, secret, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook endpoint: %w", err)
+	}
+
+	response := s.convertToEndpointResponse(endpoint)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &endpoint.ID,
+		Action:     events.ActionCreated,
+		After:      response,
+	})
+
+	response.Secret = &endpoint.Secret
+	return response, nil
+}
+
+// GetEndpoints lists the user's webhook endpoints
+func (s *WebhookService) GetEndpoints(ctx context.Context, userID string) ([]webhookmodel.EndpointResponse, error) {
+	endpoints, err := s.webhookRepo.GetEndpointsByUserID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook endpoints: %w", err)
+	}
+
+	responses := make([]webhookmodel.EndpointResponse, len(endpoints))
+	for i := range endpoints {
+		responses[i] = *s.convertToEndpointResponse(&endpoints[i])
+	}
+
+	return responses, nil
+}
+
+// GetEndpoint returns one of the user's webhook endpoints
+func (s *WebhookService) GetEndpoint(ctx context.Context, userID string, endpointID uuid.UUID) (*webhookmodel.EndpointResponse, error) {
+	endpoint, err := s.getEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.convertToEndpointResponse(endpoint), nil
+}
+
+// UpdateEndpoint changes the URL, subscriptions or state of a webhook endpoint
+func (s *WebhookService) UpdateEndpoint(ctx context.Context, userID string, endpointID uuid.UUID, payload *webhookmodel.UpdateEndpointRequest) (*webhookmodel.EndpointResponse, error) {
+	existingEndpoint, err := s.getEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return nil, err
+	}
+
+	updatedEndpoint, err := s.webhookRepo.UpdateEndpoint(ctx, userID, endpointID, payload)
+	if err != nil {
+		return nil, fmt.Errorf("failed to update webhook endpoint: %w", err)
+	}
+
+	response := s.convertToEndpointResponse(updatedEndpoint)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &endpointID,
+		Action:     events.ActionUpdated,
+		Before:     s.convertToEndpointResponse(existingEndpoint),
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// DeleteEndpoint removes a webhook endpoint together with its delivery log
+func (s *WebhookService) DeleteEndpoint(ctx context.Context, userID string, endpointID uuid.UUID) error {
+	existingEndpoint, err := s.getEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		return err
+	}
+
+	err = s.webhookRepo.DeleteEndpoint(ctx, userID, endpointID)
+	if err != nil {
+		if err.Error() == "webhook endpoint not found" {
+			return errs.NewNotFoundError("webhook endpoint not found", false, nil)
+		}
+		return fmt.Errorf("failed to delete webhook endpoint: %w", err)
+	}
+
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &endpointID,
+		Action:     events.ActionDeleted,
+		Before:     s.convertToEndpointResponse(existingEndpoint),
+	})
+
+	return nil
+}
+
+// GetDeliveries returns the delivery log of a webhook endpoint, newest first
+func (s *WebhookService) GetDeliveries(ctx context.Context, userID string, endpointID uuid.UUID, page, limit int) (*model.PaginatedResponse[webhookmodel.DeliveryResponse], error) {
+	if _, err := s.getEndpoint(ctx, userID, endpointID); err != nil {
+		return nil, err
+	}
+
+	result, err := s.webhookRepo.GetDeliveriesByEndpoint(ctx, userID, endpointID, page, limit)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get webhook deliveries: %w", err)
+	}
+
+	responses := make([]webhookmodel.DeliveryResponse, len(result.Data))
+	for i := range result.Data {
+		responses[i] = *s.convertToDeliveryResponse(&result.Data[i])
+	}
+
+	return &model.PaginatedResponse[webhookmodel.DeliveryResponse]{
+		Data:       responses,
+		Page:       result.Page,
+		Limit:      result.Limit,
+		Total:      result.Total,
+		TotalPages: result.TotalPages,
+	}, nil
+}
+
+// RedeliverWebhook sends the event of an earlier delivery to its endpoint
+// again. The redelivery is logged as a delivery of its own.
+func (s *WebhookService) RedeliverWebhook(ctx context.Context, userID string, endpointID, deliveryID uuid.UUID) (*webhookmodel.DeliveryResponse, error) {
+	original, err := s.webhookRepo.GetDeliveryByID(ctx, userID, endpointID, deliveryID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("webhook delivery not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	redelivery, err := s.webhookRepo.CreateDelivery(ctx, endpointID, original.EventID, original.EventType, original.Payload, &original.ID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create webhook redelivery: %w", err)
+	}
+
+	if err := s.enqueueDelivery(ctx, redelivery.ID); err != nil {
+		return nil, err
+	}
+
+	response := s.convertToDeliveryResponse(redelivery)
+	s.auditLog.Record(ctx, audit.Record{
+		ActorID:    userID,
+		EntityType: audit.EntityWebhook,
+		EntityID:   &endpointID,
+		Action:     audit.ActionRedelivered,
+		After:      response,
+	})
+
+	return response, nil
+}
+
+// FanOutResumeEvent creates a delivery of a resume event for every endpoint of
+// the resume owner that subscribes to it. Fanning out the same event again
+// reuses the deliveries already created.
+func (s *WebhookService) FanOutResumeEvent(ctx context.Context, eventID string, eventType webhookmodel.EventType, event job.ResumeChangedEvent) error {
+	id, err := uuid.Parse(eventID)
+	if err != nil {
+		return fmt.Errorf("invalid event id %q: %w", eventID, err)
+	}
+	resumeID, err := uuid.Parse(event.ResumeID)
+	if err != nil {
+		return fmt.Errorf("invalid resume id %q: %w", event.ResumeID, err)
+	}
+
+	resumeItem, err := s.webhookRepo.GetEventResume(ctx, resumeID)
+	if err != nil {
+		// The resume was purged before the event was handled
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get resume: %w", err)
+	}
+
+	// An update that was overtaken by a deletion is not reported
+	if eventType == webhookmodel.EventResumeUpdated && resumeItem.DeletedAt != nil {
+		return nil
+	}
+
+	endpoints, err := s.webhookRepo.GetSubscribedEndpoints(ctx, resumeItem.UserID, eventType)
+	if err != nil {
+		return fmt.Errorf("failed to get subscribed webhook endpoints: %w", err)
+	}
+	if len(endpoints) == 0 {
+		return nil
+	}
+
+	var data any
+	if eventType == webhookmodel.EventResumeUpdated {
+		document, err := s.resumeService.getResumeDocument(ctx, resumeItem.UserID, resumeID)
+		if err != nil {
+			return err
+		}
+		data = document
+	} else {
+		data = s.resumeService.convertToResumeResponse(resumeItem)
+	}
+
+	body, err := json.Marshal(webhookEnvelope{
+		ID:        eventID,
+		Type:      eventType,
+		CreatedAt: time.Now().UTC().Format(time.RFC3339),
+		Data:      data,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal webhook payload: %w", err)
+	}
+
+	for _, endpoint := range endpoints {
+		delivery, err := s.webhookRepo.CreateDelivery(ctx, endpoint.ID, id, eventType, body, nil)
+		if err != nil {
+			return fmt.Errorf("failed to create webhook delivery: %w", err)
+		}
+		if delivery.Status != webhookmodel.DeliveryPending {
+			continue
+		}
+		if err := s.enqueueDelivery(ctx, delivery.ID); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliverWebhook makes one attempt to deliver a webhook and records its
+// outcome. An error is returned when the receiver did not accept the delivery
+// so that the job is retried; final marks the last attempt.
+func (s *WebhookService) DeliverWebhook(ctx context.Context, deliveryID uuid.UUID, final bool) error {
+	target, err := s.webhookRepo.GetDeliveryTarget(ctx, deliveryID)
+	if err != nil {
+		// The endpoint and its deliveries were deleted
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil
+		}
+		return fmt.Errorf("failed to get webhook delivery: %w", err)
+	}
+
+	if target.Status != webhookmodel.DeliveryPending {
+		return nil
+	}
+
+	if !target.Active {
+		message := "endpoint is disabled"
+		return s.webhookRepo.RecordAttempt(ctx, deliveryID, &webhookmodel.Attempt{
+			Status: webhookmodel.DeliveryFailed,
+			Error:  &message,
+		})
+	}
+
+	attempt, deliveryErr := s.post(ctx, target)
+	if deliveryErr != nil && !final {
+		attempt.Status = webhookmodel.DeliveryPending
+	}
+
+	if err := s.webhookRepo.RecordAttempt(ctx, deliveryID, attempt); err != nil {
+		return err
+	}
+
+	return deliveryErr
+}
+
+// Helper methods
+
+func (s *WebhookService) getEndpoint(ctx context.Context, userID string, endpointID uuid.UUID) (*webhookmodel.Endpoint, error) {
+	endpoint, err := s.webhookRepo.GetEndpointByID(ctx, userID, endpointID)
+	if err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, errs.NewNotFoundError("webhook endpoint not found", false, nil)
+		}
+		return nil, fmt.Errorf("failed to get webhook endpoint: %w", err)
+	}
+
+	return endpoint, nil
+}
+
+func (s *WebhookService) enqueueDelivery(ctx context.Context, deliveryID uuid.UUID) error {
+	task, err := job.NewWebhookDeliveryTask(deliveryID)
+	if err != nil {
+		return fmt.Errorf("failed to create webhook delivery task: %w", err)
+	}
+
+	// The task ID is the delivery ID, so a conflict means the delivery is
+	// already queued
+	if _, err := s.server.Job.Client.EnqueueContext(ctx, task); err != nil && !errors.Is(err, asynq.ErrTaskIDConflict) {
+		return fmt.Errorf("failed to enqueue webhook delivery task: %w", err)
+	}
+
+	return nil
+}
+
+// post sends a signed delivery and describes the outcome as a failed or
+// succeeded attempt. The error is set when the attempt failed.
+func (s *WebhookService) post(ctx context.Context, target *webhookmodel.DeliveryTarget) (*webhookmodel.Attempt, error) {
+	attempt := &webhookmodel.Attempt{Status: webhookmodel.DeliveryFailed}
+	fail := func(err error) (*webhookmodel.Attempt, error) {
+		message := err.Error()
+		attempt.Error = &message
+		return attempt, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.URL, bytes.NewReader(target.Payload))
+	if err != nil {
+		return fail(fmt.Errorf("failed to create webhook request: %w", err))
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("User-Agent", "Resumify-Webhooks/1.0")
+	req.Header.Set(webhook.HeaderEvent, string(target.EventType))
+	req.Header.Set(webhook.HeaderDelivery, target.ID.String())
+	req.Header.Set(webhook.HeaderSignature, webhook.Sign(target.Secret, time.Now(), target.Payload))
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return fail(fmt.Errorf("failed to send webhook: %w", err))
+	}
+	defer resp.Body.Close()
+
+	status := resp.StatusCode
+	attempt.ResponseStatus = &status
+
+	responseBody, err := io.ReadAll(io.LimitReader(resp.Body, webhookResponseLimit))
+	if err == nil && len(responseBody) > 0 {
+		body := string(responseBody)
+		attempt.ResponseBody = &body
+	}
+
+	if status < 200 || status >= 300 {
+		return fail(fmt.Errorf("webhook endpoint responded with status %d", status))
+	}
+
+	attempt.Status = webhookmodel.DeliverySucceeded
+	return attempt, nil
+}
+
+// newWebhookSecret returns a random secret for signing deliveries
+func newWebhookSecret() (string, error) {
+	buf := make([]byte, 32)
+	if _, err := rand.Read(buf); err != nil {
+		return "", err
+	}
+	return "whsec_" + base64.RawURLEncoding.EncodeToString(buf), nil
+}
+
+func (s *WebhookService) convertToEndpointResponse(endpoint *webhookmodel.Endpoint) *webhookmodel.EndpointResponse {
+	return &webhookmodel.EndpointResponse{
+		ID:          endpoint.ID.String(),
+		URL:         endpoint.URL,
+		Description: endpoint.Description,
+		EventTypes:  endpoint.EventTypes,
+		Active:      endpoint.Active,
+		CreatedAt:   endpoint.CreatedAt.Format(time.RFC3339),
+		UpdatedAt:   endpoint.UpdatedAt.Format(time.RFC3339),
+	}
+}
+
+func (s *WebhookService) convertToDeliveryResponse(delivery *webhookmodel.Delivery) *webhookmodel.DeliveryResponse {
+	response := &webhookmodel.DeliveryResponse{
+		ID:             delivery.ID.String(),
+		EndpointID:     delivery.EndpointID,
+		EventID:        delivery.EventID,
+		EventType:      delivery.EventType,
+		Payload:        delivery.Payload,
+		Status:         delivery.Status,
+		Attempts:       delivery.Attempts,
+		ResponseStatus: delivery.ResponseStatus,
+		ResponseBody:   delivery.ResponseBody,
+		Error:          delivery.Error,
+		RedeliveryOf:   delivery.RedeliveryOf,
+		CreatedAt:      delivery.CreatedAt.Format(time.RFC3339),
+	}
+
+	if delivery.LastAttemptAt != nil {
+		lastAttemptAt := delivery.LastAttemptAt.Format(time.RFC3339)
+		response.LastAttemptAt = &lastAttemptAt
+	}
+	if delivery.DeliveredAt != nil {
+		deliveredAt := delivery.DeliveredAt.Format(time.RFC3339)
+		response.DeliveredAt = &deliveredAt
+	}
+
+	return response
+}
@@ -0,0 +1,255 @@
+package service
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/recreatedev/Resumify/internal/lib/webhook"
+	webhookmodel "github.com/recreatedev/Resumify/internal/model/webhook"
+	"github.com/recreatedev/Resumify/internal/repository"
+	testhelpers "github.com/recreatedev/Resumify/internal/testing"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// webhookReceiver is an httptest server standing in for a user's endpoint. It
+// answers with the queued status codes in order and then with 200.
+type webhookReceiver struct {
+	*httptest.Server
+	statuses []int
+	calls    atomic.Int32
+	requests chan *receivedWebhook
+}
+
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+func newWebhookReceiver(t *testing.T, responseBody string, statuses ...int) *webhookReceiver {
+	t.Helper()
+
+	receiver := &webhookReceiver{
+		statuses: statuses,
+		requests: make(chan *receivedWebhook, 16),
+	}
+	receiver.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		receiver.requests <- &receivedWebhook{header: r.Header.Clone(), body: body}
+
+		status := http.StatusOK
+		if call := int(receiver.calls.Add(1)) - 1; call < len(receiver.statuses) {
+			status = receiver.statuses[call]
+		}
+		w.WriteHeader(status)
+		_, _ = io.WriteString(w, responseBody)
+	}))
+	t.Cleanup(receiver.Close)
+
+	return receiver
+}
+
+// verifySignature checks a signature header the way receivers are told to
+func verifySignature(t *testing.T, secret string, header string, body []byte) {
+	t.Helper()
+
+	parts := strings.Split(header, ",")
+	require.Len(t, parts, 2)
+	require.True(t, strings.HasPrefix(parts[0], "t="))
+	require.True(t, strings.HasPrefix(parts[1], "v1="))
+
+	timestamp, err := strconv.ParseInt(strings.TrimPrefix(parts[0], "t="), 10, 64)
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), time.Unix(timestamp, 0), time.Minute)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(strconv.FormatInt(timestamp, 10) + "."))
+	mac.Write(body)
+	expected := hex.EncodeToString(mac.Sum(nil))
+
+	assert.True(t, hmac.Equal([]byte(expected), []byte(strings.TrimPrefix(parts[1], "v1="))), "signature does not match")
+}
+
+func newDeliveryTarget(url string) *webhookmodel.DeliveryTarget {
+	target := &webhookmodel.DeliveryTarget{
+		URL:    url,
+		Secret: "whsec_test",
+		Active: true,
+	}
+	target.ID = uuid.New()
+	target.EventType = webhookmodel.EventResumeUpdated
+	target.Payload = []byte(`{"id":"evt","type":"resume.updated"}`)
+	target.Status = webhookmodel.DeliveryPending
+
+	return target
+}
+
+func TestWebhookPost(t *testing.T) {
+	ctx := context.Background()
+	s := &WebhookService{client: webhook.NewHTTPClient(webhookTimeout, true)}
+
+	t.Run("signs the delivery", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, "ok")
+		target := newDeliveryTarget(receiver.URL)
+
+		attempt, err := s.post(ctx, target)
+		require.NoError(t, err)
+		assert.Equal(t, webhookmodel.DeliverySucceeded, attempt.Status)
+		require.NotNil(t, attempt.ResponseStatus)
+		assert.Equal(t, http.StatusOK, *attempt.ResponseStatus)
+		require.NotNil(t, attempt.ResponseBody)
+		assert.Equal(t, "ok", *attempt.ResponseBody)
+		assert.Nil(t, attempt.Error)
+
+		received := <-receiver.requests
+		assert.Equal(t, []byte(target.Payload), received.body)
+		assert.Equal(t, "application/json", received.header.Get("Content-Type"))
+		assert.Equal(t, string(webhookmodel.EventResumeUpdated), received.header.Get(webhook.HeaderEvent))
+		assert.Equal(t, target.ID.String(), received.header.Get(webhook.HeaderDelivery))
+		verifySignature(t, target.Secret, received.header.Get(webhook.HeaderSignature), received.body)
+	})
+
+	t.Run("fails on a non-2xx response and keeps the start of the body", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, strings.Repeat("x", 2*webhookResponseLimit), http.StatusInternalServerError)
+
+		attempt, err := s.post(ctx, newDeliveryTarget(receiver.URL))
+		require.Error(t, err)
+		assert.Equal(t, webhookmodel.DeliveryFailed, attempt.Status)
+		require.NotNil(t, attempt.ResponseStatus)
+		assert.Equal(t, http.StatusInternalServerError, *attempt.ResponseStatus)
+		require.NotNil(t, attempt.ResponseBody)
+		assert.Len(t, *attempt.ResponseBody, webhookResponseLimit)
+		require.NotNil(t, attempt.Error)
+		assert.Contains(t, *attempt.Error, "500")
+	})
+
+	t.Run("refuses private targets by default", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, "ok")
+		s := &WebhookService{client: webhook.NewHTTPClient(webhookTimeout, false)}
+
+		attempt, err := s.post(ctx, newDeliveryTarget(receiver.URL))
+		require.ErrorIs(t, err, webhook.ErrPrivateAddress)
+		assert.Equal(t, webhookmodel.DeliveryFailed, attempt.Status)
+		assert.Nil(t, attempt.ResponseStatus)
+		require.NotNil(t, attempt.Error)
+		assert.Zero(t, receiver.calls.Load())
+	})
+}
+
+func TestWebhookDelivery(t *testing.T) {
+	_, testServer, cleanup := testhelpers.SetupTest(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	testServer.Config.Webhook.AllowPrivateTargets = true
+	repos := repository.NewRepositories(testServer)
+	s := NewWebhookService(testServer, repos, nil)
+
+	userID := "user_webhook_test"
+	newDelivery := func(t *testing.T, url string) (*webhookmodel.Endpoint, *webhookmodel.Delivery) {
+		t.Helper()
+
+		endpoint, err := repos.Webhook.CreateEndpoint(ctx, userID, "whsec_test", &webhookmodel.CreateEndpointRequest{
+			URL:        url,
+			EventTypes: []webhookmodel.EventType{webhookmodel.EventResumeUpdated},
+		})
+		require.NoError(t, err)
+
+		delivery, err := repos.Webhook.CreateDelivery(ctx, endpoint.ID, uuid.New(), webhookmodel.EventResumeUpdated, []byte(`{"type":"resume.updated"}`), nil)
+		require.NoError(t, err)
+
+		return endpoint, delivery
+	}
+	getDelivery := func(t *testing.T, endpoint *webhookmodel.Endpoint, deliveryID uuid.UUID) *webhookmodel.Delivery {
+		t.Helper()
+
+		delivery, err := repos.Webhook.GetDeliveryByID(ctx, userID, endpoint.ID, deliveryID)
+		require.NoError(t, err)
+		return delivery
+	}
+
+	t.Run("retries until the endpoint accepts", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, "try again", http.StatusInternalServerError)
+		endpoint, delivery := newDelivery(t, receiver.URL)
+
+		err := s.DeliverWebhook(ctx, delivery.ID, false)
+		require.Error(t, err)
+
+		logged := getDelivery(t, endpoint, delivery.ID)
+		assert.Equal(t, webhookmodel.DeliveryPending, logged.Status)
+		assert.Equal(t, 1, logged.Attempts)
+		require.NotNil(t, logged.ResponseStatus)
+		assert.Equal(t, http.StatusInternalServerError, *logged.ResponseStatus)
+		require.NotNil(t, logged.ResponseBody)
+		assert.Equal(t, "try again", *logged.ResponseBody)
+		require.NotNil(t, logged.Error)
+		assert.NotNil(t, logged.LastAttemptAt)
+		assert.Nil(t, logged.DeliveredAt)
+
+		err = s.DeliverWebhook(ctx, delivery.ID, false)
+		require.NoError(t, err)
+
+		logged = getDelivery(t, endpoint, delivery.ID)
+		assert.Equal(t, webhookmodel.DeliverySucceeded, logged.Status)
+		assert.Equal(t, 2, logged.Attempts)
+		require.NotNil(t, logged.ResponseStatus)
+		assert.Equal(t, http.StatusOK, *logged.ResponseStatus)
+		assert.Nil(t, logged.Error)
+		assert.NotNil(t, logged.DeliveredAt)
+
+		// Both attempts carried the same delivery and a valid signature
+		for i := 0; i < 2; i++ {
+			received := <-receiver.requests
+			assert.Equal(t, delivery.ID.String(), received.header.Get(webhook.HeaderDelivery))
+			verifySignature(t, "whsec_test", received.header.Get(webhook.HeaderSignature), received.body)
+		}
+
+		// A settled delivery is not sent again
+		require.NoError(t, s.DeliverWebhook(ctx, delivery.ID, false))
+		assert.Equal(t, int32(2), receiver.calls.Load())
+	})
+
+	t.Run("fails after the last retry", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, "", http.StatusServiceUnavailable, http.StatusServiceUnavailable)
+		endpoint, delivery := newDelivery(t, receiver.URL)
+
+		require.Error(t, s.DeliverWebhook(ctx, delivery.ID, false))
+		require.Error(t, s.DeliverWebhook(ctx, delivery.ID, true))
+
+		logged := getDelivery(t, endpoint, delivery.ID)
+		assert.Equal(t, webhookmodel.DeliveryFailed, logged.Status)
+		assert.Equal(t, 2, logged.Attempts)
+		require.NotNil(t, logged.ResponseStatus)
+		assert.Equal(t, http.StatusServiceUnavailable, *logged.ResponseStatus)
+		assert.Nil(t, logged.ResponseBody)
+		assert.Nil(t, logged.DeliveredAt)
+	})
+
+	t.Run("does not send to a paused endpoint", func(t *testing.T) {
+		receiver := newWebhookReceiver(t, "ok")
+		endpoint, delivery := newDelivery(t, receiver.URL)
+
+		active := false
+		_, err := repos.Webhook.UpdateEndpoint(ctx, userID, endpoint.ID, &webhookmodel.UpdateEndpointRequest{Active: &active})
+		require.NoError(t, err)
+
+		require.NoError(t, s.DeliverWebhook(ctx, delivery.ID, false))
+
+		logged := getDelivery(t, endpoint, delivery.ID)
+		assert.Equal(t, webhookmodel.DeliveryFailed, logged.Status)
+		require.NotNil(t, logged.Error)
+		assert.Equal(t, "endpoint is disabled", *logged.Error)
+		assert.Zero(t, receiver.calls.Load())
+	})
+}
@@ -26,6 +26,9 @@ type TestDB struct {
 func SetupTestDB(t *testing.T) (*TestDB, func()) {
 	t.Helper()
 
+	// Container-backed tests are skipped where no Docker daemon is available
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
 	ctx := context.Background()
 	dbName := fmt.Sprintf("test_db_%s", uuid.New().String()[:8])
 	dbUser := "testuser"
@@ -87,7 +90,9 @@ func SetupTestDB(t *testing.T) (*TestDB, func()) {
 			CORSAllowedOrigins: []string{"*"},
 		},
 		Integration: config.IntegrationConfig{
-			ResendAPIKey: "test-key",
+			EmailTransport:   "memory",
+			EmailFromName:    "Resumify",
+			EmailFromAddress: "test@example.com",
 		},
 		Redis: config.RedisConfig{
 			Address: "localhost:6379",
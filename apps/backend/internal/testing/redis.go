@@ -0,0 +1,50 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// SetupTestRedis starts a Redis container for the job queue and returns its
+// address
+func SetupTestRedis(t *testing.T) string {
+	t.Helper()
+
+	// Container-backed tests are skipped where no Docker daemon is available
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+
+	req := testcontainers.ContainerRequest{
+		Image:        "redis:7-alpine",
+		ExposedPorts: []string{"6379/tcp"},
+		WaitingFor:   wait.ForLog("Ready to accept connections").WithStartupTimeout(30 * time.Second),
+	}
+
+	redisContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err, "failed to start redis container")
+
+	// Make sure the test cleans up the container
+	t.Cleanup(func() {
+		if err := redisContainer.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate container: %v", err)
+		}
+	})
+
+	host, err := redisContainer.Host(ctx)
+	require.NoError(t, err, "failed to get container host")
+
+	mappedPort, err := redisContainer.MappedPort(ctx, "6379")
+	require.NoError(t, err, "failed to get mapped port")
+
+	return fmt.Sprintf("%s:%d", host, mappedPort.Int())
+}
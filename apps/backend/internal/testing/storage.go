@@ -0,0 +1,71 @@
+package testing
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/recreatedev/Resumify/internal/lib/storage"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	"github.com/testcontainers/testcontainers-go/wait"
+)
+
+// SetupTestStorage starts a MinIO container as a stand-in for S3 and returns
+// an S3 storage backend with its bucket created
+func SetupTestStorage(t *testing.T) *storage.S3Storage {
+	t.Helper()
+
+	// Container-backed tests are skipped where no Docker daemon is available
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+
+	ctx := context.Background()
+	accessKey := "testaccesskey"
+	secretKey := "testsecretkey"
+
+	req := testcontainers.ContainerRequest{
+		Image:        "minio/minio:latest",
+		ExposedPorts: []string{"9000/tcp"},
+		Env: map[string]string{
+			"MINIO_ROOT_USER":     accessKey,
+			"MINIO_ROOT_PASSWORD": secretKey,
+		},
+		Cmd:        []string{"server", "/data"},
+		WaitingFor: wait.ForHTTP("/minio/health/live").WithPort("9000/tcp").WithStartupTimeout(30 * time.Second),
+	}
+
+	minioContainer, err := testcontainers.GenericContainer(ctx, testcontainers.GenericContainerRequest{
+		ContainerRequest: req,
+		Started:          true,
+	})
+	require.NoError(t, err, "failed to start minio container")
+
+	// Make sure the test cleans up the container
+	t.Cleanup(func() {
+		if err := minioContainer.Terminate(ctx); err != nil {
+			t.Logf("failed to terminate container: %v", err)
+		}
+	})
+
+	host, err := minioContainer.Host(ctx)
+	require.NoError(t, err, "failed to get container host")
+
+	mappedPort, err := minioContainer.MappedPort(ctx, "9000")
+	require.NoError(t, err, "failed to get mapped port")
+
+	blobStorage, err := storage.NewS3Storage(
+		fmt.Sprintf("http://%s:%d", host, mappedPort.Int()),
+		"us-east-1",
+		"test-bucket",
+		accessKey,
+		secretKey,
+		true,
+	)
+	require.NoError(t, err, "failed to create storage")
+
+	err = blobStorage.CreateBucket(ctx)
+	require.NoError(t, err, "failed to create bucket")
+
+	return blobStorage
+}
@@ -39,6 +39,47 @@ func BindAndValidate(c echo.Context, payload Validatable) error {
 	return nil
 }
 
+// ValidateItem validates one element of a collection and returns its field
+// errors under the element's indexed path, e.g. "experience[2].company".
+func ValidateItem(collection string, index int, item Validatable) CustomValidationErrors {
+	var itemErrors CustomValidationErrors
+
+	_, fieldErrors := validateStruct(item)
+	for _, fieldError := range fieldErrors {
+		itemErrors = append(itemErrors, CustomValidationError{
+			Field:   ItemPath(collection, index, fieldError.Field),
+			Message: fieldError.Error,
+		})
+	}
+
+	return itemErrors
+}
+
+// ItemPath builds the indexed path of a field within a collection element
+func ItemPath(collection string, index int, field string) string {
+	return fmt.Sprintf("%s[%d].%s", collection, index, field)
+}
+
+// CheckClearedFields reports the fields of an update that are both given a
+// value and listed to be cleared
+func CheckClearedFields(clear []string, set map[string]bool) error {
+	var fieldErrors CustomValidationErrors
+	for _, field := range clear {
+		if set[field] {
+			fieldErrors = append(fieldErrors, CustomValidationError{
+				Field:   field,
+				Message: "cannot be set and cleared in the same update",
+			})
+		}
+	}
+
+	if len(fieldErrors) > 0 {
+		return fieldErrors
+	}
+
+	return nil
+}
+
 func validateStruct(v Validatable) (string, []errs.FieldError) {
 	if err := v.Validate(); err != nil {
 		return extractValidationErrors(err)